@@ -0,0 +1,12 @@
+package models
+
+// ActiveRecipe is a recipe inferred from the inputs and outputs of the machines running it
+type ActiveRecipe struct {
+	Product     string      `json:"product"` // Main output
+	Byproducts  []string    `json:"byproducts"`
+	Inputs      []string    `json:"inputs"`
+	MachineType MachineType `json:"machineType"`
+	Machines    int         `json:"machines"`
+	Alternate   bool        `json:"alternate"` // Inputs differ from the standard recipe of the product
+	Known       bool        `json:"known"`     // The standard recipe of the product is known, otherwise Alternate is always false
+}
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+type AlertSeverity string
+
+const (
+	AlertSeverityWarning  AlertSeverity = "warning"
+	AlertSeverityCritical AlertSeverity = "critical"
+)
+
+// Alert is an ongoing power condition of a circuit. Since is kept while the condition lasts,
+// so consumers can tell a new alert from one reported before.
+type Alert struct {
+	Severity  AlertSeverity `json:"severity"`
+	Kind      AlertName     `json:"kind"`
+	CircuitID string        `json:"circuitId"`
+	Message   string        `json:"message"`
+	Since     time.Time     `json:"since"` // When the condition was first seen
+}
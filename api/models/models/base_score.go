@@ -0,0 +1,26 @@
+package models
+
+type BaseScoreFactor string
+
+const (
+	BaseScoreFactorEfficiency BaseScoreFactor = "efficiency"
+	BaseScoreFactorPower      BaseScoreFactor = "power"
+	BaseScoreFactorProduction BaseScoreFactor = "production"
+	BaseScoreFactorLogistics  BaseScoreFactor = "logistics"
+)
+
+type BaseScoreComponent struct {
+	Factor BaseScoreFactor `json:"factor"`
+	Score  float64         `json:"score"`  // 0-100
+	Weight float64         `json:"weight"` // Share of the overall score, 0-1
+	Value  float64         `json:"value"`  // Raw input, a ratio for efficiency and power, a count for production and logistics
+}
+
+type BaseScore struct {
+	Score      float64              `json:"score"`      // Weighted 0-100 score over the available components
+	Components []BaseScoreComponent `json:"components"` // Only factors whose inputs have been polled
+}
+
+func (baseScore *BaseScore) ToDTO() BaseScoreDTO {
+	return *baseScore
+}
@@ -1,15 +1,17 @@
 package models
 
 type Belt struct {
-	ID             string     `json:"id"`
-	Name           string     `json:"name"`
-	Location0      Location   `json:"location0"`
-	Location1      Location   `json:"location1"`
-	Connected0     bool       `json:"connected0"`
-	Connected1     bool       `json:"connected1"`
-	SplineData     []Location `json:"splineData"`
-	Length         float64    `json:"length"`
-	ItemsPerMinute float64    `json:"itemsPerMinute"`
+	ID                string     `json:"id"`
+	Name              string     `json:"name"`
+	Location0         Location   `json:"location0"`
+	Location1         Location   `json:"location1"`
+	Connected0        bool       `json:"connected0"`
+	Connected1        bool       `json:"connected1"`
+	SplineData        []Location `json:"splineData"`
+	Length            float64    `json:"length"`
+	ItemsPerMinute    float64    `json:"itemsPerMinute"`
+	MaxItemsPerMinute float64    `json:"maxItemsPerMinute"` // Rated capacity of the belt's mark
+	Saturation        float64    `json:"saturation"`        // ItemsPerMinute relative to the rated capacity, 0-1
 }
 
 func (belt *Belt) ToDTO() BeltDTO {
@@ -1,24 +1,31 @@
 package models
 
 type CircuitConsumption struct {
-	Total float64 `json:"total"`
-	Max   float64 `json:"max"`
+	Total          float64  `json:"total"`
+	Max            float64  `json:"max"`
+	TotalMegawatts *float64 `json:"totalMegawatts,omitempty"` // Set only when dual units are enabled
+	MaxMegawatts   *float64 `json:"maxMegawatts,omitempty"`   // Set only when dual units are enabled
 }
 
 type CircuitProduction struct {
-	Total float64 `json:"total"`
+	Total          float64  `json:"total"`
+	TotalMegawatts *float64 `json:"totalMegawatts,omitempty"` // Set only when dual units are enabled
 }
 
 type CircuitCapacity struct {
-	Total float64 `json:"total"`
+	Total          float64  `json:"total"`
+	TotalMegawatts *float64 `json:"totalMegawatts,omitempty"` // Set only when dual units are enabled
 }
 
 type CircuitBattery struct {
 	Percentage   float64 `json:"percentage"`
 	Capacity     float64 `json:"capacity"`
 	Differential float64 `json:"differential"`
-	UntilFull    float64 `json:"untilFull"`  // parsed from 00:00:00 to float64
-	UntilEmpty   float64 `json:"untilEmpty"` // parsed from 00:00:00 to float64
+
+	CapacityMegawattHours *float64 `json:"capacityMegawattHours,omitempty"` // Set only when dual units are enabled
+	DifferentialMegawatts *float64 `json:"differentialMegawatts,omitempty"` // Set only when dual units are enabled
+	UntilFull             float64  `json:"untilFull"`                       // parsed from 00:00:00 to float64
+	UntilEmpty            float64  `json:"untilEmpty"`                      // parsed from 00:00:00 to float64
 }
 
 type Circuit struct {
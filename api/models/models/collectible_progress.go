@@ -0,0 +1,18 @@
+package models
+
+type CollectibleUnit string
+
+const (
+	CollectibleUnitItems       CollectibleUnit = "items"
+	CollectibleUnitPowerShards CollectibleUnit = "powerShards" // Power slugs in the power shards they refine into
+)
+
+// CollectibleProgress compares the collectibles discovered by radar towers with those held in inventories
+type CollectibleProgress struct {
+	Name       string          `json:"name"`
+	Unit       CollectibleUnit `json:"unit"`
+	Discovered int             `json:"discovered"` // Uncollected signals revealed by radar towers
+	Collected  int             `json:"collected"`  // Held in player and storage inventories
+	Remaining  int             `json:"remaining"`  // Discovered minus collected, never negative
+	Note       string          `json:"note"`
+}
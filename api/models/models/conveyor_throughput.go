@@ -0,0 +1,14 @@
+package models
+
+type ItemThroughput struct {
+	Name      string       `json:"name"`
+	Form      ResourceForm `json:"form"`
+	PerMinute float64      `json:"perMinute"` // Summed flow over every belt or pipe carrying the item
+	Conveyors int          `json:"conveyors"` // Number of belts or pipes carrying the item
+}
+
+type ConveyorThroughput struct {
+	Items                     []ItemThroughput `json:"items"`
+	UnattributedBeltPerMinute float64          `json:"unattributedBeltPerMinute"` // Flow on belts whose items could not be resolved
+	UnattributedPipePerMinute float64          `json:"unattributedPipePerMinute"` // Flow in pipes whose fluid could not be resolved
+}
@@ -0,0 +1,53 @@
+package models
+
+import "time"
+
+// DiagnosticsDump is a snapshot of the internal state of this instance, for bug reports
+type DiagnosticsDump struct {
+	InstanceID  string                 `json:"instanceId"`
+	GeneratedAt time.Time              `json:"generatedAt"`
+	Publishers  []PublisherDiagnostics `json:"publishers"` // Sessions polled by this instance
+}
+
+// PublisherDiagnostics is the state of one session publisher and its trackers
+type PublisherDiagnostics struct {
+	SessionID      string               `json:"sessionId"`
+	SaveName       string               `json:"saveName"`
+	Disconnected   bool                 `json:"disconnected"`
+	LeaseOwned     bool                 `json:"leaseOwned"`
+	LeaseUncertain bool                 `json:"leaseUncertain"`
+	GameTimeID     int64                `json:"gameTimeId"`
+	Client         *ClientDiagnostics   `json:"client"` // Null until the publisher created its client
+	Trackers       []TrackerDiagnostics `json:"trackers"`
+}
+
+// TrackerDiagnostics summarizes the state of one stateful tracker
+type TrackerDiagnostics struct {
+	Name    string         `json:"name"`
+	Enabled bool           `json:"enabled"`
+	Entries int            `json:"entries"`          // Tracked entities or values
+	Oldest  *time.Time     `json:"oldest,omitempty"` // Earliest timestamp held, if the tracker keeps any
+	Details map[string]any `json:"details,omitempty"`
+}
+
+// ClientDiagnostics is the connection state of a session's game server client
+type ClientDiagnostics struct {
+	ApiUp            bool                                   `json:"apiUp"`
+	GamePaused       bool                                   `json:"gamePaused"`
+	FailureCount     int                                    `json:"failureCount"`
+	Disconnected     bool                                   `json:"disconnected"`
+	EndpointErrors   []EndpointError                        `json:"endpointErrors"`
+	SuppressedErrors map[SatisfactoryEventType]int          `json:"suppressedErrors"` // Failing endpoints -> errors not logged since the last logged one
+	PollBudget       *PollBudget                            `json:"pollBudget"`       // Null when no poll budget is configured
+	BackoffSeconds   map[SatisfactoryEventType]float64      `json:"backoffSeconds"`   // Endpoints backed off after repeated failures -> seconds until the next attempt
+	QueueDepth       int                                    `json:"queueDepth"`       // Polls waiting for a request queue worker
+	QueueStats       map[SatisfactoryEventType]EndpointStat `json:"queueStats"`       // Endpoints -> timing of their polls
+}
+
+// EndpointStat is the timing of an endpoint's polls through the request queue
+type EndpointStat struct {
+	LastDurationSeconds    float64 `json:"lastDurationSeconds"`
+	AverageDurationSeconds float64 `json:"averageDurationSeconds"` // Exponentially weighted, recent polls count most
+	Successes              int     `json:"successes"`
+	Failures               int     `json:"failures"`
+}
@@ -0,0 +1,12 @@
+package models
+
+// DrainingBattery is a circuit whose batteries only ever discharged over the history window,
+// meaning the grid lacks generation to recharge them
+type DrainingBattery struct {
+	CircuitID           string  `json:"circuitId"`
+	Samples             int     `json:"samples"`             // History samples in which the circuit had battery capacity
+	DrainingSamples     int     `json:"drainingSamples"`     // Samples with a negative battery differential
+	AverageDifferential float64 `json:"averageDifferential"` // Average battery differential over the samples, negative
+	Percentage          float64 `json:"percentage"`          // Latest battery charge, 0-100
+	UntilEmpty          float64 `json:"untilEmpty"`          // Latest estimate of the seconds until the batteries are empty
+}
@@ -9,16 +9,19 @@ const (
 )
 
 type Drone struct {
-	Name           string        `json:"name"`
-	Speed          float64       `json:"speed"`
-	Status         DroneStatus   `json:"status"`
-	Home           DroneStation  `json:"home"`
-	Paired         *DroneStation `json:"paired,omitempty"`
-	Destination    *DroneStation `json:"destination,omitempty"`
-	CircuitID      int           `json:"circuitId"`
-	CircuitGroupID int           `json:"circuitGroupId"`
-	Location       `json:",inline" tstype:",extends"`
-	CircuitIDs     `json:",inline" tstype:",extends"`
+	Name             string        `json:"name"`
+	Speed            float64       `json:"speed"`
+	SpeedRaw         *float64      `json:"speedRaw,omitempty"` // Unsmoothed speed, set only when rate smoothing is enabled
+	Status           DroneStatus   `json:"status"`
+	Home             DroneStation  `json:"home"`
+	Paired           *DroneStation `json:"paired,omitempty"`
+	Destination      *DroneStation `json:"destination,omitempty"`
+	Fuel             *Fuel         `json:"fuel,omitempty"`   // Active fuel of the home station, FRM reports no fuel or battery per drone
+	EstimatedArrival float64       `json:"estimatedArrival"` // Seconds until the destination is reached at the current speed, zero unless flying
+	CircuitID        int           `json:"circuitId"`
+	CircuitGroupID   int           `json:"circuitGroupId"`
+	Location         `json:",inline" tstype:",extends"`
+	CircuitIDs       `json:",inline" tstype:",extends"`
 }
 
 func (drone *Drone) ToDTO() DroneDTO {
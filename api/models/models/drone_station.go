@@ -6,6 +6,7 @@ type DroneStation struct {
 	BoundingBox     BoundingBox `json:"boundingBox"`
 	IncomingRate    float64     `json:"incomingRate"`    // Average incoming items/minute
 	OutgoingRate    float64     `json:"outgoingRate"`    // Average outgoing items/minute
+	RateUnit        RateUnit    `json:"rateUnit"`        // Unit of IncomingRate and OutgoingRate
 	InputInventory  []ItemStats `json:"inputInventory"`  // Items being received
 	OutputInventory []ItemStats `json:"outputInventory"` // Items being sent
 	Location        `json:",inline" tstype:",extends"`
@@ -31,6 +31,44 @@ type StorageDTO = Storage
 type SpaceElevatorDTO = SpaceElevator
 type HubDTO = Hub
 type RadarTowerDTO = RadarTower
+type MisroutedBeltDTO = MisroutedBelt
+type OrphanedItemDTO = OrphanedItem
+type DuplicateTrainStationNameDTO = DuplicateTrainStationName
+type BaseScoreDTO = BaseScore
+type ItemProducersDTO = ItemProducers
+type ConveyorThroughputDTO = ConveyorThroughput
+type ResourceCapacityDTO = ResourceCapacity
+type OscillatingItemDTO = OscillatingItem
+type RemovedEntitiesDTO = RemovedEntities
+type FuelBalanceDTO = FuelBalance
+type MachineBuildStatsDTO = MachineBuildStats
+type TrainRouteDTO = TrainRoute
+type PipeNetworkBalanceDTO = PipeNetworkBalance
+type EndpointErrorDTO = EndpointError
+type PowerPlantDTO = PowerPlant
+type WaterBalanceDTO = WaterBalance
+type StalledVehicleDTO = StalledVehicle
+type ActiveRecipeDTO = ActiveRecipe
+type IdleConveyorDTO = IdleConveyor
+type CollectibleProgressDTO = CollectibleProgress
+type RecipeRatioIssueDTO = RecipeRatioIssue
+type DrainingBatteryDTO = DrainingBattery
+type FactoryStatusPointDTO = FactoryStatusPoint
+type ItemRunwayDTO = ItemRunway
+type TrainPlatformMismatchDTO = TrainPlatformMismatch
+type SinkCompositionDTO = SinkComposition
+type PackagedCommodityDTO = PackagedCommodity
+type DiagnosticsDumpDTO = DiagnosticsDump
+type EventDeltaDTO = EventDelta
+type ResourceNodeSummaryDTO = ResourceNodeSummary
+type TrainCycleDTO = TrainCycle
+type ProdSampleDTO = ProdSample
+type AlertDTO = Alert
+type SplitterOutputFilterDTO = SplitterOutputFilter
+type WorldSnapshotDTO = WorldSnapshot
+type WorldSnapshotLineDTO = WorldSnapshotLine
+type StorageSummaryDTO = StorageSummary
+type TechProgressDTO = TechProgress
 
 type DroneSetupDTO struct {
 	Drones        []DroneDTO        `json:"drones"`
@@ -0,0 +1,6 @@
+package models
+
+type DuplicateTrainStationName struct {
+	Name      string     `json:"name"`
+	Locations []Location `json:"locations"` // Location of every station sharing the name
+}
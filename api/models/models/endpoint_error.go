@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+// EndpointError is the last failure of an endpoint, kept until its next successful fetch
+type EndpointError struct {
+	EventType SatisfactoryEventType `json:"eventType"`
+	Message   string                `json:"message"`
+	Timestamp time.Time             `json:"timestamp"`
+}
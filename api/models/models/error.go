@@ -1,6 +1,9 @@
 package models
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 type BindingError struct {
 	ValidationErrors map[string][]string `json:"validationErrors"`
@@ -29,3 +32,45 @@ func NewSatisfactoryApiError(message string) *SatisfactoryApiError {
 		Message: message,
 	}
 }
+
+// NonJSONResponseError is returned when the Satisfactory API answers with something other than JSON,
+// such as an HTML error page from the mod's web server. The server is reachable but misbehaving.
+type NonJSONResponseError struct {
+	Path        string
+	ContentType string
+	Snippet     string
+}
+
+func (e *NonJSONResponseError) Error() string {
+	return fmt.Sprintf("API Error: non-JSON response from %s (content type %q): %s", e.Path, e.ContentType, e.Snippet)
+}
+
+func NewNonJSONResponseError(path, contentType, snippet string) *NonJSONResponseError {
+	return &NonJSONResponseError{
+		Path:        path,
+		ContentType: contentType,
+		Snippet:     snippet,
+	}
+}
+
+// PartialDataError is returned together with data when some of the sub-fetches behind it failed.
+// The data is usable but incomplete, callers wanting all-or-nothing treat it like any other error.
+type PartialDataError struct {
+	Failed []string // Sources that could not be fetched
+	Err    error    // First failure
+}
+
+func (e *PartialDataError) Error() string {
+	return fmt.Sprintf("partial data, failed to fetch %s: %v", strings.Join(e.Failed, ", "), e.Err)
+}
+
+func (e *PartialDataError) Unwrap() error {
+	return e.Err
+}
+
+func NewPartialDataError(failed []string, err error) *PartialDataError {
+	return &PartialDataError{
+		Failed: failed,
+		Err:    err,
+	}
+}
@@ -0,0 +1,21 @@
+package models
+
+// EventDelta is the data of a list-based event sent as changes against the previous event of the same type.
+// A consumer applies the removals and upserts per kind to its entities, or replaces them if Snapshot is set.
+type EventDelta struct {
+	Snapshot bool          `json:"snapshot"` // If set, the upserted entities replace all previously received ones
+	Kinds    []EntityDelta `json:"kinds"`
+}
+
+// EntityDelta holds the changes of one kind of entity, e.g. belts or splitterMergers of the belts event
+type EntityDelta struct {
+	Kind     string        `json:"kind"`
+	Upserted []DeltaEntity `json:"upserted"` // Added and changed entities, in payload order
+	Removed  []string      `json:"removed"`  // IDs of entities no longer present
+}
+
+// DeltaEntity is an entity with the ID it is tracked by in deltas
+type DeltaEntity struct {
+	ID   string `json:"id"`
+	Data any    `json:"data"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+type EventLogEntryType string
+
+const (
+	EventLogEntryFuseTriggered      EventLogEntryType = "fuseTriggered"
+	EventLogEntryTrainDerailed      EventLogEntryType = "trainDerailed"
+	EventLogEntryPlayerDied         EventLogEntryType = "playerDied"
+	EventLogEntryMilestoneCompleted EventLogEntryType = "milestoneCompleted"
+	EventLogEntryPhaseCompleted     EventLogEntryType = "phaseCompleted"
+	EventLogEntrySchematicUnlocked  EventLogEntryType = "schematicUnlocked"
+)
+
+// EventLogEntry is a notable discrete change in a session, kept for scrollback
+type EventLogEntry struct {
+	Timestamp   time.Time         `json:"timestamp"`
+	Type        EventLogEntryType `json:"type"`
+	Description string            `json:"description"`
+}
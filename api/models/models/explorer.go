@@ -13,6 +13,7 @@ type Explorer struct {
 	ID         string         `json:"id"`
 	Name       string         `json:"name"`
 	Speed      float64        `json:"speed"`
+	SpeedRaw   *float64       `json:"speedRaw,omitempty"` // Unsmoothed speed, set only when rate smoothing is enabled
 	Status     ExplorerStatus `json:"status"`
 	Fuel       *Fuel          `json:"fuel"`
 	Inventory  []ItemStats    `json:"inventory"`
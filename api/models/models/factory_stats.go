@@ -1,11 +1,23 @@
 package models
 
 type MachineEfficiency struct {
-	MachinesOperating    int `json:"machinesOperating"`
-	MachinesIdle         int `json:"machinesIdle"`
-	MachinesPaused       int `json:"machinesPaused"`
-	MachinesUnconfigured int `json:"machinesUnconfigured"`
-	MachinesUnknown      int `json:"machinesUnknown"`
+	MachinesOperating    int     `json:"machinesOperating"`
+	MachinesIdle         int     `json:"machinesIdle"`
+	MachinesPaused       int     `json:"machinesPaused"`
+	MachinesUnconfigured int     `json:"machinesUnconfigured"`
+	MachinesUnknown      int     `json:"machinesUnknown"`
+	OverallEfficiency    float64 `json:"overallEfficiency"` // Operating / (operating + idle + paused), 0-1, 0 without such machines
+}
+
+// ComputeOverallEfficiency sets OverallEfficiency from the machine counts. Unconfigured and unknown
+// machines are left out, since they are not expected to run.
+func (efficiency *MachineEfficiency) ComputeOverallEfficiency() {
+	considered := efficiency.MachinesOperating + efficiency.MachinesIdle + efficiency.MachinesPaused
+	if considered == 0 {
+		efficiency.OverallEfficiency = 0
+		return
+	}
+	efficiency.OverallEfficiency = float64(efficiency.MachinesOperating) / float64(considered)
 }
 
 type FactoryStats struct {
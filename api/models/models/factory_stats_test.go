@@ -0,0 +1,30 @@
+package models
+
+import (
+	"math"
+	"testing"
+)
+
+func TestComputeOverallEfficiency(t *testing.T) {
+	tests := []struct {
+		name       string
+		efficiency MachineEfficiency
+		want       float64
+	}{
+		{"no machines", MachineEfficiency{}, 0},
+		{"all operating", MachineEfficiency{MachinesOperating: 4}, 1},
+		{"idle and paused count against it", MachineEfficiency{MachinesOperating: 2, MachinesIdle: 1, MachinesPaused: 1}, 0.5},
+		{"unconfigured and unknown are left out", MachineEfficiency{MachinesOperating: 3, MachinesIdle: 1, MachinesUnconfigured: 10, MachinesUnknown: 5}, 0.75},
+		{"only unconfigured", MachineEfficiency{MachinesUnconfigured: 3}, 0},
+		{"stale value is reset", MachineEfficiency{MachinesUnknown: 1, OverallEfficiency: 0.9}, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.efficiency.ComputeOverallEfficiency()
+			if math.Abs(test.efficiency.OverallEfficiency-test.want) > 1e-9 {
+				t.Errorf("got %v, want %v", test.efficiency.OverallEfficiency, test.want)
+			}
+		})
+	}
+}
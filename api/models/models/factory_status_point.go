@@ -0,0 +1,11 @@
+package models
+
+// FactoryStatusPoint is the machine count per status at a point in game time
+type FactoryStatusPoint struct {
+	GameTimeID   int64 `json:"gameTimeId"`
+	Operating    int   `json:"operating"`
+	Idle         int   `json:"idle"`
+	Paused       int   `json:"paused"`
+	Unconfigured int   `json:"unconfigured"`
+	Unknown      int   `json:"unknown"`
+}
@@ -0,0 +1,11 @@
+package models
+
+type FuelBalance struct {
+	Fuel              string   `json:"fuel"`
+	Generators        int      `json:"generators"`        // Fuel generators burning this fuel
+	ProducedPerMinute float64  `json:"producedPerMinute"` // From prod stats
+	ConsumedPerMinute float64  `json:"consumedPerMinute"` // Summed generator inputs
+	NetPerMinute      float64  `json:"netPerMinute"`      // Produced minus consumed, negative when generators burn faster than fuel is made
+	Stored            float64  `json:"stored"`            // Fuel buffered in storage and generator inventories
+	RunwayMinutes     *float64 `json:"runwayMinutes"`     // Minutes until the stored fuel runs out, nil when the balance is not negative
+}
@@ -16,8 +16,13 @@ type PowerSource struct {
 	TotalProduction float64 `json:"totalProduction"`
 }
 
+// GeneratorStats holds power production per generator type and the power balance of the whole base.
+// The totals are zero when the circuits could not be fetched.
 type GeneratorStats struct {
-	Sources map[PowerType]PowerSource `json:"sources"`
+	Sources          map[PowerType]PowerSource `json:"sources"`
+	TotalProduction  float64                   `json:"totalProduction"`  // W, summed over all circuits
+	TotalConsumption float64                   `json:"totalConsumption"` // W, summed over all circuits
+	NetBalance       float64                   `json:"netBalance"`       // W, production minus consumption
 }
 
 func (generatorStats *GeneratorStats) ToDTO() GeneratorStatsDTO {
@@ -10,10 +10,12 @@ type HubMilestoneCost struct {
 
 // HubMilestone represents the active milestone at the HUB
 type HubMilestone struct {
-	Name     string             `json:"name"`
-	TechTier int                `json:"techTier"`
-	Type     string             `json:"type"` // "Milestone" or "No Milestone Selected"
-	Cost     []HubMilestoneCost `json:"cost"`
+	Name            string             `json:"name"`
+	TechTier        int                `json:"techTier"`
+	Type            string             `json:"type"` // "Milestone" or "No Milestone Selected"
+	Cost            []HubMilestoneCost `json:"cost"`
+	ProgressPercent float64            `json:"progressPercent"` // 0-1, mean progress of the cost items
+	Completable     bool               `json:"completable"`     // Every cost item is fully submitted
 }
 
 // Hub represents the HUB Terminal
@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+type ConveyorKind string
+
+const (
+	ConveyorKindBelt ConveyorKind = "belt"
+	ConveyorKindPipe ConveyorKind = "pipe"
+)
+
+// IdleConveyor is a belt or pipe connected at both ends that has carried nothing for several polls,
+// usually because an upstream machine stopped or a splitter is misconfigured
+type IdleConveyor struct {
+	ID         string       `json:"id"`
+	Name       string       `json:"name"`
+	Kind       ConveyorKind `json:"kind"`
+	Location0  Location     `json:"location0"`
+	Location1  Location     `json:"location1"`
+	IdlePolls  int          `json:"idlePolls"`  // Consecutive polls without throughput
+	LastFlowAt *time.Time   `json:"lastFlowAt"` // Last poll with throughput, nil if none was seen since tracking started
+}
@@ -0,0 +1,25 @@
+package models
+
+type AlertName string
+
+const (
+	AlertFuseTriggered   AlertName = "fuseTriggered"   // A circuit has a triggered fuse
+	AlertTrainDerailed   AlertName = "trainDerailed"   // A train is derailed
+	AlertVehicleStalled  AlertName = "vehicleStalled"  // A self-driving vehicle is stalled
+	AlertConveyorIdle    AlertName = "conveyorIdle"    // A connected belt or pipe carries nothing
+	AlertEndpointFailing AlertName = "endpointFailing" // An FRM endpoint is failing
+	AlertBatteryLow      AlertName = "batteryLow"      // A circuit battery is below the configured charge
+	AlertPowerDeficit    AlertName = "powerDeficit"    // A circuit consumes more than it produces
+)
+
+// AlertNames lists all alerts in export order
+var AlertNames = []AlertName{AlertFuseTriggered, AlertTrainDerailed, AlertVehicleStalled, AlertConveyorIdle, AlertEndpointFailing, AlertBatteryLow, AlertPowerDeficit}
+
+// IncidentStats accumulates the alerts and incidents of a session over its lifetime, for metrics export.
+// Counts only ever increase until the session is deleted.
+type IncidentStats struct {
+	Firing      map[AlertName]bool                  `json:"firing"`      // Alert -> currently firing
+	AlertsFired map[AlertName]int                   `json:"alertsFired"` // Alert -> times it started firing
+	Incidents   map[EventLogEntryType]int           `json:"incidents"`   // Event log entry type -> occurrences
+	Last        map[EventLogEntryType]EventLogEntry `json:"last"`        // Latest entry per type
+}
@@ -0,0 +1,17 @@
+package models
+
+type ItemProducer struct {
+	Type       MachineType   `json:"type"`
+	Status     MachineStatus `json:"status"`
+	Current    float64       `json:"current"`    // Items produced per minute
+	Max        float64       `json:"max"`        // Items produced per minute at full efficiency
+	Efficiency float64       `json:"efficiency"` // 0-1
+	Location   `json:",inline" tstype:",extends"`
+}
+
+type ItemProducers struct {
+	Item         string         `json:"item"`
+	Producers    []ItemProducer `json:"producers"`
+	TotalCurrent float64        `json:"totalCurrent"`
+	TotalMax     float64        `json:"totalMax"`
+}
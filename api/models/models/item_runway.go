@@ -0,0 +1,10 @@
+package models
+
+// ItemRunway is how long the stored amount of an item lasts at its current consumption if production stopped
+type ItemRunway struct {
+	Name              string   `json:"name"`
+	Stored            float64  `json:"stored"`                  // Global stored amount
+	ConsumedPerMinute float64  `json:"consumedPerMinute"`       // Current consumption
+	RunwayMinutes     *float64 `json:"runwayMinutes,omitempty"` // Stored divided by consumption, null if nothing consumes the item
+	Low               bool     `json:"low"`                     // Runway is below the configured floor
+}
@@ -1,6 +1,39 @@
 package models
 
+type ItemCategory string
+
+const (
+	ItemCategoryOre           ItemCategory = "ore"
+	ItemCategoryIngot         ItemCategory = "ingot"
+	ItemCategoryMineral       ItemCategory = "mineral"
+	ItemCategoryStandardPart  ItemCategory = "standardPart"
+	ItemCategoryIndustrial    ItemCategory = "industrial"
+	ItemCategoryElectronic    ItemCategory = "electronic"
+	ItemCategoryCommunication ItemCategory = "communication"
+	ItemCategoryOilDerived    ItemCategory = "oilDerived"
+	ItemCategoryFluid         ItemCategory = "fluid"
+	ItemCategoryPackaged      ItemCategory = "packaged"
+	ItemCategoryFuel          ItemCategory = "fuel"
+	ItemCategoryBiomass       ItemCategory = "biomass"
+	ItemCategoryNuclear       ItemCategory = "nuclear"
+	ItemCategoryQuantum       ItemCategory = "quantum"
+	ItemCategorySpaceElevator ItemCategory = "spaceElevator"
+	ItemCategoryAmmunition    ItemCategory = "ammunition"
+	ItemCategoryOther         ItemCategory = "other"
+)
+
+type ResourceForm string
+
+const (
+	ResourceFormSolid  ResourceForm = "solid"
+	ResourceFormLiquid ResourceForm = "liquid"
+	ResourceFormGas    ResourceForm = "gas"
+)
+
 type ItemStats struct {
-	Name  string  `json:"name"`
-	Count float64 `json:"count"`
+	Name     string       `json:"name"`
+	Count    float64      `json:"count"`
+	Category ItemCategory `json:"category"`
+	Form     ResourceForm `json:"form"`
+	Tier     int          `json:"tier"` // Tier the item is first unlocked in, 0 if unknown
 }
@@ -5,9 +5,25 @@ type Location struct {
 	Y        float64 `json:"y"`
 	Z        float64 `json:"z"`
 	Rotation float64 `json:"rotation"`
+
+	// Set only when dual units are enabled
+	XMeters *float64 `json:"xMeters,omitempty"`
+	YMeters *float64 `json:"yMeters,omitempty"`
+	ZMeters *float64 `json:"zMeters,omitempty"`
 }
 
 type BoundingBox struct {
 	Min Location `json:"min"`
 	Max Location `json:"max"`
 }
+
+// Contains reports whether the location lies within the box, expanded by tolerance on every side.
+// An unset (zero) box contains nothing.
+func (box BoundingBox) Contains(location Location, tolerance float64) bool {
+	if box.Min.X == 0 && box.Min.Y == 0 && box.Min.Z == 0 && box.Max.X == 0 && box.Max.Y == 0 && box.Max.Z == 0 {
+		return false
+	}
+	return location.X >= box.Min.X-tolerance && location.X <= box.Max.X+tolerance &&
+		location.Y >= box.Min.Y-tolerance && location.Y <= box.Max.Y+tolerance &&
+		location.Z >= box.Min.Z-tolerance && location.Z <= box.Max.Z+tolerance
+}
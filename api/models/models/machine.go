@@ -42,6 +42,14 @@ const (
 	MachineStatusUnknown      MachineStatus = "unknown"
 )
 
+// MachineIdleReason explains why an idle machine is not producing
+type MachineIdleReason string
+
+const (
+	MachineIdleReasonUnpowered MachineIdleReason = "unpowered" // Its circuit has no power available, e.g. a tripped fuse
+	MachineIdleReasonStarved   MachineIdleReason = "starved"   // Powered, but lacking input material or output space
+)
+
 type MachineProdStats struct {
 	Name       string  `json:"name"`
 	Stored     float64 `json:"stored"`
@@ -51,8 +59,10 @@ type MachineProdStats struct {
 }
 
 type Machine struct {
+	ID           string             `json:"id"` // Stable across polls: the building ID if known, otherwise type and rounded location
 	Type         MachineType        `json:"type"`
 	Status       MachineStatus      `json:"status"`
+	IdleReason   MachineIdleReason  `json:"idleReason,omitempty"` // Set for idle factory machines and extractors once circuits are known
 	Category     MachineCategory    `json:"category"`
 	Productivity float64            `json:"productivity"` // 0-1
 	Input        []MachineProdStats `json:"input"`
@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+type MachineBuild struct {
+	Type      MachineType `json:"type"`
+	FirstSeen time.Time   `json:"firstSeen"`
+	Location  `json:",inline" tstype:",extends"`
+}
+
+type MachineCountPoint struct {
+	Timestamp time.Time `json:"timestamp"`
+	Count     int       `json:"count"`
+}
+
+// MachineBuildLog is the persisted record of machines first seen since tracking started.
+// Machines already present when tracking started are not builds.
+type MachineBuildLog struct {
+	TrackingSince time.Time           `json:"trackingSince"`
+	TotalBuilt    int                 `json:"totalBuilt"` // All builds since tracking started, Builds only keeps the most recent
+	Builds        []MachineBuild      `json:"builds"`     // Oldest first
+	Counts        []MachineCountPoint `json:"counts"`     // Total machine count, one point per change
+}
+
+type MachineBuildStats struct {
+	TrackingSince time.Time           `json:"trackingSince"`
+	TotalMachines int                 `json:"totalMachines"`
+	TotalBuilt    int                 `json:"totalBuilt"`
+	BuiltPerHour  float64             `json:"builtPerHour"` // Average since tracking started
+	NewMachines   []MachineBuild      `json:"newMachines"`  // Builds first seen after the requested time
+	Counts        []MachineCountPoint `json:"counts"`
+}
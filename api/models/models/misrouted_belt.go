@@ -0,0 +1,11 @@
+package models
+
+type MisroutedBelt struct {
+	BeltID        string      `json:"beltId"`
+	BeltName      string      `json:"beltName"`
+	Items         []string    `json:"items"`         // Items the belt can carry given its upstream sources
+	AcceptedItems []string    `json:"acceptedItems"` // Inputs of the downstream machine
+	MachineType   MachineType `json:"machineType"`
+	Location0     Location    `json:"location0"`
+	Location1     Location    `json:"location1"`
+}
@@ -0,0 +1,18 @@
+package models
+
+type OrphanedItemDestination string
+
+const (
+	OrphanedItemDestinationStorage          OrphanedItemDestination = "storage"
+	OrphanedItemDestinationDimensionalDepot OrphanedItemDestination = "dimensionalDepot"
+	OrphanedItemDestinationSink             OrphanedItemDestination = "sink"
+	OrphanedItemDestinationNone             OrphanedItemDestination = "none"
+	OrphanedItemDestinationUnknown          OrphanedItemDestination = "unknown" // The sink is active, but the item's sink value is unknown
+)
+
+type OrphanedItem struct {
+	Name              string                  `json:"name"`
+	ProducedPerMinute float64                 `json:"producedPerMinute"`
+	Destination       OrphanedItemDestination `json:"destination"` // Best guess of where the unconsumed items go
+	Wasted            bool                    `json:"wasted"`      // True when the items are neither stored nor can be going to the sink
+}
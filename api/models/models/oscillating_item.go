@@ -0,0 +1,12 @@
+package models
+
+// OscillatingItem is an item whose production periodically starves and recovers,
+// which points to under-buffered inputs rather than a steady deficit
+type OscillatingItem struct {
+	Name          string  `json:"name"`
+	Cycles        int     `json:"cycles"`        // Number of full peak-to-peak cycles in the history
+	PeriodSeconds float64 `json:"periodSeconds"` // Average game time between peaks
+	MinPerMinute  float64 `json:"minPerMinute"`
+	MaxPerMinute  float64 `json:"maxPerMinute"`
+	MeanPerMinute float64 `json:"meanPerMinute"`
+}
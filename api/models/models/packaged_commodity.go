@@ -0,0 +1,30 @@
+package models
+
+type PackagingDirection string
+
+const (
+	PackagingDirectionPackage   PackagingDirection = "package"   // Fluid into packaged items
+	PackagingDirectionUnpackage PackagingDirection = "unpackage" // Packaged items back into fluid
+)
+
+// PackagingSite is a packager converting a commodity between its fluid and packaged form
+type PackagingSite struct {
+	Direction PackagingDirection `json:"direction"`
+	Rate      float64            `json:"rate"` // Converted per minute, 1 packaged item per m³
+	Location  `json:",inline" tstype:",extends"`
+}
+
+// PackagedCommodity combines a fluid and its packaged form, which are one commodity in two transport states
+type PackagedCommodity struct {
+	Fluid                     string          `json:"fluid"`
+	Packaged                  string          `json:"packaged"`
+	ProducedPerMinute         float64         `json:"producedPerMinute"` // Both forms, excluding conversions by packagers
+	ConsumedPerMinute         float64         `json:"consumedPerMinute"` // Both forms, excluding conversions by packagers
+	FluidProducedPerMinute    float64         `json:"fluidProducedPerMinute"`
+	FluidConsumedPerMinute    float64         `json:"fluidConsumedPerMinute"`
+	PackagedProducedPerMinute float64         `json:"packagedProducedPerMinute"`
+	PackagedConsumedPerMinute float64         `json:"packagedConsumedPerMinute"`
+	PackagedPerMinute         float64         `json:"packagedPerMinute"`   // Fluid packaged by packagers
+	UnpackagedPerMinute       float64         `json:"unpackagedPerMinute"` // Packaged items turned back into fluid by packagers
+	Packagers                 []PackagingSite `json:"packagers"`
+}
@@ -10,6 +10,8 @@ type Pipe struct {
 	SplineData     []Location `json:"splineData"`
 	Length         float64    `json:"length"`
 	ItemsPerMinute float64    `json:"itemsPerMinute"`
+	FlowRate       float64    `json:"flowRate"`    // Signed, positive from location0 to location1. Zero when FRM does not report flow
+	FillPercent    float64    `json:"fillPercent"` // 0-1. Zero when FRM does not report the fluid content
 }
 
 func (pipe *Pipe) ToDTO() PipeDTO {
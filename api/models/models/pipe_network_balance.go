@@ -0,0 +1,10 @@
+package models
+
+type PipeNetworkBalance struct {
+	JunctionIDs              []string                           `json:"junctionIds"` // Junctions joined into one network by the pipes between them
+	InflowPerMinute          float64                            `json:"inflowPerMinute"`
+	OutflowCapacityPerMinute float64                            `json:"outflowCapacityPerMinute"` // Max fluid intake of the consumers fed by the network
+	NetPerMinute             float64                            `json:"netPerMinute"`             // Inflow minus outflow capacity, positive when over-supplied
+	OverSupplied             bool                               `json:"overSupplied"`
+	Location                 `json:",inline" tstype:",extends"` // Location of the first junction
+}
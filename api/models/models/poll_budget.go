@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+type PollBudgetThrottle string
+
+const (
+	PollBudgetThrottleNone   PollBudgetThrottle = "none"   // All endpoints are polled
+	PollBudgetThrottleLow    PollBudgetThrottle = "low"    // Low-priority endpoints are skipped
+	PollBudgetThrottleNormal PollBudgetThrottle = "normal" // Only critical endpoints are polled
+	PollBudgetThrottleAll    PollBudgetThrottle = "all"    // The budget is exhausted until the window resets
+)
+
+// PollBudget is the state of the per-session cap on requests to the game server
+type PollBudget struct {
+	Limit     int                `json:"limit"` // Requests allowed per window
+	Used      int                `json:"used"`
+	Remaining int                `json:"remaining"`
+	ResetsAt  time.Time          `json:"resetsAt"`
+	Throttle  PollBudgetThrottle `json:"throttle"`
+}
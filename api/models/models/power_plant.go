@@ -0,0 +1,17 @@
+package models
+
+type PowerPlantFuel struct {
+	Name      string  `json:"name"`
+	PerMinute float64 `json:"perMinute"`
+}
+
+// PowerPlant is a cluster of nearby generators of the same type, e.g. "Coal Generator Plant A"
+type PowerPlant struct {
+	Name       string                             `json:"name"`
+	Type       MachineType                        `json:"type"`
+	Generators int                                `json:"generators"`
+	Output     float64                            `json:"output"`    // W
+	MaxOutput  float64                            `json:"maxOutput"` // W
+	Fuel       []PowerPlantFuel                   `json:"fuel"`      // Summed generator inputs, empty until generators report fuel inputs
+	Location   `json:",inline" tstype:",extends"` // Center of the generators
+}
@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+// ProdSample is the production and consumption rate of one item at a single GetProdStats poll
+type ProdSample struct {
+	Timestamp         time.Time `json:"timestamp"`
+	ProducedPerMinute float64   `json:"producedPerMinute"`
+	ConsumedPerMinute float64   `json:"consumedPerMinute"`
+}
@@ -3,13 +3,15 @@ package models
 type ItemProdStats struct {
 	ItemStats `json:",inline" tstype:",extends"`
 
-	ProducedPerMinute   float64 `json:"producedPerMinute"`
-	MaxProducePerMinute float64 `json:"maxProducePerMinute"`
-	ProduceEfficiency   float64 `json:"produceEfficiency"`
+	ProducedPerMinute    float64  `json:"producedPerMinute"`
+	ProducedPerMinuteRaw *float64 `json:"producedPerMinuteRaw,omitempty"` // Unsmoothed rate, set only when rate smoothing is enabled
+	MaxProducePerMinute  float64  `json:"maxProducePerMinute"`
+	ProduceEfficiency    float64  `json:"produceEfficiency"`
 
-	ConsumedPerMinute   float64 `json:"consumedPerMinute"`
-	MaxConsumePerMinute float64 `json:"maxConsumePerMinute"`
-	ConsumeEfficiency   float64 `json:"consumeEfficiency"`
+	ConsumedPerMinute    float64  `json:"consumedPerMinute"`
+	ConsumedPerMinuteRaw *float64 `json:"consumedPerMinuteRaw,omitempty"` // Unsmoothed rate, set only when rate smoothing is enabled
+	MaxConsumePerMinute  float64  `json:"maxConsumePerMinute"`
+	ConsumeEfficiency    float64  `json:"consumeEfficiency"`
 
 	CloudCount float64 `json:"cloudCount"`
 
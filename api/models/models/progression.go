@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+type ProgressionKind string
+
+const (
+	ProgressionSchematicUnlocked  ProgressionKind = "schematicUnlocked"
+	ProgressionMilestoneCompleted ProgressionKind = "milestoneCompleted"
+	ProgressionPhaseCompleted     ProgressionKind = "phaseCompleted"
+)
+
+// Progression is a schematic, milestone or space elevator phase transition between two polls
+type Progression struct {
+	Kind      ProgressionKind `json:"kind"`
+	Name      string          `json:"name"`           // Schematic name, or the delivered objectives of a phase
+	Tier      int             `json:"tier,omitempty"` // Tech tier, not set for phases
+	Timestamp time.Time       `json:"timestamp"`
+}
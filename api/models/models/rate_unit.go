@@ -0,0 +1,9 @@
+package models
+
+// RateUnit is the unit a transfer rate is reported in by FRM
+type RateUnit string
+
+const (
+	RateUnitStacksPerSecond RateUnit = "stacks/sec"
+	RateUnitItemsPerMinute  RateUnit = "items/min"
+)
@@ -0,0 +1,13 @@
+package models
+
+// RecipeRatioIssue is a machine running below capacity because one input arrives too slowly while
+// the others pile up, e.g. plenty of screws but too few iron plates for reinforced iron plates
+type RecipeRatioIssue struct {
+	MachineType   MachineType `json:"machineType"`
+	Product       string      `json:"product"`
+	Productivity  float64     `json:"productivity"`  // 0-1
+	Limiter       string      `json:"limiter"`       // Input holding the machine back
+	LimiterBuffer float64     `json:"limiterBuffer"` // Minutes of the limiter buffered at full speed
+	Oversupplied  []string    `json:"oversupplied"`  // Inputs buffered well beyond what the limiter allows
+	Location      `json:",inline" tstype:",extends"`
+}
@@ -0,0 +1,14 @@
+package models
+
+type ResourceCapacity struct {
+	ResourceType   ResourceType `json:"resourceType"`
+	ExploitedNodes int          `json:"exploitedNodes"`
+	AvailableNodes int          `json:"availableNodes"`
+
+	AvailableImpure int `json:"availableImpure"`
+	AvailableNormal int `json:"availableNormal"`
+	AvailablePure   int `json:"availablePure"`
+
+	CurrentPerMinute    float64 `json:"currentPerMinute"`    // Output of extractors currently producing the resource
+	AdditionalPerMinute float64 `json:"additionalPerMinute"` // Extra output if every available node was exploited at the given miner tier
+}
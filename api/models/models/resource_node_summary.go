@@ -0,0 +1,14 @@
+package models
+
+type PurityCounts struct {
+	Impure int `json:"impure"`
+	Normal int `json:"normal"`
+	Pure   int `json:"pure"`
+	Total  int `json:"total"`
+}
+
+type ResourceNodeSummary struct {
+	ResourceType ResourceType `json:"resourceType"`
+	Exploited    PurityCounts `json:"exploited"`
+	Unexploited  PurityCounts `json:"unexploited"`
+}
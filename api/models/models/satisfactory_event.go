@@ -3,31 +3,41 @@ package models
 type SatisfactoryEventType string
 
 const (
-	SatisfactoryEventApiStatus       SatisfactoryEventType = "satisfactoryApiCheck"
-	SatisfactoryEventCircuits        SatisfactoryEventType = "circuits"
-	SatisfactoryEventFactoryStats    SatisfactoryEventType = "factoryStats"
-	SatisfactoryEventProdStats       SatisfactoryEventType = "prodStats"
-	SatisfactoryEventSinkStats       SatisfactoryEventType = "sinkStats"
-	SatisfactoryEventPlayers         SatisfactoryEventType = "players"
-	SatisfactoryEventGeneratorStats  SatisfactoryEventType = "generatorStats"
-	SatisfactoryEventVehicles        SatisfactoryEventType = "vehicles"
-	SatisfactoryEventVehicleStations SatisfactoryEventType = "vehicleStations"
-	SatisfactoryEventSessionUpdate   SatisfactoryEventType = "sessionUpdate"
-	SatisfactoryEventBelts           SatisfactoryEventType = "belts"
-	SatisfactoryEventPipes           SatisfactoryEventType = "pipes"
-	SatisfactoryEventTrainRails      SatisfactoryEventType = "trainRails"
-	SatisfactoryEventCables          SatisfactoryEventType = "cables"
-	SatisfactoryEventStorages        SatisfactoryEventType = "storages"
-	SatisfactoryEventMachines        SatisfactoryEventType = "machines"
-	SatisfactoryEventTractors        SatisfactoryEventType = "tractors"
-	SatisfactoryEventExplorers       SatisfactoryEventType = "explorers"
-	SatisfactoryEventVehiclePaths    SatisfactoryEventType = "vehiclePaths"
-	SatisfactoryEventSpaceElevator   SatisfactoryEventType = "spaceElevator"
-	SatisfactoryEventHub             SatisfactoryEventType = "hub"
-	SatisfactoryEventRadarTowers     SatisfactoryEventType = "radarTowers"
-	SatisfactoryEventResourceNodes   SatisfactoryEventType = "resourceNodes"
-	SatisfactoryEventHypertubes      SatisfactoryEventType = "hypertubes"
-	SatisfactoryEventSchematics      SatisfactoryEventType = "schematics"
+	SatisfactoryEventApiStatus         SatisfactoryEventType = "satisfactoryApiCheck"
+	SatisfactoryEventCircuits          SatisfactoryEventType = "circuits"
+	SatisfactoryEventFactoryStats      SatisfactoryEventType = "factoryStats"
+	SatisfactoryEventProdStats         SatisfactoryEventType = "prodStats"
+	SatisfactoryEventSinkStats         SatisfactoryEventType = "sinkStats"
+	SatisfactoryEventPlayers           SatisfactoryEventType = "players"
+	SatisfactoryEventGeneratorStats    SatisfactoryEventType = "generatorStats"
+	SatisfactoryEventVehicles          SatisfactoryEventType = "vehicles"
+	SatisfactoryEventVehicleStations   SatisfactoryEventType = "vehicleStations"
+	SatisfactoryEventSessionUpdate     SatisfactoryEventType = "sessionUpdate"
+	SatisfactoryEventBelts             SatisfactoryEventType = "belts"
+	SatisfactoryEventPipes             SatisfactoryEventType = "pipes"
+	SatisfactoryEventTrainRails        SatisfactoryEventType = "trainRails"
+	SatisfactoryEventCables            SatisfactoryEventType = "cables"
+	SatisfactoryEventStorages          SatisfactoryEventType = "storages"
+	SatisfactoryEventMachines          SatisfactoryEventType = "machines"
+	SatisfactoryEventTractors          SatisfactoryEventType = "tractors"
+	SatisfactoryEventExplorers         SatisfactoryEventType = "explorers"
+	SatisfactoryEventVehiclePaths      SatisfactoryEventType = "vehiclePaths"
+	SatisfactoryEventSpaceElevator     SatisfactoryEventType = "spaceElevator"
+	SatisfactoryEventHub               SatisfactoryEventType = "hub"
+	SatisfactoryEventRadarTowers       SatisfactoryEventType = "radarTowers"
+	SatisfactoryEventResourceNodes     SatisfactoryEventType = "resourceNodes"
+	SatisfactoryEventHypertubes        SatisfactoryEventType = "hypertubes"
+	SatisfactoryEventSchematics        SatisfactoryEventType = "schematics"
+	SatisfactoryEventBaseScore         SatisfactoryEventType = "baseScore"
+	SatisfactoryEventRemoved           SatisfactoryEventType = "removed"
+	SatisfactoryEventDiagnostics       SatisfactoryEventType = "diagnostics"
+	SatisfactoryEventStalledVehicles   SatisfactoryEventType = "stalledVehicles"
+	SatisfactoryEventIdleConveyors     SatisfactoryEventType = "idleConveyors"
+	SatisfactoryEventStuckStorageItems SatisfactoryEventType = "stuckStorageItems"
+	SatisfactoryEventPollBudget        SatisfactoryEventType = "pollBudget"
+	SatisfactoryEventProgression       SatisfactoryEventType = "progression"
+	SatisfactoryEventShipTimer         SatisfactoryEventType = "shipTimer"
+	SatisfactoryEventAlerts            SatisfactoryEventType = "alerts"
 
 	SatisfactoryEventKey string = "satisfactory_events"
 )
@@ -35,7 +45,27 @@ const (
 type SatisfactoryEvent struct {
 	Type       SatisfactoryEventType `json:"type"`
 	Data       any                   `json:"data"`
-	GameTimeID int64                 `json:"gameTimeId"` // Game time when event was captured (0 for non-history types)
+	GameTimeID int64                 `json:"gameTimeId"`           // Game time when event was captured (0 for non-history types)
+	Partial    bool                  `json:"partial,omitempty"`    // Set when part of the data could not be fetched, see PartialDataError
+	Truncated  bool                  `json:"truncated,omitempty"`  // Set when the entity lists were capped to the configured maximum
+	TotalCount int                   `json:"totalCount,omitempty"` // Number of entities before capping, only set when truncated
+	Filtered   int                   `json:"filtered,omitempty"`   // Entities dropped for lying outside the valid coordinate bounds
+	Delta      bool                  `json:"delta,omitempty"`      // Set when Data is an EventDelta, only on streams opened in delta mode
+	Session    *EventSession         `json:"session,omitempty"`    // Session the event belongs to, set when published
+}
+
+// EventSession identifies the session of an event, so consumers can show its label without a lookup
+type EventSession struct {
+	ID       string            `json:"id"`
+	Label    string            `json:"label"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// RemovedEntities lists entities that were present in the previous poll of a list-based event but are gone now
+type RemovedEntities struct {
+	EventType SatisfactoryEventType `json:"eventType"` // Event the entities were listed in
+	Kind      string                `json:"kind"`      // Field of the event data holding the entities, e.g. belts or splitterMergers
+	IDs       []string              `json:"ids"`       // Entity IDs, or the name (location for machines) for entities without an ID
 }
 
 type SseSatisfactoryEvent struct {
@@ -0,0 +1,10 @@
+package models
+
+// ScaledValue is a display hint for a raw value, scaled to the largest SI prefix that keeps it at or above 1
+type ScaledValue struct {
+	Raw     float64 `json:"raw"`
+	Value   float64 `json:"value"`   // Raw divided by the prefix multiplier
+	Prefix  string  `json:"prefix"`  // "", "k", "M" or "G"
+	Unit    string  `json:"unit"`    // Unit of the raw value
+	Display string  `json:"display"` // Value, prefix and unit ready for rendering, e.g. "2.3 GW"
+}
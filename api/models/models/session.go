@@ -1,6 +1,9 @@
 package models
 
-import "time"
+import (
+	"sort"
+	"time"
+)
 
 // SessionStage represents the initialization stage of a session
 type SessionStage string
@@ -27,15 +30,18 @@ var RequiredEventTypes = []SatisfactoryEventType{
 
 // Session represents a Satisfactory server connection target
 type Session struct {
-	ID                  string    `json:"id"`             // UUID
-	Name                string    `json:"name"`           // User-provided display name
-	Address             string    `json:"address"`        // IP:port (e.g., "192.168.1.100:8080")
-	SessionName         string    `json:"sessionName"`    // From getSessionInfo API
-	IsOnline            bool      `json:"isOnline"`       // Current connection status
-	IsPaused            bool      `json:"isPaused"`       // True if polling is paused by user
-	IsDisconnected      bool      `json:"isDisconnected"` // True if session has failed to connect multiple times
-	ConsecutiveFailures int       `json:"-"`              // Transient counter for consecutive connection failures
-	CreatedAt           time.Time `json:"createdAt"`
+	ID                  string            `json:"id"`                 // UUID
+	Name                string            `json:"name"`               // User-provided display name
+	Address             string            `json:"address"`            // IP:port (e.g., "192.168.1.100:8080")
+	Headers             map[string]string `json:"headers,omitempty"`  // Sent with every FRM request, e.g. Authorization for a reverse proxy
+	Label               string            `json:"label"`              // Set on creation, defaults to the name, carried by every event
+	Metadata            map[string]string `json:"metadata,omitempty"` // Set on creation, e.g. region or owner, carried by every event
+	SessionName         string            `json:"sessionName"`        // From getSessionInfo API
+	IsOnline            bool              `json:"isOnline"`           // Current connection status
+	IsPaused            bool              `json:"isPaused"`           // True if polling is paused by user
+	IsDisconnected      bool              `json:"isDisconnected"`     // True if session has failed to connect multiple times
+	ConsecutiveFailures int               `json:"-"`                  // Transient counter for consecutive connection failures
+	CreatedAt           time.Time         `json:"createdAt"`
 }
 
 // SessionInfoRaw represents the raw response from Satisfactory's getSessionInfo endpoint (PascalCase)
@@ -90,28 +96,35 @@ func (r *SessionInfoRaw) ToDTO() *SessionInfo {
 
 // CreateSessionRequest is the request body for creating a new session
 type CreateSessionRequest struct {
-	Name    string `json:"name" binding:"required"`
-	Address string `json:"address" binding:"required"`
+	Name     string            `json:"name" binding:"required"`
+	Address  string            `json:"address" binding:"required"`
+	Headers  map[string]string `json:"headers,omitempty"`
+	Label    string            `json:"label,omitempty"`    // Defaults to the name, cannot be changed later
+	Metadata map[string]string `json:"metadata,omitempty"` // Cannot be changed later
 }
 
 // UpdateSessionRequest is the request body for updating a session (all fields optional)
 type UpdateSessionRequest struct {
-	Name     *string `json:"name,omitempty"`
-	IsPaused *bool   `json:"isPaused,omitempty"`
-	Address  *string `json:"address,omitempty"`
+	Name     *string            `json:"name,omitempty"`
+	IsPaused *bool              `json:"isPaused,omitempty"`
+	Address  *string            `json:"address,omitempty"`
+	Headers  *map[string]string `json:"headers,omitempty"` // Replaces the whole header set, an empty object clears it
 }
 
 // SessionDTO is the data transfer object for Session with computed fields
 type SessionDTO struct {
-	ID             string       `json:"id"`
-	Name           string       `json:"name"`
-	Address        string       `json:"address"`
-	SessionName    string       `json:"sessionName"`
-	IsOnline       bool         `json:"isOnline"`
-	IsPaused       bool         `json:"isPaused"`
-	IsDisconnected bool         `json:"isDisconnected"` // True if session is in disconnected state
-	CreatedAt      time.Time    `json:"createdAt"`
-	Stage          SessionStage `json:"stage"`
+	ID             string            `json:"id"`
+	Name           string            `json:"name"`
+	Address        string            `json:"address"`
+	SessionName    string            `json:"sessionName"`
+	IsOnline       bool              `json:"isOnline"`
+	IsPaused       bool              `json:"isPaused"`
+	IsDisconnected bool              `json:"isDisconnected"` // True if session is in disconnected state
+	HeaderNames    []string          `json:"headerNames"`    // Names of the configured FRM request headers, values are never exposed
+	Label          string            `json:"label"`
+	Metadata       map[string]string `json:"metadata"`
+	CreatedAt      time.Time         `json:"createdAt"`
+	Stage          SessionStage      `json:"stage"`
 }
 
 // ToDTO converts Session to SessionDTO with computed stage field
@@ -124,7 +137,29 @@ func (s *Session) ToDTO(stage SessionStage) SessionDTO {
 		IsOnline:       s.IsOnline,
 		IsPaused:       s.IsPaused,
 		IsDisconnected: s.IsDisconnected,
+		HeaderNames:    s.HeaderNames(),
+		Label:          s.Label,
+		Metadata:       s.Metadata,
 		CreatedAt:      s.CreatedAt,
 		Stage:          stage,
 	}
 }
+
+// HeaderNames returns the names of the configured FRM request headers, sorted
+func (s *Session) HeaderNames() []string {
+	names := make([]string, 0, len(s.Headers))
+	for name := range s.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// EventSession returns the session details attached to every event of the session
+func (s *Session) EventSession() *EventSession {
+	return &EventSession{
+		ID:       s.ID,
+		Label:    s.Label,
+		Metadata: s.Metadata,
+	}
+}
@@ -0,0 +1,15 @@
+package models
+
+type ShipTimerState string
+
+const (
+	ShipTimerStateLaunched ShipTimerState = "launched"
+	ShipTimerStateDocked   ShipTimerState = "docked"
+)
+
+// ShipTimer announces a hub ship launch or return, so clients can run the return countdown locally
+type ShipTimer struct {
+	State      ShipTimerState `json:"state"`
+	ReturnTime *int64         `json:"returnTime,omitempty"` // Unix timestamp (ms) when the ship returns, only set when launched
+	Corrected  bool           `json:"corrected,omitempty"`  // Set when re-emitted because the countdown drifted from the game
+}
@@ -0,0 +1,28 @@
+package models
+
+type SinkCompositionConfidence string
+
+const (
+	SinkCompositionConfidenceHigh   SinkCompositionConfidence = "high"   // Estimate within 25% of the measured points
+	SinkCompositionConfidenceMedium SinkCompositionConfidence = "medium" // Estimate within a factor of two
+	SinkCompositionConfidenceLow    SinkCompositionConfidence = "low"
+)
+
+// SinkFeedItem is an item estimated to be fed into the AWESOME sink
+type SinkFeedItem struct {
+	Name             string   `json:"name"`
+	SurplusPerMinute float64  `json:"surplusPerMinute"`        // Produced minus consumed
+	PointsPerItem    *float64 `json:"pointsPerItem,omitempty"` // Sink value, null if unknown
+	PointsPerMinute  float64  `json:"pointsPerMinute"`         // Estimated contribution, scaled to the measured sink rate
+	Share            float64  `json:"share"`                   // 0-1 of the measured sink rate
+	Stored           bool     `json:"stored"`                  // Also found in storage, so part of the surplus may fill containers instead
+}
+
+// SinkComposition estimates what is going into the AWESOME sink, as the game only reports total points
+type SinkComposition struct {
+	PointsPerMinute          float64                   `json:"pointsPerMinute"`          // Measured sink rate
+	EstimatedPointsPerMinute float64                   `json:"estimatedPointsPerMinute"` // Sum of surplus times sink value, before scaling
+	Confidence               SinkCompositionConfidence `json:"confidence"`
+	Note                     string                    `json:"note"`
+	Items                    []SinkFeedItem            `json:"items"`
+}
@@ -1,17 +1,21 @@
 package models
 
 type SpaceElevatorPhaseObjective struct {
-	Name      string  `json:"name"`
-	Amount    float64 `json:"amount"`
-	TotalCost float64 `json:"totalCost"`
+	Name                string  `json:"name"`
+	Amount              float64 `json:"amount"`
+	TotalCost           float64 `json:"totalCost"`
+	DeliveryRate        float64 `json:"deliveryRate"`        // Items per minute submitted since the previous poll
+	EstimatedCompletion float64 `json:"estimatedCompletion"` // Seconds until done at the current rate, zero when no progress is detected
+	Stalled             bool    `json:"stalled"`             // Unfinished and nothing was submitted since the previous poll
 }
 
 type SpaceElevator struct {
-	ID            string                        `json:"id"`
-	Name          string                        `json:"name"`
-	BoundingBox   BoundingBox                   `json:"boundingBox"`
-	CurrentPhase  []SpaceElevatorPhaseObjective `json:"currentPhase"`
-	FullyUpgraded bool                          `json:"fullyUpgraded"`
-	UpgradeReady  bool                          `json:"upgradeReady"`
-	Location      `json:",inline" tstype:",extends"`
+	ID                  string                        `json:"id"`
+	Name                string                        `json:"name"`
+	BoundingBox         BoundingBox                   `json:"boundingBox"`
+	CurrentPhase        []SpaceElevatorPhaseObjective `json:"currentPhase"`
+	FullyUpgraded       bool                          `json:"fullyUpgraded"`
+	UpgradeReady        bool                          `json:"upgradeReady"`
+	EstimatedCompletion float64                       `json:"estimatedCompletion"` // Seconds until the slowest objective is done, zero while any unfinished objective has no progress
+	Location            `json:",inline" tstype:",extends"`
 }
@@ -9,9 +9,26 @@ const (
 	SplitterMergerTypeSmartSplitter        SplitterMergerType = "Smart Splitter"
 )
 
+type SplitterOutput string
+
+const (
+	SplitterOutputLeft   SplitterOutput = "left"
+	SplitterOutputCenter SplitterOutput = "center"
+	SplitterOutputRight  SplitterOutput = "right"
+)
+
+// SplitterOutputFilter is an item a smart or programmable splitter routes to one of its outputs.
+// Item may also be a wildcard such as Any, None or Overflow.
+type SplitterOutputFilter struct {
+	Output    SplitterOutput `json:"output"`
+	Item      string         `json:"item"`
+	ItemClass string         `json:"itemClass"`
+}
+
 type SplitterMerger struct {
 	ID          string             `json:"id"`
 	Type        SplitterMergerType `json:"type"`
 	Location    `json:",inline" tstype:",extends"`
-	BoundingBox BoundingBox `json:"boundingBox"`
+	BoundingBox BoundingBox            `json:"boundingBox"`
+	Filters     []SplitterOutputFilter `json:"filters"` // Empty for plain splitters and mergers
 }
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+type StalledVehicleKind string
+
+const (
+	StalledVehicleKindTruck    StalledVehicleKind = "truck"
+	StalledVehicleKindTractor  StalledVehicleKind = "tractor"
+	StalledVehicleKindExplorer StalledVehicleKind = "explorer"
+	StalledVehicleKindDrone    StalledVehicleKind = "drone"
+)
+
+// StalledVehicle is a self-driving vehicle standing still away from any station, e.g. on a blocked path or out of fuel
+type StalledVehicle struct {
+	ID           string             `json:"id"` // Name for drones, which have no ID
+	Name         string             `json:"name"`
+	Kind         StalledVehicleKind `json:"kind"`
+	StalledSince time.Time          `json:"stalledSince"`
+	Location     `json:",inline" tstype:",extends"`
+}
@@ -7,6 +7,7 @@ type State struct {
 	ProdStats      ProdStats      `json:"prodStats"`
 	GeneratorStats GeneratorStats `json:"generatorStats"`
 	SinkStats      SinkStats      `json:"sinkStats"`
+	BaseScore      *BaseScore     `json:"baseScore"`
 
 	Circuits      []Circuit      `json:"circuits"`
 	Players       []Player       `json:"players"`
@@ -33,6 +34,13 @@ type State struct {
 	RadarTowers        []RadarTower        `json:"radarTowers"`
 	ResourceNodes      []ResourceNode      `json:"resourceNodes"`
 	Schematics         []Schematic         `json:"schematics"`
+	Diagnostics        []EndpointError     `json:"diagnostics"`
+	StalledVehicles    []StalledVehicle    `json:"stalledVehicles"`
+	IdleConveyors      []IdleConveyor      `json:"idleConveyors"`
+	StuckStorageItems  []StuckStorageItem  `json:"stuckStorageItems"`
+	PollBudget         *PollBudget         `json:"pollBudget"`
+	ShipTimer          *ShipTimer          `json:"shipTimer"`
+	Alerts             []Alert             `json:"alerts"`
 }
 
 func (state *State) ToDTO() StateDTO {
@@ -11,9 +11,27 @@ const (
 )
 
 type Storage struct {
-	ID          string      `json:"id"`
-	Type        StorageType `json:"type"`
-	Inventory   []ItemStats `json:"inventory"`
-	BoundingBox BoundingBox `json:"boundingBox"`
+	ID          string             `json:"id"`
+	Type        StorageType        `json:"type"`
+	Inventory   []ItemStats        `json:"inventory"`
+	BoundingBox BoundingBox        `json:"boundingBox"`
+	FillPercent float64            `json:"fillPercent"` // 0-1 of the summed MaxAmount of the inventory items. Zero when no capacity is reported
+	Capacities  map[string]float64 `json:"capacities"`  // Summed MaxAmount per item name of the inventory
 	Location    `json:",inline" tstype:",extends"`
 }
+
+type StorageItemTotal struct {
+	Name        string  `json:"name"`
+	Stored      float64 `json:"stored"`
+	Capacity    float64 `json:"capacity"`
+	FillPercent float64 `json:"fillPercent"` // 0-1
+}
+
+type StorageSummary struct {
+	Type        StorageType        `json:"type"`
+	Containers  int                `json:"containers"`
+	Stored      float64            `json:"stored"`
+	Capacity    float64            `json:"capacity"`
+	FillPercent float64            `json:"fillPercent"` // 0-1
+	Items       []StorageItemTotal `json:"items"`
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// StuckStorageItem is an item piling up in storage while the machines consuming it starve,
+// which usually means a belt or routing gap between the storage and those consumers
+type StuckStorageItem struct {
+	Name               string    `json:"name"`
+	Stored             float64   `json:"stored"`             // Amount across storage containers
+	GrowthPerMinute    float64   `json:"growthPerMinute"`    // Stored amount growth since it started growing
+	Consumers          int       `json:"consumers"`          // Machines taking the item as input
+	ConsumerEfficiency float64   `json:"consumerEfficiency"` // Average input efficiency of those machines, 0-1
+	Since              time.Time `json:"since"`              // When growth and starvation both started
+}
@@ -0,0 +1,16 @@
+package models
+
+type TechTierProgress struct {
+	Tier              int         `json:"tier"`
+	Total             int         `json:"total"`
+	Purchased         int         `json:"purchased"`
+	CompletionPercent float64     `json:"completionPercent"` // 0-1 of the tier's milestones purchased
+	Schematics        []Schematic `json:"schematics"`
+}
+
+type TechProgress struct {
+	Tiers       []TechTierProgress `json:"tiers"`
+	Unlockable  []Schematic        `json:"unlockable"`  // Not purchased and not locked, can be selected at the HUB now
+	PhaseLocked []Schematic        `json:"phaseLocked"` // Waiting for a space elevator phase
+	TierLocked  []Schematic        `json:"tierLocked"`  // Locked for another reason, e.g. a previous tier
+}
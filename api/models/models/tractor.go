@@ -13,6 +13,7 @@ type Tractor struct {
 	ID         string        `json:"id"`
 	Name       string        `json:"name"`
 	Speed      float64       `json:"speed"`
+	SpeedRaw   *float64      `json:"speedRaw,omitempty"` // Unsmoothed speed, set only when rate smoothing is enabled
 	Status     TractorStatus `json:"status"`
 	Fuel       *Fuel         `json:"fuel,omitempty"`
 	Inventory  []ItemStats   `json:"inventory"`
@@ -25,18 +25,21 @@ type TrainVehicle struct {
 }
 
 type TrainTimetableEntry struct {
-	Station string `json:"station"`
+	Station  string    `json:"station"`
+	Location *Location `json:"location"` // Nearest station with this name, nil if no such station exists
 }
 
 type Train struct {
 	ID               string                `json:"id"`
 	Name             string                `json:"name"`
 	Speed            float64               `json:"speed"`
+	SpeedRaw         *float64              `json:"speedRaw,omitempty"` // Unsmoothed speed, set only when rate smoothing is enabled
 	Status           TrainStatus           `json:"status"`
 	PowerConsumption float64               `json:"powerConsumption"`
 	Vehicles         []TrainVehicle        `json:"vehicles"`
 	Timetable        []TrainTimetableEntry `json:"timetable"`
 	TimetableIndex   int                   `json:"timetableIndex"`
+	CycleSeconds     *float64              `json:"cycleSeconds,omitempty"` // Estimated loop time of the timetable, set only by the trains route when the rails connect every stop
 	Location         `json:",inline" tstype:",extends"`
 	CircuitIDs       `json:",inline" tstype:",extends"`
 }
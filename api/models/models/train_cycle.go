@@ -0,0 +1,11 @@
+package models
+
+// TrainCycle is the estimated time a train takes for one loop of its timetable
+type TrainCycle struct {
+	TrainID        string   `json:"trainId"`
+	TrainName      string   `json:"trainName"`
+	Stops          int      `json:"stops"`
+	DistanceMeters float64  `json:"distanceMeters"`         // Rail distance of one loop
+	CycleSeconds   *float64 `json:"cycleSeconds,omitempty"` // Travel at cruising speed plus a docking allowance per stop, nil if it could not be estimated
+	Error          string   `json:"error,omitempty"`        // Why the cycle could not be estimated
+}
@@ -0,0 +1,16 @@
+package models
+
+// TrainPlatformMismatch is a docked train whose car composition does not fit the platforms of its station,
+// which leaves cargo untransferred on every stop
+type TrainPlatformMismatch struct {
+	TrainID                string `json:"trainId"`
+	TrainName              string `json:"trainName"`
+	Station                string `json:"station"`
+	Locomotives            int    `json:"locomotives"`
+	FreightCars            int    `json:"freightCars"`
+	Platforms              int    `json:"platforms"`
+	UnservedPlatforms      int    `json:"unservedPlatforms"`      // Platforms past the end of the train
+	UnservedCars           int    `json:"unservedCars"`           // Freight cars past the last platform
+	LocomotivesAtPlatforms int    `json:"locomotivesAtPlatforms"` // Locomotives lined up with a platform instead of a freight car
+	Underpowered           bool   `json:"underpowered"`           // More freight cars per locomotive than can keep up speed
+}
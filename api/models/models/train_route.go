@@ -0,0 +1,20 @@
+package models
+
+type TrainRouteLoad string
+
+const (
+	TrainRouteLoadOverTrained  TrainRouteLoad = "overTrained"  // Trains spend long docked without transferring, waiting on each other
+	TrainRouteLoadUnderTrained TrainRouteLoad = "underTrained" // Platforms sit full or empty waiting for a train
+	TrainRouteLoadBalanced     TrainRouteLoad = "balanced"
+)
+
+type TrainRoute struct {
+	Stations           []string       `json:"stations"` // Timetable station sequence shared by the trains
+	TrainIDs           []string       `json:"trainIds"`
+	TrainCount         int            `json:"trainCount"`
+	DockCount          int            `json:"dockCount"`          // Completed dock visits at the route's stations
+	AverageDockTime    float64        `json:"averageDockTime"`    // Seconds, weighted by dock count
+	AverageBlockedTime float64        `json:"averageBlockedTime"` // Seconds docked without transferring, weighted by dock count
+	StalledPlatforms   int            `json:"stalledPlatforms"`   // Full export or empty import platforms at the route's stations
+	Load               TrainRouteLoad `json:"load"`
+}
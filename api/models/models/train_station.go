@@ -28,16 +28,28 @@ type TrainStationPlatform struct {
 	Status       TrainStationPlatformStatus `json:"status"`
 	BoundingBox  BoundingBox                `json:"boundingBox"`
 	Inventory    []ItemStats                `json:"inventory"`
+	Capacity     float64                    `json:"capacity"`     // Total inventory capacity, 0 if unknown
+	Fill         float64                    `json:"fill"`         // 0-1
+	Stalled      bool                       `json:"stalled"`      // Full export or empty import platform
 	TransferRate float64                    `json:"transferRate"` // Solid items rate
 	InflowRate   float64                    `json:"inflowRate"`   // Fluid incoming rate
 	OutflowRate  float64                    `json:"outflowRate"`  // Fluid outgoing rate
 	Location     `json:",inline" tstype:",extends"`
 }
 
+type TrainStationDockStats struct {
+	DockCount          int     `json:"dockCount"`
+	AverageDockTime    float64 `json:"averageDockTime"`    // Seconds
+	LastDockTime       float64 `json:"lastDockTime"`       // Seconds
+	AverageLoadingTime float64 `json:"averageLoadingTime"` // Seconds spent with a platform transferring cargo
+	AverageBlockedTime float64 `json:"averageBlockedTime"` // Seconds docked without transferring, e.g. waiting on a signal
+}
+
 type TrainStation struct {
 	Name        string                 `json:"name"`
 	BoundingBox BoundingBox            `json:"boundingBox"`
 	Platforms   []TrainStationPlatform `json:"platforms"`
+	DockStats   TrainStationDockStats  `json:"dockStats"`
 	Location    `json:",inline" tstype:",extends"`
 	CircuitIDs  `json:",inline" tstype:",extends"`
 }
@@ -1,12 +1,13 @@
 package models
 
 type TruckStation struct {
-	Name            string      `json:"name"`
-	BoundingBox     BoundingBox `json:"boundingBox"`
-	TransferRate    float64     `json:"transferRate"`    // Current transfer rate
-	MaxTransferRate float64     `json:"maxTransferRate"` // Max stacks/sec for all vehicles
-	Inventory       []ItemStats `json:"inventory"`       // Station inventory
-	CircuitID       int         `json:"circuitId"`
-	Location        `json:",inline" tstype:",extends"`
-	CircuitIDs      `json:",inline" tstype:",extends"`
+	Name             string      `json:"name"`
+	BoundingBox      BoundingBox `json:"boundingBox"`
+	TransferRate     float64     `json:"transferRate"`     // Current transfer rate
+	MaxTransferRate  float64     `json:"maxTransferRate"`  // Max stacks/sec for all vehicles
+	TransferRateUnit RateUnit    `json:"transferRateUnit"` // Unit of TransferRate and MaxTransferRate
+	Inventory        []ItemStats `json:"inventory"`        // Station inventory
+	CircuitID        int         `json:"circuitId"`
+	Location         `json:",inline" tstype:",extends"`
+	CircuitIDs       `json:",inline" tstype:",extends"`
 }
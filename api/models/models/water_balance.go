@@ -0,0 +1,13 @@
+package models
+
+type WaterBalance struct {
+	ProducedPerMinute        float64 `json:"producedPerMinute"`        // Summed Water outputs, mostly water extractors
+	ConsumedPerMinute        float64 `json:"consumedPerMinute"`        // Summed Water inputs
+	NetPerMinute             float64 `json:"netPerMinute"`             // Produced minus consumed
+	Producers                int     `json:"producers"`                // Machines outputting Water
+	Consumers                int     `json:"consumers"`                // Machines taking Water as input
+	NuclearConsumedPerMinute float64 `json:"nuclearConsumedPerMinute"` // Part of the consumption going to nuclear power plants
+	Deficit                  bool    `json:"deficit"`                  // Consumption exceeds production
+	NuclearThrottled         bool    `json:"nuclearThrottled"`         // The deficit reaches nuclear power plants
+	AffectedPower            float64 `json:"affectedPower"`            // W, estimated nuclear output lost to the deficit
+}
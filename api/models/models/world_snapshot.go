@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// WorldSnapshot is the full state of a game server fetched in one go. Sections that could not be
+// fetched are left empty and their error is kept in Errors, keyed by section name.
+type WorldSnapshot struct {
+	TakenAt         time.Time         `json:"takenAt"`
+	Machines        []Machine         `json:"machines"`
+	Circuits        []Circuit         `json:"circuits"`
+	ProdStats       *ProdStats        `json:"prodStats"`
+	Vehicles        Vehicles          `json:"vehicles"`
+	VehicleStations VehicleStations   `json:"vehicleStations"`
+	Belts           Belts             `json:"belts"`
+	Pipes           Pipes             `json:"pipes"`
+	Storages        []Storage         `json:"storages"`
+	RadarTowers     []RadarTower      `json:"radarTowers"`
+	SpaceElevator   *SpaceElevator    `json:"spaceElevator"`
+	Hub             *Hub              `json:"hub"`
+	Schematics      []Schematic       `json:"schematics"`
+	Errors          map[string]string `json:"errors,omitempty"`
+}
+
+// WorldSnapshotLine is one line of a JSON Lines world snapshot export. The first line only carries
+// TakenAt, then every entity of a list section is a line of its own so huge sections are streamed,
+// and a section that failed to fetch is a single line carrying its Error.
+type WorldSnapshotLine struct {
+	Section string     `json:"section"`
+	TakenAt *time.Time `json:"takenAt,omitempty"`
+	Data    any        `json:"data,omitempty"`
+	Error   string     `json:"error,omitempty"`
+}
@@ -5,18 +5,63 @@ var (
 )
 
 type Type struct {
-	Port                  int    `json:"port"`
-	Mode                  string `json:"mode"`
-	ExternalURL           string `json:"externalUrl"`
-	Filepath              string `json:"filepath"`
-	NodeName              string `json:"nodeName"` // If set, uses this instead of GenerateInstanceID()
-	MaxSampleGameDuration int64  `json:"maxSampleGameDuration"`
+	Port                     int      `json:"port"`
+	Mode                     string   `json:"mode"`
+	ExternalURL              string   `json:"externalUrl"`
+	Filepath                 string   `json:"filepath"`
+	NodeName                 string   `json:"nodeName"` // If set, uses this instead of GenerateInstanceID()
+	MaxSampleGameDuration    int64    `json:"maxSampleGameDuration"`
+	ClientType               string   `json:"clientType"`               // Backend of sessions whose address has no playback:// or mock:// scheme: frm (default) or mock
+	MaxConcurrentRequests    int      `json:"maxConcurrentRequests"`    // Max in-flight FRM requests per session, defaults to 4
+	RequestQueueConcurrency  int      `json:"requestQueueConcurrency"`  // Polls run at once per session, one worker is kept free of low priority polls, defaults to 2
+	ApiDownThreshold         int      `json:"apiDownThreshold"`         // Consecutive failed status checks before reporting the API down, defaults to 3
+	ApiUpThreshold           int      `json:"apiUpThreshold"`           // Consecutive successful status checks before reporting the API up again, defaults to 2
+	DualUnits                bool     `json:"dualUnits"`                // If set, normalized-unit fields (MW, m) are emitted next to raw-unit fields
+	RecordingDir             string   `json:"recordingDir"`             // If set, event streams are recorded to this directory, and playback:// sessions may only replay recordings inside it
+	HistoryArchiveDir        string   `json:"historyArchiveDir"`        // If set, history points are also appended to files in this directory and restored from them when a publisher starts
+	HistoryArchiveRetention  int64    `json:"historyArchiveRetention"`  // Game seconds of history kept in the archive files, 0 keeps everything
+	JSONNaming               string   `json:"jsonNaming"`               // Key naming of streamed events: asIs (default), camelCase or snake_case
+	EntityTombstones         bool     `json:"entityTombstones"`         // If set, a removed event is emitted for entities that disappear between polls
+	MaxEventEntities         int      `json:"maxEventEntities"`         // If set, list events are capped to this many entities, highest priority first
+	WarmUpRetries            *int     `json:"warmUpRetries"`            // Retries of the initial fetch of each endpoint on a fresh connection, defaults to 3, 0 disables
+	DiagnosticsEvents        bool     `json:"diagnosticsEvents"`        // If set, a diagnostics event with the failing endpoints is emitted whenever that set changes
+	RateSmoothing            float64  `json:"rateSmoothing"`            // If set (0-1 exclusive), weight of the newest value in the moving average of prod stats rates and vehicle speeds
+	CoordinateBound          *float64 `json:"coordinateBound"`          // Max absolute X, Y and Z of emitted entities, defaults to 1000000, 0 disables
+	VehicleStallSeconds      int      `json:"vehicleStallSeconds"`      // Seconds a self-driving vehicle may stand still away from stations before it is reported stalled, defaults to 60
+	SuppressUnchanged        bool     `json:"suppressUnchanged"`        // If set, polled events equal to the last published one within the deadbands are not published again
+	AlertBatteryLowPercent   float64  `json:"alertBatteryLowPercent"`   // Battery charge percentage below which a circuit alert fires, defaults to 20
+	AlertPowerDeficitPercent float64  `json:"alertPowerDeficitPercent"` // Percentage consumption may exceed production by before a circuit alert fires, defaults to 5
+	ProdHistorySamples       int      `json:"prodHistorySamples"`       // Samples of per-item production rates kept per session, oldest evicted first, defaults to 60
+	LeaseWeight              int      `json:"leaseWeight"`              // Relative capacity of this instance, higher weights are preferred owners of proportionally more sessions, defaults to 1
+	LeaseStatusGraceSeconds  int      `json:"leaseStatusGraceSeconds"`  // Seconds a starting node stays in init before it may acquire new leases, defaults to 10
+	DebugDiagnostics         bool     `json:"debugDiagnostics"`         // If set, the diagnostics endpoint dumps the internal state of this instance's trackers
+	AlertGraceSeconds        *int     `json:"alertGraceSeconds"`        // Seconds after a publisher starts during which alerts are held back while detectors collect a baseline, defaults to 60, 0 disables
+	SplineTolerance          float64  `json:"splineTolerance"`          // If set, max deviation in location units when simplifying belt, pipe and hypertube splines, 0 keeps every point
+	PollBudgetPerMinute      int      `json:"pollBudgetPerMinute"`      // If set, max requests per minute to each session's game server, low-priority endpoints are skipped first as it runs out
+	RunwayFloorMinutes       float64  `json:"runwayFloorMinutes"`       // Minutes of stored supply below which an item is flagged low, defaults to 60
+	ErrorLogIntervalSeconds  int      `json:"errorLogIntervalSeconds"`  // Seconds between logged fetch errors of one failing endpoint, defaults to 60
 
 	Redis struct {
 		URL      string `json:"url"`
 		Password string `json:"password,default=default"`
 	}
 
+	// BaseScore weights the factors of the composite base score. If all are zero, factors are weighted equally.
+	BaseScore struct {
+		EfficiencyWeight float64 `json:"efficiencyWeight"`
+		PowerWeight      float64 `json:"powerWeight"`
+		ProductionWeight float64 `json:"productionWeight"`
+		LogisticsWeight  float64 `json:"logisticsWeight"`
+	} `json:"baseScore"`
+
+	// Deadbands are the changes below which a number counts as unchanged when suppressing unchanged events.
+	// Zero values use the defaults.
+	Deadbands struct {
+		Efficiency   float64 `json:"efficiency"`   // Absolute, for efficiencies and productivity (0-1), defaults to 0.001
+		RateRelative float64 `json:"rateRelative"` // Relative, for per-minute rates, current values and speeds, defaults to 0.005
+		Percentage   float64 `json:"percentage"`   // Absolute, for battery percentages (0-100), defaults to 0.1
+	} `json:"deadbands"`
+
 	Auth struct {
 		BootstrapPassword string
 	}
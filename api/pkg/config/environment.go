@@ -1,6 +1,7 @@
 package config
 
 import (
+	"api/utils"
 	"fmt"
 	"os"
 	"sigs.k8s.io/yaml"
@@ -64,5 +65,320 @@ func SetupEnvironment(appMode string) error {
 		fmt.Printf("Using max sample game duration from SD_MAX_SAMPLE_GAME_DURATION: %d seconds\n", maxSampleDuration)
 	}
 
+	if maxConcurrentStr := os.Getenv("SD_MAX_CONCURRENT_REQUESTS"); maxConcurrentStr != "" {
+		maxConcurrent, err := strconv.Atoi(maxConcurrentStr)
+		if err != nil {
+			return makeError(fmt.Errorf("invalid SD_MAX_CONCURRENT_REQUESTS: %w", err))
+		}
+		if maxConcurrent <= 0 {
+			return makeError(fmt.Errorf("SD_MAX_CONCURRENT_REQUESTS must be a positive integer, got: %d", maxConcurrent))
+		}
+		Config.MaxConcurrentRequests = maxConcurrent
+		fmt.Printf("Using max concurrent requests from SD_MAX_CONCURRENT_REQUESTS: %d\n", maxConcurrent)
+	}
+
+	if queueConcurrencyStr := os.Getenv("SD_REQUEST_QUEUE_CONCURRENCY"); queueConcurrencyStr != "" {
+		queueConcurrency, err := strconv.Atoi(queueConcurrencyStr)
+		if err != nil {
+			return makeError(fmt.Errorf("invalid SD_REQUEST_QUEUE_CONCURRENCY: %w", err))
+		}
+		if queueConcurrency <= 0 {
+			return makeError(fmt.Errorf("SD_REQUEST_QUEUE_CONCURRENCY must be a positive integer, got: %d", queueConcurrency))
+		}
+		Config.RequestQueueConcurrency = queueConcurrency
+		fmt.Printf("Using request queue concurrency from SD_REQUEST_QUEUE_CONCURRENCY: %d\n", queueConcurrency)
+	}
+
+	if warmUpStr := os.Getenv("SD_WARM_UP_RETRIES"); warmUpStr != "" {
+		warmUp, err := strconv.Atoi(warmUpStr)
+		if err != nil {
+			return makeError(fmt.Errorf("invalid SD_WARM_UP_RETRIES: %w", err))
+		}
+		if warmUp < 0 {
+			return makeError(fmt.Errorf("SD_WARM_UP_RETRIES must be a non-negative integer, got: %d", warmUp))
+		}
+		Config.WarmUpRetries = &warmUp
+		fmt.Printf("Using warm-up retries from SD_WARM_UP_RETRIES: %d\n", warmUp)
+	}
+
+	if batteryStr := os.Getenv("SD_ALERT_BATTERY_LOW_PERCENT"); batteryStr != "" {
+		battery, err := strconv.ParseFloat(batteryStr, 64)
+		if err != nil {
+			return makeError(fmt.Errorf("invalid SD_ALERT_BATTERY_LOW_PERCENT: %w", err))
+		}
+		if battery <= 0 || battery > 100 {
+			return makeError(fmt.Errorf("SD_ALERT_BATTERY_LOW_PERCENT must be between 0 (exclusive) and 100, got: %g", battery))
+		}
+		Config.AlertBatteryLowPercent = battery
+		fmt.Printf("Using battery low alert threshold from SD_ALERT_BATTERY_LOW_PERCENT: %g%%\n", battery)
+	}
+
+	if deficitStr := os.Getenv("SD_ALERT_POWER_DEFICIT_PERCENT"); deficitStr != "" {
+		deficit, err := strconv.ParseFloat(deficitStr, 64)
+		if err != nil {
+			return makeError(fmt.Errorf("invalid SD_ALERT_POWER_DEFICIT_PERCENT: %w", err))
+		}
+		if deficit <= 0 {
+			return makeError(fmt.Errorf("SD_ALERT_POWER_DEFICIT_PERCENT must be positive, got: %g", deficit))
+		}
+		Config.AlertPowerDeficitPercent = deficit
+		fmt.Printf("Using power deficit alert margin from SD_ALERT_POWER_DEFICIT_PERCENT: %g%%\n", deficit)
+	}
+
+	if samplesStr := os.Getenv("SD_PROD_HISTORY_SAMPLES"); samplesStr != "" {
+		samples, err := strconv.Atoi(samplesStr)
+		if err != nil {
+			return makeError(fmt.Errorf("invalid SD_PROD_HISTORY_SAMPLES: %w", err))
+		}
+		if samples <= 0 {
+			return makeError(fmt.Errorf("SD_PROD_HISTORY_SAMPLES must be a positive integer, got: %d", samples))
+		}
+		Config.ProdHistorySamples = samples
+		fmt.Printf("Using production history length from SD_PROD_HISTORY_SAMPLES: %d\n", samples)
+	}
+
+	if weightStr := os.Getenv("SD_LEASE_WEIGHT"); weightStr != "" {
+		weight, err := strconv.Atoi(weightStr)
+		if err != nil {
+			return makeError(fmt.Errorf("invalid SD_LEASE_WEIGHT: %w", err))
+		}
+		if weight <= 0 {
+			return makeError(fmt.Errorf("SD_LEASE_WEIGHT must be a positive integer, got: %d", weight))
+		}
+		Config.LeaseWeight = weight
+		fmt.Printf("Using lease weight from SD_LEASE_WEIGHT: %d\n", weight)
+	}
+
+	if graceStr := os.Getenv("SD_LEASE_STATUS_GRACE_SECONDS"); graceStr != "" {
+		grace, err := strconv.Atoi(graceStr)
+		if err != nil {
+			return makeError(fmt.Errorf("invalid SD_LEASE_STATUS_GRACE_SECONDS: %w", err))
+		}
+		if grace <= 0 {
+			return makeError(fmt.Errorf("SD_LEASE_STATUS_GRACE_SECONDS must be a positive integer, got: %d", grace))
+		}
+		Config.LeaseStatusGraceSeconds = grace
+		fmt.Printf("Using lease status grace period from SD_LEASE_STATUS_GRACE_SECONDS: %ds\n", grace)
+	}
+
+	if debugStr := os.Getenv("SD_DEBUG_DIAGNOSTICS"); debugStr != "" {
+		debug, err := strconv.ParseBool(debugStr)
+		if err != nil {
+			return makeError(fmt.Errorf("invalid SD_DEBUG_DIAGNOSTICS: %w", err))
+		}
+		Config.DebugDiagnostics = debug
+		fmt.Printf("Using debug diagnostics from SD_DEBUG_DIAGNOSTICS: %t\n", debug)
+	}
+
+	if graceStr := os.Getenv("SD_ALERT_GRACE_SECONDS"); graceStr != "" {
+		grace, err := strconv.Atoi(graceStr)
+		if err != nil {
+			return makeError(fmt.Errorf("invalid SD_ALERT_GRACE_SECONDS: %w", err))
+		}
+		if grace < 0 {
+			return makeError(fmt.Errorf("SD_ALERT_GRACE_SECONDS must be a non-negative integer, got: %d", grace))
+		}
+		Config.AlertGraceSeconds = &grace
+		fmt.Printf("Using alert grace from SD_ALERT_GRACE_SECONDS: %ds\n", grace)
+	}
+
+	if boundStr := os.Getenv("SD_COORDINATE_BOUND"); boundStr != "" {
+		bound, err := strconv.ParseFloat(boundStr, 64)
+		if err != nil {
+			return makeError(fmt.Errorf("invalid SD_COORDINATE_BOUND: %w", err))
+		}
+		if bound < 0 {
+			return makeError(fmt.Errorf("SD_COORDINATE_BOUND must be a non-negative number, got: %v", bound))
+		}
+		Config.CoordinateBound = &bound
+		fmt.Printf("Using coordinate bound from SD_COORDINATE_BOUND: %v\n", bound)
+	}
+
+	if apiDownStr := os.Getenv("SD_API_DOWN_THRESHOLD"); apiDownStr != "" {
+		apiDown, err := strconv.Atoi(apiDownStr)
+		if err != nil {
+			return makeError(fmt.Errorf("invalid SD_API_DOWN_THRESHOLD: %w", err))
+		}
+		if apiDown <= 0 {
+			return makeError(fmt.Errorf("SD_API_DOWN_THRESHOLD must be a positive integer, got: %d", apiDown))
+		}
+		Config.ApiDownThreshold = apiDown
+		fmt.Printf("Using API down threshold from SD_API_DOWN_THRESHOLD: %d\n", apiDown)
+	}
+
+	if apiUpStr := os.Getenv("SD_API_UP_THRESHOLD"); apiUpStr != "" {
+		apiUp, err := strconv.Atoi(apiUpStr)
+		if err != nil {
+			return makeError(fmt.Errorf("invalid SD_API_UP_THRESHOLD: %w", err))
+		}
+		if apiUp <= 0 {
+			return makeError(fmt.Errorf("SD_API_UP_THRESHOLD must be a positive integer, got: %d", apiUp))
+		}
+		Config.ApiUpThreshold = apiUp
+		fmt.Printf("Using API up threshold from SD_API_UP_THRESHOLD: %d\n", apiUp)
+	}
+
+	if dualUnitsStr := os.Getenv("SD_DUAL_UNITS"); dualUnitsStr != "" {
+		dualUnits, err := strconv.ParseBool(dualUnitsStr)
+		if err != nil {
+			return makeError(fmt.Errorf("invalid SD_DUAL_UNITS: %w", err))
+		}
+		Config.DualUnits = dualUnits
+		fmt.Printf("Using dual units from SD_DUAL_UNITS: %t\n", dualUnits)
+	}
+
+	if diagnosticsStr := os.Getenv("SD_DIAGNOSTICS_EVENTS"); diagnosticsStr != "" {
+		diagnostics, err := strconv.ParseBool(diagnosticsStr)
+		if err != nil {
+			return makeError(fmt.Errorf("invalid SD_DIAGNOSTICS_EVENTS: %w", err))
+		}
+		Config.DiagnosticsEvents = diagnostics
+		fmt.Printf("Using diagnostics events from SD_DIAGNOSTICS_EVENTS: %t\n", diagnostics)
+	}
+
+	if stallStr := os.Getenv("SD_VEHICLE_STALL_SECONDS"); stallStr != "" {
+		stall, err := strconv.Atoi(stallStr)
+		if err != nil {
+			return makeError(fmt.Errorf("invalid SD_VEHICLE_STALL_SECONDS: %w", err))
+		}
+		if stall <= 0 {
+			return makeError(fmt.Errorf("SD_VEHICLE_STALL_SECONDS must be a positive integer, got: %d", stall))
+		}
+		Config.VehicleStallSeconds = stall
+		fmt.Printf("Using vehicle stall threshold from SD_VEHICLE_STALL_SECONDS: %ds\n", stall)
+	}
+
+	if errorIntervalStr := os.Getenv("SD_ERROR_LOG_INTERVAL_SECONDS"); errorIntervalStr != "" {
+		errorInterval, err := strconv.Atoi(errorIntervalStr)
+		if err != nil {
+			return makeError(fmt.Errorf("invalid SD_ERROR_LOG_INTERVAL_SECONDS: %w", err))
+		}
+		if errorInterval <= 0 {
+			return makeError(fmt.Errorf("SD_ERROR_LOG_INTERVAL_SECONDS must be a positive integer, got: %d", errorInterval))
+		}
+		Config.ErrorLogIntervalSeconds = errorInterval
+		fmt.Printf("Using error log interval from SD_ERROR_LOG_INTERVAL_SECONDS: %ds\n", errorInterval)
+	}
+
+	if toleranceStr := os.Getenv("SD_SPLINE_TOLERANCE"); toleranceStr != "" {
+		tolerance, err := strconv.ParseFloat(toleranceStr, 64)
+		if err != nil {
+			return makeError(fmt.Errorf("invalid SD_SPLINE_TOLERANCE: %w", err))
+		}
+		if tolerance < 0 {
+			return makeError(fmt.Errorf("SD_SPLINE_TOLERANCE must be a non-negative number, got: %v", tolerance))
+		}
+		Config.SplineTolerance = tolerance
+		fmt.Printf("Using spline tolerance from SD_SPLINE_TOLERANCE: %v\n", tolerance)
+	}
+
+	if budgetStr := os.Getenv("SD_POLL_BUDGET_PER_MINUTE"); budgetStr != "" {
+		budget, err := strconv.Atoi(budgetStr)
+		if err != nil {
+			return makeError(fmt.Errorf("invalid SD_POLL_BUDGET_PER_MINUTE: %w", err))
+		}
+		if budget < 0 {
+			return makeError(fmt.Errorf("SD_POLL_BUDGET_PER_MINUTE must be a non-negative integer, got: %d", budget))
+		}
+		Config.PollBudgetPerMinute = budget
+		fmt.Printf("Using poll budget from SD_POLL_BUDGET_PER_MINUTE: %d requests per minute\n", budget)
+	}
+
+	if floorStr := os.Getenv("SD_RUNWAY_FLOOR_MINUTES"); floorStr != "" {
+		floor, err := strconv.ParseFloat(floorStr, 64)
+		if err != nil {
+			return makeError(fmt.Errorf("invalid SD_RUNWAY_FLOOR_MINUTES: %w", err))
+		}
+		if floor <= 0 {
+			return makeError(fmt.Errorf("SD_RUNWAY_FLOOR_MINUTES must be positive, got: %g", floor))
+		}
+		Config.RunwayFloorMinutes = floor
+		fmt.Printf("Using runway floor from SD_RUNWAY_FLOOR_MINUTES: %g minutes\n", floor)
+	}
+
+	if smoothingStr := os.Getenv("SD_RATE_SMOOTHING"); smoothingStr != "" {
+		smoothing, err := strconv.ParseFloat(smoothingStr, 64)
+		if err != nil {
+			return makeError(fmt.Errorf("invalid SD_RATE_SMOOTHING: %w", err))
+		}
+		if smoothing <= 0 || smoothing >= 1 {
+			return makeError(fmt.Errorf("SD_RATE_SMOOTHING must be between 0 and 1 (exclusive), got: %g", smoothing))
+		}
+		Config.RateSmoothing = smoothing
+		fmt.Printf("Using rate smoothing factor from SD_RATE_SMOOTHING: %g\n", smoothing)
+	}
+
+	if tombstonesStr := os.Getenv("SD_ENTITY_TOMBSTONES"); tombstonesStr != "" {
+		tombstones, err := strconv.ParseBool(tombstonesStr)
+		if err != nil {
+			return makeError(fmt.Errorf("invalid SD_ENTITY_TOMBSTONES: %w", err))
+		}
+		Config.EntityTombstones = tombstones
+		fmt.Printf("Using entity tombstones from SD_ENTITY_TOMBSTONES: %t\n", tombstones)
+	}
+
+	if suppressStr := os.Getenv("SD_SUPPRESS_UNCHANGED"); suppressStr != "" {
+		suppress, err := strconv.ParseBool(suppressStr)
+		if err != nil {
+			return makeError(fmt.Errorf("invalid SD_SUPPRESS_UNCHANGED: %w", err))
+		}
+		Config.SuppressUnchanged = suppress
+		fmt.Printf("Using unchanged event suppression from SD_SUPPRESS_UNCHANGED: %t\n", suppress)
+	}
+
+	if maxEntitiesStr := os.Getenv("SD_MAX_EVENT_ENTITIES"); maxEntitiesStr != "" {
+		maxEntities, err := strconv.Atoi(maxEntitiesStr)
+		if err != nil {
+			return makeError(fmt.Errorf("invalid SD_MAX_EVENT_ENTITIES: %w", err))
+		}
+		if maxEntities <= 0 {
+			return makeError(fmt.Errorf("SD_MAX_EVENT_ENTITIES must be a positive integer, got: %d", maxEntities))
+		}
+		Config.MaxEventEntities = maxEntities
+		fmt.Printf("Using max event entities from SD_MAX_EVENT_ENTITIES: %d\n", maxEntities)
+	}
+
+	if recordingDir := os.Getenv("SD_RECORDING_DIR"); recordingDir != "" {
+		Config.RecordingDir = recordingDir
+		fmt.Printf("Recording event streams to directory from SD_RECORDING_DIR: %s\n", recordingDir)
+	}
+
+	if archiveDir := os.Getenv("SD_HISTORY_ARCHIVE_DIR"); archiveDir != "" {
+		Config.HistoryArchiveDir = archiveDir
+		fmt.Printf("Archiving history to directory from SD_HISTORY_ARCHIVE_DIR: %s\n", archiveDir)
+	}
+
+	if retentionStr := os.Getenv("SD_HISTORY_ARCHIVE_RETENTION"); retentionStr != "" {
+		retention, err := strconv.ParseInt(retentionStr, 10, 64)
+		if err != nil {
+			return makeError(fmt.Errorf("invalid SD_HISTORY_ARCHIVE_RETENTION: %w", err))
+		}
+		if retention < 0 {
+			return makeError(fmt.Errorf("SD_HISTORY_ARCHIVE_RETENTION must be a non-negative integer, got: %d", retention))
+		}
+		Config.HistoryArchiveRetention = retention
+		fmt.Printf("Using history archive retention from SD_HISTORY_ARCHIVE_RETENTION: %d seconds\n", retention)
+	}
+
+	if jsonNaming := os.Getenv("SD_JSON_NAMING"); jsonNaming != "" {
+		Config.JSONNaming = jsonNaming
+		fmt.Printf("Using JSON naming from SD_JSON_NAMING: %s\n", jsonNaming)
+	}
+
+	if clientType := os.Getenv("SD_CLIENT_TYPE"); clientType != "" {
+		Config.ClientType = clientType
+		fmt.Printf("Using client type from SD_CLIENT_TYPE: %s\n", clientType)
+	}
+
+	switch Config.ClientType {
+	case "", "frm", "mock":
+	default:
+		return makeError(fmt.Errorf("invalid client type %q, must be one of frm, mock", Config.ClientType))
+	}
+
+	if !utils.IsValidJSONNaming(utils.JSONNaming(Config.JSONNaming)) {
+		return makeError(fmt.Errorf("invalid JSON naming %q, must be one of asIs, camelCase, snake_case", Config.JSONNaming))
+	}
+
 	return nil
 }
@@ -149,6 +149,25 @@ func (client *Client) ZRemRangeByScore(key string, min, max float64) (int64, err
 	return client.RedisClient.ZRemRangeByScore(context.Background(), key, fmt.Sprintf("%v", min), fmt.Sprintf("%v", max)).Result()
 }
 
+// AppendCapped appends a value to each list, keeping only the newest size values per list,
+// and sets every list to expire after ttl. All lists are written in a single round trip.
+func (client *Client) AppendCapped(values map[string]string, size int64, ttl time.Duration) error {
+	_, err := client.RedisClient.TxPipelined(context.Background(), func(pipe redis.Pipeliner) error {
+		for key, value := range values {
+			pipe.RPush(context.Background(), key, value)
+			pipe.LTrim(context.Background(), key, -size, -1)
+			pipe.Expire(context.Background(), key, ttl)
+		}
+		return nil
+	})
+	return err
+}
+
+// LRange returns every value of a list, oldest first.
+func (client *Client) LRange(key string) ([]string, error) {
+	return client.RedisClient.LRange(context.Background(), key, 0, -1).Result()
+}
+
 // SetUpExpirationListener sets up a listener for expired key events for every key that matches the given pattern.
 // It is non-blocking and will run in a separate goroutine.
 func (client *Client) SetUpExpirationListener(ctx context.Context, pattern string, handler func(key string) error) error {
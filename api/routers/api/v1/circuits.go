@@ -2,7 +2,9 @@ package v1
 
 import (
 	"api/models/models"
+	"api/service/analysis"
 	"api/service/session"
+	"encoding/json"
 	"fmt"
 
 	"github.com/gin-gonic/gin"
@@ -47,3 +49,56 @@ func ListCircuits(ginContext *gin.Context) {
 
 	requestContext.Ok(circuitsDto)
 }
+
+// ListDrainingBatteries godoc
+// @Summary List Draining Batteries
+// @Description List circuits whose batteries only discharged and never charged over the circuit history, meaning the grid needs more generation
+// @Tags Circuits
+// @Accept json
+// @Produce json
+// @Param session_id query string true "Session ID"
+// @Success 200 {array} models.DrainingBatteryDTO "List of circuits with draining batteries"
+// @Success 400 {object} models.ErrorResponse "Bad Request"
+// @Router /v1/circuits/drainingBatteries [get]
+func ListDrainingBatteries(ginContext *gin.Context) {
+	requestContext := NewRequestContext(ginContext)
+
+	sessionID := ginContext.Query("session_id")
+	if sessionID == "" {
+		requestContext.UserError("session_id query parameter is required")
+		return
+	}
+
+	store := session.NewStore()
+	sess, err := store.Get(sessionID)
+	if err != nil {
+		requestContext.ServerError(fmt.Errorf("failed to get session: %w", err), err)
+		return
+	}
+	if sess == nil {
+		requestContext.NotFound("Session not found")
+		return
+	}
+
+	historyChunk, err := session.GetHistory(sessionID, sess.SessionName, string(models.SatisfactoryEventCircuits), 0)
+	if err != nil {
+		requestContext.ServerError(fmt.Errorf("failed to get circuit history: %w", err), err)
+		return
+	}
+
+	// History points hold generic JSON, decode them back into circuits
+	samples := make([]analysis.CircuitSample, 0, len(historyChunk.Points))
+	for _, point := range historyChunk.Points {
+		data, err := json.Marshal(point.Data)
+		if err != nil {
+			continue
+		}
+		sample := analysis.CircuitSample{GameTimeID: point.GameTimeID}
+		if err := json.Unmarshal(data, &sample.Circuits); err != nil {
+			continue
+		}
+		samples = append(samples, sample)
+	}
+
+	requestContext.Ok(analysis.FindDrainingBatteries(samples))
+}
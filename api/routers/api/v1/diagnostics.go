@@ -0,0 +1,38 @@
+package v1
+
+import (
+	"api/pkg/config"
+	"api/worker"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetDiagnostics godoc
+// @Summary Get Diagnostics
+// @Description Dump the internal state of this instance's publishers, clients and trackers. Only available when SD_DEBUG_DIAGNOSTICS is enabled.
+// @Tags Status
+// @Produce json
+// @Success 200 {object} models.DiagnosticsDumpDTO "Diagnostics dump"
+// @Failure 404 {object} models.ErrorResponse "Diagnostics are disabled"
+// @Failure 500 {object} models.ErrorResponse "Internal Server Error"
+// @Router /v1/diagnostics [get]
+func GetDiagnostics(ginContext *gin.Context) {
+	requestContext := NewRequestContext(ginContext)
+
+	if !config.Config.DebugDiagnostics {
+		requestContext.NotFound("Diagnostics are disabled")
+		return
+	}
+
+	manager := worker.GetGlobalSessionManager()
+	if manager == nil {
+		requestContext.ServerError(
+			fmt.Errorf("session manager not initialized"),
+			fmt.Errorf("session manager not available"),
+		)
+		return
+	}
+
+	requestContext.Ok(manager.DumpDiagnostics())
+}
@@ -0,0 +1,57 @@
+package v1
+
+import (
+	"api/models/models"
+	"api/pkg/config"
+	"api/pkg/db/key_value"
+	"api/service/lease"
+	"api/worker"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+func TestGetDiagnosticsIsGated(t *testing.T) {
+	previous := config.Config
+	t.Cleanup(func() { config.Config = previous })
+
+	server := miniredis.RunT(t)
+	redisClient := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = redisClient.Close() })
+	leaseManager := lease.NewLeaseManagerWithID("node-a", &key_value.Client{RedisClient: redisClient}, lease.DefaultLeaseConfig(), zap.NewNop())
+	worker.SetGlobalSessionManager(worker.NewSessionManager(leaseManager))
+	t.Cleanup(func() { worker.SetGlobalSessionManager(nil) })
+
+	serve := func() *httptest.ResponseRecorder {
+		gin.SetMode(gin.TestMode)
+		recorder := httptest.NewRecorder()
+		ginContext, _ := gin.CreateTestContext(recorder)
+		ginContext.Request = httptest.NewRequest(http.MethodGet, "/v1/diagnostics", nil)
+		GetDiagnostics(ginContext)
+		return recorder
+	}
+
+	config.Config = &config.Type{}
+	if recorder := serve(); recorder.Code != http.StatusNotFound {
+		t.Errorf("got status %d with diagnostics disabled, want %d", recorder.Code, http.StatusNotFound)
+	}
+
+	config.Config = &config.Type{DebugDiagnostics: true}
+	recorder := serve()
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("got status %d with diagnostics enabled: %s", recorder.Code, recorder.Body)
+	}
+	var dump models.DiagnosticsDump
+	if err := json.Unmarshal(recorder.Body.Bytes(), &dump); err != nil {
+		t.Fatal(err)
+	}
+	if dump.InstanceID != "node-a" || dump.Publishers == nil {
+		t.Errorf("got %+v, want the dump of node-a", dump)
+	}
+}
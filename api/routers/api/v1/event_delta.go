@@ -0,0 +1,140 @@
+package v1
+
+import (
+	"api/models/models"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// deltaKind is one entity list of an event sent as deltas
+type deltaKind struct {
+	kind   string
+	field  string       // Field of the event data holding the list, empty if the data is the list itself
+	entity reflect.Type // Type of the entities, used to rename their keys
+	id     func(entity map[string]any) string
+}
+
+// deltaKinds lists the event types sent as deltas in delta mode
+var deltaKinds = map[models.SatisfactoryEventType][]deltaKind{
+	models.SatisfactoryEventMachines: {{kind: "machines", entity: reflect.TypeFor[models.Machine](), id: fieldDeltaID}},
+	models.SatisfactoryEventStorages: {{kind: "storages", entity: reflect.TypeFor[models.Storage](), id: fieldDeltaID}},
+	models.SatisfactoryEventBelts: {
+		{kind: "belts", field: "belts", entity: reflect.TypeFor[models.Belt](), id: fieldDeltaID},
+		{kind: "splitterMergers", field: "splitterMergers", entity: reflect.TypeFor[models.SplitterMerger](), id: fieldDeltaID},
+	},
+}
+
+func fieldDeltaID(entity map[string]any) string {
+	return fmt.Sprint(entity["id"])
+}
+
+// deltaEncoder turns list-based events into deltas against the last event of the same type
+// sent to one stream. It runs on drained events, so coalesced events never lose changes.
+type deltaEncoder struct {
+	sent map[models.SatisfactoryEventType]map[string]map[string]string // event type -> kind -> ID -> entity JSON
+}
+
+func newDeltaEncoder() *deltaEncoder {
+	return &deltaEncoder{sent: make(map[models.SatisfactoryEventType]map[string]map[string]string)}
+}
+
+// Encode returns the event with its data replaced by an EventDelta, or the event unchanged if its type
+// is not sent as deltas. The first event of a type, and partial or truncated events whose missing
+// entities were not removed, are sent as snapshots.
+func (encoder *deltaEncoder) Encode(event models.SatisfactoryEvent) models.SatisfactoryEvent {
+	kinds, ok := deltaKinds[event.Type]
+	if !ok {
+		return event
+	}
+
+	previous, seen := encoder.sent[event.Type]
+	delta := models.EventDelta{
+		Snapshot: !seen || event.Partial || event.Truncated,
+		Kinds:    make([]models.EntityDelta, 0, len(kinds)),
+	}
+	current := make(map[string]map[string]string, len(kinds))
+
+	for _, kind := range kinds {
+		entities := deltaEntities(event.Data, kind.field)
+		if entities == nil {
+			return event
+		}
+
+		entityDelta := models.EntityDelta{Kind: kind.kind, Upserted: []models.DeltaEntity{}, Removed: []string{}}
+		sent := make(map[string]string, len(entities))
+		for _, entity := range entities {
+			id := uniqueDeltaID(sent, kind.id(entity))
+			encoded, err := json.Marshal(entity)
+			if err != nil {
+				return event
+			}
+			sent[id] = string(encoded)
+			if delta.Snapshot || previous[kind.kind][id] != sent[id] {
+				entityDelta.Upserted = append(entityDelta.Upserted, models.DeltaEntity{ID: id, Data: entity})
+			}
+		}
+		if !delta.Snapshot {
+			for id := range previous[kind.kind] {
+				if _, exists := sent[id]; !exists {
+					entityDelta.Removed = append(entityDelta.Removed, id)
+				}
+			}
+			sort.Strings(entityDelta.Removed)
+		}
+
+		current[kind.kind] = sent
+		delta.Kinds = append(delta.Kinds, entityDelta)
+	}
+
+	encoder.sent[event.Type] = current
+	event.Data = delta
+	event.Delta = true
+	return event
+}
+
+// deltaEntities returns the entities of a decoded event, or nil if the data is not shaped as expected
+func deltaEntities(data any, field string) []map[string]any {
+	if field != "" {
+		object, ok := data.(map[string]any)
+		if !ok {
+			return nil
+		}
+		data = object[field]
+		if data == nil {
+			return []map[string]any{}
+		}
+	}
+
+	list, ok := data.([]any)
+	if !ok {
+		if data == nil {
+			return []map[string]any{}
+		}
+		return nil
+	}
+	entities := make([]map[string]any, 0, len(list))
+	for _, item := range list {
+		entity, ok := item.(map[string]any)
+		if !ok {
+			return nil
+		}
+		entities = append(entities, entity)
+	}
+	return entities
+}
+
+// uniqueDeltaID suffixes IDs already taken in this event, so entities sharing an ID
+// (e.g. stacked machines) are tracked separately in payload order
+func uniqueDeltaID(taken map[string]string, id string) string {
+	if _, exists := taken[id]; !exists {
+		return id
+	}
+	for n := 2; ; n++ {
+		candidate := fmt.Sprintf("%s#%d", id, n)
+		if _, exists := taken[candidate]; !exists {
+			return candidate
+		}
+	}
+}
@@ -0,0 +1,213 @@
+package v1
+
+import (
+	"api/models/models"
+	"api/utils"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// decodeData decodes event data the way the stream receives it from Redis
+func decodeData(t *testing.T, data string) any {
+	t.Helper()
+	var decoded any
+	if err := json.Unmarshal([]byte(data), &decoded); err != nil {
+		t.Fatalf("failed to decode %s: %v", data, err)
+	}
+	return decoded
+}
+
+// deltaState is the entity state a client rebuilds from snapshots and deltas: kind -> ID -> entity
+type deltaState map[string]map[string]any
+
+func (state deltaState) apply(t *testing.T, event models.SatisfactoryEvent) {
+	t.Helper()
+	delta, ok := event.Data.(models.EventDelta)
+	if !ok || !event.Delta {
+		t.Fatalf("event not encoded as a delta: %+v", event)
+	}
+	for _, kind := range delta.Kinds {
+		if delta.Snapshot || state[kind.Kind] == nil {
+			state[kind.Kind] = make(map[string]any)
+		}
+		for _, id := range kind.Removed {
+			delete(state[kind.Kind], id)
+		}
+		for _, entity := range kind.Upserted {
+			state[kind.Kind][entity.ID] = entity.Data
+		}
+	}
+}
+
+// wantState returns the state a client must hold after the event, tracking stacked entities apart
+func wantState(t *testing.T, event models.SatisfactoryEvent) deltaState {
+	t.Helper()
+	state := make(deltaState)
+	for _, kind := range deltaKinds[event.Type] {
+		taken := make(map[string]string)
+		state[kind.kind] = make(map[string]any)
+		for _, entity := range deltaEntities(event.Data, kind.field) {
+			id := uniqueDeltaID(taken, kind.id(entity))
+			taken[id] = ""
+			state[kind.kind][id] = entity
+		}
+	}
+	return state
+}
+
+func TestDeltaEncoderRebuildsState(t *testing.T) {
+	steps := []struct {
+		name         string
+		event        models.SatisfactoryEvent
+		wantSnapshot bool
+		wantUpserted map[string][]string
+		wantRemoved  map[string][]string
+	}{
+		{
+			name: "first event is a snapshot",
+			event: models.SatisfactoryEvent{Type: models.SatisfactoryEventMachines, Data: decodeData(t, `[
+				{"id": "a", "rate": 1}, {"id": "b", "rate": 2}, {"id": "b", "rate": 3}
+			]`)},
+			wantSnapshot: true,
+			wantUpserted: map[string][]string{"machines": {"a", "b", "b#2"}},
+		},
+		{
+			name: "unchanged entities are left out",
+			event: models.SatisfactoryEvent{Type: models.SatisfactoryEventMachines, Data: decodeData(t, `[
+				{"id": "a", "rate": 1}, {"id": "b", "rate": 2}, {"id": "b", "rate": 3}
+			]`)},
+			wantUpserted: map[string][]string{"machines": nil},
+		},
+		{
+			name: "changed and added entities are upserted",
+			event: models.SatisfactoryEvent{Type: models.SatisfactoryEventMachines, Data: decodeData(t, `[
+				{"id": "a", "rate": 5}, {"id": "b", "rate": 2}, {"id": "b", "rate": 3}, {"id": "c", "rate": 4}
+			]`)},
+			wantUpserted: map[string][]string{"machines": {"a", "c"}},
+		},
+		{
+			name: "missing entities are removed, including one of a stack",
+			event: models.SatisfactoryEvent{Type: models.SatisfactoryEventMachines, Data: decodeData(t, `[
+				{"id": "b", "rate": 2}, {"id": "c", "rate": 4}
+			]`)},
+			wantUpserted: map[string][]string{"machines": nil},
+			wantRemoved:  map[string][]string{"machines": {"a", "b#2"}},
+		},
+		{
+			name: "a stacked entity taking the place of another is upserted",
+			event: models.SatisfactoryEvent{Type: models.SatisfactoryEventMachines, Data: decodeData(t, `[
+				{"id": "b", "rate": 3}, {"id": "b", "rate": 2}, {"id": "c", "rate": 4}
+			]`)},
+			wantUpserted: map[string][]string{"machines": {"b", "b#2"}},
+		},
+		{
+			name: "partial events are snapshots",
+			event: models.SatisfactoryEvent{Type: models.SatisfactoryEventMachines, Partial: true, Data: decodeData(t, `[
+				{"id": "c", "rate": 4}
+			]`)},
+			wantSnapshot: true,
+			wantUpserted: map[string][]string{"machines": {"c"}},
+		},
+		{
+			name: "kinds of one event are tracked separately",
+			event: models.SatisfactoryEvent{Type: models.SatisfactoryEventBelts, Data: decodeData(t, `{
+				"belts": [{"id": "1", "rate": 60}],
+				"splitterMergers": [{"id": "1", "type": "splitter"}]
+			}`)},
+			wantSnapshot: true,
+			wantUpserted: map[string][]string{"belts": {"1"}, "splitterMergers": {"1"}},
+		},
+		{
+			name: "a missing kind field removes all its entities",
+			event: models.SatisfactoryEvent{Type: models.SatisfactoryEventBelts, Data: decodeData(t, `{
+				"belts": [{"id": "1", "rate": 30}]
+			}`)},
+			wantUpserted: map[string][]string{"belts": {"1"}, "splitterMergers": nil},
+			wantRemoved:  map[string][]string{"splitterMergers": {"1"}},
+		},
+	}
+
+	encoder := newDeltaEncoder()
+	state := make(deltaState)
+	for _, step := range steps {
+		t.Run(step.name, func(t *testing.T) {
+			encoded := encoder.Encode(step.event)
+			state.apply(t, encoded)
+
+			delta := encoded.Data.(models.EventDelta)
+			if delta.Snapshot != step.wantSnapshot {
+				t.Errorf("snapshot %v, want %v", delta.Snapshot, step.wantSnapshot)
+			}
+			for _, kind := range delta.Kinds {
+				var upserted []string
+				for _, entity := range kind.Upserted {
+					upserted = append(upserted, entity.ID)
+				}
+				if !reflect.DeepEqual(upserted, step.wantUpserted[kind.Kind]) {
+					t.Errorf("%s: upserted %v, want %v", kind.Kind, upserted, step.wantUpserted[kind.Kind])
+				}
+				if len(kind.Removed) > 0 || len(step.wantRemoved[kind.Kind]) > 0 {
+					if !reflect.DeepEqual(kind.Removed, step.wantRemoved[kind.Kind]) {
+						t.Errorf("%s: removed %v, want %v", kind.Kind, kind.Removed, step.wantRemoved[kind.Kind])
+					}
+				}
+			}
+
+			for kind, entities := range wantState(t, step.event) {
+				if !reflect.DeepEqual(state[kind], entities) {
+					t.Errorf("%s: rebuilt %v, want %v", kind, state[kind], entities)
+				}
+			}
+		})
+	}
+}
+
+func TestDeltaEncoderPassesOtherEvents(t *testing.T) {
+	encoder := newDeltaEncoder()
+	event := models.SatisfactoryEvent{Type: models.SatisfactoryEventCircuits, Data: decodeData(t, `[{"id": "1"}]`)}
+	if encoded := encoder.Encode(event); encoded.Delta || !reflect.DeepEqual(encoded, event) {
+		t.Errorf("got %+v, want the event unchanged", encoded)
+	}
+
+	malformed := models.SatisfactoryEvent{Type: models.SatisfactoryEventMachines, Data: decodeData(t, `{"id": "1"}`)}
+	if encoded := encoder.Encode(malformed); encoded.Delta {
+		t.Errorf("got %+v, want a malformed event unchanged", encoded)
+	}
+}
+
+func TestEncodeSseEventRenamesDeltaEntities(t *testing.T) {
+	event := models.SatisfactoryEvent{Type: models.SatisfactoryEventBelts, Data: decodeData(t, `{
+		"belts": [{"id": "1", "location0": {"xMeters": 1}}],
+		"splitterMergers": [{"id": "2", "xMeters": 2, "boundingBox": {}}]
+	}`)}
+	encoded := newDeltaEncoder().Encode(event)
+
+	namer := utils.NewJSONNamer(utils.JSONNamingSnakeCase)
+	got := encodeSseEvent(models.SseSatisfactoryEvent{SatisfactoryEvent: encoded, ClientID: 1}, namer)
+
+	want := `{"client_id":1,"data":{"kinds":[` +
+		`{"kind":"belts","removed":[],"upserted":[{"data":{"id":"1","location0":{"x_meters":1}},"id":"1"}]},` +
+		`{"kind":"splitterMergers","removed":[],"upserted":[{"data":{"bounding_box":{},"id":"2","x_meters":2},"id":"2"}]}` +
+		`],"snapshot":true},"delta":true,"game_time_id":0,"type":"belts"}`
+	if got != want {
+		t.Errorf("got %v, want %s", got, want)
+	}
+}
+
+func TestUniqueDeltaID(t *testing.T) {
+	taken := map[string]string{"a": "", "a#2": "", "b": ""}
+	tests := []struct {
+		id   string
+		want string
+	}{
+		{"c", "c"},
+		{"b", "b#2"},
+		{"a", "a#3"},
+	}
+	for _, test := range tests {
+		if got := uniqueDeltaID(taken, test.id); got != test.want {
+			t.Errorf("uniqueDeltaID(%q) = %q, want %q", test.id, got, test.want)
+		}
+	}
+}
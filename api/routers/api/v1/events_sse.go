@@ -2,12 +2,15 @@ package v1
 
 import (
 	"api/models/models"
+	"api/pkg/config"
 	"api/pkg/db/key_value"
 	"api/pkg/log"
+	"api/utils"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"reflect"
 	"sync"
 	"time"
 
@@ -28,9 +31,11 @@ var (
 
 // CoalescingQueue stores only the latest message per event type.
 // When a new message of the same type arrives, it replaces the old one.
+// Removal events are never coalesced, since each one carries different entities.
 type CoalescingQueue struct {
 	mu       sync.Mutex
 	messages map[models.SatisfactoryEventType]models.SseSatisfactoryEvent
+	removals []models.SseSatisfactoryEvent
 	signal   chan struct{}
 	closed   bool
 }
@@ -51,7 +56,11 @@ func (q *CoalescingQueue) Push(msg models.SseSatisfactoryEvent) {
 		return
 	}
 
-	q.messages[msg.Type] = msg
+	if msg.Type == models.SatisfactoryEventRemoved {
+		q.removals = append(q.removals, msg)
+	} else {
+		q.messages[msg.Type] = msg
+	}
 
 	// Non-blocking signal that there's data available
 	select {
@@ -65,15 +74,17 @@ func (q *CoalescingQueue) Drain() []models.SseSatisfactoryEvent {
 	q.mu.Lock()
 	defer q.mu.Unlock()
 
-	if len(q.messages) == 0 {
+	if len(q.messages) == 0 && len(q.removals) == 0 {
 		return nil
 	}
 
-	result := make([]models.SseSatisfactoryEvent, 0, len(q.messages))
+	result := make([]models.SseSatisfactoryEvent, 0, len(q.messages)+len(q.removals))
 	for _, msg := range q.messages {
 		result = append(result, msg)
 	}
+	result = append(result, q.removals...)
 	q.messages = make(map[models.SatisfactoryEventType]models.SseSatisfactoryEvent)
+	q.removals = nil
 	return result
 }
 
@@ -131,11 +142,12 @@ func AddClientMessageCount(client *Client) {
 
 // StartSessionEventsSSE godoc
 // @Summary Stream events for a session
-// @Description Stream events from a specific session
+// @Description Stream events from a specific session. In delta mode, machines, belts and storages are sent as an EventDelta with the changed, added and removed entities since the last event of the same type on this stream, starting with a snapshot
 // @Tags Sessions
 // @Accept json
 // @Produce json
 // @Param id path string true "Session ID"
+// @Param delta query bool false "Send list-based events as deltas"
 // @Success 200 "SSE stream"
 // @Failure 400 {object} models.ErrorResponse "Bad Request"
 // @Failure 500 {object} models.ErrorResponse "Internal Server Error"
@@ -158,6 +170,13 @@ func StartSessionEventsSSE(ginContext *gin.Context) {
 	queue := NewCoalescingQueue()
 	defer queue.Close()
 
+	namer := utils.NewJSONNamer(utils.JSONNaming(config.Config.JSONNaming))
+
+	var deltas *deltaEncoder
+	if ginContext.Query("delta") == "true" {
+		deltas = newDeltaEncoder()
+	}
+
 	// Create a new client
 	client := CreateNewClient()
 	defer RemoveClient(client)
@@ -193,10 +212,115 @@ func StartSessionEventsSSE(ginContext *gin.Context) {
 			// Drain all pending messages and send them
 			messages := queue.Drain()
 			for _, msg := range messages {
-				requestContext.GinContext.SSEvent(models.SatisfactoryEventKey, msg)
+				if deltas != nil {
+					msg.SatisfactoryEvent = deltas.Encode(msg.SatisfactoryEvent)
+				}
+				requestContext.GinContext.SSEvent(models.SatisfactoryEventKey, encodeSseEvent(msg, namer))
 				AddClientMessageCount(client)
 			}
 			return true
 		}
 	})
 }
+
+// eventDataTypes are the data types of the streamed events. Only their field names are renamed by
+// the configured key naming, keys holding data such as IDs or item names are kept as they are.
+var eventDataTypes = map[models.SatisfactoryEventType]reflect.Type{
+	models.SatisfactoryEventApiStatus:         reflect.TypeFor[models.SatisfactoryApiStatus](),
+	models.SatisfactoryEventCircuits:          reflect.TypeFor[[]models.Circuit](),
+	models.SatisfactoryEventFactoryStats:      reflect.TypeFor[models.FactoryStats](),
+	models.SatisfactoryEventProdStats:         reflect.TypeFor[models.ProdStats](),
+	models.SatisfactoryEventSinkStats:         reflect.TypeFor[models.SinkStats](),
+	models.SatisfactoryEventPlayers:           reflect.TypeFor[[]models.Player](),
+	models.SatisfactoryEventGeneratorStats:    reflect.TypeFor[models.GeneratorStats](),
+	models.SatisfactoryEventVehicles:          reflect.TypeFor[models.Vehicles](),
+	models.SatisfactoryEventVehicleStations:   reflect.TypeFor[models.VehicleStations](),
+	models.SatisfactoryEventSessionUpdate:     reflect.TypeFor[models.SessionDTO](),
+	models.SatisfactoryEventBelts:             reflect.TypeFor[models.Belts](),
+	models.SatisfactoryEventPipes:             reflect.TypeFor[models.Pipes](),
+	models.SatisfactoryEventTrainRails:        reflect.TypeFor[[]models.TrainRail](),
+	models.SatisfactoryEventCables:            reflect.TypeFor[[]models.Cable](),
+	models.SatisfactoryEventStorages:          reflect.TypeFor[[]models.Storage](),
+	models.SatisfactoryEventMachines:          reflect.TypeFor[[]models.Machine](),
+	models.SatisfactoryEventTractors:          reflect.TypeFor[[]models.Tractor](),
+	models.SatisfactoryEventExplorers:         reflect.TypeFor[[]models.Explorer](),
+	models.SatisfactoryEventVehiclePaths:      reflect.TypeFor[[]models.VehiclePath](),
+	models.SatisfactoryEventSpaceElevator:     reflect.TypeFor[models.SpaceElevator](),
+	models.SatisfactoryEventHub:               reflect.TypeFor[models.Hub](),
+	models.SatisfactoryEventRadarTowers:       reflect.TypeFor[[]models.RadarTower](),
+	models.SatisfactoryEventResourceNodes:     reflect.TypeFor[[]models.ResourceNode](),
+	models.SatisfactoryEventHypertubes:        reflect.TypeFor[models.Hypertubes](),
+	models.SatisfactoryEventSchematics:        reflect.TypeFor[[]models.Schematic](),
+	models.SatisfactoryEventBaseScore:         reflect.TypeFor[models.BaseScore](),
+	models.SatisfactoryEventRemoved:           reflect.TypeFor[models.RemovedEntities](),
+	models.SatisfactoryEventDiagnostics:       reflect.TypeFor[[]models.EndpointError](),
+	models.SatisfactoryEventStalledVehicles:   reflect.TypeFor[[]models.StalledVehicle](),
+	models.SatisfactoryEventIdleConveyors:     reflect.TypeFor[[]models.IdleConveyor](),
+	models.SatisfactoryEventStuckStorageItems: reflect.TypeFor[[]models.StuckStorageItem](),
+	models.SatisfactoryEventPollBudget:        reflect.TypeFor[models.PollBudget](),
+	models.SatisfactoryEventProgression:       reflect.TypeFor[[]models.Progression](),
+	models.SatisfactoryEventShipTimer:         reflect.TypeFor[models.ShipTimer](),
+	models.SatisfactoryEventAlerts:            reflect.TypeFor[[]models.Alert](),
+}
+
+// encodeSseEvent serializes an event with the configured key naming.
+// Falls back to the event itself, serialized as-is by gin, if renaming fails.
+func encodeSseEvent(event models.SseSatisfactoryEvent, namer *utils.JSONNamer) any {
+	if namer == nil {
+		return event
+	}
+
+	// The envelope's data is an interface, so it is renamed with the type of the event's data first
+	if delta, ok := event.Data.(models.EventDelta); ok {
+		data, err := renameEventDelta(event.Type, delta, namer)
+		if err != nil {
+			log.PrettyError(fmt.Errorf("failed to apply JSON naming to event %s. details: %w", event.Type, err))
+			return event
+		}
+		event.Data = data
+	} else {
+		event.Data = namer.Rename(event.Data, eventDataTypes[event.Type])
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		log.PrettyError(fmt.Errorf("failed to marshal event %s. details: %w", event.Type, err))
+		return event
+	}
+
+	renamed, err := namer.Apply(data, reflect.TypeFor[models.SseSatisfactoryEvent]())
+	if err != nil {
+		log.PrettyError(fmt.Errorf("failed to apply JSON naming to event %s. details: %w", event.Type, err))
+		return event
+	}
+	return string(renamed)
+}
+
+// renameEventDelta renames the keys of a delta, its upserted entities with the type of their kind
+func renameEventDelta(eventType models.SatisfactoryEventType, delta models.EventDelta, namer *utils.JSONNamer) (any, error) {
+	entityTypes := make(map[string]reflect.Type)
+	for _, kind := range deltaKinds[eventType] {
+		entityTypes[kind.kind] = kind.entity
+	}
+
+	kinds := make([]models.EntityDelta, len(delta.Kinds))
+	for idx, kind := range delta.Kinds {
+		upserted := make([]models.DeltaEntity, len(kind.Upserted))
+		for entityIdx, entity := range kind.Upserted {
+			upserted[entityIdx] = models.DeltaEntity{ID: entity.ID, Data: namer.Rename(entity.Data, entityTypes[kind.Kind])}
+		}
+		kind.Upserted = upserted
+		kinds[idx] = kind
+	}
+	delta.Kinds = kinds
+
+	data, err := json.Marshal(delta)
+	if err != nil {
+		return nil, err
+	}
+	var decoded any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, err
+	}
+	return namer.Rename(decoded, reflect.TypeFor[models.EventDelta]()), nil
+}
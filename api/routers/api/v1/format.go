@@ -0,0 +1,31 @@
+package v1
+
+import (
+	"api/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ScaleValue godoc
+// @Summary Scale Value
+// @Description Get a value scaled to a k/M/G prefix with a display string, so all views render units consistently
+// @Tags Status
+// @Accept json
+// @Produce json
+// @Param value query number true "Raw value"
+// @Param unit query string false "Unit of the raw value, e.g. W or items/min"
+// @Success 200 {object} models.ScaledValue "Scaled value"
+// @Success 400 {object} models.ErrorResponse "Bad Request"
+// @Router /v1/format/scale [get]
+func ScaleValue(ginContext *gin.Context) {
+	requestContext := NewRequestContext(ginContext)
+
+	value, err := strconv.ParseFloat(ginContext.Query("value"), 64)
+	if err != nil {
+		requestContext.UserError("Invalid value parameter: must be a number")
+		return
+	}
+
+	requestContext.Ok(utils.ScaleValue(value, ginContext.Query("unit")))
+}
@@ -137,3 +137,42 @@ func ListHistorySaves(ginContext *gin.Context) {
 		CurrentSave: existingSession.SessionName,
 	})
 }
+
+// GetEventLog godoc
+// @Summary Get the event log for a session
+// @Description Returns recent notable state changes such as fuse trips, derailments, deaths and milestone or phase completions, oldest first
+// @Tags History
+// @Accept json
+// @Produce json
+// @Param id path string true "Session ID"
+// @Success 200 {array} models.EventLogEntry "Event log entries"
+// @Failure 404 {object} models.ErrorResponse "Session not found"
+// @Failure 500 {object} models.ErrorResponse "Internal Server Error"
+// @Router /v1/sessions/{id}/eventLog [get]
+func GetEventLog(ginContext *gin.Context) {
+	requestContext := NewRequestContext(ginContext)
+
+	sessionID := ginContext.Param("id")
+	if sessionID == "" {
+		requestContext.UserError("Session ID is required")
+		return
+	}
+
+	existingSession, err := getSessionStore().Get(sessionID)
+	if err != nil {
+		requestContext.ServerError(err, err)
+		return
+	}
+	if existingSession == nil {
+		requestContext.NotFound("Session not found")
+		return
+	}
+
+	eventLog, err := session.GetEventLog(sessionID)
+	if err != nil {
+		requestContext.ServerError(err, err)
+		return
+	}
+
+	requestContext.Ok(eventLog)
+}
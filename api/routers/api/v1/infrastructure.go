@@ -2,6 +2,7 @@ package v1
 
 import (
 	"api/models/models"
+	"api/service/analysis"
 	"api/service/session"
 	"fmt"
 
@@ -48,6 +49,76 @@ func ListBelts(ginContext *gin.Context) {
 	requestContext.Ok(beltsDto)
 }
 
+// ListMisroutedBelts godoc
+// @Summary List Misrouted Belts
+// @Description List belts whose carried items are not accepted by the machine they feed into
+// @Tags Infrastructure
+// @Accept json
+// @Produce json
+// @Param session_id query string true "Session ID"
+// @Success 200 {array} models.MisroutedBeltDTO "List of misrouted belts"
+// @Success 400 {object} models.ErrorResponse "Bad Request"
+// @Router /v1/belts/misrouted [get]
+func ListMisroutedBelts(ginContext *gin.Context) {
+	requestContext := NewRequestContext(ginContext)
+
+	sessionID := ginContext.Query("session_id")
+	if sessionID == "" {
+		requestContext.UserError("session_id query parameter is required")
+		return
+	}
+
+	store := session.NewStore()
+	sess, err := store.Get(sessionID)
+	if err != nil {
+		requestContext.ServerError(fmt.Errorf("failed to get session: %w", err), err)
+		return
+	}
+	if sess == nil {
+		requestContext.NotFound("Session not found")
+		return
+	}
+
+	state := session.GetCachedState(sessionID, sess.SessionName)
+
+	requestContext.Ok(analysis.FindMisroutedBelts(state.Belts, state.SplitterMergers, state.Machines))
+}
+
+// GetConveyorThroughput godoc
+// @Summary Get Conveyor Throughput
+// @Description Get the total flow on belts and pipes per carried item from cached session state
+// @Tags Infrastructure
+// @Accept json
+// @Produce json
+// @Param session_id query string true "Session ID"
+// @Success 200 {object} models.ConveyorThroughputDTO "Conveyor throughput by item"
+// @Success 400 {object} models.ErrorResponse "Bad Request"
+// @Router /v1/conveyors/throughput [get]
+func GetConveyorThroughput(ginContext *gin.Context) {
+	requestContext := NewRequestContext(ginContext)
+
+	sessionID := ginContext.Query("session_id")
+	if sessionID == "" {
+		requestContext.UserError("session_id query parameter is required")
+		return
+	}
+
+	store := session.NewStore()
+	sess, err := store.Get(sessionID)
+	if err != nil {
+		requestContext.ServerError(fmt.Errorf("failed to get session: %w", err), err)
+		return
+	}
+	if sess == nil {
+		requestContext.NotFound("Session not found")
+		return
+	}
+
+	state := session.GetCachedState(sessionID, sess.SessionName)
+
+	requestContext.Ok(analysis.GetConveyorThroughputByItem(state.Belts, state.SplitterMergers, state.Pipes, state.Machines, state.ProdStats))
+}
+
 // ListPipes godoc
 // @Summary List Pipes
 // @Description List all pipes from cached session state
@@ -197,3 +268,38 @@ func ListHypertubes(ginContext *gin.Context) {
 
 	requestContext.Ok(hypertubesDto)
 }
+
+// ListPipeNetworkBalances godoc
+// @Summary List Pipe Network Balances
+// @Description List the fluid balance of every pipe junction network, flagging networks fed more than their consumers can take, from cached session state
+// @Tags Infrastructure
+// @Accept json
+// @Produce json
+// @Param session_id query string true "Session ID"
+// @Success 200 {array} models.PipeNetworkBalanceDTO "Pipe network balances, most over-supplied first"
+// @Success 400 {object} models.ErrorResponse "Bad Request"
+// @Router /v1/pipes/balance [get]
+func ListPipeNetworkBalances(ginContext *gin.Context) {
+	requestContext := NewRequestContext(ginContext)
+
+	sessionID := ginContext.Query("session_id")
+	if sessionID == "" {
+		requestContext.UserError("session_id query parameter is required")
+		return
+	}
+
+	store := session.NewStore()
+	sess, err := store.Get(sessionID)
+	if err != nil {
+		requestContext.ServerError(fmt.Errorf("failed to get session: %w", err), err)
+		return
+	}
+	if sess == nil {
+		requestContext.NotFound("Session not found")
+		return
+	}
+
+	state := session.GetCachedState(sessionID, sess.SessionName)
+
+	requestContext.Ok(analysis.GetPipeNetworkBalances(state.Pipes, state.PipeJunctions, state.Machines, state.ProdStats))
+}
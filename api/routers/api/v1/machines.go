@@ -1,8 +1,13 @@
 package v1
 
 import (
+	"api/models/models"
+	"api/service/analysis"
 	"api/service/session"
 	"fmt"
+	"math"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -40,3 +45,278 @@ func GetMachines(ginContext *gin.Context) {
 	state := session.GetCachedState(sessionID, sess.SessionName)
 	requestContext.Ok(state.Machines)
 }
+
+// GetMachinesInBounds godoc
+// @Summary Get Machines In Bounds
+// @Description Get the machines whose bounding box intersects a region, e.g. the visible part of the map, from cached session state. A region without size, or with its min beyond its max, contains no machines.
+// @Tags Machines
+// @Accept json
+// @Produce json
+// @Param session_id query string true "Session ID"
+// @Param minX query number true "Min X of the region, in location units"
+// @Param minY query number true "Min Y of the region, in location units"
+// @Param maxX query number true "Max X of the region, in location units"
+// @Param maxY query number true "Max Y of the region, in location units"
+// @Param minZ query number false "Min Z of the region, in location units, unbounded if omitted"
+// @Param maxZ query number false "Max Z of the region, in location units, unbounded if omitted"
+// @Param category query string false "Only machines of this category: factory, extractor or generator"
+// @Success 200 {array} models.MachineDTO "Machines in the region"
+// @Success 400 {object} models.ErrorResponse "Bad Request"
+// @Router /v1/machines/inBounds [get]
+func GetMachinesInBounds(ginContext *gin.Context) {
+	requestContext := NewRequestContext(ginContext)
+
+	sessionID := ginContext.Query("session_id")
+	if sessionID == "" {
+		requestContext.UserError("session_id query parameter is required")
+		return
+	}
+
+	region := models.BoundingBox{
+		Min: models.Location{Z: math.Inf(-1)},
+		Max: models.Location{Z: math.Inf(1)},
+	}
+	bounds := []struct {
+		param    string
+		target   *float64
+		required bool
+	}{
+		{"minX", &region.Min.X, true},
+		{"minY", &region.Min.Y, true},
+		{"maxX", &region.Max.X, true},
+		{"maxY", &region.Max.Y, true},
+		{"minZ", &region.Min.Z, false},
+		{"maxZ", &region.Max.Z, false},
+	}
+	for _, bound := range bounds {
+		value := ginContext.Query(bound.param)
+		if value == "" {
+			if bound.required {
+				requestContext.UserError(fmt.Sprintf("%s query parameter is required", bound.param))
+				return
+			}
+			continue
+		}
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil || math.IsNaN(parsed) || math.IsInf(parsed, 0) {
+			requestContext.UserError(fmt.Sprintf("Invalid %s parameter: must be a number", bound.param))
+			return
+		}
+		*bound.target = parsed
+	}
+
+	category := models.MachineCategory(ginContext.Query("category"))
+	switch category {
+	case "", models.MachineCategoryFactory, models.MachineCategoryExtractor, models.MachineCategoryGenerator:
+	default:
+		requestContext.UserError("Invalid category parameter: must be one of factory, extractor, generator")
+		return
+	}
+
+	store := session.NewStore()
+	sess, err := store.Get(sessionID)
+	if err != nil {
+		requestContext.ServerError(fmt.Errorf("failed to get session: %w", err), err)
+		return
+	}
+	if sess == nil {
+		requestContext.NotFound("Session not found")
+		return
+	}
+
+	state := session.GetCachedState(sessionID, sess.SessionName)
+	requestContext.Ok(analysis.MachinesInBounds(state.Machines, region, category))
+}
+
+// GetItemProducers godoc
+// @Summary Get Item Producers
+// @Description Get the machines producing an item with their individual rates and efficiencies, from cached session state
+// @Tags Machines
+// @Accept json
+// @Produce json
+// @Param session_id query string true "Session ID"
+// @Param item query string true "Item name"
+// @Success 200 {object} models.ItemProducersDTO "Machines producing the item"
+// @Success 400 {object} models.ErrorResponse "Bad Request"
+// @Router /v1/machines/producers [get]
+func GetItemProducers(ginContext *gin.Context) {
+	requestContext := NewRequestContext(ginContext)
+
+	sessionID := ginContext.Query("session_id")
+	if sessionID == "" {
+		requestContext.UserError("session_id query parameter is required")
+		return
+	}
+
+	itemName := ginContext.Query("item")
+	if itemName == "" {
+		requestContext.UserError("item query parameter is required")
+		return
+	}
+
+	store := session.NewStore()
+	sess, err := store.Get(sessionID)
+	if err != nil {
+		requestContext.ServerError(fmt.Errorf("failed to get session: %w", err), err)
+		return
+	}
+	if sess == nil {
+		requestContext.NotFound("Session not found")
+		return
+	}
+
+	state := session.GetCachedState(sessionID, sess.SessionName)
+	requestContext.Ok(analysis.GetItemProducers(state.Machines, itemName))
+}
+
+// ListActiveRecipes godoc
+// @Summary List Active Recipes
+// @Description Get the recipes in use, inferred from machine inputs and outputs, with the number of machines running each and whether it is an alternate, from cached session state
+// @Tags Machines
+// @Accept json
+// @Produce json
+// @Param session_id query string true "Session ID"
+// @Success 200 {array} models.ActiveRecipeDTO "Active recipes, by product"
+// @Success 400 {object} models.ErrorResponse "Bad Request"
+// @Router /v1/machines/recipes [get]
+func ListActiveRecipes(ginContext *gin.Context) {
+	requestContext := NewRequestContext(ginContext)
+
+	sessionID := ginContext.Query("session_id")
+	if sessionID == "" {
+		requestContext.UserError("session_id query parameter is required")
+		return
+	}
+
+	store := session.NewStore()
+	sess, err := store.Get(sessionID)
+	if err != nil {
+		requestContext.ServerError(fmt.Errorf("failed to get session: %w", err), err)
+		return
+	}
+	if sess == nil {
+		requestContext.NotFound("Session not found")
+		return
+	}
+
+	state := session.GetCachedState(sessionID, sess.SessionName)
+	requestContext.Ok(analysis.GetActiveRecipes(state.Machines))
+}
+
+// ListRecipeRatioIssues godoc
+// @Summary List Recipe Ratio Issues
+// @Description Get the machines running below capacity because one input is starved while others are oversupplied, naming the limiting input, from cached session state
+// @Tags Machines
+// @Accept json
+// @Produce json
+// @Param session_id query string true "Session ID"
+// @Success 200 {array} models.RecipeRatioIssueDTO "Recipe ratio issues, lowest productivity first"
+// @Success 400 {object} models.ErrorResponse "Bad Request"
+// @Router /v1/machines/ratioIssues [get]
+func ListRecipeRatioIssues(ginContext *gin.Context) {
+	requestContext := NewRequestContext(ginContext)
+
+	sessionID := ginContext.Query("session_id")
+	if sessionID == "" {
+		requestContext.UserError("session_id query parameter is required")
+		return
+	}
+
+	store := session.NewStore()
+	sess, err := store.Get(sessionID)
+	if err != nil {
+		requestContext.ServerError(fmt.Errorf("failed to get session: %w", err), err)
+		return
+	}
+	if sess == nil {
+		requestContext.NotFound("Session not found")
+		return
+	}
+
+	state := session.GetCachedState(sessionID, sess.SessionName)
+	requestContext.Ok(analysis.GetRecipeRatioIssues(state.Machines))
+}
+
+// ListFuelBalances godoc
+// @Summary List Fuel Balances
+// @Description Get the net balance between production and fuel generator consumption per fuel, with a runway estimate from stored fuel, from cached session state
+// @Tags Machines
+// @Accept json
+// @Produce json
+// @Param session_id query string true "Session ID"
+// @Success 200 {array} models.FuelBalanceDTO "Fuel balances, worst first"
+// @Success 400 {object} models.ErrorResponse "Bad Request"
+// @Router /v1/machines/fuelBalance [get]
+func ListFuelBalances(ginContext *gin.Context) {
+	requestContext := NewRequestContext(ginContext)
+
+	sessionID := ginContext.Query("session_id")
+	if sessionID == "" {
+		requestContext.UserError("session_id query parameter is required")
+		return
+	}
+
+	store := session.NewStore()
+	sess, err := store.Get(sessionID)
+	if err != nil {
+		requestContext.ServerError(fmt.Errorf("failed to get session: %w", err), err)
+		return
+	}
+	if sess == nil {
+		requestContext.NotFound("Session not found")
+		return
+	}
+
+	state := session.GetCachedState(sessionID, sess.SessionName)
+	requestContext.Ok(analysis.GetFuelBalances(state.Machines, state.ProdStats))
+}
+
+// GetMachineBuilds godoc
+// @Summary Get Machine Builds
+// @Description Get the machine build pace since tracking started and the machines first seen after a time. Machines rebuilt in place are not counted again.
+// @Tags Machines
+// @Accept json
+// @Produce json
+// @Param session_id query string true "Session ID"
+// @Param since query string false "RFC 3339 time to list new machines after, defaults to when tracking started"
+// @Success 200 {object} models.MachineBuildStatsDTO "Machine build stats"
+// @Success 400 {object} models.ErrorResponse "Bad Request"
+// @Router /v1/machines/builds [get]
+func GetMachineBuilds(ginContext *gin.Context) {
+	requestContext := NewRequestContext(ginContext)
+
+	sessionID := ginContext.Query("session_id")
+	if sessionID == "" {
+		requestContext.UserError("session_id query parameter is required")
+		return
+	}
+
+	var since time.Time
+	if sinceParam := ginContext.Query("since"); sinceParam != "" {
+		parsed, err := time.Parse(time.RFC3339, sinceParam)
+		if err != nil {
+			requestContext.UserError("Invalid since parameter: must be an RFC 3339 time")
+			return
+		}
+		since = parsed
+	}
+
+	store := session.NewStore()
+	sess, err := store.Get(sessionID)
+	if err != nil {
+		requestContext.ServerError(fmt.Errorf("failed to get session: %w", err), err)
+		return
+	}
+	if sess == nil {
+		requestContext.NotFound("Session not found")
+		return
+	}
+
+	buildLog, err := session.GetMachineBuildLog(sessionID)
+	if err != nil {
+		requestContext.ServerError(err, err)
+		return
+	}
+
+	requestContext.Ok(analysis.GetMachineBuildStats(buildLog, since, time.Now()))
+}
@@ -0,0 +1,106 @@
+package v1
+
+import (
+	"api/models/models"
+	"api/service/session"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	openMetricsContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+	maxExemplarLabelLength = 100 // OpenMetrics caps the exemplar label set at 128 characters
+)
+
+// GetOpenMetrics godoc
+// @Summary Get OpenMetrics
+// @Description Export the alert states and incident counters of all sessions in the OpenMetrics text format. Alerts are gauges (1 firing, 0 clear), fired alerts and incidents such as derailments and fuse trips are counters that only increase until the session is deleted, with the latest incident as exemplar
+// @Tags Status
+// @Produce plain
+// @Success 200 {string} string "OpenMetrics exposition"
+// @Failure 500 {object} models.ErrorResponse "Internal Server Error"
+// @Router /v1/metrics [get]
+func GetOpenMetrics(ginContext *gin.Context) {
+	requestContext := NewRequestContext(ginContext)
+
+	sessions, err := session.NewStore().List()
+	if err != nil {
+		requestContext.ServerError(fmt.Errorf("failed to list sessions: %w", err), err)
+		return
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].ID < sessions[j].ID })
+
+	stats := make([]models.IncidentStats, len(sessions))
+	for idx, sess := range sessions {
+		stats[idx], err = session.GetIncidentStats(sess.ID)
+		if err != nil {
+			requestContext.ServerError(fmt.Errorf("failed to get incident stats: %w", err), err)
+			return
+		}
+	}
+
+	ginContext.Data(http.StatusOK, openMetricsContentType, []byte(renderOpenMetrics(sessions, stats)))
+}
+
+// renderOpenMetrics writes one metric family at a time, as OpenMetrics requires
+func renderOpenMetrics(sessions []*models.Session, stats []models.IncidentStats) string {
+	var out strings.Builder
+	labels := func(sess *models.Session, name, value string) string {
+		return fmt.Sprintf(`session="%s",label="%s",%s="%s"`, escapeLabel(sess.ID), escapeLabel(sess.Label), name, escapeLabel(value))
+	}
+
+	out.WriteString("# TYPE satisfactory_alert_firing gauge\n")
+	out.WriteString("# HELP satisfactory_alert_firing Whether the alert is currently firing.\n")
+	for idx, sess := range sessions {
+		for _, alert := range models.AlertNames {
+			firing := 0
+			if stats[idx].Firing[alert] {
+				firing = 1
+			}
+			fmt.Fprintf(&out, "satisfactory_alert_firing{%s} %d\n", labels(sess, "alert", string(alert)), firing)
+		}
+	}
+
+	out.WriteString("# TYPE satisfactory_alerts_fired counter\n")
+	out.WriteString("# HELP satisfactory_alerts_fired Times the alert started firing.\n")
+	for idx, sess := range sessions {
+		for _, alert := range models.AlertNames {
+			fmt.Fprintf(&out, "satisfactory_alerts_fired_total{%s} %d\n", labels(sess, "alert", string(alert)), stats[idx].AlertsFired[alert])
+		}
+	}
+
+	incidentTypes := []models.EventLogEntryType{
+		models.EventLogEntryFuseTriggered,
+		models.EventLogEntryTrainDerailed,
+		models.EventLogEntryPlayerDied,
+		models.EventLogEntryMilestoneCompleted,
+		models.EventLogEntryPhaseCompleted,
+		models.EventLogEntrySchematicUnlocked,
+	}
+	out.WriteString("# TYPE satisfactory_incidents counter\n")
+	out.WriteString("# HELP satisfactory_incidents Event log incidents, such as fuse trips and derailments.\n")
+	for idx, sess := range sessions {
+		for _, incidentType := range incidentTypes {
+			fmt.Fprintf(&out, "satisfactory_incidents_total{%s} %d", labels(sess, "type", string(incidentType)), stats[idx].Incidents[incidentType])
+			if last, ok := stats[idx].Last[incidentType]; ok {
+				description := []rune(last.Description)
+				if len(description) > maxExemplarLabelLength {
+					description = description[:maxExemplarLabelLength]
+				}
+				fmt.Fprintf(&out, ` # {description="%s"} 1 %.3f`, escapeLabel(string(description)), float64(last.Timestamp.UnixMilli())/1000)
+			}
+			out.WriteString("\n")
+		}
+	}
+
+	out.WriteString("# EOF\n")
+	return out.String()
+}
+
+func escapeLabel(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
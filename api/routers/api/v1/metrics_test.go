@@ -0,0 +1,74 @@
+package v1
+
+import (
+	"api/models/models"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRenderOpenMetrics(t *testing.T) {
+	sessions := []*models.Session{{ID: "a", Label: `Main "base"` + "\n"}, {ID: "b"}}
+	stats := []models.IncidentStats{
+		{
+			Firing:      map[models.AlertName]bool{models.AlertFuseTriggered: true},
+			AlertsFired: map[models.AlertName]int{models.AlertFuseTriggered: 3},
+			Incidents:   map[models.EventLogEntryType]int{models.EventLogEntryTrainDerailed: 2},
+			Last: map[models.EventLogEntryType]models.EventLogEntry{models.EventLogEntryTrainDerailed: {
+				Timestamp:   time.UnixMilli(1700000000250),
+				Type:        models.EventLogEntryTrainDerailed,
+				Description: strings.Repeat("ä", 120),
+			}},
+		},
+		{},
+	}
+
+	output := renderOpenMetrics(sessions, stats)
+	if !strings.HasSuffix(output, "\n# EOF\n") {
+		t.Errorf("got output not terminated by # EOF: %q", output)
+	}
+
+	// Every sample belongs to the family declared last, counters with the _total suffix
+	lines := strings.Split(strings.TrimSuffix(output, "\n"), "\n")
+	family, familyType, samples := "", "", 0
+	for _, line := range lines[:len(lines)-1] {
+		fields := strings.Fields(line)
+		if strings.HasPrefix(line, "# TYPE ") {
+			family, familyType = fields[2], fields[3]
+			continue
+		}
+		if strings.HasPrefix(line, "# HELP ") {
+			if fields[2] != family {
+				t.Errorf("got help for %s inside family %s", fields[2], family)
+			}
+			continue
+		}
+		name := line[:strings.Index(line, "{")]
+		wantName := family
+		if familyType == "counter" {
+			wantName += "_total"
+		}
+		if name != wantName {
+			t.Errorf("got sample %s in family %s of type %s, want %s", name, family, familyType, wantName)
+		}
+		samples++
+	}
+	// Both sessions, with every alert twice and every incident type once
+	if want := 2 * (2*len(models.AlertNames) + 6); samples != want {
+		t.Errorf("got %d samples, want %d", samples, want)
+	}
+
+	expected := []string{
+		`satisfactory_alert_firing{session="a",label="Main \"base\"\n",alert="fuseTriggered"} 1` + "\n",
+		`satisfactory_alert_firing{session="b",label="",alert="fuseTriggered"} 0` + "\n",
+		`satisfactory_alerts_fired_total{session="a",label="Main \"base\"\n",alert="fuseTriggered"} 3` + "\n",
+		`satisfactory_incidents_total{session="a",label="Main \"base\"\n",type="trainDerailed"} 2 # {description="` +
+			strings.Repeat("ä", maxExemplarLabelLength) + `"} 1 1700000000.250` + "\n",
+		`satisfactory_incidents_total{session="b",label="",type="trainDerailed"} 0` + "\n",
+	}
+	for _, want := range expected {
+		if !strings.Contains(output, want) {
+			t.Errorf("got output without %q:\n%s", want, output)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package v1
 
 import (
 	"api/models/models"
+	"api/service/lease"
 	"api/worker"
 	"context"
 	"fmt"
@@ -155,3 +156,48 @@ func GetNodes(ginContext *gin.Context) {
 
 	requestContext.Ok(response)
 }
+
+// GetNodeLeases godoc
+// @Summary Get Cluster Lease Map
+// @Description Get every session lease in Redis with its owner, timestamps and whether the owner's heartbeat is live. Leases of owners without a heartbeat are marked orphaned.
+// @Tags Nodes
+// @Produce json
+// @Success 200 {object} lease.ClusterSnapshot "Cluster lease map"
+// @Failure 500 {object} models.ErrorResponse "Internal Server Error"
+// @Router /v1/nodes/leases [get]
+func GetNodeLeases(ginContext *gin.Context) {
+	requestContext := NewRequestContext(ginContext)
+
+	leaseManager := worker.GetGlobalLeaseManager()
+	if leaseManager == nil {
+		requestContext.ServerError(
+			fmt.Errorf("lease manager not initialized"),
+			fmt.Errorf("lease manager not available"),
+		)
+		return
+	}
+
+	var snapshot *lease.ClusterSnapshot
+	snapshot, err := leaseManager.ClusterSnapshot(ginContext.Request.Context())
+	if err != nil {
+		requestContext.ServerError(
+			fmt.Errorf("failed to take cluster snapshot: %w", err),
+			err,
+		)
+		return
+	}
+
+	requestContext.Ok(snapshot)
+}
+
+// GetNodeLeaseEvents godoc
+// @Summary Get Lease Events
+// @Description Get the recent lease lifecycle events of this instance (acquired, released, uncertain, reacquired, taken over), oldest first
+// @Tags Nodes
+// @Produce json
+// @Success 200 {array} lease.LeaseEvent "Recent lease events"
+// @Router /v1/nodes/leases/events [get]
+func GetNodeLeaseEvents(ginContext *gin.Context) {
+	requestContext := NewRequestContext(ginContext)
+	requestContext.Ok(worker.RecentLeaseEvents())
+}
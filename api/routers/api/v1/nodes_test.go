@@ -0,0 +1,69 @@
+package v1
+
+import (
+	"api/pkg/db/key_value"
+	"api/service/lease"
+	"api/worker"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+func TestGetNodeLeasesClassifiesLeases(t *testing.T) {
+	server := miniredis.RunT(t)
+	redisClient := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = redisClient.Close() })
+	client := &key_value.Client{RedisClient: redisClient}
+	ctx := context.Background()
+
+	for _, node := range []string{"node-a", "node-b"} {
+		if err := lease.RefreshHeartbeat(ctx, client, node, "online", time.Now(), 1, time.Minute); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Leases are stored under poll:lease:{sessionID}, node-dead has no heartbeat
+	for sessionID, owner := range map[string]string{"other-owned": "node-a", "orphaned": "node-dead"} {
+		value, _ := lease.RedisLeaseValue{OwnerID: owner, Token: 1}.Marshal()
+		if err := redisClient.Set(ctx, "poll:lease:"+sessionID, value, time.Minute).Err(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	worker.SetGlobalLeaseManager(lease.NewLeaseManagerWithID("node-b", client, lease.DefaultLeaseConfig(), zap.NewNop()))
+	t.Cleanup(func() { worker.SetGlobalLeaseManager(nil) })
+
+	gin.SetMode(gin.TestMode)
+	recorder := httptest.NewRecorder()
+	ginContext, _ := gin.CreateTestContext(recorder)
+	ginContext.Request = httptest.NewRequest(http.MethodGet, "/v1/nodes/leases", nil)
+	GetNodeLeases(ginContext)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("got status %d: %s", recorder.Code, recorder.Body)
+	}
+	var snapshot lease.ClusterSnapshot
+	if err := json.Unmarshal(recorder.Body.Bytes(), &snapshot); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]lease.LeaseStatus{"other-owned": lease.LeaseStatusLive, "orphaned": lease.LeaseStatusOrphaned}
+	if len(snapshot.Leases) != len(want) {
+		t.Fatalf("got %d leases, want %d: %+v", len(snapshot.Leases), len(want), snapshot.Leases)
+	}
+	for _, got := range snapshot.Leases {
+		if got.Status != want[got.SessionID] {
+			t.Errorf("%s: got status %s, want %s", got.SessionID, got.Status, want[got.SessionID])
+		}
+	}
+	if snapshot.InstanceID != "node-b" || len(snapshot.LiveNodes) != 2 {
+		t.Errorf("got instance %s with live nodes %v, want node-b with 2 live nodes", snapshot.InstanceID, snapshot.LiveNodes)
+	}
+}
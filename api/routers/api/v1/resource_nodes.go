@@ -1,8 +1,10 @@
 package v1
 
 import (
+	"api/service/analysis"
 	"api/service/session"
 	"fmt"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
@@ -41,3 +43,85 @@ func ListResourceNodes(ginContext *gin.Context) {
 
 	requestContext.Ok(state.ResourceNodes)
 }
+
+// defaultMinerTier is the miner tier used for capacity estimates unless one is requested
+const defaultMinerTier = 3
+
+// ListResourceCapacity godoc
+// @Summary List Resource Capacity
+// @Description List exploited and available nodes per resource type with the current and additional extraction rate, from cached session state
+// @Tags Resources
+// @Accept json
+// @Produce json
+// @Param session_id query string true "Session ID"
+// @Param minerTier query int false "Miner tier (1-3) used for the additional rate on solid nodes, defaults to 3"
+// @Success 200 {array} models.ResourceCapacityDTO "Resource capacity per resource type"
+// @Success 400 {object} models.ErrorResponse "Bad Request"
+// @Router /v1/resourceNodes/capacity [get]
+func ListResourceCapacity(ginContext *gin.Context) {
+	requestContext := NewRequestContext(ginContext)
+
+	sessionID := ginContext.Query("session_id")
+	if sessionID == "" {
+		requestContext.UserError("session_id query parameter is required")
+		return
+	}
+
+	minerTier := defaultMinerTier
+	if minerTierParam := ginContext.Query("minerTier"); minerTierParam != "" {
+		parsed, err := strconv.Atoi(minerTierParam)
+		if err != nil || !analysis.IsValidMinerTier(parsed) {
+			requestContext.UserError("Invalid minerTier parameter: must be 1, 2 or 3")
+			return
+		}
+		minerTier = parsed
+	}
+
+	store := session.NewStore()
+	sess, err := store.Get(sessionID)
+	if err != nil {
+		requestContext.ServerError(fmt.Errorf("failed to get session: %w", err), err)
+		return
+	}
+	if sess == nil {
+		requestContext.NotFound("Session not found")
+		return
+	}
+
+	state := session.GetCachedState(sessionID, sess.SessionName)
+	requestContext.Ok(analysis.EstimateResourceCapacity(state.ResourceNodes, state.Machines, minerTier))
+}
+
+// ListResourceNodeSummary godoc
+// @Summary List Resource Node Summary
+// @Description Count the resource nodes of each resource type by purity, split by exploited and unexploited, across all radar towers and the world node list, from cached session state. Nodes in overlapping radar ranges are counted once
+// @Tags Resources
+// @Accept json
+// @Produce json
+// @Param session_id query string true "Session ID"
+// @Success 200 {array} models.ResourceNodeSummaryDTO "Node counts per resource type"
+// @Success 400 {object} models.ErrorResponse "Bad Request"
+// @Router /v1/resourceNodes/summary [get]
+func ListResourceNodeSummary(ginContext *gin.Context) {
+	requestContext := NewRequestContext(ginContext)
+
+	sessionID := ginContext.Query("session_id")
+	if sessionID == "" {
+		requestContext.UserError("session_id query parameter is required")
+		return
+	}
+
+	store := session.NewStore()
+	sess, err := store.Get(sessionID)
+	if err != nil {
+		requestContext.ServerError(fmt.Errorf("failed to get session: %w", err), err)
+		return
+	}
+	if sess == nil {
+		requestContext.NotFound("Session not found")
+		return
+	}
+
+	state := session.GetCachedState(sessionID, sess.SessionName)
+	requestContext.Ok(analysis.SummarizeResourceNodes(state.RadarTowers, state.ResourceNodes))
+}
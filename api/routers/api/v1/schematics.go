@@ -2,6 +2,7 @@ package v1
 
 import (
 	"api/models/models"
+	"api/service/analysis"
 	"api/service/session"
 	"fmt"
 
@@ -47,3 +48,37 @@ func ListSchematics(ginContext *gin.Context) {
 
 	requestContext.Ok(schematicsDto)
 }
+
+// GetTechProgress godoc
+// @Summary Get Tech Progress
+// @Description Get the milestones grouped by tier with the share purchased per tier, and which are unlockable now, phase-locked or tier-locked, from cached session state. Lists are sorted by tier then name
+// @Tags Schematics
+// @Accept json
+// @Produce json
+// @Param session_id query string true "Session ID"
+// @Success 200 {object} models.TechProgressDTO "Tech progress"
+// @Success 400 {object} models.ErrorResponse "Bad Request"
+// @Router /v1/schematics/progress [get]
+func GetTechProgress(ginContext *gin.Context) {
+	requestContext := NewRequestContext(ginContext)
+
+	sessionID := ginContext.Query("session_id")
+	if sessionID == "" {
+		requestContext.UserError("session_id query parameter is required")
+		return
+	}
+
+	store := session.NewStore()
+	sess, err := store.Get(sessionID)
+	if err != nil {
+		requestContext.ServerError(fmt.Errorf("failed to get session: %w", err), err)
+		return
+	}
+	if sess == nil {
+		requestContext.NotFound("Session not found")
+		return
+	}
+
+	state := session.GetCachedState(sessionID, sess.SessionName)
+	requestContext.Ok(analysis.GetTechProgress(state.Schematics))
+}
@@ -2,6 +2,7 @@ package v1
 
 import (
 	"api/models/models"
+	"api/pkg/config"
 	"api/pkg/log"
 	"api/service"
 	"api/service/session"
@@ -84,16 +85,28 @@ func CreateSession(ginContext *gin.Context) {
 		return
 	}
 
+	if req.Label == "" {
+		req.Label = req.Name
+	}
+
 	// Create session object
 	newSession := &models.Session{
 		Name:      req.Name,
 		Address:   req.Address,
+		Headers:   req.Headers,
+		Label:     req.Label,
+		Metadata:  req.Metadata,
 		IsOnline:  false,
 		CreatedAt: time.Now(),
 	}
 
 	// Try to fetch session info from the target (but don't fail if it's offline)
-	client := service.NewClientWithAddress(req.Address)
+	client, err := service.NewClient(service.ClientConfig{Address: req.Address, Headers: req.Headers})
+	if err != nil {
+		requestContext.UserError(err.Error())
+		return
+	}
+	defer client.Close()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -161,6 +174,28 @@ func DeleteSession(ginContext *gin.Context) {
 		// Continue with deletion even if cleanup fails
 	}
 
+	if config.Config.HistoryArchiveDir != "" {
+		if err := session.ClearHistoryArchive(config.Config.HistoryArchiveDir, sessionID); err != nil {
+			log.Warnf("Failed to clear history archive for session %s: %v", sessionID, err)
+		}
+	}
+
+	if err := session.ClearEventLog(sessionID); err != nil {
+		log.Warnf("Failed to clear event log for session %s: %v", sessionID, err)
+	}
+
+	if err := session.ClearMachineBuildLog(sessionID); err != nil {
+		log.Warnf("Failed to clear machine build log for session %s: %v", sessionID, err)
+	}
+
+	if err := session.ClearProdHistory(sessionID); err != nil {
+		log.Warnf("Failed to clear prod history for session %s: %v", sessionID, err)
+	}
+
+	if err := session.ClearIncidentStats(sessionID); err != nil {
+		log.Warnf("Failed to clear incident stats for session %s: %v", sessionID, err)
+	}
+
 	// Delete the session
 	if err := getSessionStore().Delete(sessionID); err != nil {
 		requestContext.ServerError(fmt.Errorf("failed to delete session: %w", err), err)
@@ -206,7 +241,7 @@ func GetSession(ginContext *gin.Context) {
 
 // UpdateSession godoc
 // @Summary Update Session
-// @Description Update a session's properties (name, paused state, address). All fields are optional.
+// @Description Update a session's properties (name, paused state, address, FRM request headers). All fields are optional.
 // @Tags Sessions
 // @Accept json
 // @Produce json
@@ -233,8 +268,8 @@ func UpdateSession(ginContext *gin.Context) {
 	}
 
 	// Check that at least one field is provided
-	if req.Name == nil && req.IsPaused == nil && req.Address == nil {
-		requestContext.UserError("At least one field (name, isPaused, or address) must be provided")
+	if req.Name == nil && req.IsPaused == nil && req.Address == nil && req.Headers == nil {
+		requestContext.UserError("At least one field (name, isPaused, address, or headers) must be provided")
 		return
 	}
 
@@ -270,6 +305,9 @@ func UpdateSession(ginContext *gin.Context) {
 	if req.Address != nil {
 		existingSession.Address = *req.Address
 	}
+	if req.Headers != nil {
+		existingSession.Headers = *req.Headers
+	}
 
 	if err := getSessionStore().Update(existingSession); err != nil {
 		requestContext.ServerError(fmt.Errorf("failed to update session: %w", err), err)
@@ -311,7 +349,12 @@ func ValidateSession(ginContext *gin.Context) {
 	}
 
 	// Create client for the session
-	client := service.NewClientWithAddress(existingSession.Address)
+	client, err := service.NewClient(service.ClientConfig{Address: existingSession.Address, Headers: existingSession.Headers})
+	if err != nil {
+		requestContext.UserError(err.Error())
+		return
+	}
+	defer client.Close()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -357,7 +400,12 @@ func PreviewSession(ginContext *gin.Context) {
 		return
 	}
 
-	client := service.NewClientWithAddress(address)
+	client, err := service.NewClient(service.ClientConfig{Address: address})
+	if err != nil {
+		requestContext.UserError(err.Error())
+		return
+	}
+	defer client.Close()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -0,0 +1,57 @@
+package v1
+
+import (
+	"api/models/models"
+	"api/pkg/db"
+	"api/service/session"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestUpdateSessionKeepsLabelAndMetadata(t *testing.T) {
+	server := miniredis.RunT(t)
+	previous := db.DB.RedisClient
+	db.DB.RedisClient = redis.NewClient(&redis.Options{Addr: server.Addr()})
+	// The handlers share a store bound to the Redis client at first use
+	sessionStore, sessionStoreOnce = nil, sync.Once{}
+	t.Cleanup(func() {
+		_ = db.DB.RedisClient.Close()
+		db.DB.RedisClient = previous
+		sessionStore, sessionStoreOnce = nil, sync.Once{}
+	})
+
+	sess := &models.Session{Name: "base", Address: "localhost:8080", Label: "Main base", Metadata: map[string]string{"region": "eu"}}
+	if err := session.NewStore().Create(sess); err != nil {
+		t.Fatal(err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	recorder := httptest.NewRecorder()
+	ginContext, _ := gin.CreateTestContext(recorder)
+	body := `{"name": "Renamed", "label": "Other base", "metadata": {"region": "us"}}`
+	ginContext.Request = httptest.NewRequest(http.MethodPatch, "/v1/sessions/"+sess.ID, strings.NewReader(body))
+	ginContext.Request.Header.Set("Content-Type", "application/json")
+	ginContext.Params = gin.Params{{Key: "id", Value: sess.ID}}
+	UpdateSession(ginContext)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("got status %d: %s", recorder.Code, recorder.Body)
+	}
+	stored, err := session.NewStore().Get(sess.ID)
+	if err != nil || stored == nil {
+		t.Fatalf("failed to get the updated session: %v", err)
+	}
+	if stored.Name != "Renamed" {
+		t.Errorf("got name %q, want the update applied", stored.Name)
+	}
+	if stored.Label != "Main base" || stored.Metadata["region"] != "eu" {
+		t.Errorf("got label %q and metadata %v, want the values set on creation", stored.Label, stored.Metadata)
+	}
+}
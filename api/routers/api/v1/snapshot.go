@@ -0,0 +1,190 @@
+package v1
+
+import (
+	"api/models/models"
+	"api/service"
+	"api/service/frm_client"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// worldSnapshotTimeout bounds fetching all sections of a world snapshot
+const worldSnapshotTimeout = 60 * time.Second
+
+// worldSnapshotter is implemented by clients that can fetch the whole world at once
+type worldSnapshotter interface {
+	SnapshotAll(ctx context.Context) (models.WorldSnapshot, error)
+}
+
+// ExportWorldSnapshot godoc
+// @Summary Export World Snapshot
+// @Description Fetch the full current state of the game server and stream it as JSON Lines. The first line carries the snapshot time, then every entity is a line of its own tagged with its section. Sections that failed to fetch are a single line with their error.
+// @Tags Sessions
+// @Produce application/x-ndjson
+// @Param id path string true "Session ID"
+// @Success 200 {object} models.WorldSnapshotLine "One snapshot line, repeated"
+// @Failure 400 {object} models.ErrorResponse "Bad Request"
+// @Failure 404 {object} models.ErrorResponse "Session not found"
+// @Failure 500 {object} models.ErrorResponse "Internal Server Error"
+// @Router /v1/sessions/{id}/snapshot [get]
+func ExportWorldSnapshot(ginContext *gin.Context) {
+	requestContext := NewRequestContext(ginContext)
+
+	sessionID := ginContext.Param("id")
+	if sessionID == "" {
+		requestContext.UserError("Session ID is required")
+		return
+	}
+
+	existingSession, err := getSessionStore().Get(sessionID)
+	if err != nil {
+		requestContext.ServerError(fmt.Errorf("failed to get session: %w", err), err)
+		return
+	}
+	if existingSession == nil {
+		requestContext.NotFound("Session not found")
+		return
+	}
+
+	sessionClient, err := service.NewClient(service.ClientConfig{Address: existingSession.Address, Headers: existingSession.Headers})
+	if err != nil {
+		requestContext.UserError(err.Error())
+		return
+	}
+	// The client only lives for this export, stop its request queue workers when done
+	defer sessionClient.Close()
+
+	snapshotter, ok := sessionClient.(worldSnapshotter)
+	if !ok {
+		requestContext.UserError("World snapshots are not supported for this session")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ginContext.Request.Context(), worldSnapshotTimeout)
+	defer cancel()
+
+	snapshot, err := snapshotter.SnapshotAll(ctx)
+	if err != nil {
+		requestContext.ServerError(fmt.Errorf("failed to take world snapshot: %w", err), err)
+		return
+	}
+
+	ginContext.Header("Content-Type", "application/x-ndjson")
+	ginContext.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fmt.Sprintf("snapshot-%s.jsonl", snapshot.TakenAt.UTC().Format("20060102-150405"))))
+	ginContext.Status(http.StatusOK)
+
+	// Errors past this point can only be from a client that went away
+	_ = writeWorldSnapshotLines(ginContext.Writer, &snapshot)
+}
+
+// writeSnapshotItems writes one line per item of a section
+func writeSnapshotItems[T any](encoder *json.Encoder, section string, items []T) error {
+	for _, item := range items {
+		if err := encoder.Encode(models.WorldSnapshotLine{Section: section, Data: item}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeSnapshotObject writes a single-object section as one line, nothing if it is missing
+func writeSnapshotObject[T any](encoder *json.Encoder, section string, object *T) error {
+	if object == nil {
+		return nil
+	}
+	return encoder.Encode(models.WorldSnapshotLine{Section: section, Data: object})
+}
+
+// writeWorldSnapshotLines serializes a snapshot as JSON Lines, flushing after every section so
+// huge snapshots leave as they are written. Composite sections are split into their entity lists,
+// e.g. vehicles.trains, and a section that failed to fetch is a single error line.
+func writeWorldSnapshotLines(writer io.Writer, snapshot *models.WorldSnapshot) error {
+	encoder := json.NewEncoder(writer)
+	flusher, _ := writer.(http.Flusher)
+
+	takenAt := snapshot.TakenAt
+	if err := encoder.Encode(models.WorldSnapshotLine{Section: "snapshot", TakenAt: &takenAt}); err != nil {
+		return err
+	}
+
+	sections := []struct {
+		name  string
+		write func(section string) error
+	}{
+		{frm_client.SnapshotSectionMachines, func(section string) error {
+			return writeSnapshotItems(encoder, section, snapshot.Machines)
+		}},
+		{frm_client.SnapshotSectionCircuits, func(section string) error {
+			return writeSnapshotItems(encoder, section, snapshot.Circuits)
+		}},
+		{frm_client.SnapshotSectionProdStats, func(section string) error {
+			return writeSnapshotObject(encoder, section, snapshot.ProdStats)
+		}},
+		{frm_client.SnapshotSectionVehicles, func(section string) error {
+			return errors.Join(
+				writeSnapshotItems(encoder, section+".trains", snapshot.Vehicles.Trains),
+				writeSnapshotItems(encoder, section+".drones", snapshot.Vehicles.Drones),
+				writeSnapshotItems(encoder, section+".trucks", snapshot.Vehicles.Trucks),
+				writeSnapshotItems(encoder, section+".tractors", snapshot.Vehicles.Tractors),
+				writeSnapshotItems(encoder, section+".explorers", snapshot.Vehicles.Explorers),
+			)
+		}},
+		{frm_client.SnapshotSectionVehicleStations, func(section string) error {
+			return errors.Join(
+				writeSnapshotItems(encoder, section+".trainStations", snapshot.VehicleStations.TrainStations),
+				writeSnapshotItems(encoder, section+".droneStations", snapshot.VehicleStations.DroneStations),
+				writeSnapshotItems(encoder, section+".truckStations", snapshot.VehicleStations.TruckStations),
+			)
+		}},
+		{frm_client.SnapshotSectionBelts, func(section string) error {
+			return errors.Join(
+				writeSnapshotItems(encoder, section, snapshot.Belts.Belts),
+				writeSnapshotItems(encoder, section+".splitterMergers", snapshot.Belts.SplitterMergers),
+			)
+		}},
+		{frm_client.SnapshotSectionPipes, func(section string) error {
+			return errors.Join(
+				writeSnapshotItems(encoder, section, snapshot.Pipes.Pipes),
+				writeSnapshotItems(encoder, section+".pipeJunctions", snapshot.Pipes.PipeJunctions),
+			)
+		}},
+		{frm_client.SnapshotSectionStorages, func(section string) error {
+			return writeSnapshotItems(encoder, section, snapshot.Storages)
+		}},
+		{frm_client.SnapshotSectionRadarTowers, func(section string) error {
+			return writeSnapshotItems(encoder, section, snapshot.RadarTowers)
+		}},
+		{frm_client.SnapshotSectionSpaceElevator, func(section string) error {
+			return writeSnapshotObject(encoder, section, snapshot.SpaceElevator)
+		}},
+		{frm_client.SnapshotSectionHub, func(section string) error {
+			return writeSnapshotObject(encoder, section, snapshot.Hub)
+		}},
+		{frm_client.SnapshotSectionSchematics, func(section string) error {
+			return writeSnapshotItems(encoder, section, snapshot.Schematics)
+		}},
+	}
+
+	for _, section := range sections {
+		var err error
+		if message, failed := snapshot.Errors[section.name]; failed {
+			err = encoder.Encode(models.WorldSnapshotLine{Section: section.name, Error: message})
+		} else {
+			err = section.write(section.name)
+		}
+		if err != nil {
+			return err
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+	return nil
+}
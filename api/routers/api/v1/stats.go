@@ -1,8 +1,15 @@
 package v1
 
 import (
+	"api/models/models"
+	"api/pkg/config"
+	"api/service/analysis"
+	"api/service/frm_client"
 	"api/service/session"
+	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
@@ -109,6 +116,245 @@ func GetProdStats(ginContext *gin.Context) {
 	requestContext.Ok(state.ProdStats.ToDTO())
 }
 
+// GetProdStatsFiltered godoc
+// @Summary Get Filtered Prod Stats
+// @Description Get the prod stats items matching the given filters from cached session state, sorted by produced rate
+// @Tags Stats
+// @Accept json
+// @Produce json
+// @Param session_id query string true "Session ID"
+// @Param form query string false "Resource form (solid, liquid, gas)"
+// @Param category query string false "Item category"
+// @Param minable query bool false "Only minable (true) or only non-minable (false) items"
+// @Param minRate query number false "Minimum produced or consumed rate per minute"
+// @Success 200 {array} models.ItemProdStats "Filtered prod stats items"
+// @Success 400 {object} models.ErrorResponse "Bad Request"
+// @Router /v1/prodStats/filtered [get]
+func GetProdStatsFiltered(ginContext *gin.Context) {
+	requestContext := NewRequestContext(ginContext)
+
+	sessionID := ginContext.Query("session_id")
+	if sessionID == "" {
+		requestContext.UserError("session_id query parameter is required")
+		return
+	}
+
+	filter := analysis.ProdStatsFilter{
+		Form:     models.ResourceForm(ginContext.Query("form")),
+		Category: models.ItemCategory(ginContext.Query("category")),
+	}
+
+	switch filter.Form {
+	case "", models.ResourceFormSolid, models.ResourceFormLiquid, models.ResourceFormGas:
+	default:
+		requestContext.UserError("Invalid form parameter: must be one of solid, liquid, gas")
+		return
+	}
+
+	if minableParam := ginContext.Query("minable"); minableParam != "" {
+		minable, err := strconv.ParseBool(minableParam)
+		if err != nil {
+			requestContext.UserError("Invalid minable parameter: must be a boolean")
+			return
+		}
+		filter.Minable = &minable
+	}
+
+	if minRateParam := ginContext.Query("minRate"); minRateParam != "" {
+		minRate, err := strconv.ParseFloat(minRateParam, 64)
+		if err != nil || minRate < 0 {
+			requestContext.UserError("Invalid minRate parameter: must be a non-negative number")
+			return
+		}
+		filter.MinRate = minRate
+	}
+
+	store := session.NewStore()
+	sess, err := store.Get(sessionID)
+	if err != nil {
+		requestContext.ServerError(fmt.Errorf("failed to get session: %w", err), err)
+		return
+	}
+	if sess == nil {
+		requestContext.NotFound("Session not found")
+		return
+	}
+
+	state := session.GetCachedState(sessionID, sess.SessionName)
+	requestContext.Ok(analysis.FilterProdStats(state.ProdStats, filter))
+}
+
+// GetProdStatsForItems godoc
+// @Summary Get Prod Stats For Items
+// @Description Get the prod stats of exactly the requested items, in the requested order, from cached session state. Names are case-insensitive and may be class names such as Desc_IronPlate_C or aliases such as hmf, items without stats are returned zeroed
+// @Tags Stats
+// @Accept json
+// @Produce json
+// @Param session_id query string true "Session ID"
+// @Param items query string true "Comma-separated item names"
+// @Success 200 {array} models.ItemProdStats "Prod stats items, in the requested order"
+// @Success 400 {object} models.ErrorResponse "Bad Request"
+// @Router /v1/prodStats/items [get]
+func GetProdStatsForItems(ginContext *gin.Context) {
+	requestContext := NewRequestContext(ginContext)
+
+	sessionID := ginContext.Query("session_id")
+	if sessionID == "" {
+		requestContext.UserError("session_id query parameter is required")
+		return
+	}
+
+	var items []string
+	for _, item := range strings.Split(ginContext.Query("items"), ",") {
+		if strings.TrimSpace(item) != "" {
+			items = append(items, frm_client.CanonicalItemName(item))
+		}
+	}
+	if len(items) == 0 {
+		requestContext.UserError("items query parameter is required")
+		return
+	}
+
+	store := session.NewStore()
+	sess, err := store.Get(sessionID)
+	if err != nil {
+		requestContext.ServerError(fmt.Errorf("failed to get session: %w", err), err)
+		return
+	}
+	if sess == nil {
+		requestContext.NotFound("Session not found")
+		return
+	}
+
+	state := session.GetCachedState(sessionID, sess.SessionName)
+	requestContext.Ok(analysis.GetProdStatsForItems(state.ProdStats, items))
+}
+
+// GetProdHistory godoc
+// @Summary Get Prod History
+// @Description Get the recent production and consumption rates of an item, oldest first. The item may be given by display or class name, items not produced or consumed for a while have no samples
+// @Tags Stats
+// @Accept json
+// @Produce json
+// @Param session_id query string true "Session ID"
+// @Param item query string true "Item name"
+// @Success 200 {array} models.ProdSampleDTO "Prod samples, oldest first"
+// @Success 400 {object} models.ErrorResponse "Bad Request"
+// @Router /v1/prodStats/history [get]
+func GetProdHistory(ginContext *gin.Context) {
+	requestContext := NewRequestContext(ginContext)
+
+	sessionID := ginContext.Query("session_id")
+	if sessionID == "" {
+		requestContext.UserError("session_id query parameter is required")
+		return
+	}
+
+	item := frm_client.CanonicalItemName(ginContext.Query("item"))
+	if item == "" {
+		requestContext.UserError("item query parameter is required")
+		return
+	}
+
+	store := session.NewStore()
+	sess, err := store.Get(sessionID)
+	if err != nil {
+		requestContext.ServerError(fmt.Errorf("failed to get session: %w", err), err)
+		return
+	}
+	if sess == nil {
+		requestContext.NotFound("Session not found")
+		return
+	}
+
+	samples, err := session.GetProdHistory(sessionID, item)
+	if err != nil {
+		requestContext.ServerError(fmt.Errorf("failed to get prod history: %w", err), err)
+		return
+	}
+	requestContext.Ok(samples)
+}
+
+const (
+	defaultFactoryStatusWindow    = 3600 // Game seconds of factory status history returned unless requested
+	defaultFactoryStatusMaxPoints = 120
+)
+
+// GetFactoryStatusHistory godoc
+// @Summary Get Factory Status History
+// @Description Get the machine count per status over the factory stats history, for charting. Long histories are averaged down to maxPoints points
+// @Tags Stats
+// @Accept json
+// @Produce json
+// @Param session_id query string true "Session ID"
+// @Param window query int false "Game seconds before the latest sample to include, 0 for all, defaults to 3600"
+// @Param maxPoints query int false "Max number of points, defaults to 120"
+// @Success 200 {array} models.FactoryStatusPointDTO "Machine counts per status, oldest first"
+// @Success 400 {object} models.ErrorResponse "Bad Request"
+// @Router /v1/factoryStats/statusHistory [get]
+func GetFactoryStatusHistory(ginContext *gin.Context) {
+	requestContext := NewRequestContext(ginContext)
+
+	sessionID := ginContext.Query("session_id")
+	if sessionID == "" {
+		requestContext.UserError("session_id query parameter is required")
+		return
+	}
+
+	window := int64(defaultFactoryStatusWindow)
+	if windowParam := ginContext.Query("window"); windowParam != "" {
+		parsed, err := strconv.ParseInt(windowParam, 10, 64)
+		if err != nil || parsed < 0 {
+			requestContext.UserError("Invalid window parameter: must be a non-negative integer")
+			return
+		}
+		window = parsed
+	}
+
+	maxPoints := defaultFactoryStatusMaxPoints
+	if maxPointsParam := ginContext.Query("maxPoints"); maxPointsParam != "" {
+		parsed, err := strconv.Atoi(maxPointsParam)
+		if err != nil || parsed <= 0 {
+			requestContext.UserError("Invalid maxPoints parameter: must be a positive integer")
+			return
+		}
+		maxPoints = parsed
+	}
+
+	store := session.NewStore()
+	sess, err := store.Get(sessionID)
+	if err != nil {
+		requestContext.ServerError(fmt.Errorf("failed to get session: %w", err), err)
+		return
+	}
+	if sess == nil {
+		requestContext.NotFound("Session not found")
+		return
+	}
+
+	historyChunk, err := session.GetHistory(sessionID, sess.SessionName, string(models.SatisfactoryEventFactoryStats), 0)
+	if err != nil {
+		requestContext.ServerError(fmt.Errorf("failed to get factory stats history: %w", err), err)
+		return
+	}
+
+	// History points hold generic JSON, decode them back into factory stats
+	samples := make([]analysis.FactoryStatsSample, 0, len(historyChunk.Points))
+	for _, point := range historyChunk.Points {
+		data, err := json.Marshal(point.Data)
+		if err != nil {
+			continue
+		}
+		sample := analysis.FactoryStatsSample{GameTimeID: point.GameTimeID}
+		if err := json.Unmarshal(data, &sample.FactoryStats); err != nil {
+			continue
+		}
+		samples = append(samples, sample)
+	}
+
+	requestContext.Ok(analysis.GetFactoryStatusHistory(samples, window, maxPoints))
+}
+
 // GetSinkStats godoc
 // @Summary Get Sink Stats
 // @Description Get sink stats from cached session state
@@ -142,3 +388,285 @@ func GetSinkStats(ginContext *gin.Context) {
 	state := session.GetCachedState(sessionID, sess.SessionName)
 	requestContext.Ok(state.SinkStats.ToDTO())
 }
+
+// ListOrphanedItems godoc
+// @Summary List Orphaned Items
+// @Description List items that are produced but not consumed by any machine, with their likely destination
+// @Tags Stats
+// @Accept json
+// @Produce json
+// @Param session_id query string true "Session ID"
+// @Success 200 {array} models.OrphanedItemDTO "List of orphaned items"
+// @Success 400 {object} models.ErrorResponse "Bad Request"
+// @Router /v1/prodStats/orphaned [get]
+func ListOrphanedItems(ginContext *gin.Context) {
+	requestContext := NewRequestContext(ginContext)
+
+	sessionID := ginContext.Query("session_id")
+	if sessionID == "" {
+		requestContext.UserError("session_id query parameter is required")
+		return
+	}
+
+	store := session.NewStore()
+	sess, err := store.Get(sessionID)
+	if err != nil {
+		requestContext.ServerError(fmt.Errorf("failed to get session: %w", err), err)
+		return
+	}
+	if sess == nil {
+		requestContext.NotFound("Session not found")
+		return
+	}
+
+	state := session.GetCachedState(sessionID, sess.SessionName)
+	requestContext.Ok(analysis.FindOrphanedItems(state.ProdStats, state.SinkStats, state.Storages))
+}
+
+// ListOscillatingItems godoc
+// @Summary List Oscillating Items
+// @Description List items whose production history periodically starves and recovers, which indicates under-buffered inputs
+// @Tags Stats
+// @Accept json
+// @Produce json
+// @Param session_id query string true "Session ID"
+// @Success 200 {array} models.OscillatingItemDTO "List of oscillating items"
+// @Success 400 {object} models.ErrorResponse "Bad Request"
+// @Router /v1/prodStats/oscillating [get]
+func ListOscillatingItems(ginContext *gin.Context) {
+	requestContext := NewRequestContext(ginContext)
+
+	sessionID := ginContext.Query("session_id")
+	if sessionID == "" {
+		requestContext.UserError("session_id query parameter is required")
+		return
+	}
+
+	store := session.NewStore()
+	sess, err := store.Get(sessionID)
+	if err != nil {
+		requestContext.ServerError(fmt.Errorf("failed to get session: %w", err), err)
+		return
+	}
+	if sess == nil {
+		requestContext.NotFound("Session not found")
+		return
+	}
+
+	historyChunk, err := session.GetHistory(sessionID, sess.SessionName, string(models.SatisfactoryEventProdStats), 0)
+	if err != nil {
+		requestContext.ServerError(fmt.Errorf("failed to get prod stats history: %w", err), err)
+		return
+	}
+
+	// History points hold generic JSON, decode them back into prod stats
+	samples := make([]analysis.ProductionSample, 0, len(historyChunk.Points))
+	for _, point := range historyChunk.Points {
+		data, err := json.Marshal(point.Data)
+		if err != nil {
+			continue
+		}
+		sample := analysis.ProductionSample{GameTimeID: point.GameTimeID}
+		if err := json.Unmarshal(data, &sample.ProdStats); err != nil {
+			continue
+		}
+		samples = append(samples, sample)
+	}
+
+	requestContext.Ok(analysis.FindOscillatingItems(samples))
+}
+
+// defaultPowerPlantRadius is how close generators must be to join a power plant unless requested, in location units
+const defaultPowerPlantRadius = 5000.0
+
+// ListPowerPlants godoc
+// @Summary List Power Plants
+// @Description Group nearby generators of the same type into power plants with combined output, fuel consumption and count, from cached session state
+// @Tags Stats
+// @Accept json
+// @Produce json
+// @Param session_id query string true "Session ID"
+// @Param radius query number false "Max distance between generators of one plant, in location units, defaults to 5000"
+// @Success 200 {array} models.PowerPlantDTO "Power plants, largest output first"
+// @Success 400 {object} models.ErrorResponse "Bad Request"
+// @Router /v1/generatorStats/powerPlants [get]
+func ListPowerPlants(ginContext *gin.Context) {
+	requestContext := NewRequestContext(ginContext)
+
+	sessionID := ginContext.Query("session_id")
+	if sessionID == "" {
+		requestContext.UserError("session_id query parameter is required")
+		return
+	}
+
+	radius := defaultPowerPlantRadius
+	if radiusParam := ginContext.Query("radius"); radiusParam != "" {
+		parsed, err := strconv.ParseFloat(radiusParam, 64)
+		if err != nil || parsed <= 0 {
+			requestContext.UserError("Invalid radius parameter: must be a positive number")
+			return
+		}
+		radius = parsed
+	}
+
+	store := session.NewStore()
+	sess, err := store.Get(sessionID)
+	if err != nil {
+		requestContext.ServerError(fmt.Errorf("failed to get session: %w", err), err)
+		return
+	}
+	if sess == nil {
+		requestContext.NotFound("Session not found")
+		return
+	}
+
+	state := session.GetCachedState(sessionID, sess.SessionName)
+	requestContext.Ok(analysis.GroupPowerPlants(state.Machines, radius))
+}
+
+// GetWaterBalance godoc
+// @Summary Get Water Balance
+// @Description Compare water production with the water consumed by machines listing Water as an input, flagging deficits that throttle nuclear power plants, from cached session state
+// @Tags Stats
+// @Accept json
+// @Produce json
+// @Param session_id query string true "Session ID"
+// @Success 200 {object} models.WaterBalanceDTO "Water balance"
+// @Success 400 {object} models.ErrorResponse "Bad Request"
+// @Router /v1/generatorStats/waterBalance [get]
+func GetWaterBalance(ginContext *gin.Context) {
+	requestContext := NewRequestContext(ginContext)
+
+	sessionID := ginContext.Query("session_id")
+	if sessionID == "" {
+		requestContext.UserError("session_id query parameter is required")
+		return
+	}
+
+	store := session.NewStore()
+	sess, err := store.Get(sessionID)
+	if err != nil {
+		requestContext.ServerError(fmt.Errorf("failed to get session: %w", err), err)
+		return
+	}
+	if sess == nil {
+		requestContext.NotFound("Session not found")
+		return
+	}
+
+	state := session.GetCachedState(sessionID, sess.SessionName)
+	requestContext.Ok(analysis.GetWaterBalance(state.Machines))
+}
+
+// defaultRunwayFloorMinutes is the runway below which items are flagged low unless configured
+const defaultRunwayFloorMinutes = 60.0
+
+// ListItemRunways godoc
+// @Summary List Item Runways
+// @Description List how many minutes the stored amount of each item lasts at its current consumption if production stopped, flagging items below the configured floor, from cached session state
+// @Tags Stats
+// @Accept json
+// @Produce json
+// @Param session_id query string true "Session ID"
+// @Success 200 {array} models.ItemRunwayDTO "Item runways, shortest first"
+// @Success 400 {object} models.ErrorResponse "Bad Request"
+// @Router /v1/prodStats/runway [get]
+func ListItemRunways(ginContext *gin.Context) {
+	requestContext := NewRequestContext(ginContext)
+
+	sessionID := ginContext.Query("session_id")
+	if sessionID == "" {
+		requestContext.UserError("session_id query parameter is required")
+		return
+	}
+
+	store := session.NewStore()
+	sess, err := store.Get(sessionID)
+	if err != nil {
+		requestContext.ServerError(fmt.Errorf("failed to get session: %w", err), err)
+		return
+	}
+	if sess == nil {
+		requestContext.NotFound("Session not found")
+		return
+	}
+
+	state := session.GetCachedState(sessionID, sess.SessionName)
+	requestContext.Ok(analysis.GetItemRunways(state.ProdStats, runwayFloorMinutes()))
+}
+
+// runwayFloorMinutes returns the configured runway below which items are flagged low
+func runwayFloorMinutes() float64 {
+	if config.Config.RunwayFloorMinutes > 0 {
+		return config.Config.RunwayFloorMinutes
+	}
+	return defaultRunwayFloorMinutes
+}
+
+// GetSinkComposition godoc
+// @Summary Get Sink Composition
+// @Description Estimate which items feed the AWESOME sink and their points per minute from surplus production, with a confidence of the estimate, from cached session state
+// @Tags Stats
+// @Accept json
+// @Produce json
+// @Param session_id query string true "Session ID"
+// @Success 200 {object} models.SinkCompositionDTO "Estimated sink composition"
+// @Success 400 {object} models.ErrorResponse "Bad Request"
+// @Router /v1/sinkStats/composition [get]
+func GetSinkComposition(ginContext *gin.Context) {
+	requestContext := NewRequestContext(ginContext)
+
+	sessionID := ginContext.Query("session_id")
+	if sessionID == "" {
+		requestContext.UserError("session_id query parameter is required")
+		return
+	}
+
+	store := session.NewStore()
+	sess, err := store.Get(sessionID)
+	if err != nil {
+		requestContext.ServerError(fmt.Errorf("failed to get session: %w", err), err)
+		return
+	}
+	if sess == nil {
+		requestContext.NotFound("Session not found")
+		return
+	}
+
+	state := session.GetCachedState(sessionID, sess.SessionName)
+	requestContext.Ok(analysis.GetSinkComposition(state.ProdStats, state.SinkStats, state.Storages))
+}
+
+// ListPackagedCommodities godoc
+// @Summary List Packaged Commodities
+// @Description List fluids used in packaged form with the production and consumption of both forms combined, and the packagers converting between them, from cached session state
+// @Tags Stats
+// @Accept json
+// @Produce json
+// @Param session_id query string true "Session ID"
+// @Success 200 {array} models.PackagedCommodityDTO "Packaged commodities, highest production first"
+// @Success 400 {object} models.ErrorResponse "Bad Request"
+// @Router /v1/prodStats/packaged [get]
+func ListPackagedCommodities(ginContext *gin.Context) {
+	requestContext := NewRequestContext(ginContext)
+
+	sessionID := ginContext.Query("session_id")
+	if sessionID == "" {
+		requestContext.UserError("session_id query parameter is required")
+		return
+	}
+
+	store := session.NewStore()
+	sess, err := store.Get(sessionID)
+	if err != nil {
+		requestContext.ServerError(fmt.Errorf("failed to get session: %w", err), err)
+		return
+	}
+	if sess == nil {
+		requestContext.NotFound("Session not found")
+		return
+	}
+
+	state := session.GetCachedState(sessionID, sess.SessionName)
+	requestContext.Ok(analysis.GetPackagedCommodities(state.ProdStats, state.Machines))
+}
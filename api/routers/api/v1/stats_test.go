@@ -0,0 +1,155 @@
+package v1
+
+import (
+	"api/models/models"
+	"api/pkg/db"
+	"api/pkg/log"
+	"api/service/session"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestMain(m *testing.M) {
+	if err := log.SetupLogger("test"); err != nil {
+		panic(err)
+	}
+	os.Exit(m.Run())
+}
+
+// seedProdStats stores a session with cached prod stats in a fresh miniredis and returns the session ID
+func seedProdStats(t *testing.T, prodStats models.ProdStats) string {
+	t.Helper()
+	server := miniredis.RunT(t)
+	previous := db.DB.RedisClient
+	db.DB.RedisClient = redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() {
+		_ = db.DB.RedisClient.Close()
+		db.DB.RedisClient = previous
+	})
+
+	sess := &models.Session{Name: "test", SessionName: "save"}
+	if err := session.NewStore().Create(sess); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := json.Marshal(prodStats)
+	// Cached state is stored under state:{sessionID}:{saveName}:{eventType}
+	key := "state:" + sess.ID + ":" + sess.SessionName + ":" + string(models.SatisfactoryEventProdStats)
+	if err := db.DB.RedisClient.Set(context.Background(), key, data, 0).Err(); err != nil {
+		t.Fatal(err)
+	}
+	return sess.ID
+}
+
+// serveStats runs the handler on a GET request with the given query and returns the recorder
+func serveStats(handler gin.HandlerFunc, query string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	recorder := httptest.NewRecorder()
+	ginContext, _ := gin.CreateTestContext(recorder)
+	ginContext.Request = httptest.NewRequest(http.MethodGet, "/v1/prodStats?"+query, nil)
+	handler(ginContext)
+	return recorder
+}
+
+func TestGetProdStatsFiltered(t *testing.T) {
+	sessionID := seedProdStats(t, models.ProdStats{Items: []models.ItemProdStats{
+		{ItemStats: models.ItemStats{Name: "Iron Ore", Category: models.ItemCategoryOre, Form: models.ResourceFormSolid}, ProducedPerMinute: 480},
+		{ItemStats: models.ItemStats{Name: "Water", Category: models.ItemCategoryFluid, Form: models.ResourceFormLiquid}, ProducedPerMinute: 600},
+		{ItemStats: models.ItemStats{Name: "Fuel", Category: models.ItemCategoryFuel, Form: models.ResourceFormLiquid}, ProducedPerMinute: 120},
+		{ItemStats: models.ItemStats{Name: "Iron Ingot", Category: models.ItemCategoryIngot, Form: models.ResourceFormSolid}, ProducedPerMinute: 30},
+		{ItemStats: models.ItemStats{Name: "Copper Ingot", Category: models.ItemCategoryIngot, Form: models.ResourceFormSolid}, ProducedPerMinute: 90},
+	}})
+
+	tests := []struct {
+		name     string
+		query    string
+		status   int
+		expected []string
+	}{
+		{"fluids only", "form=liquid", http.StatusOK, []string{"Water", "Fuel"}},
+		{"category", "category=ingot", http.StatusOK, []string{"Copper Ingot", "Iron Ingot"}},
+		{"category without matches", "category=nuclear", http.StatusOK, []string{}},
+		{"invalid form", "form=plasma", http.StatusBadRequest, nil},
+		{"invalid min rate", "minRate=-1", http.StatusBadRequest, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			recorder := serveStats(GetProdStatsFiltered, "session_id="+sessionID+"&"+test.query)
+			if recorder.Code != test.status {
+				t.Fatalf("got status %d, want %d: %s", recorder.Code, test.status, recorder.Body)
+			}
+			if test.status != http.StatusOK {
+				return
+			}
+			var items []models.ItemProdStats
+			if err := json.Unmarshal(recorder.Body.Bytes(), &items); err != nil {
+				t.Fatal(err)
+			}
+			if len(items) != len(test.expected) {
+				t.Fatalf("got %d items, want %v: %+v", len(items), test.expected, items)
+			}
+			for i, item := range items {
+				if item.Name != test.expected[i] {
+					t.Errorf("item %d: got %s, want %s", i, item.Name, test.expected[i])
+				}
+			}
+		})
+	}
+
+	if recorder := serveStats(GetProdStatsFiltered, "session_id=missing&form=liquid"); recorder.Code != http.StatusNotFound {
+		t.Errorf("got status %d for unknown session, want %d", recorder.Code, http.StatusNotFound)
+	}
+}
+
+func TestGetProdStatsForItems(t *testing.T) {
+	sessionID := seedProdStats(t, models.ProdStats{Items: []models.ItemProdStats{
+		{ItemStats: models.ItemStats{Name: "Iron Plate"}, ProducedPerMinute: 120, ConsumedPerMinute: 90},
+		{ItemStats: models.ItemStats{Name: "Wire"}, ProducedPerMinute: 300},
+		{ItemStats: models.ItemStats{Name: "Heavy Modular Frame"}, ProducedPerMinute: 2},
+	}})
+
+	// Class names, aliases and display names in any case, with an item lacking stats
+	query := url.Values{"session_id": {sessionID}, "items": {"Desc_IronPlate_C, hmf,WIRE,Mystery Part"}}
+	recorder := serveStats(GetProdStatsForItems, query.Encode())
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("got status %d: %s", recorder.Code, recorder.Body)
+	}
+	var items []models.ItemProdStats
+	if err := json.Unmarshal(recorder.Body.Bytes(), &items); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		name     string
+		produced float64
+	}{
+		{"Iron Plate", 120},
+		{"Heavy Modular Frame", 2},
+		{"Wire", 300},
+		{"Mystery Part", 0},
+	}
+	if len(items) != len(want) {
+		t.Fatalf("got %d items, want %d: %+v", len(items), len(want), items)
+	}
+	for i, item := range items {
+		if item.Name != want[i].name || item.ProducedPerMinute != want[i].produced {
+			t.Errorf("item %d: got %s at %v, want %s at %v", i, item.Name, item.ProducedPerMinute, want[i].name, want[i].produced)
+		}
+	}
+
+	for _, empty := range []string{"", " , "} {
+		query := url.Values{"session_id": {sessionID}, "items": {empty}}
+		if recorder := serveStats(GetProdStatsForItems, query.Encode()); recorder.Code != http.StatusBadRequest {
+			t.Errorf("items %q: got status %d, want %d", empty, recorder.Code, http.StatusBadRequest)
+		}
+	}
+}
@@ -2,15 +2,17 @@ package v1
 
 import (
 	"api/models/models"
+	"api/service/analysis"
 	"api/service/session"
 	"fmt"
+	"math"
 
 	"github.com/gin-gonic/gin"
 )
 
 // ListTrains godoc
 // @Summary List Trains
-// @Description List all trains from cached session state
+// @Description List all trains from cached session state, with the estimated loop time of their timetable where the rails connect every stop
 // @Tags Trains
 // @Accept json
 // @Produce json
@@ -42,6 +44,10 @@ func ListTrains(ginContext *gin.Context) {
 
 	trainsDto := make([]models.TrainDTO, len(state.Trains))
 	for i, train := range state.Trains {
+		if cycle, err := analysis.EstimateTrainCycle(train, state.TrainRails); err == nil {
+			seconds := math.Round(cycle.Seconds())
+			train.CycleSeconds = &seconds
+		}
 		trainsDto[i] = train.ToDTO()
 	}
 
@@ -88,6 +94,40 @@ func ListTrainStations(ginContext *gin.Context) {
 	requestContext.Ok(trainStationsDto)
 }
 
+// ListDuplicateTrainStations godoc
+// @Summary List Duplicate Train Stations
+// @Description List train station names shared by multiple stations, which make timetables ambiguous
+// @Tags Trains
+// @Accept json
+// @Produce json
+// @Param session_id query string true "Session ID"
+// @Success 200 {array} models.DuplicateTrainStationNameDTO "List of duplicate train station names"
+// @Success 400 {object} models.ErrorResponse "Bad Request"
+// @Router /v1/trainStations/duplicates [get]
+func ListDuplicateTrainStations(ginContext *gin.Context) {
+	requestContext := NewRequestContext(ginContext)
+
+	sessionID := ginContext.Query("session_id")
+	if sessionID == "" {
+		requestContext.UserError("session_id query parameter is required")
+		return
+	}
+
+	store := session.NewStore()
+	sess, err := store.Get(sessionID)
+	if err != nil {
+		requestContext.ServerError(fmt.Errorf("failed to get session: %w", err), err)
+		return
+	}
+	if sess == nil {
+		requestContext.NotFound("Session not found")
+		return
+	}
+
+	state := session.GetCachedState(sessionID, sess.SessionName)
+	requestContext.Ok(analysis.FindDuplicateTrainStationNames(state.TrainStations))
+}
+
 // GetTrainSetup godoc
 // @Summary Get TrainSetup
 // @Description List all trains and train stations from cached session state
@@ -127,3 +167,105 @@ func GetTrainSetup(ginContext *gin.Context) {
 
 	requestContext.Ok(trainSetupDto)
 }
+
+// ListTrainRoutes godoc
+// @Summary List Train Routes
+// @Description Group trains sharing a timetable into routes and classify each as over-trained, under-trained or balanced from station dock statistics, from cached session state
+// @Tags Trains
+// @Accept json
+// @Produce json
+// @Param session_id query string true "Session ID"
+// @Success 200 {array} models.TrainRouteDTO "Train routes, busiest first"
+// @Success 400 {object} models.ErrorResponse "Bad Request"
+// @Router /v1/trains/routes [get]
+func ListTrainRoutes(ginContext *gin.Context) {
+	requestContext := NewRequestContext(ginContext)
+
+	sessionID := ginContext.Query("session_id")
+	if sessionID == "" {
+		requestContext.UserError("session_id query parameter is required")
+		return
+	}
+
+	store := session.NewStore()
+	sess, err := store.Get(sessionID)
+	if err != nil {
+		requestContext.ServerError(fmt.Errorf("failed to get session: %w", err), err)
+		return
+	}
+	if sess == nil {
+		requestContext.NotFound("Session not found")
+		return
+	}
+
+	state := session.GetCachedState(sessionID, sess.SessionName)
+	requestContext.Ok(analysis.GetTrainRoutes(state.Trains, state.TrainStations))
+}
+
+// ListTrainPlatformMismatches godoc
+// @Summary List Train Platform Mismatches
+// @Description List docked trains whose locomotives and freight cars do not line up with the platforms of their station, from cached session state
+// @Tags Trains
+// @Accept json
+// @Produce json
+// @Param session_id query string true "Session ID"
+// @Success 200 {array} models.TrainPlatformMismatchDTO "Mismatched trains, by station"
+// @Success 400 {object} models.ErrorResponse "Bad Request"
+// @Router /v1/trains/platformMismatches [get]
+func ListTrainPlatformMismatches(ginContext *gin.Context) {
+	requestContext := NewRequestContext(ginContext)
+
+	sessionID := ginContext.Query("session_id")
+	if sessionID == "" {
+		requestContext.UserError("session_id query parameter is required")
+		return
+	}
+
+	store := session.NewStore()
+	sess, err := store.Get(sessionID)
+	if err != nil {
+		requestContext.ServerError(fmt.Errorf("failed to get session: %w", err), err)
+		return
+	}
+	if sess == nil {
+		requestContext.NotFound("Session not found")
+		return
+	}
+
+	state := session.GetCachedState(sessionID, sess.SessionName)
+	requestContext.Ok(analysis.FindTrainPlatformMismatches(state.Trains, state.TrainStations))
+}
+
+// ListTrainCycles godoc
+// @Summary List Train Cycles
+// @Description Estimate how long each train takes for one loop of its timetable, from the rail distance between its stops at cruising speed plus a docking allowance per stop, from cached session state. Trains whose stops cannot be connected by rails carry an error instead
+// @Tags Trains
+// @Accept json
+// @Produce json
+// @Param session_id query string true "Session ID"
+// @Success 200 {array} models.TrainCycleDTO "Estimated cycle per train"
+// @Success 400 {object} models.ErrorResponse "Bad Request"
+// @Router /v1/trains/cycles [get]
+func ListTrainCycles(ginContext *gin.Context) {
+	requestContext := NewRequestContext(ginContext)
+
+	sessionID := ginContext.Query("session_id")
+	if sessionID == "" {
+		requestContext.UserError("session_id query parameter is required")
+		return
+	}
+
+	store := session.NewStore()
+	sess, err := store.Get(sessionID)
+	if err != nil {
+		requestContext.ServerError(fmt.Errorf("failed to get session: %w", err), err)
+		return
+	}
+	if sess == nil {
+		requestContext.NotFound("Session not found")
+		return
+	}
+
+	state := session.GetCachedState(sessionID, sess.SessionName)
+	requestContext.Ok(analysis.GetTrainCycles(state.Trains, state.TrainRails))
+}
@@ -1,6 +1,7 @@
 package v1
 
 import (
+	"api/service/analysis"
 	"api/service/session"
 	"fmt"
 
@@ -42,6 +43,40 @@ func ListStorages(ginContext *gin.Context) {
 	requestContext.Ok(state.Storages)
 }
 
+// GetStorageSummary godoc
+// @Summary Get Storage Summary
+// @Description Get the stored amount and capacity of every item across all storage containers, grouped by container type, from cached session state
+// @Tags World
+// @Accept json
+// @Produce json
+// @Param session_id query string true "Session ID"
+// @Success 200 {array} models.StorageSummaryDTO "Storage totals per container type"
+// @Success 400 {object} models.ErrorResponse "Bad Request"
+// @Router /v1/storages/summary [get]
+func GetStorageSummary(ginContext *gin.Context) {
+	requestContext := NewRequestContext(ginContext)
+
+	sessionID := ginContext.Query("session_id")
+	if sessionID == "" {
+		requestContext.UserError("session_id query parameter is required")
+		return
+	}
+
+	store := session.NewStore()
+	sess, err := store.Get(sessionID)
+	if err != nil {
+		requestContext.ServerError(fmt.Errorf("failed to get session: %w", err), err)
+		return
+	}
+	if sess == nil {
+		requestContext.NotFound("Session not found")
+		return
+	}
+
+	state := session.GetCachedState(sessionID, sess.SessionName)
+	requestContext.Ok(analysis.SummarizeStorages(state.Storages))
+}
+
 // ListTractors godoc
 // @Summary List Tractors
 // @Description List all tractors from cached session state
@@ -251,3 +286,37 @@ func ListRadarTowers(ginContext *gin.Context) {
 
 	requestContext.Ok(state.RadarTowers)
 }
+
+// GetCollectibleProgress godoc
+// @Summary Get Collectible Progress
+// @Description Compare the somersloops, mercer spheres, hard drives and power slugs revealed by radar towers with those held in inventories, from cached session state. Collectibles consumed into buildings are not counted as collected
+// @Tags World
+// @Accept json
+// @Produce json
+// @Param session_id query string true "Session ID"
+// @Success 200 {array} models.CollectibleProgressDTO "Progress per collectible"
+// @Success 400 {object} models.ErrorResponse "Bad Request"
+// @Router /v1/radarTowers/collectibles [get]
+func GetCollectibleProgress(ginContext *gin.Context) {
+	requestContext := NewRequestContext(ginContext)
+
+	sessionID := ginContext.Query("session_id")
+	if sessionID == "" {
+		requestContext.UserError("session_id query parameter is required")
+		return
+	}
+
+	store := session.NewStore()
+	sess, err := store.Get(sessionID)
+	if err != nil {
+		requestContext.ServerError(fmt.Errorf("failed to get session: %w", err), err)
+		return
+	}
+	if sess == nil {
+		requestContext.NotFound("Session not found")
+		return
+	}
+
+	state := session.GetCachedState(sessionID, sess.SessionName)
+	requestContext.Ok(analysis.GetCollectibleProgress(state.RadarTowers, state.Players, state.Storages))
+}
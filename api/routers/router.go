@@ -5,10 +5,12 @@ import (
 	"api/routers/api/v1/middleware"
 	"api/routers/routes"
 	"api/service/auth"
+	"api/worker"
 
 	"github.com/gin-contrib/cors"
 	ginzap "github.com/gin-contrib/zap"
 	"github.com/penglongli/gin-metrics/ginmetrics"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	docsV2 "api/docs/api/v1"
 	docsHandlers "api/docs/api/v1/handlers"
@@ -43,6 +45,7 @@ func NewRouter() *gin.Engine {
 	m.SetMetricPath("/internal/metrics")
 	m.SetMetricPrefix(metrics.Prefix)
 	m.Use(router)
+	router.GET("/internal/metrics/lease", gin.WrapH(promhttp.HandlerFor(worker.LeaseMetricsRegistry, promhttp.HandlerOpts{})))
 
 	// Private routing group - requires authentication
 	authService := auth.NewService()
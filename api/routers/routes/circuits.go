@@ -8,7 +8,8 @@ import (
 )
 
 const (
-	CircuitsPath = "/v1/circuits"
+	CircuitsPath          = "/v1/circuits"
+	DrainingBatteriesPath = "/v1/circuits/drainingBatteries"
 )
 
 type CircuitsRoutingGroup struct{ RoutingGroupBase }
@@ -19,5 +20,6 @@ func (group *CircuitsRoutingGroup) PrivateRoutes() []Route {
 	stageCheck := []gin.HandlerFunc{middleware.RequireSessionReady()}
 	return []Route{
 		{Method: "GET", Pattern: CircuitsPath, HandlerFunc: v1.ListCircuits, Middleware: stageCheck},
+		{Method: "GET", Pattern: DrainingBatteriesPath, HandlerFunc: v1.ListDrainingBatteries, Middleware: stageCheck},
 	}
 }
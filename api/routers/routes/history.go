@@ -10,6 +10,7 @@ import (
 const (
 	HistoryPath      = "/v1/sessions/:id/history/:dataType"
 	HistorySavesPath = "/v1/sessions/:id/history"
+	EventLogPath     = "/v1/sessions/:id/eventLog"
 )
 
 // HistoryRoutingGroup defines routes for historical data retrieval.
@@ -24,5 +25,6 @@ func (group *HistoryRoutingGroup) PrivateRoutes() []Route {
 	return []Route{
 		{Method: "GET", Pattern: HistorySavesPath, HandlerFunc: v1.ListHistorySaves, Middleware: stageCheck},
 		{Method: "GET", Pattern: HistoryPath, HandlerFunc: v1.GetHistory, Middleware: stageCheck},
+		{Method: "GET", Pattern: EventLogPath, HandlerFunc: v1.GetEventLog, Middleware: stageCheck},
 	}
 }
@@ -8,11 +8,14 @@ import (
 )
 
 const (
-	BeltsPath      = "/v1/belts"
-	PipesPath      = "/v1/pipes"
-	CablesPath     = "/v1/cables"
-	TrainRailsPath = "/v1/trainRails"
-	HypertubesPath = "/v1/hypertubes"
+	BeltsPath              = "/v1/belts"
+	MisroutedBeltsPath     = "/v1/belts/misrouted"
+	ConveyorThroughputPath = "/v1/conveyors/throughput"
+	PipesPath              = "/v1/pipes"
+	PipeBalancePath        = "/v1/pipes/balance"
+	CablesPath             = "/v1/cables"
+	TrainRailsPath         = "/v1/trainRails"
+	HypertubesPath         = "/v1/hypertubes"
 )
 
 type InfrastructureRoutingGroup struct{ RoutingGroupBase }
@@ -23,7 +26,10 @@ func (group *InfrastructureRoutingGroup) PrivateRoutes() []Route {
 	stageCheck := []gin.HandlerFunc{middleware.RequireSessionReady()}
 	return []Route{
 		{Method: "GET", Pattern: BeltsPath, HandlerFunc: v1.ListBelts, Middleware: stageCheck},
+		{Method: "GET", Pattern: MisroutedBeltsPath, HandlerFunc: v1.ListMisroutedBelts, Middleware: stageCheck},
+		{Method: "GET", Pattern: ConveyorThroughputPath, HandlerFunc: v1.GetConveyorThroughput, Middleware: stageCheck},
 		{Method: "GET", Pattern: PipesPath, HandlerFunc: v1.ListPipes, Middleware: stageCheck},
+		{Method: "GET", Pattern: PipeBalancePath, HandlerFunc: v1.ListPipeNetworkBalances, Middleware: stageCheck},
 		{Method: "GET", Pattern: CablesPath, HandlerFunc: v1.ListCables, Middleware: stageCheck},
 		{Method: "GET", Pattern: TrainRailsPath, HandlerFunc: v1.ListTrainRails, Middleware: stageCheck},
 		{Method: "GET", Pattern: HypertubesPath, HandlerFunc: v1.ListHypertubes, Middleware: stageCheck},
@@ -8,7 +8,13 @@ import (
 )
 
 const (
-	MachinesPath = "/v1/machines"
+	MachinesPath         = "/v1/machines"
+	MachinesInBoundsPath = "/v1/machines/inBounds"
+	ItemProducersPath    = "/v1/machines/producers"
+	FuelBalancesPath     = "/v1/machines/fuelBalance"
+	MachineBuildsPath    = "/v1/machines/builds"
+	ActiveRecipesPath    = "/v1/machines/recipes"
+	RatioIssuesPath      = "/v1/machines/ratioIssues"
 )
 
 type MachinesRoutingGroup struct{ RoutingGroupBase }
@@ -19,5 +25,11 @@ func (group *MachinesRoutingGroup) PrivateRoutes() []Route {
 	stageCheck := []gin.HandlerFunc{middleware.RequireSessionReady()}
 	return []Route{
 		{Method: "GET", Pattern: MachinesPath, HandlerFunc: v1.GetMachines, Middleware: stageCheck},
+		{Method: "GET", Pattern: MachinesInBoundsPath, HandlerFunc: v1.GetMachinesInBounds, Middleware: stageCheck},
+		{Method: "GET", Pattern: ItemProducersPath, HandlerFunc: v1.GetItemProducers, Middleware: stageCheck},
+		{Method: "GET", Pattern: FuelBalancesPath, HandlerFunc: v1.ListFuelBalances, Middleware: stageCheck},
+		{Method: "GET", Pattern: MachineBuildsPath, HandlerFunc: v1.GetMachineBuilds, Middleware: stageCheck},
+		{Method: "GET", Pattern: ActiveRecipesPath, HandlerFunc: v1.ListActiveRecipes, Middleware: stageCheck},
+		{Method: "GET", Pattern: RatioIssuesPath, HandlerFunc: v1.ListRecipeRatioIssues, Middleware: stageCheck},
 	}
 }
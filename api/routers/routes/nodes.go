@@ -5,7 +5,9 @@ import (
 )
 
 const (
-	NodesPath = "/v1/nodes"
+	NodesPath           = "/v1/nodes"
+	NodeLeasesPath      = "/v1/nodes/leases"
+	NodeLeaseEventsPath = "/v1/nodes/leases/events"
 )
 
 type NodesRoutingGroup struct{ RoutingGroupBase }
@@ -15,5 +17,7 @@ func NodeRoutes() *NodesRoutingGroup { return &NodesRoutingGroup{} }
 func (group *NodesRoutingGroup) PublicRoutes() []Route {
 	return []Route{
 		{Method: "GET", Pattern: NodesPath, HandlerFunc: v1.GetNodes},
+		{Method: "GET", Pattern: NodeLeasesPath, HandlerFunc: v1.GetNodeLeases},
+		{Method: "GET", Pattern: NodeLeaseEventsPath, HandlerFunc: v1.GetNodeLeaseEvents},
 	}
 }
@@ -8,7 +8,9 @@ import (
 )
 
 const (
-	ResourceNodesPath = "/v1/resourceNodes"
+	ResourceNodesPath    = "/v1/resourceNodes"
+	ResourceCapacityPath = "/v1/resourceNodes/capacity"
+	ResourceSummaryPath  = "/v1/resourceNodes/summary"
 )
 
 type ResourceNodesRoutingGroup struct{ RoutingGroupBase }
@@ -19,5 +21,7 @@ func (group *ResourceNodesRoutingGroup) PrivateRoutes() []Route {
 	stageCheck := []gin.HandlerFunc{middleware.RequireSessionReady()}
 	return []Route{
 		{Method: "GET", Pattern: ResourceNodesPath, HandlerFunc: v1.ListResourceNodes, Middleware: stageCheck},
+		{Method: "GET", Pattern: ResourceCapacityPath, HandlerFunc: v1.ListResourceCapacity, Middleware: stageCheck},
+		{Method: "GET", Pattern: ResourceSummaryPath, HandlerFunc: v1.ListResourceNodeSummary, Middleware: stageCheck},
 	}
 }
@@ -8,7 +8,8 @@ import (
 )
 
 const (
-	SchematicsPath = "/v1/schematics"
+	SchematicsPath   = "/v1/schematics"
+	TechProgressPath = "/v1/schematics/progress"
 )
 
 // SchematicsRoutingGroup handles routing for schematics/milestones endpoints.
@@ -22,5 +23,6 @@ func (group *SchematicsRoutingGroup) PrivateRoutes() []Route {
 	stageCheck := []gin.HandlerFunc{middleware.RequireSessionReady()}
 	return []Route{
 		{Method: "GET", Pattern: SchematicsPath, HandlerFunc: v1.ListSchematics, Middleware: stageCheck},
+		{Method: "GET", Pattern: TechProgressPath, HandlerFunc: v1.GetTechProgress, Middleware: stageCheck},
 	}
 }
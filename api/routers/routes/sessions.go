@@ -14,6 +14,7 @@ const (
 	SessionPreviewPath  = "/v1/sessions/preview"
 	SessionEventsPath   = "/v1/sessions/:id/events"
 	SessionStatePath    = "/v1/sessions/:id/state"
+	SessionSnapshotPath = "/v1/sessions/:id/snapshot"
 )
 
 type SessionsRoutingGroup struct{ RoutingGroupBase }
@@ -32,5 +33,6 @@ func (group *SessionsRoutingGroup) PrivateRoutes() []Route {
 		{Method: "GET", Pattern: SessionValidatePath, HandlerFunc: v1.ValidateSession},
 		{Method: "GET", Pattern: SessionEventsPath, HandlerFunc: v1.StartSessionEventsSSE, Middleware: []gin.HandlerFunc{middleware.SseSetup()}},
 		{Method: "GET", Pattern: SessionStatePath, HandlerFunc: v1.GetSessionState, Middleware: stageCheck},
+		{Method: "GET", Pattern: SessionSnapshotPath, HandlerFunc: v1.ExportWorldSnapshot},
 	}
 }
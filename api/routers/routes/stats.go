@@ -8,10 +8,21 @@ import (
 )
 
 const (
-	GeneratorStatsPath = "/v1/generatorStats"
-	ProdStatsPath      = "/v1/prodStats"
-	FactoryStatsPath   = "/v1/factoryStats"
-	SinkStatsPath      = "/v1/sinkStats"
+	GeneratorStatsPath       = "/v1/generatorStats"
+	PowerPlantsPath          = "/v1/generatorStats/powerPlants"
+	WaterBalancePath         = "/v1/generatorStats/waterBalance"
+	ProdStatsPath            = "/v1/prodStats"
+	OrphanedItemsPath        = "/v1/prodStats/orphaned"
+	OscillatingItemsPath     = "/v1/prodStats/oscillating"
+	FilteredProdStatsPath    = "/v1/prodStats/filtered"
+	ItemsProdStatsPath       = "/v1/prodStats/items"
+	PackagedCommoditiesPath  = "/v1/prodStats/packaged"
+	ItemRunwaysPath          = "/v1/prodStats/runway"
+	ProdHistoryPath          = "/v1/prodStats/history"
+	FactoryStatsPath         = "/v1/factoryStats"
+	FactoryStatusHistoryPath = "/v1/factoryStats/statusHistory"
+	SinkStatsPath            = "/v1/sinkStats"
+	SinkCompositionPath      = "/v1/sinkStats/composition"
 )
 
 type StatsRoutingGroup struct{ RoutingGroupBase }
@@ -22,8 +33,19 @@ func (group *StatsRoutingGroup) PrivateRoutes() []Route {
 	stageCheck := []gin.HandlerFunc{middleware.RequireSessionReady()}
 	return []Route{
 		{Method: "GET", Pattern: GeneratorStatsPath, HandlerFunc: v1.GetGeneratorStats, Middleware: stageCheck},
+		{Method: "GET", Pattern: PowerPlantsPath, HandlerFunc: v1.ListPowerPlants, Middleware: stageCheck},
+		{Method: "GET", Pattern: WaterBalancePath, HandlerFunc: v1.GetWaterBalance, Middleware: stageCheck},
 		{Method: "GET", Pattern: ProdStatsPath, HandlerFunc: v1.GetProdStats, Middleware: stageCheck},
+		{Method: "GET", Pattern: FilteredProdStatsPath, HandlerFunc: v1.GetProdStatsFiltered, Middleware: stageCheck},
+		{Method: "GET", Pattern: ItemsProdStatsPath, HandlerFunc: v1.GetProdStatsForItems, Middleware: stageCheck},
+		{Method: "GET", Pattern: OrphanedItemsPath, HandlerFunc: v1.ListOrphanedItems, Middleware: stageCheck},
+		{Method: "GET", Pattern: PackagedCommoditiesPath, HandlerFunc: v1.ListPackagedCommodities, Middleware: stageCheck},
+		{Method: "GET", Pattern: ItemRunwaysPath, HandlerFunc: v1.ListItemRunways, Middleware: stageCheck},
+		{Method: "GET", Pattern: OscillatingItemsPath, HandlerFunc: v1.ListOscillatingItems, Middleware: stageCheck},
+		{Method: "GET", Pattern: ProdHistoryPath, HandlerFunc: v1.GetProdHistory, Middleware: stageCheck},
 		{Method: "GET", Pattern: FactoryStatsPath, HandlerFunc: v1.GetFactoryStats, Middleware: stageCheck},
+		{Method: "GET", Pattern: FactoryStatusHistoryPath, HandlerFunc: v1.GetFactoryStatusHistory, Middleware: stageCheck},
 		{Method: "GET", Pattern: SinkStatsPath, HandlerFunc: v1.GetSinkStats, Middleware: stageCheck},
+		{Method: "GET", Pattern: SinkCompositionPath, HandlerFunc: v1.GetSinkComposition, Middleware: stageCheck},
 	}
 }
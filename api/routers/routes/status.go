@@ -10,6 +10,9 @@ import (
 const (
 	SatisfactoryApiStatusPath = "/v1/satisfactoryApiStatus"
 	ClientIPPath              = "/v1/client-ip"
+	ScaleValuePath            = "/v1/format/scale"
+	OpenMetricsPath           = "/v1/metrics"
+	DiagnosticsPath           = "/v1/diagnostics"
 )
 
 type StatusRoutingGroup struct{ RoutingGroupBase }
@@ -21,5 +24,8 @@ func (group *StatusRoutingGroup) PrivateRoutes() []Route {
 	return []Route{
 		{Method: "GET", Pattern: SatisfactoryApiStatusPath, HandlerFunc: v1.GetSatisfactoryApiStatus, Middleware: stageCheck},
 		{Method: "GET", Pattern: ClientIPPath, HandlerFunc: v1.GetClientIP},
+		{Method: "GET", Pattern: ScaleValuePath, HandlerFunc: v1.ScaleValue},
+		{Method: "GET", Pattern: OpenMetricsPath, HandlerFunc: v1.GetOpenMetrics},
+		{Method: "GET", Pattern: DiagnosticsPath, HandlerFunc: v1.GetDiagnostics},
 	}
 }
@@ -8,9 +8,13 @@ import (
 )
 
 const (
-	TrainsPath        = "/v1/trains"
-	TrainStationsPath = "/v1/trainStations"
-	TrainSetupPath    = "/v1/trainSetup"
+	TrainsPath                  = "/v1/trains"
+	TrainRoutesPath             = "/v1/trains/routes"
+	TrainPlatformMismatchesPath = "/v1/trains/platformMismatches"
+	TrainCyclesPath             = "/v1/trains/cycles"
+	TrainStationsPath           = "/v1/trainStations"
+	DuplicateTrainStationsPath  = "/v1/trainStations/duplicates"
+	TrainSetupPath              = "/v1/trainSetup"
 )
 
 type TrainsRoutingGroup struct{ RoutingGroupBase }
@@ -21,7 +25,11 @@ func (group *TrainsRoutingGroup) PrivateRoutes() []Route {
 	stageCheck := []gin.HandlerFunc{middleware.RequireSessionReady()}
 	return []Route{
 		{Method: "GET", Pattern: TrainsPath, HandlerFunc: v1.ListTrains, Middleware: stageCheck},
+		{Method: "GET", Pattern: TrainRoutesPath, HandlerFunc: v1.ListTrainRoutes, Middleware: stageCheck},
+		{Method: "GET", Pattern: TrainPlatformMismatchesPath, HandlerFunc: v1.ListTrainPlatformMismatches, Middleware: stageCheck},
+		{Method: "GET", Pattern: TrainCyclesPath, HandlerFunc: v1.ListTrainCycles, Middleware: stageCheck},
 		{Method: "GET", Pattern: TrainStationsPath, HandlerFunc: v1.ListTrainStations, Middleware: stageCheck},
+		{Method: "GET", Pattern: DuplicateTrainStationsPath, HandlerFunc: v1.ListDuplicateTrainStations, Middleware: stageCheck},
 		{Method: "GET", Pattern: TrainSetupPath, HandlerFunc: v1.GetTrainSetup, Middleware: stageCheck},
 	}
 }
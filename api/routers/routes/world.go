@@ -8,13 +8,15 @@ import (
 )
 
 const (
-	StoragesPath      = "/v1/storages"
-	TractorsPath      = "/v1/tractors"
-	ExplorersPath     = "/v1/explorers"
-	VehiclePathsPath  = "/v1/vehiclePaths"
-	SpaceElevatorPath = "/v1/spaceElevator"
-	HubPath           = "/v1/hub"
-	RadarTowersPath   = "/v1/radarTowers"
+	StoragesPath       = "/v1/storages"
+	StorageSummaryPath = "/v1/storages/summary"
+	TractorsPath       = "/v1/tractors"
+	ExplorersPath      = "/v1/explorers"
+	VehiclePathsPath   = "/v1/vehiclePaths"
+	SpaceElevatorPath  = "/v1/spaceElevator"
+	HubPath            = "/v1/hub"
+	RadarTowersPath    = "/v1/radarTowers"
+	CollectiblesPath   = "/v1/radarTowers/collectibles"
 )
 
 type WorldRoutingGroup struct{ RoutingGroupBase }
@@ -25,11 +27,13 @@ func (group *WorldRoutingGroup) PrivateRoutes() []Route {
 	stageCheck := []gin.HandlerFunc{middleware.RequireSessionReady()}
 	return []Route{
 		{Method: "GET", Pattern: StoragesPath, HandlerFunc: v1.ListStorages, Middleware: stageCheck},
+		{Method: "GET", Pattern: StorageSummaryPath, HandlerFunc: v1.GetStorageSummary, Middleware: stageCheck},
 		{Method: "GET", Pattern: TractorsPath, HandlerFunc: v1.ListTractors, Middleware: stageCheck},
 		{Method: "GET", Pattern: ExplorersPath, HandlerFunc: v1.ListExplorers, Middleware: stageCheck},
 		{Method: "GET", Pattern: VehiclePathsPath, HandlerFunc: v1.ListVehiclePaths, Middleware: stageCheck},
 		{Method: "GET", Pattern: SpaceElevatorPath, HandlerFunc: v1.GetSpaceElevator, Middleware: stageCheck},
 		{Method: "GET", Pattern: HubPath, HandlerFunc: v1.GetHub, Middleware: stageCheck},
 		{Method: "GET", Pattern: RadarTowersPath, HandlerFunc: v1.ListRadarTowers, Middleware: stageCheck},
+		{Method: "GET", Pattern: CollectiblesPath, HandlerFunc: v1.GetCollectibleProgress, Middleware: stageCheck},
 	}
 }
@@ -0,0 +1,132 @@
+package analysis
+
+import (
+	"api/models/models"
+	"math"
+)
+
+const (
+	// healthyPowerHeadroom is the share of spare power capacity that scores full marks
+	healthyPowerHeadroom = 0.25
+	// alertPenalty is the number of points each production deficit or logistics alert costs its factor
+	alertPenalty = 10.0
+)
+
+// BaseScoreInputs holds the latest polled data feeding the base score. Nil fields have not been polled yet.
+type BaseScoreInputs struct {
+	FactoryStats    *models.FactoryStats
+	Circuits        []models.Circuit
+	ProdStats       *models.ProdStats
+	Vehicles        *models.Vehicles
+	VehicleStations *models.VehicleStations
+}
+
+type BaseScoreWeights struct {
+	Efficiency float64
+	Power      float64
+	Production float64
+	Logistics  float64
+}
+
+// DefaultBaseScoreWeights weights every factor equally
+var DefaultBaseScoreWeights = BaseScoreWeights{Efficiency: 1, Power: 1, Production: 1, Logistics: 1}
+
+// ComputeBaseScore combines factory efficiency, power headroom, production deficits and logistics alerts
+// into a 0-100 score. Factors whose inputs are missing are left out of the weighted average
+// instead of counting as zero, so a freshly started session is not reported as unhealthy.
+func ComputeBaseScore(inputs BaseScoreInputs, weights BaseScoreWeights) models.BaseScore {
+	components := make([]models.BaseScoreComponent, 0, 4)
+	add := func(factor models.BaseScoreFactor, weight, value, score float64) {
+		if weight <= 0 {
+			return
+		}
+		components = append(components, models.BaseScoreComponent{
+			Factor: factor,
+			Score:  math.Max(0, math.Min(100, score)),
+			Weight: weight,
+			Value:  value,
+		})
+	}
+
+	if inputs.FactoryStats != nil {
+		// Same ratio as the factory stats report, left out when no machine is expected to run
+		efficiency := inputs.FactoryStats.Efficiency
+		efficiency.ComputeOverallEfficiency()
+		if efficiency.MachinesOperating+efficiency.MachinesIdle+efficiency.MachinesPaused > 0 {
+			ratio := efficiency.OverallEfficiency
+			add(models.BaseScoreFactorEfficiency, weights.Efficiency, ratio, ratio*100)
+		}
+	}
+
+	if headroom, ok := powerHeadroom(inputs.Circuits); ok {
+		add(models.BaseScoreFactorPower, weights.Power, headroom, headroom/healthyPowerHeadroom*100)
+	}
+
+	if inputs.ProdStats != nil {
+		deficits := 0
+		for _, item := range inputs.ProdStats.Items {
+			if item.ConsumedPerMinute > item.ProducedPerMinute {
+				deficits++
+			}
+		}
+		add(models.BaseScoreFactorProduction, weights.Production, float64(deficits), 100-float64(deficits)*alertPenalty)
+	}
+
+	if inputs.Vehicles != nil || inputs.VehicleStations != nil {
+		alerts := logisticsAlerts(inputs.Vehicles, inputs.VehicleStations)
+		add(models.BaseScoreFactorLogistics, weights.Logistics, float64(alerts), 100-float64(alerts)*alertPenalty)
+	}
+
+	totalWeight := 0.0
+	for _, component := range components {
+		totalWeight += component.Weight
+	}
+
+	result := models.BaseScore{Components: components}
+	for idx := range components {
+		components[idx].Weight /= totalWeight
+		result.Score += components[idx].Score * components[idx].Weight
+	}
+
+	return result
+}
+
+// powerHeadroom returns the share of generation capacity left unused across all circuits.
+// A tripped fuse anywhere counts as having no headroom. Reports false when no capacity is known.
+func powerHeadroom(circuits []models.Circuit) (float64, bool) {
+	capacity := 0.0
+	consumption := 0.0
+	for _, circuit := range circuits {
+		if circuit.FuseTriggered {
+			return 0, true
+		}
+		capacity += circuit.Capacity.Total
+		consumption += circuit.Consumption.Total
+	}
+	if capacity <= 0 {
+		return 0, false
+	}
+	return (capacity - consumption) / capacity, true
+}
+
+// logisticsAlerts counts derailed trains and stalled train platforms
+func logisticsAlerts(vehicles *models.Vehicles, stations *models.VehicleStations) int {
+	alerts := 0
+	if vehicles != nil {
+		for _, train := range vehicles.Trains {
+			if train.Status == models.TrainStatusDerailed {
+				alerts++
+			}
+		}
+	}
+	if stations != nil {
+		for _, station := range stations.TrainStations {
+			for _, platform := range station.Platforms {
+				if platform.Stalled {
+					alerts++
+				}
+			}
+		}
+	}
+	return alerts
+}
@@ -0,0 +1,137 @@
+package analysis
+
+import (
+	"api/models/models"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestBaseScoreEfficiencyMatchesFactoryStats(t *testing.T) {
+	tests := []struct {
+		name      string
+		stats     models.FactoryStats
+		wantScore float64
+		wantLeft  bool // Factor left out of the score
+	}{
+		{
+			name: "idle and paused machines count against it",
+			stats: models.FactoryStats{TotalMachines: 10, Efficiency: models.MachineEfficiency{
+				MachinesOperating: 6, MachinesIdle: 1, MachinesPaused: 1, MachinesUnconfigured: 1, MachinesUnknown: 1,
+			}},
+			wantScore: 75,
+		},
+		{
+			name:     "no machine expected to run",
+			stats:    models.FactoryStats{TotalMachines: 2, Efficiency: models.MachineEfficiency{MachinesUnconfigured: 1, MachinesUnknown: 1}},
+			wantLeft: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			score := ComputeBaseScore(BaseScoreInputs{FactoryStats: &test.stats}, DefaultBaseScoreWeights)
+
+			var component *models.BaseScoreComponent
+			for i := range score.Components {
+				if score.Components[i].Factor == models.BaseScoreFactorEfficiency {
+					component = &score.Components[i]
+				}
+			}
+			if test.wantLeft {
+				if component != nil {
+					t.Errorf("got efficiency component %+v, want it left out", *component)
+				}
+				return
+			}
+			if component == nil {
+				t.Fatal("efficiency component missing")
+			}
+
+			efficiency := test.stats.Efficiency
+			efficiency.ComputeOverallEfficiency()
+			if component.Score != test.wantScore || component.Value != efficiency.OverallEfficiency {
+				t.Errorf("got score %v of value %v, want %v of the overall efficiency %v", component.Score, component.Value, test.wantScore, efficiency.OverallEfficiency)
+			}
+		})
+	}
+}
+
+func TestComputeBaseScore(t *testing.T) {
+	running := &models.FactoryStats{TotalMachines: 10, Efficiency: models.MachineEfficiency{MachinesOperating: 10}}
+	circuit := func(capacity, consumption float64, fuseTriggered bool) []models.Circuit {
+		return []models.Circuit{{
+			ID:            "1",
+			FuseTriggered: fuseTriggered,
+			Capacity:      models.CircuitCapacity{Total: capacity},
+			Consumption:   models.CircuitConsumption{Total: consumption},
+		}}
+	}
+	balanced := &models.ProdStats{Items: []models.ItemProdStats{{ItemStats: models.ItemStats{Name: "Iron Plate"}, ProducedPerMinute: 60, ConsumedPerMinute: 30}}}
+	noAlerts := &models.Vehicles{}
+
+	tests := []struct {
+		name        string
+		inputs      BaseScoreInputs
+		wantScore   float64
+		wantFactors []models.BaseScoreFactor
+	}{
+		{
+			name:        "healthy base",
+			inputs:      BaseScoreInputs{FactoryStats: running, Circuits: circuit(100, 50, false), ProdStats: balanced, Vehicles: noAlerts},
+			wantScore:   100,
+			wantFactors: []models.BaseScoreFactor{models.BaseScoreFactorEfficiency, models.BaseScoreFactorPower, models.BaseScoreFactorProduction, models.BaseScoreFactorLogistics},
+		},
+		{
+			name:        "tripped fuse",
+			inputs:      BaseScoreInputs{FactoryStats: running, Circuits: circuit(100, 50, true), ProdStats: balanced, Vehicles: noAlerts},
+			wantScore:   75,
+			wantFactors: []models.BaseScoreFactor{models.BaseScoreFactorEfficiency, models.BaseScoreFactorPower, models.BaseScoreFactorProduction, models.BaseScoreFactorLogistics},
+		},
+		{
+			name:        "power nearly exhausted",
+			inputs:      BaseScoreInputs{FactoryStats: running, Circuits: circuit(100, 95, false), ProdStats: balanced, Vehicles: noAlerts},
+			wantScore:   80, // Power scores 0.05 / 0.25 = 20
+			wantFactors: []models.BaseScoreFactor{models.BaseScoreFactorEfficiency, models.BaseScoreFactorPower, models.BaseScoreFactorProduction, models.BaseScoreFactorLogistics},
+		},
+		{
+			name:        "missing inputs are left out",
+			inputs:      BaseScoreInputs{Circuits: circuit(100, 95, false)},
+			wantScore:   20,
+			wantFactors: []models.BaseScoreFactor{models.BaseScoreFactorPower},
+		},
+		{
+			name:        "circuits without capacity are left out",
+			inputs:      BaseScoreInputs{FactoryStats: running, Circuits: circuit(0, 0, false)},
+			wantScore:   100,
+			wantFactors: []models.BaseScoreFactor{models.BaseScoreFactorEfficiency},
+		},
+		{
+			name:      "nothing polled yet",
+			inputs:    BaseScoreInputs{},
+			wantScore: 0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			score := ComputeBaseScore(test.inputs, DefaultBaseScoreWeights)
+			if math.Abs(score.Score-test.wantScore) > 1e-9 {
+				t.Errorf("got score %v, want %v", score.Score, test.wantScore)
+			}
+
+			var factors []models.BaseScoreFactor
+			weights := 0.0
+			for _, component := range score.Components {
+				factors = append(factors, component.Factor)
+				weights += component.Weight
+			}
+			if !reflect.DeepEqual(factors, test.wantFactors) {
+				t.Errorf("got factors %v, want %v", factors, test.wantFactors)
+			}
+			if len(factors) > 0 && math.Abs(weights-1) > 1e-9 {
+				t.Errorf("got weights summing to %v, want 1", weights)
+			}
+		})
+	}
+}
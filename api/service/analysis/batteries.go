@@ -0,0 +1,60 @@
+package analysis
+
+import (
+	"api/models/models"
+	"sort"
+)
+
+// minDrainingSamples is how many samples a battery must be seen discharging, without ever charging,
+// before its circuit is flagged, so a one-off drain is not reported
+const minDrainingSamples = 5
+
+// CircuitSample is the circuits captured at a point in game time
+type CircuitSample struct {
+	GameTimeID int64
+	Circuits   []models.Circuit
+}
+
+// FindDrainingBatteries returns the circuits whose batteries discharged in at least minDrainingSamples
+// samples and never charged over the samples, ordered by circuit ID. Samples must be in ascending game time.
+// Circuits that charge and discharge in turn are healthy and not flagged.
+func FindDrainingBatteries(samples []CircuitSample) []models.DrainingBattery {
+	batteries := make(map[string]*models.DrainingBattery)
+	charged := make(map[string]bool)
+	for _, sample := range samples {
+		for _, circuit := range sample.Circuits {
+			if circuit.Battery.Capacity <= 0 {
+				continue
+			}
+			battery, ok := batteries[circuit.ID]
+			if !ok {
+				battery = &models.DrainingBattery{CircuitID: circuit.ID}
+				batteries[circuit.ID] = battery
+			}
+			battery.Samples++
+			battery.AverageDifferential += circuit.Battery.Differential
+			battery.Percentage = circuit.Battery.Percentage
+			battery.UntilEmpty = circuit.Battery.UntilEmpty
+			if circuit.Battery.Differential > 0 {
+				charged[circuit.ID] = true
+			} else if circuit.Battery.Differential < 0 {
+				battery.DrainingSamples++
+			}
+		}
+	}
+
+	result := make([]models.DrainingBattery, 0)
+	for id, battery := range batteries {
+		if charged[id] || battery.DrainingSamples < minDrainingSamples {
+			continue
+		}
+		battery.AverageDifferential /= float64(battery.Samples)
+		result = append(result, *battery)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CircuitID < result[j].CircuitID
+	})
+
+	return result
+}
@@ -0,0 +1,43 @@
+package analysis
+
+import (
+	"api/models/models"
+	"testing"
+)
+
+func TestFindDrainingBatteries(t *testing.T) {
+	circuit := func(id string, differential, percentage float64) models.Circuit {
+		return models.Circuit{ID: id, Battery: models.CircuitBattery{Capacity: 100, Differential: differential, Percentage: percentage}}
+	}
+	// Differentials per sample of each circuit
+	differentials := map[string][]float64{
+		"2":       {-10, -10, 0, -10, -10, -10},
+		"1":       {-20, -20, -20, -20, -20, -20},
+		"cycling": {-10, -10, -10, -10, -10, 10},
+		"brief":   {0, 0, -10, -10, -10, -10},
+	}
+
+	samples := make([]CircuitSample, 6)
+	for idx := range samples {
+		samples[idx].GameTimeID = int64(idx * 60)
+		// Circuit 2 is listed first to check the ordering
+		for _, id := range []string{"2", "1", "cycling", "brief"} {
+			samples[idx].Circuits = append(samples[idx].Circuits, circuit(id, differentials[id][idx], float64(90-idx*10)))
+		}
+		samples[idx].Circuits = append(samples[idx].Circuits, models.Circuit{ID: "no battery"})
+	}
+
+	draining := FindDrainingBatteries(samples)
+	expected := []models.DrainingBattery{
+		{CircuitID: "1", Samples: 6, DrainingSamples: 6, AverageDifferential: -20, Percentage: 40},
+		{CircuitID: "2", Samples: 6, DrainingSamples: 5, AverageDifferential: -50.0 / 6, Percentage: 40},
+	}
+	if len(draining) != len(expected) {
+		t.Fatalf("got %d draining batteries, want %d: %+v", len(draining), len(expected), draining)
+	}
+	for i, want := range expected {
+		if draining[i] != want {
+			t.Errorf("battery %d: got %+v, want %+v", i, draining[i], want)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package analysis
+
+import (
+	"api/models/models"
+	"math"
+	"sort"
+)
+
+// connectorTolerance is how far (in centimeters, the unit of FRM locations) a belt end
+// may be from a building's bounding box and still be considered attached to it.
+const connectorTolerance = 100.0 // 1 m
+
+// beltGraph links every belt to the machine or splitter/merger at both of its ends.
+// Items flow from Location0 to Location1.
+type beltGraph struct {
+	belts          []models.Belt
+	sourceMachine  []*models.Machine
+	sourceSplitter []string
+	targetMachine  []*models.Machine
+	targetSplitter []string
+}
+
+func newBeltGraph(belts []models.Belt, splitterMergers []models.SplitterMerger, machines []models.Machine) *beltGraph {
+	graph := &beltGraph{
+		belts:          belts,
+		sourceMachine:  make([]*models.Machine, len(belts)),
+		sourceSplitter: make([]string, len(belts)),
+		targetMachine:  make([]*models.Machine, len(belts)),
+		targetSplitter: make([]string, len(belts)),
+	}
+
+	for idx, belt := range belts {
+		if belt.Connected0 {
+			graph.sourceMachine[idx] = findMachineAt(machines, belt.Location0)
+			if graph.sourceMachine[idx] == nil {
+				graph.sourceSplitter[idx] = findSplitterMergerAt(splitterMergers, belt.Location0)
+			}
+		}
+		if belt.Connected1 {
+			graph.targetMachine[idx] = findMachineAt(machines, belt.Location1)
+			if graph.targetMachine[idx] == nil {
+				graph.targetSplitter[idx] = findSplitterMergerAt(splitterMergers, belt.Location1)
+			}
+		}
+	}
+
+	return graph
+}
+
+// carriedItems resolves the set of items each belt can carry.
+// Belts fed by a machine carry its outputs, and belts leaving a splitter/merger carry
+// everything fed into it, since a splitter may legitimately branch any of its inputs.
+func (graph *beltGraph) carriedItems() []map[string]bool {
+	items := make([]map[string]bool, len(graph.belts))
+	for idx := range graph.belts {
+		items[idx] = map[string]bool{}
+		if machine := graph.sourceMachine[idx]; machine != nil {
+			for _, output := range machine.Output {
+				items[idx][output.Name] = true
+			}
+		}
+	}
+
+	// Propagate through splitters/mergers until stable; each pass can only add items,
+	// so the number of passes is bounded by the number of belts
+	for pass := 0; pass <= len(graph.belts); pass++ {
+		splitterItems := map[string]map[string]bool{}
+		for idx := range graph.belts {
+			id := graph.targetSplitter[idx]
+			if id == "" {
+				continue
+			}
+			if splitterItems[id] == nil {
+				splitterItems[id] = map[string]bool{}
+			}
+			for item := range items[idx] {
+				splitterItems[id][item] = true
+			}
+		}
+
+		changed := false
+		for idx := range graph.belts {
+			id := graph.sourceSplitter[idx]
+			if id == "" {
+				continue
+			}
+			for item := range splitterItems[id] {
+				if !items[idx][item] {
+					items[idx][item] = true
+					changed = true
+				}
+			}
+		}
+
+		if !changed {
+			break
+		}
+	}
+
+	return items
+}
+
+// FindMisroutedBelts returns belts whose carried items are not accepted by the
+// machine they feed into. A belt is only flagged when none of the items it can
+// carry is an input of the downstream machine, so mixed belts leaving splitters
+// are not reported as long as one of their items is usable.
+func FindMisroutedBelts(belts []models.Belt, splitterMergers []models.SplitterMerger, machines []models.Machine) []models.MisroutedBelt {
+	graph := newBeltGraph(belts, splitterMergers, machines)
+	carried := graph.carriedItems()
+
+	result := make([]models.MisroutedBelt, 0)
+	for idx, belt := range belts {
+		machine := graph.targetMachine[idx]
+		if machine == nil || len(machine.Input) == 0 || len(carried[idx]) == 0 {
+			continue
+		}
+
+		accepted := make([]string, 0, len(machine.Input))
+		matches := false
+		for _, input := range machine.Input {
+			accepted = append(accepted, input.Name)
+			if carried[idx][input.Name] {
+				matches = true
+			}
+		}
+		if matches {
+			continue
+		}
+
+		result = append(result, models.MisroutedBelt{
+			BeltID:        belt.ID,
+			BeltName:      belt.Name,
+			Items:         sortedKeys(carried[idx]),
+			AcceptedItems: accepted,
+			MachineType:   machine.Type,
+			Location0:     belt.Location0,
+			Location1:     belt.Location1,
+		})
+	}
+
+	return result
+}
+
+func findMachineAt(machines []models.Machine, location models.Location) *models.Machine {
+	for idx := range machines {
+		if machines[idx].BoundingBox.Contains(location, connectorTolerance) {
+			return &machines[idx]
+		}
+	}
+	return nil
+}
+
+func findSplitterMergerAt(splitterMergers []models.SplitterMerger, location models.Location) string {
+	for _, splitterMerger := range splitterMergers {
+		if splitterMerger.BoundingBox.Contains(location, connectorTolerance) {
+			return splitterMerger.ID
+		}
+	}
+	return ""
+}
+
+func distance(a, b models.Location) float64 {
+	dx, dy, dz := a.X-b.X, a.Y-b.Y, a.Z-b.Z
+	return math.Sqrt(dx*dx + dy*dy + dz*dz)
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,94 @@
+package analysis
+
+import (
+	"api/models/models"
+	"reflect"
+	"testing"
+)
+
+func TestFindMisroutedBelts(t *testing.T) {
+	machine := func(id string, x float64, inputs, outputs []string) models.Machine {
+		m := machineAt(id, models.MachineCategoryFactory, x, 0)
+		m.Type = models.MachineType(id)
+		for _, name := range inputs {
+			m.Input = append(m.Input, models.MachineProdStats{Name: name})
+		}
+		for _, name := range outputs {
+			m.Output = append(m.Output, models.MachineProdStats{Name: name})
+		}
+		return m
+	}
+	machines := []models.Machine{
+		machine("smelter", 0, nil, []string{"Iron Ingot"}),
+		machine("plates", 5000, []string{"Iron Ingot"}, []string{"Iron Plate"}),
+		machine("wire", 10000, []string{"Copper Ingot"}, []string{"Wire"}),
+		machine("copper", 20000, nil, []string{"Copper Ingot"}),
+	}
+	splitter := func(id string, x float64) models.SplitterMerger {
+		return models.SplitterMerger{ID: id, BoundingBox: models.BoundingBox{
+			Min: models.Location{X: x, Y: -100, Z: -100},
+			Max: models.Location{X: x + 200, Y: 100, Z: 100},
+		}}
+	}
+	splitterMergers := []models.SplitterMerger{splitter("s1", 2000), splitter("s2", 3000)}
+	belt := func(id string, x0, x1 float64) models.Belt {
+		return models.Belt{ID: id, Location0: models.Location{X: x0}, Location1: models.Location{X: x1}, Connected0: true, Connected1: true}
+	}
+	unconnected := belt("unconnected", 500, 9500)
+	unconnected.Connected0 = false
+
+	belts := []models.Belt{
+		belt("smelter-s1", 550, 2000), // Within the connector tolerance of the smelter
+		belt("s1-plates", 2200, 4500),
+		belt("s1-wire", 2200, 9500),
+		belt("s1-s2", 2200, 3000),
+		belt("s2-wire", 3200, 9500),          // Items propagate through both splitters
+		belt("copper-plates", 20590, 4450),   // Both ends within the tolerance
+		belt("detached-plates", 20700, 4500), // Beyond the tolerance, so its items are unknown
+		unconnected,
+	}
+
+	misrouted := FindMisroutedBelts(belts, splitterMergers, machines)
+
+	want := map[string][]string{
+		"s1-wire":       {"Iron Ingot"},
+		"s2-wire":       {"Iron Ingot"},
+		"copper-plates": {"Copper Ingot"},
+	}
+	got := make(map[string][]string, len(misrouted))
+	for _, belt := range misrouted {
+		got[belt.BeltID] = belt.Items
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got misrouted belts %v, want %v", got, want)
+	}
+	for _, belt := range misrouted {
+		if belt.BeltID == "copper-plates" && (belt.MachineType != "plates" || !reflect.DeepEqual(belt.AcceptedItems, []string{"Iron Ingot"})) {
+			t.Errorf("got %+v, want the plates machine accepting Iron Ingot", belt)
+		}
+	}
+}
+
+func TestFindMisroutedBeltsAcceptsMixedSplitterOutputs(t *testing.T) {
+	machines := []models.Machine{
+		machineAt("iron", models.MachineCategoryFactory, 0, 0),
+		machineAt("copper", models.MachineCategoryFactory, 0, 5000),
+		machineAt("wire", models.MachineCategoryFactory, 10000, 0),
+	}
+	machines[0].Output = []models.MachineProdStats{{Name: "Iron Ingot"}}
+	machines[1].Output = []models.MachineProdStats{{Name: "Copper Ingot"}}
+	machines[2].Input = []models.MachineProdStats{{Name: "Copper Ingot"}}
+	merger := models.SplitterMerger{ID: "merger", BoundingBox: models.BoundingBox{
+		Min: models.Location{X: 3000, Y: -100, Z: -100},
+		Max: models.Location{X: 3200, Y: 100, Z: 100},
+	}}
+	belts := []models.Belt{
+		{ID: "iron-merger", Location0: models.Location{X: 500}, Location1: models.Location{X: 3000}, Connected0: true, Connected1: true},
+		{ID: "copper-merger", Location0: models.Location{Y: 4500}, Location1: models.Location{X: 3100}, Connected0: true, Connected1: true},
+		{ID: "merger-wire", Location0: models.Location{X: 3200}, Location1: models.Location{X: 9500}, Connected0: true, Connected1: true},
+	}
+
+	if misrouted := FindMisroutedBelts(belts, []models.SplitterMerger{merger}, machines); len(misrouted) != 0 {
+		t.Errorf("got %+v, want a mixed belt carrying one accepted item left alone", misrouted)
+	}
+}
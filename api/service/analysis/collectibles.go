@@ -0,0 +1,66 @@
+package analysis
+
+import (
+	"api/models/models"
+)
+
+// powerShardsPerSlug is how many power shards each slug refines into
+var powerShardsPerSlug = map[models.SignalType]int{
+	models.SignalTypeBluePowerSlug:   1,
+	models.SignalTypeYellowPowerSlug: 2,
+	models.SignalTypePurplePowerSlug: 5,
+}
+
+// GetCollectibleProgress compares the collectible signals revealed by radar towers with the
+// collectibles held in player and storage inventories. Slugs are counted in power shards, since
+// collected slugs are usually refined. This is a best-effort estimate: collectibles consumed into
+// buildings (slotted shards and somersloops, spent spheres and hard drives) are no longer in any
+// inventory, and overlapping radar towers may report the same signal twice.
+func GetCollectibleProgress(radarTowers []models.RadarTower, players []models.Player, storages []models.Storage) []models.CollectibleProgress {
+	discovered := make(map[models.SignalType]int)
+	for _, tower := range radarTowers {
+		for _, signal := range tower.Signal {
+			discovered[signal.Name] += signal.Amount
+		}
+	}
+
+	held := make(map[string]int)
+	for _, player := range players {
+		for _, item := range player.Items {
+			held[item.Name] += int(item.Count)
+		}
+	}
+	for _, storage := range storages {
+		for _, item := range storage.Inventory {
+			held[item.Name] += int(item.Count)
+		}
+	}
+
+	const consumedNote = "Collectibles consumed into buildings are not counted as collected"
+	result := make([]models.CollectibleProgress, 0, 4)
+	for _, signalType := range []models.SignalType{models.SignalTypeSomersloop, models.SignalTypeMercerSphere, models.SignalTypeHardDrive} {
+		result = append(result, collectibleProgress(string(signalType), models.CollectibleUnitItems,
+			discovered[signalType], held[string(signalType)], consumedNote))
+	}
+
+	slugShards, collectedShards := 0, held["Power Shard"]
+	for slug, shards := range powerShardsPerSlug {
+		slugShards += discovered[slug] * shards
+		collectedShards += held[string(slug)] * shards
+	}
+	result = append(result, collectibleProgress("Power Slugs", models.CollectibleUnitPowerShards,
+		slugShards, collectedShards, "Power shards slotted into machines are not counted as collected"))
+
+	return result
+}
+
+func collectibleProgress(name string, unit models.CollectibleUnit, discovered, collected int, note string) models.CollectibleProgress {
+	return models.CollectibleProgress{
+		Name:       name,
+		Unit:       unit,
+		Discovered: discovered,
+		Collected:  collected,
+		Remaining:  max(discovered-collected, 0),
+		Note:       note,
+	}
+}
@@ -0,0 +1,61 @@
+package analysis
+
+import (
+	"api/models/models"
+	"testing"
+)
+
+func TestGetCollectibleProgress(t *testing.T) {
+	radarTowers := []models.RadarTower{
+		{ID: "north", Signal: []models.ScannedSignal{
+			{Name: models.SignalTypeSomersloop, Amount: 3},
+			{Name: models.SignalTypeBluePowerSlug, Amount: 10},
+			{Name: models.SignalTypeHardDrive, Amount: 4},
+		}},
+		{ID: "south", Signal: []models.ScannedSignal{
+			{Name: models.SignalTypeSomersloop, Amount: 2},
+			{Name: models.SignalTypeYellowPowerSlug, Amount: 3},
+			{Name: models.SignalTypePurplePowerSlug, Amount: 1},
+		}},
+	}
+	players := []models.Player{{Items: []models.ItemStats{
+		{Name: "Somersloop", Count: 2},
+		{Name: "Blue Power Slug", Count: 1},
+		{Name: "Power Shard", Count: 3},
+	}}}
+	storages := []models.Storage{{Inventory: []models.ItemStats{
+		{Name: "Somersloop", Count: 1},
+		{Name: "Hard Drive", Count: 6}, // More than the radar towers revealed
+		{Name: "Purple Power Slug", Count: 1},
+	}}}
+
+	expected := []struct {
+		name                             string
+		unit                             models.CollectibleUnit
+		discovered, collected, remaining int
+	}{
+		{"Somersloop", models.CollectibleUnitItems, 5, 3, 2},
+		{"Mercer Sphere", models.CollectibleUnitItems, 0, 0, 0},
+		{"Hard Drive", models.CollectibleUnitItems, 4, 6, 0},
+		// 10 blue + 3 yellow * 2 + 1 purple * 5 against 3 shards + 1 blue + 1 purple * 5
+		{"Power Slugs", models.CollectibleUnitPowerShards, 21, 9, 12},
+	}
+
+	progress := GetCollectibleProgress(radarTowers, players, storages)
+	if len(progress) != len(expected) {
+		t.Fatalf("got %d entries, want %d: %+v", len(progress), len(expected), progress)
+	}
+	for i, want := range expected {
+		got := progress[i]
+		if got.Name != want.name || got.Unit != want.unit {
+			t.Errorf("entry %d: got %s in %s, want %s in %s", i, got.Name, got.Unit, want.name, want.unit)
+		}
+		if got.Discovered != want.discovered || got.Collected != want.collected || got.Remaining != want.remaining {
+			t.Errorf("%s: got %d/%d/%d discovered/collected/remaining, want %d/%d/%d", want.name,
+				got.Discovered, got.Collected, got.Remaining, want.discovered, want.collected, want.remaining)
+		}
+		if got.Note == "" {
+			t.Errorf("%s: got no note on the estimate", want.name)
+		}
+	}
+}
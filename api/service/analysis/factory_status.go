@@ -0,0 +1,58 @@
+package analysis
+
+import (
+	"api/models/models"
+	"math"
+)
+
+// FactoryStatsSample is the factory stats captured at a point in game time
+type FactoryStatsSample struct {
+	GameTimeID   int64
+	FactoryStats models.FactoryStats
+}
+
+// GetFactoryStatusHistory returns the machine count per status of the samples within windowSeconds
+// of game time before the latest sample, 0 meaning all samples. When more than maxPoints remain,
+// consecutive samples are averaged into maxPoints buckets, each stamped with its last game time.
+// Samples must be in ascending game time.
+func GetFactoryStatusHistory(samples []FactoryStatsSample, windowSeconds int64, maxPoints int) []models.FactoryStatusPoint {
+	if windowSeconds > 0 && len(samples) > 0 {
+		cutoff := samples[len(samples)-1].GameTimeID - windowSeconds
+		start := 0
+		for start < len(samples) && samples[start].GameTimeID < cutoff {
+			start++
+		}
+		samples = samples[start:]
+	}
+
+	buckets := len(samples)
+	if maxPoints > 0 && buckets > maxPoints {
+		buckets = maxPoints
+	}
+
+	result := make([]models.FactoryStatusPoint, 0, buckets)
+	for bucket := 0; bucket < buckets; bucket++ {
+		from, to := bucket*len(samples)/buckets, (bucket+1)*len(samples)/buckets
+		var operating, idle, paused, unconfigured, unknown int
+		for _, sample := range samples[from:to] {
+			efficiency := sample.FactoryStats.Efficiency
+			operating += efficiency.MachinesOperating
+			idle += efficiency.MachinesIdle
+			paused += efficiency.MachinesPaused
+			unconfigured += efficiency.MachinesUnconfigured
+			unknown += efficiency.MachinesUnknown
+		}
+		count := float64(to - from)
+		average := func(total int) int { return int(math.Round(float64(total) / count)) }
+		result = append(result, models.FactoryStatusPoint{
+			GameTimeID:   samples[to-1].GameTimeID,
+			Operating:    average(operating),
+			Idle:         average(idle),
+			Paused:       average(paused),
+			Unconfigured: average(unconfigured),
+			Unknown:      average(unknown),
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,62 @@
+package analysis
+
+import (
+	"api/models/models"
+	"testing"
+)
+
+func TestGetFactoryStatusHistory(t *testing.T) {
+	samples := make([]FactoryStatsSample, 10)
+	for idx := range samples {
+		samples[idx] = FactoryStatsSample{
+			GameTimeID: int64(idx * 60),
+			FactoryStats: models.FactoryStats{Efficiency: models.MachineEfficiency{
+				MachinesOperating: 10 + idx,
+				MachinesIdle:      idx % 2,
+				MachinesPaused:    2,
+			}},
+		}
+	}
+	point := func(gameTimeID int64, operating, idle int) models.FactoryStatusPoint {
+		return models.FactoryStatusPoint{GameTimeID: gameTimeID, Operating: operating, Idle: idle, Paused: 2}
+	}
+
+	tests := []struct {
+		name          string
+		windowSeconds int64
+		maxPoints     int
+		expected      []models.FactoryStatusPoint
+	}{
+		{"window", 240, 0, []models.FactoryStatusPoint{
+			point(300, 15, 1), point(360, 16, 0), point(420, 17, 1), point(480, 18, 0), point(540, 19, 1),
+		}},
+		// Buckets of 3, 3 and 4 samples, averaged and rounded
+		{"downsampled", 0, 3, []models.FactoryStatusPoint{
+			point(120, 11, 0), point(300, 14, 1), point(540, 18, 1),
+		}},
+		{"window and downsampled", 240, 2, []models.FactoryStatusPoint{
+			point(360, 16, 1), point(540, 18, 1),
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			history := GetFactoryStatusHistory(samples, test.windowSeconds, test.maxPoints)
+			if len(history) != len(test.expected) {
+				t.Fatalf("got %d points, want %d: %+v", len(history), len(test.expected), history)
+			}
+			for i, want := range test.expected {
+				if history[i] != want {
+					t.Errorf("point %d: got %+v, want %+v", i, history[i], want)
+				}
+			}
+		})
+	}
+
+	if history := GetFactoryStatusHistory(samples, 0, 0); len(history) != len(samples) {
+		t.Errorf("got %d points without window or limit, want all %d", len(history), len(samples))
+	}
+	if history := GetFactoryStatusHistory(nil, 240, 3); len(history) != 0 {
+		t.Errorf("got %+v without samples, want none", history)
+	}
+}
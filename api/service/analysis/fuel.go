@@ -0,0 +1,55 @@
+package analysis
+
+import (
+	"api/models/models"
+	"sort"
+)
+
+// GetFuelBalances pairs the production rate of every fuel burned by fuel generators with the
+// summed consumption of those generators, as reported in their inputs. When the balance is
+// negative, the runway until the stored fuel (in storage and generator inventories) runs out is estimated.
+// Fuels are returned worst balance first.
+func GetFuelBalances(machines []models.Machine, prodStats models.ProdStats) []models.FuelBalance {
+	balances := make(map[string]*models.FuelBalance)
+	for _, machine := range machines {
+		if machine.Type != models.MachineTypeFuelGenerator {
+			continue
+		}
+		for _, input := range machine.Input {
+			balance, ok := balances[input.Name]
+			if !ok {
+				balance = &models.FuelBalance{Fuel: input.Name}
+				balances[input.Name] = balance
+			}
+			balance.Generators++
+			balance.ConsumedPerMinute += input.Current
+			balance.Stored += input.Stored
+		}
+	}
+
+	for _, item := range prodStats.Items {
+		if balance, ok := balances[item.Name]; ok {
+			balance.ProducedPerMinute = item.ProducedPerMinute
+			balance.Stored += item.Count
+		}
+	}
+
+	result := make([]models.FuelBalance, 0, len(balances))
+	for _, balance := range balances {
+		balance.NetPerMinute = balance.ProducedPerMinute - balance.ConsumedPerMinute
+		if balance.NetPerMinute < 0 {
+			runway := balance.Stored / -balance.NetPerMinute
+			balance.RunwayMinutes = &runway
+		}
+		result = append(result, *balance)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].NetPerMinute != result[j].NetPerMinute {
+			return result[i].NetPerMinute < result[j].NetPerMinute
+		}
+		return result[i].Fuel < result[j].Fuel
+	})
+
+	return result
+}
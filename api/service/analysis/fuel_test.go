@@ -0,0 +1,43 @@
+package analysis
+
+import (
+	"api/models/models"
+	"testing"
+)
+
+func TestGetFuelBalances(t *testing.T) {
+	generator := func(machineType models.MachineType, fuel string, current, stored float64) models.Machine {
+		return models.Machine{Type: machineType, Input: []models.MachineProdStats{{Name: fuel, Current: current, Stored: stored}}}
+	}
+	machines := []models.Machine{
+		generator(models.MachineTypeFuelGenerator, "Fuel", 20, 50),
+		generator(models.MachineTypeFuelGenerator, "Fuel", 20, 50),
+		generator(models.MachineTypeFuelGenerator, "Fuel", 20, 100),
+		generator(models.MachineTypeFuelGenerator, "Turbofuel", 7.5, 10),
+		generator(models.MachineTypeCoalGenerator, "Coal", 15, 0), // Only fuel generators are balanced
+	}
+	prodStats := models.ProdStats{Items: []models.ItemProdStats{
+		{ItemStats: models.ItemStats{Name: "Fuel", Count: 300}, ProducedPerMinute: 40},
+		{ItemStats: models.ItemStats{Name: "Turbofuel", Count: 500}, ProducedPerMinute: 10},
+		{ItemStats: models.ItemStats{Name: "Coal", Count: 1000}, ProducedPerMinute: 0},
+	}}
+
+	balances := GetFuelBalances(machines, prodStats)
+	if len(balances) != 2 {
+		t.Fatalf("got %+v, want Fuel and Turbofuel", balances)
+	}
+
+	fuel := balances[0]
+	if fuel.Fuel != "Fuel" || fuel.Generators != 3 || fuel.ConsumedPerMinute != 60 || fuel.NetPerMinute != -20 {
+		t.Errorf("got %+v, want 3 Fuel generators burning 60/min against 40/min produced", fuel)
+	}
+	// Buffered in generators (200) and in storage (300), drained at 20/min
+	if fuel.Stored != 500 || fuel.RunwayMinutes == nil || *fuel.RunwayMinutes != 25 {
+		t.Errorf("got stored %v with runway %v, want 500 lasting 25 minutes", fuel.Stored, fuel.RunwayMinutes)
+	}
+
+	turbofuel := balances[1]
+	if turbofuel.Fuel != "Turbofuel" || turbofuel.NetPerMinute != 2.5 || turbofuel.RunwayMinutes != nil {
+		t.Errorf("got %+v, want a Turbofuel surplus of 2.5/min without a runway", turbofuel)
+	}
+}
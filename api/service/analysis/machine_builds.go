@@ -0,0 +1,37 @@
+package analysis
+
+import (
+	"api/models/models"
+	"time"
+)
+
+// GetNewMachinesSince returns the machines first seen after since, oldest first
+func GetNewMachinesSince(buildLog models.MachineBuildLog, since time.Time) []models.MachineBuild {
+	result := make([]models.MachineBuild, 0)
+	for _, build := range buildLog.Builds {
+		if build.FirstSeen.After(since) {
+			result = append(result, build)
+		}
+	}
+	return result
+}
+
+// GetMachineBuildStats summarizes the build log as of now, listing the machines built after since
+func GetMachineBuildStats(buildLog models.MachineBuildLog, since, now time.Time) models.MachineBuildStats {
+	stats := models.MachineBuildStats{
+		TrackingSince: buildLog.TrackingSince,
+		TotalBuilt:    buildLog.TotalBuilt,
+		NewMachines:   GetNewMachinesSince(buildLog, since),
+		Counts:        buildLog.Counts,
+	}
+	if stats.Counts == nil {
+		stats.Counts = make([]models.MachineCountPoint, 0)
+	}
+	if len(buildLog.Counts) > 0 {
+		stats.TotalMachines = buildLog.Counts[len(buildLog.Counts)-1].Count
+	}
+	if hours := now.Sub(buildLog.TrackingSince).Hours(); !buildLog.TrackingSince.IsZero() && hours > 0 {
+		stats.BuiltPerHour = float64(buildLog.TotalBuilt) / hours
+	}
+	return stats
+}
@@ -0,0 +1,36 @@
+package analysis
+
+import (
+	"api/models/models"
+	"testing"
+	"time"
+)
+
+func TestGetMachineBuildStats(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	buildLog := models.MachineBuildLog{
+		TrackingSince: start,
+		TotalBuilt:    6,
+		Builds: []models.MachineBuild{
+			{Type: models.MachineTypeConstructor, FirstSeen: start.Add(10 * time.Minute)},
+			{Type: models.MachineTypeAssembler, FirstSeen: start.Add(90 * time.Minute)},
+			{Type: models.MachineTypeSmelter, FirstSeen: start.Add(100 * time.Minute)},
+		},
+		Counts: []models.MachineCountPoint{{Timestamp: start, Count: 40}, {Timestamp: start.Add(100 * time.Minute), Count: 46}},
+	}
+
+	stats := GetMachineBuildStats(buildLog, start.Add(time.Hour), start.Add(2*time.Hour))
+	if stats.BuiltPerHour != 3 {
+		t.Errorf("got %v built per hour, want 6 over 2 hours", stats.BuiltPerHour)
+	}
+	if stats.TotalMachines != 46 || stats.TotalBuilt != 6 {
+		t.Errorf("got %d machines and %d built, want 46 and 6", stats.TotalMachines, stats.TotalBuilt)
+	}
+	if len(stats.NewMachines) != 2 || stats.NewMachines[0].Type != models.MachineTypeAssembler {
+		t.Errorf("got new machines %+v, want the two built after the first hour", stats.NewMachines)
+	}
+
+	if empty := GetMachineBuildStats(models.MachineBuildLog{}, start, start); empty.BuiltPerHour != 0 || empty.Counts == nil {
+		t.Errorf("got %+v, want no rate and empty counts before tracking starts", empty)
+	}
+}
@@ -0,0 +1,42 @@
+package analysis
+
+import (
+	"api/models/models"
+	"strconv"
+)
+
+// ClassifyIdleMachines sets the idle reason of idle factory machines and extractors in place. A machine
+// is unpowered when its circuit is unknown (not connected), has a tripped fuse, or has neither production
+// nor battery charge left, and starved otherwise. Nothing is classified until circuits are known.
+func ClassifyIdleMachines(machines []models.Machine, circuits []models.Circuit) {
+	if circuits == nil {
+		return
+	}
+
+	byID := make(map[string]models.Circuit, len(circuits))
+	for _, circuit := range circuits {
+		byID[circuit.ID] = circuit
+	}
+
+	for idx := range machines {
+		machine := &machines[idx]
+		machine.IdleReason = ""
+		if machine.Status != models.MachineStatusIdle || machine.Category == models.MachineCategoryGenerator {
+			continue
+		}
+
+		circuit, ok := byID[strconv.Itoa(machine.CircuitID)]
+		if !ok || !circuitHasPower(circuit) {
+			machine.IdleReason = models.MachineIdleReasonUnpowered
+		} else {
+			machine.IdleReason = models.MachineIdleReasonStarved
+		}
+	}
+}
+
+func circuitHasPower(circuit models.Circuit) bool {
+	if circuit.FuseTriggered {
+		return false
+	}
+	return circuit.Production.Total > 0 || circuit.Battery.Percentage > 0
+}
@@ -0,0 +1,50 @@
+package analysis
+
+import (
+	"api/models/models"
+	"testing"
+)
+
+func TestClassifyIdleMachines(t *testing.T) {
+	circuits := []models.Circuit{
+		{ID: "1", Production: models.CircuitProduction{Total: 100e6}},
+		{ID: "2", FuseTriggered: true, Production: models.CircuitProduction{Total: 100e6}},
+		{ID: "3"}, // Generators out of fuel
+		{ID: "4", Battery: models.CircuitBattery{Percentage: 40}},
+	}
+	machine := func(id string, category models.MachineCategory, status models.MachineStatus, circuitID int) models.Machine {
+		return models.Machine{ID: id, Category: category, Status: status, CircuitIDs: models.CircuitIDs{CircuitID: circuitID}}
+	}
+	machines := []models.Machine{
+		machine("starved", models.MachineCategoryFactory, models.MachineStatusIdle, 1),
+		machine("fuse", models.MachineCategoryFactory, models.MachineStatusIdle, 2),
+		machine("no production", models.MachineCategoryExtractor, models.MachineStatusIdle, 3),
+		machine("on battery", models.MachineCategoryFactory, models.MachineStatusIdle, 4),
+		machine("not connected", models.MachineCategoryFactory, models.MachineStatusIdle, 99),
+		machine("operating", models.MachineCategoryFactory, models.MachineStatusOperating, 2),
+		machine("generator", models.MachineCategoryGenerator, models.MachineStatusIdle, 3),
+	}
+	machines[5].IdleReason = models.MachineIdleReasonStarved // Stale reason from an earlier poll
+
+	want := map[string]models.MachineIdleReason{
+		"starved":       models.MachineIdleReasonStarved,
+		"fuse":          models.MachineIdleReasonUnpowered,
+		"no production": models.MachineIdleReasonUnpowered,
+		"on battery":    models.MachineIdleReasonStarved,
+		"not connected": models.MachineIdleReasonUnpowered,
+		"operating":     "",
+		"generator":     "",
+	}
+
+	ClassifyIdleMachines(machines, circuits)
+	for _, machine := range machines {
+		if machine.IdleReason != want[machine.ID] {
+			t.Errorf("%s: got idle reason %q, want %q", machine.ID, machine.IdleReason, want[machine.ID])
+		}
+	}
+
+	unknown := []models.Machine{machine("idle", models.MachineCategoryFactory, models.MachineStatusIdle, 1)}
+	if ClassifyIdleMachines(unknown, nil); unknown[0].IdleReason != "" {
+		t.Errorf("got idle reason %q before circuits are known, want none", unknown[0].IdleReason)
+	}
+}
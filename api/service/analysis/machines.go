@@ -0,0 +1,73 @@
+package analysis
+
+import (
+	"api/models/models"
+	"sort"
+	"strings"
+)
+
+// GetItemProducers returns every machine whose output includes the item, with its individual
+// rate and efficiency, and the summed contribution of all of them. Item names are matched
+// ignoring case and surrounding whitespace.
+func GetItemProducers(machines []models.Machine, itemName string) models.ItemProducers {
+	result := models.ItemProducers{
+		Item:      strings.TrimSpace(itemName),
+		Producers: make([]models.ItemProducer, 0),
+	}
+
+	for _, machine := range machines {
+		for _, output := range machine.Output {
+			if !itemNamesEqual(output.Name, itemName) {
+				continue
+			}
+			result.Item = output.Name
+			result.Producers = append(result.Producers, models.ItemProducer{
+				Type:       machine.Type,
+				Status:     machine.Status,
+				Current:    output.Current,
+				Max:        output.Max,
+				Efficiency: output.Efficiency,
+				Location:   machine.Location,
+			})
+			result.TotalCurrent += output.Current
+			result.TotalMax += output.Max
+		}
+	}
+
+	// Least efficient first, those are the ones worth investigating
+	sort.SliceStable(result.Producers, func(i, j int) bool {
+		return result.Producers[i].Efficiency < result.Producers[j].Efficiency
+	})
+
+	return result
+}
+
+func itemNamesEqual(a, b string) bool {
+	return strings.EqualFold(strings.TrimSpace(a), strings.TrimSpace(b))
+}
+
+// MachinesInBounds returns the machines whose bounding box intersects the region, optionally only
+// those of the category (empty for all). A region without size on any axis, or with its min beyond
+// its max, contains no machines. Machines without a bounding box are matched by their location.
+func MachinesInBounds(machines []models.Machine, region models.BoundingBox, category models.MachineCategory) []models.Machine {
+	inBounds := make([]models.Machine, 0)
+	if region.Max.X <= region.Min.X || region.Max.Y <= region.Min.Y || region.Max.Z <= region.Min.Z {
+		return inBounds
+	}
+
+	for _, machine := range machines {
+		if category != "" && machine.Category != category {
+			continue
+		}
+		box := machine.BoundingBox
+		if box.Min.X == box.Max.X && box.Min.Y == box.Max.Y && box.Min.Z == box.Max.Z {
+			box = models.BoundingBox{Min: machine.Location, Max: machine.Location}
+		}
+		if box.Min.X <= region.Max.X && box.Max.X >= region.Min.X &&
+			box.Min.Y <= region.Max.Y && box.Max.Y >= region.Min.Y &&
+			box.Min.Z <= region.Max.Z && box.Max.Z >= region.Min.Z {
+			inBounds = append(inBounds, machine)
+		}
+	}
+	return inBounds
+}
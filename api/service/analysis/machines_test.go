@@ -0,0 +1,110 @@
+package analysis
+
+import (
+	"api/models/models"
+	"testing"
+)
+
+func machineAt(id string, category models.MachineCategory, x, y float64) models.Machine {
+	return models.Machine{
+		ID:       id,
+		Category: category,
+		Location: models.Location{X: x, Y: y},
+		BoundingBox: models.BoundingBox{
+			Min: models.Location{X: x - 500, Y: y - 500, Z: -100},
+			Max: models.Location{X: x + 500, Y: y + 500, Z: 100},
+		},
+	}
+}
+
+func TestMachinesInBounds(t *testing.T) {
+	machines := []models.Machine{
+		machineAt("center", models.MachineCategoryFactory, 0, 0),
+		machineAt("edge", models.MachineCategoryExtractor, 10400, 0), // Box reaches into the region
+		machineAt("far", models.MachineCategoryFactory, 200000, -150000),
+		machineAt("west", models.MachineCategoryGenerator, -9000, 9000),
+		// No bounding box reported, matched by location
+		{ID: "point", Category: models.MachineCategoryFactory, Location: models.Location{X: 5000, Y: 5000}},
+	}
+	region := func(minX, minY, maxX, maxY float64) models.BoundingBox {
+		return models.BoundingBox{
+			Min: models.Location{X: minX, Y: minY, Z: -1000},
+			Max: models.Location{X: maxX, Y: maxY, Z: 1000},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		region   models.BoundingBox
+		category models.MachineCategory
+		want     []string
+	}{
+		{"viewport", region(-10000, -10000, 10000, 10000), "", []string{"center", "edge", "west", "point"}},
+		{"category filter", region(-10000, -10000, 10000, 10000), models.MachineCategoryFactory, []string{"center", "point"}},
+		{"empty area", region(50000, 50000, 60000, 60000), "", nil},
+		{"zero size box", region(0, 0, 0, 0), "", nil},
+		{"zero width box", region(0, -10000, 0, 10000), "", nil},
+		{"inverted box", region(10000, 10000, -10000, -10000), "", nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := MachinesInBounds(machines, test.region, test.category)
+			if got == nil {
+				t.Fatal("got nil, want an empty list")
+			}
+			if len(got) != len(test.want) {
+				t.Fatalf("got %d machines, want %v", len(got), test.want)
+			}
+			for idx, id := range test.want {
+				if got[idx].ID != id {
+					t.Errorf("machine %d is %s, want %s", idx, got[idx].ID, id)
+				}
+			}
+		})
+	}
+}
+
+func TestGetItemProducers(t *testing.T) {
+	foundry := func(id string, current, max float64) models.Machine {
+		machine := machineAt(id, models.MachineCategoryFactory, 0, 0)
+		machine.Type = models.MachineTypeFoundry
+		machine.Output = []models.MachineProdStats{
+			{Name: "Steel Ingot", Current: current, Max: max, Efficiency: current / max},
+		}
+		return machine
+	}
+	smelter := machineAt("smelter", models.MachineCategoryFactory, 0, 0)
+	smelter.Type = models.MachineTypeSmelter
+	smelter.Output = []models.MachineProdStats{{Name: "Iron Ingot", Current: 30, Max: 30, Efficiency: 1}}
+	machines := []models.Machine{foundry("full", 45, 45), smelter, foundry("starved", 15, 45), foundry("half", 22.5, 45)}
+
+	producers := GetItemProducers(machines, "  steel ingot ")
+	if producers.Item != "Steel Ingot" {
+		t.Errorf("got item %q, want the in-game name Steel Ingot", producers.Item)
+	}
+	if len(producers.Producers) != 3 {
+		t.Fatalf("got %d producers, want the 3 foundries: %+v", len(producers.Producers), producers.Producers)
+	}
+
+	var sum float64
+	for i, producer := range producers.Producers {
+		if producer.Type != models.MachineTypeFoundry {
+			t.Errorf("got producer of type %s, want only foundries", producer.Type)
+		}
+		if i > 0 && producer.Efficiency < producers.Producers[i-1].Efficiency {
+			t.Errorf("producers not sorted least efficient first: %+v", producers.Producers)
+		}
+		sum += producer.Current
+	}
+	if producers.TotalCurrent != sum || sum != 82.5 {
+		t.Errorf("got total current %v, want the per-foundry sum 82.5 (summed %v)", producers.TotalCurrent, sum)
+	}
+	if producers.TotalMax != 135 {
+		t.Errorf("got total max %v, want 135", producers.TotalMax)
+	}
+
+	if none := GetItemProducers(machines, "Copper Ingot"); len(none.Producers) != 0 || none.TotalCurrent != 0 {
+		t.Errorf("got %+v for an item nobody produces, want no producers", none)
+	}
+}
@@ -0,0 +1,121 @@
+package analysis
+
+import (
+	"api/models/models"
+	"math"
+	"sort"
+)
+
+const (
+	// minOscillationCycles is the number of full cycles required before an item is flagged
+	minOscillationCycles = 3
+	// oscillationSwing is the drop or rise, relative to the mean rate, that counts as a turning point.
+	// Noise below it is ignored.
+	oscillationSwing = 0.25
+	// maxPeriodVariation is the largest coefficient of variation between peak intervals
+	// for the pattern to count as regular
+	maxPeriodVariation = 0.35
+)
+
+// ProductionSample is the production stats captured at a point in game time
+type ProductionSample struct {
+	GameTimeID int64
+	ProdStats  models.ProdStats
+}
+
+// FindOscillatingItems returns items whose produced rate follows a regular sawtooth of peaks
+// and troughs over the samples, ordered by name. Samples must be in ascending game time.
+// A steady or slowly drifting rate, and noise smaller than the swing threshold, are not flagged.
+func FindOscillatingItems(samples []ProductionSample) []models.OscillatingItem {
+	series := map[string][]float64{}
+	times := map[string][]int64{}
+	for _, sample := range samples {
+		for _, item := range sample.ProdStats.Items {
+			series[item.Name] = append(series[item.Name], item.ProducedPerMinute)
+			times[item.Name] = append(times[item.Name], sample.GameTimeID)
+		}
+	}
+
+	result := make([]models.OscillatingItem, 0)
+	for name, values := range series {
+		if item, ok := detectOscillation(values, times[name]); ok {
+			item.Name = name
+			result = append(result, item)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result
+}
+
+// detectOscillation finds alternating peaks and troughs separated by at least the swing threshold
+func detectOscillation(values []float64, times []int64) (models.OscillatingItem, bool) {
+	if len(values) < minOscillationCycles*2+1 {
+		return models.OscillatingItem{}, false
+	}
+
+	item := models.OscillatingItem{MinPerMinute: math.Inf(1), MaxPerMinute: math.Inf(-1)}
+	for _, value := range values {
+		item.MeanPerMinute += value
+		item.MinPerMinute = math.Min(item.MinPerMinute, value)
+		item.MaxPerMinute = math.Max(item.MaxPerMinute, value)
+	}
+	item.MeanPerMinute /= float64(len(values))
+	if item.MeanPerMinute <= 0 {
+		return models.OscillatingItem{}, false
+	}
+	swing := item.MeanPerMinute * oscillationSwing
+
+	var peakTimes []int64
+	rising := true
+	extreme := values[0]
+	extremeTime := times[0]
+	for idx := 1; idx < len(values); idx++ {
+		value := values[idx]
+		if rising {
+			if value > extreme {
+				extreme, extremeTime = value, times[idx]
+			} else if extreme-value >= swing {
+				peakTimes = append(peakTimes, extremeTime)
+				rising = false
+				extreme, extremeTime = value, times[idx]
+			}
+		} else {
+			if value < extreme {
+				extreme, extremeTime = value, times[idx]
+			} else if value-extreme >= swing {
+				rising = true
+				extreme, extremeTime = value, times[idx]
+			}
+		}
+	}
+
+	item.Cycles = len(peakTimes) - 1
+	if item.Cycles < minOscillationCycles {
+		return models.OscillatingItem{}, false
+	}
+
+	intervals := make([]float64, item.Cycles)
+	for idx := range intervals {
+		intervals[idx] = float64(peakTimes[idx+1] - peakTimes[idx])
+		item.PeriodSeconds += intervals[idx]
+	}
+	item.PeriodSeconds /= float64(len(intervals))
+	if item.PeriodSeconds <= 0 {
+		return models.OscillatingItem{}, false
+	}
+
+	variance := 0.0
+	for _, interval := range intervals {
+		variance += (interval - item.PeriodSeconds) * (interval - item.PeriodSeconds)
+	}
+	variance /= float64(len(intervals))
+	if math.Sqrt(variance)/item.PeriodSeconds > maxPeriodVariation {
+		return models.OscillatingItem{}, false
+	}
+
+	return item, true
+}
@@ -0,0 +1,58 @@
+package analysis
+
+import (
+	"api/models/models"
+	"testing"
+)
+
+// productionSamples builds samples 10 seconds apart with the produced rate of each item at every sample
+func productionSamples(count int, rates map[string]func(idx int) float64) []ProductionSample {
+	samples := make([]ProductionSample, count)
+	for idx := range samples {
+		samples[idx].GameTimeID = int64(idx * 10)
+		for name, rate := range rates {
+			samples[idx].ProdStats.Items = append(samples[idx].ProdStats.Items, prodItem(name, rate(idx), 0))
+		}
+	}
+	return samples
+}
+
+// squareWave alternates between high and low, staying on each for the given number of samples in turn
+func squareWave(high, low float64, lengths ...int) func(idx int) float64 {
+	return func(idx int) float64 {
+		for segment := 0; ; segment++ {
+			length := lengths[segment%len(lengths)]
+			if idx < length {
+				if segment%2 == 0 {
+					return high
+				}
+				return low
+			}
+			idx -= length
+		}
+	}
+}
+
+func TestFindOscillatingItems(t *testing.T) {
+	samples := productionSamples(40, map[string]func(int) float64{
+		"Iron Plate":   squareWave(120, 20, 3, 3), // Starves every minute
+		"Wire":         func(int) float64 { return 90 },
+		"Screw":        func(idx int) float64 { return 100 + float64(idx%2)*10 }, // Noise below the swing threshold
+		"Rotor":        func(idx int) float64 { return float64(idx * 5) },        // Slowly ramping up
+		"Copper Sheet": squareWave(60, 10, 1, 1, 1, 8),                           // Starves at irregular intervals
+	})
+
+	oscillating := FindOscillatingItems(samples)
+	if len(oscillating) != 1 {
+		t.Fatalf("got %+v, want only Iron Plate", oscillating)
+	}
+	got := oscillating[0]
+	want := models.OscillatingItem{Name: "Iron Plate", Cycles: 6, PeriodSeconds: 60, MinPerMinute: 20, MaxPerMinute: 120, MeanPerMinute: 72.5}
+	if got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	if short := FindOscillatingItems(samples[:minOscillationCycles*2]); len(short) != 0 {
+		t.Errorf("got %+v from %d samples, want too few samples to flag anything", short, minOscillationCycles*2)
+	}
+}
@@ -0,0 +1,100 @@
+package analysis
+
+import (
+	"api/models/models"
+	"sort"
+)
+
+// packagedForms maps each packaged item to the fluid it holds
+var packagedForms = map[string]string{
+	"Packaged Water":             "Water",
+	"Packaged Oil":               "Crude Oil",
+	"Packaged Heavy Oil Residue": "Heavy Oil Residue",
+	"Packaged Fuel":              "Fuel",
+	"Packaged Liquid Biofuel":    "Liquid Biofuel",
+	"Packaged Turbofuel":         "Turbofuel",
+	"Packaged Rocket Fuel":       "Rocket Fuel",
+	"Packaged Ionized Fuel":      "Ionized Fuel",
+	"Packaged Alumina Solution":  "Alumina Solution",
+	"Packaged Sulfuric Acid":     "Sulfuric Acid",
+	"Packaged Nitrogen Gas":      "Nitrogen Gas",
+	"Packaged Nitric Acid":       "Nitric Acid",
+}
+
+// GetPackagedCommodities reports every fluid that is produced or consumed in packaged form, with the
+// production and consumption of both forms combined. Conversions by packagers only change the transport
+// state, so their inputs and outputs are left out of the combined rates and listed per packager instead.
+// Sorted by combined production, highest first.
+func GetPackagedCommodities(prodStats models.ProdStats, machines []models.Machine) []models.PackagedCommodity {
+	commodities := make(map[string]*models.PackagedCommodity)
+	commodity := func(packaged string) *models.PackagedCommodity {
+		if existing, ok := commodities[packaged]; ok {
+			return existing
+		}
+		created := &models.PackagedCommodity{Fluid: packagedForms[packaged], Packaged: packaged, Packagers: make([]models.PackagingSite, 0)}
+		commodities[packaged] = created
+		return created
+	}
+
+	fluidToPackaged := make(map[string]string, len(packagedForms))
+	for packaged, fluid := range packagedForms {
+		fluidToPackaged[fluid] = packaged
+	}
+
+	for _, item := range prodStats.Items {
+		if item.ProducedPerMinute <= 0 && item.ConsumedPerMinute <= 0 {
+			continue
+		}
+		if _, ok := packagedForms[item.Name]; ok {
+			entry := commodity(item.Name)
+			entry.PackagedProducedPerMinute += item.ProducedPerMinute
+			entry.PackagedConsumedPerMinute += item.ConsumedPerMinute
+		}
+	}
+	for _, machine := range machines {
+		if machine.Type != models.MachineTypePackager {
+			continue
+		}
+		for _, output := range machine.Output {
+			if output.Current <= 0 {
+				continue
+			}
+			if _, ok := packagedForms[output.Name]; ok {
+				entry := commodity(output.Name)
+				entry.PackagedPerMinute += output.Current
+				entry.Packagers = append(entry.Packagers, models.PackagingSite{Direction: models.PackagingDirectionPackage, Rate: output.Current, Location: machine.Location})
+			} else if packaged, ok := fluidToPackaged[output.Name]; ok {
+				entry := commodity(packaged)
+				entry.UnpackagedPerMinute += output.Current
+				entry.Packagers = append(entry.Packagers, models.PackagingSite{Direction: models.PackagingDirectionUnpackage, Rate: output.Current, Location: machine.Location})
+			}
+		}
+	}
+
+	// Fluids are only tracked once their packaged form is in use
+	for _, item := range prodStats.Items {
+		if packaged, ok := fluidToPackaged[item.Name]; ok {
+			if entry, tracked := commodities[packaged]; tracked {
+				entry.FluidProducedPerMinute += item.ProducedPerMinute
+				entry.FluidConsumedPerMinute += item.ConsumedPerMinute
+			}
+		}
+	}
+
+	result := make([]models.PackagedCommodity, 0, len(commodities))
+	for _, entry := range commodities {
+		// Packaging consumes fluid and produces packaged items, unpackaging the reverse
+		entry.ProducedPerMinute = max(entry.FluidProducedPerMinute-entry.UnpackagedPerMinute, 0) + max(entry.PackagedProducedPerMinute-entry.PackagedPerMinute, 0)
+		entry.ConsumedPerMinute = max(entry.FluidConsumedPerMinute-entry.PackagedPerMinute, 0) + max(entry.PackagedConsumedPerMinute-entry.UnpackagedPerMinute, 0)
+		result = append(result, *entry)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].ProducedPerMinute != result[j].ProducedPerMinute {
+			return result[i].ProducedPerMinute > result[j].ProducedPerMinute
+		}
+		return result[i].Packaged < result[j].Packaged
+	})
+
+	return result
+}
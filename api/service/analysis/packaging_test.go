@@ -0,0 +1,66 @@
+package analysis
+
+import (
+	"api/models/models"
+	"testing"
+)
+
+func TestGetPackagedCommodities(t *testing.T) {
+	// 120 fuel refined, 60 of it packaged and shipped, 40 unpackaged at an outpost, 100 burned in total.
+	// Prod stats count the packager conversions as production and consumption of both forms.
+	prodStats := models.ProdStats{Items: []models.ItemProdStats{
+		prodItem("Fuel", 160, 160),
+		prodItem("Packaged Fuel", 60, 40),
+		prodItem("Empty Canister", 40, 60),
+		prodItem("Water", 600, 500),
+		prodItem("Packaged Water", 0, 10),
+		prodItem("Crude Oil", 300, 300),
+	}}
+	packager := func(x float64, outputs ...models.MachineProdStats) models.Machine {
+		return models.Machine{Type: models.MachineTypePackager, Output: outputs, Location: models.Location{X: x}}
+	}
+	machines := []models.Machine{
+		packager(0, models.MachineProdStats{Name: "Packaged Fuel", Current: 60}),
+		packager(1000, models.MachineProdStats{Name: "Fuel", Current: 40}, models.MachineProdStats{Name: "Empty Canister", Current: 40}),
+		packager(2000, models.MachineProdStats{Name: "Packaged Fuel"}), // Idle
+		{Type: models.MachineTypeRefinery, Output: []models.MachineProdStats{{Name: "Fuel", Current: 120}}},
+	}
+
+	commodities := GetPackagedCommodities(prodStats, machines)
+	if len(commodities) != 2 {
+		t.Fatalf("got %d commodities, want water and fuel: %+v", len(commodities), commodities)
+	}
+
+	// Water has no packager, so both forms are combined as they are
+	water := commodities[0]
+	if water.Packaged != "Packaged Water" || water.Fluid != "Water" || water.ProducedPerMinute != 600 || water.ConsumedPerMinute != 510 {
+		t.Errorf("got %+v, want water producing 600 and consuming 510", water)
+	}
+
+	fuel := commodities[1]
+	if fuel.Packaged != "Packaged Fuel" || fuel.Fluid != "Fuel" {
+		t.Fatalf("got %+v second, want fuel", fuel)
+	}
+	if fuel.ProducedPerMinute != 120 || fuel.ConsumedPerMinute != 100 {
+		t.Errorf("got fuel producing %v and consuming %v, want 120 and 100 without the conversions", fuel.ProducedPerMinute, fuel.ConsumedPerMinute)
+	}
+	if fuel.PackagedPerMinute != 60 || fuel.UnpackagedPerMinute != 40 {
+		t.Errorf("got %v packaged and %v unpackaged, want 60 and 40", fuel.PackagedPerMinute, fuel.UnpackagedPerMinute)
+	}
+	if fuel.FluidProducedPerMinute != 160 || fuel.PackagedConsumedPerMinute != 40 {
+		t.Errorf("got %+v, want the raw rates of both forms kept", fuel)
+	}
+
+	expectedSites := []models.PackagingSite{
+		{Direction: models.PackagingDirectionPackage, Rate: 60, Location: models.Location{X: 0}},
+		{Direction: models.PackagingDirectionUnpackage, Rate: 40, Location: models.Location{X: 1000}},
+	}
+	if len(fuel.Packagers) != len(expectedSites) {
+		t.Fatalf("got packagers %+v, want %+v", fuel.Packagers, expectedSites)
+	}
+	for i, want := range expectedSites {
+		if fuel.Packagers[i] != want {
+			t.Errorf("packager %d: got %+v, want %+v", i, fuel.Packagers[i], want)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package analysis
+
+import (
+	"api/models/models"
+	"math"
+	"sort"
+)
+
+const (
+	// pipeJunctionRadius is how far a pipe end may be from a junction's center, in location units,
+	// and still be considered attached to it
+	pipeJunctionRadius = 150.0
+
+	// overSupplyTolerance is the share by which inflow may exceed outflow capacity before a network
+	// is flagged, so momentary surges while buffers fill are not reported
+	overSupplyTolerance = 0.05
+)
+
+// GetPipeNetworkBalances computes the fluid balance of every pipe junction network. Junctions joined
+// by pipes form one network. Pipes between the network and a machine that only outputs fluids count
+// as inflow, and consumers that only take fluids add their max intake to the outflow capacity.
+// Pipes may report negative flow when fluid runs against their build direction (e.g. with headlift),
+// so only flow magnitudes are used. Most over-supplied networks come first.
+func GetPipeNetworkBalances(pipes []models.Pipe, junctions []models.PipeJunction, machines []models.Machine, prodStats models.ProdStats) []models.PipeNetworkBalance {
+	forms := make(map[string]models.ResourceForm, len(prodStats.Items))
+	for _, item := range prodStats.Items {
+		forms[item.Name] = item.Form
+	}
+
+	// Union junctions connected by pipes into networks
+	parent := make([]int, len(junctions))
+	for idx := range parent {
+		parent[idx] = idx
+	}
+	var find func(int) int
+	find = func(idx int) int {
+		if parent[idx] != idx {
+			parent[idx] = find(parent[idx])
+		}
+		return parent[idx]
+	}
+
+	type pipeEnds struct {
+		junction [2]int
+		machine  [2]*models.Machine
+	}
+	ends := make([]pipeEnds, len(pipes))
+	for idx, pipe := range pipes {
+		locations := [2]models.Location{pipe.Location0, pipe.Location1}
+		connected := [2]bool{pipe.Connected0, pipe.Connected1}
+		for side := range locations {
+			ends[idx].junction[side] = -1
+			if !connected[side] {
+				continue
+			}
+			ends[idx].junction[side] = findPipeJunctionAt(junctions, locations[side])
+			if ends[idx].junction[side] < 0 {
+				ends[idx].machine[side] = findMachineAt(machines, locations[side])
+			}
+		}
+		if a, b := ends[idx].junction[0], ends[idx].junction[1]; a >= 0 && b >= 0 {
+			parent[find(a)] = find(b)
+		}
+	}
+
+	networks := make(map[int]*models.PipeNetworkBalance)
+	consumers := make(map[int]map[*models.Machine]bool)
+	for idx := range junctions {
+		root := find(idx)
+		network, ok := networks[root]
+		if !ok {
+			network = &models.PipeNetworkBalance{Location: junctions[idx].Location}
+			networks[root] = network
+			consumers[root] = make(map[*models.Machine]bool)
+		}
+		network.JunctionIDs = append(network.JunctionIDs, junctions[idx].ID)
+	}
+
+	for idx, pipe := range pipes {
+		for side := range 2 {
+			junction, machine := ends[idx].junction[side], ends[idx].machine[1-side]
+			if junction < 0 || machine == nil {
+				continue
+			}
+			root := find(junction)
+			produces, consumes := fluidRoles(machine, forms)
+			switch {
+			case produces && !consumes:
+				networks[root].InflowPerMinute += math.Abs(pipe.ItemsPerMinute)
+			case consumes && !produces && !consumers[root][machine]:
+				consumers[root][machine] = true
+				for _, input := range machine.Input {
+					if isFluid(forms[input.Name]) {
+						networks[root].OutflowCapacityPerMinute += input.Max
+					}
+				}
+			}
+		}
+	}
+
+	result := make([]models.PipeNetworkBalance, 0, len(networks))
+	for _, network := range networks {
+		network.NetPerMinute = network.InflowPerMinute - network.OutflowCapacityPerMinute
+		network.OverSupplied = network.OutflowCapacityPerMinute > 0 &&
+			network.InflowPerMinute > network.OutflowCapacityPerMinute*(1+overSupplyTolerance)
+		sort.Strings(network.JunctionIDs)
+		result = append(result, *network)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].NetPerMinute != result[j].NetPerMinute {
+			return result[i].NetPerMinute > result[j].NetPerMinute
+		}
+		return result[i].JunctionIDs[0] < result[j].JunctionIDs[0]
+	})
+
+	return result
+}
+
+func findPipeJunctionAt(junctions []models.PipeJunction, location models.Location) int {
+	for idx, junction := range junctions {
+		if distance(junction.Location, location) <= pipeJunctionRadius {
+			return idx
+		}
+	}
+	return -1
+}
+
+// fluidRoles reports whether a machine outputs and whether it takes in fluids
+func fluidRoles(machine *models.Machine, forms map[string]models.ResourceForm) (produces bool, consumes bool) {
+	for _, output := range machine.Output {
+		produces = produces || isFluid(forms[output.Name])
+	}
+	for _, input := range machine.Input {
+		consumes = consumes || isFluid(forms[input.Name])
+	}
+	return produces, consumes
+}
+
+func isFluid(form models.ResourceForm) bool {
+	return form == models.ResourceFormLiquid || form == models.ResourceFormGas
+}
@@ -0,0 +1,70 @@
+package analysis
+
+import (
+	"api/models/models"
+	"testing"
+)
+
+func TestGetPipeNetworkBalances(t *testing.T) {
+	machine := func(id string, x, y float64, input, output []models.MachineProdStats) models.Machine {
+		m := machineAt(id, models.MachineCategoryFactory, x, y)
+		m.Input, m.Output = input, output
+		return m
+	}
+	water := func(max float64) []models.MachineProdStats {
+		return []models.MachineProdStats{{Name: "Water", Max: max}}
+	}
+	machines := []models.Machine{
+		machine("extractor-1", -5000, 0, nil, water(120)),
+		machine("extractor-2", -5000, 3000, nil, water(120)),
+		machine("generator-1", 5000, 0, water(45), nil),
+		machine("generator-2", 5000, 3000, water(45), nil),
+		machine("refinery", 0, -5000, water(60), []models.MachineProdStats{{Name: "Fuel"}}),
+		machine("extractor-3", 45000, 0, nil, water(120)),
+		machine("generator-3", 55000, 0, water(100), []models.MachineProdStats{{Name: "Coal"}}), // Solid output only
+	}
+	junctions := []models.PipeJunction{
+		{ID: "j1", Location: models.Location{X: 0, Y: 0}},
+		{ID: "j2", Location: models.Location{X: 0, Y: 3000}},
+		{ID: "j3", Location: models.Location{X: 50000, Y: 0}},
+	}
+	pipe := func(from, to models.Location, perMinute float64) models.Pipe {
+		return models.Pipe{Location0: from, Location1: to, Connected0: true, Connected1: true, ItemsPerMinute: perMinute}
+	}
+	at := func(x, y float64) models.Location { return models.Location{X: x, Y: y} }
+	pipes := []models.Pipe{
+		pipe(at(-4550, 0), at(0, 0), 120),
+		pipe(at(0, 3000), at(-4550, 3000), -120), // Built from the junction, so the flow runs backwards
+		pipe(at(0, 100), at(0, 2900), 90),        // Joins both junctions into one network
+		pipe(at(0, 0), at(4550, 0), 45),
+		pipe(at(0, 3000), at(4550, 3000), 20),
+		pipe(at(0, 3000), at(4550, 2800), 25), // Second pipe into the same generator
+		pipe(at(0, 0), at(0, -4550), 0),       // Refinery takes and makes fluids, so it is neither side
+		pipe(at(45450, 0), at(50000, 0), 104),
+		pipe(at(50000, 0), at(54550, 0), 100),
+	}
+	prodStats := models.ProdStats{Items: []models.ItemProdStats{
+		{ItemStats: models.ItemStats{Name: "Water", Form: models.ResourceFormLiquid}},
+		{ItemStats: models.ItemStats{Name: "Fuel", Form: models.ResourceFormLiquid}},
+		{ItemStats: models.ItemStats{Name: "Coal", Form: models.ResourceFormSolid}},
+	}}
+
+	balances := GetPipeNetworkBalances(pipes, junctions, machines, prodStats)
+	if len(balances) != 2 {
+		t.Fatalf("got %d networks, want 2: %+v", len(balances), balances)
+	}
+
+	joined := balances[0]
+	if len(joined.JunctionIDs) != 2 || joined.JunctionIDs[0] != "j1" || joined.JunctionIDs[1] != "j2" {
+		t.Errorf("got junctions %v, want j1 and j2 joined", joined.JunctionIDs)
+	}
+	if joined.InflowPerMinute != 240 || joined.OutflowCapacityPerMinute != 90 || joined.NetPerMinute != 150 || !joined.OverSupplied {
+		t.Errorf("got %+v, want 240 in against 90 capacity, over-supplied", joined)
+	}
+
+	// Within the tolerance of its consumer's capacity
+	nearlyBalanced := balances[1]
+	if nearlyBalanced.JunctionIDs[0] != "j3" || nearlyBalanced.NetPerMinute != 4 || nearlyBalanced.OverSupplied {
+		t.Errorf("got %+v, want j3 with 4 surplus, not over-supplied", nearlyBalanced)
+	}
+}
@@ -0,0 +1,115 @@
+package analysis
+
+import (
+	"api/models/models"
+	"fmt"
+	"sort"
+)
+
+// GroupPowerPlants clusters generators of the same type into power plants. Generators within radius
+// (in location units) of any generator of a plant belong to it, so a long row of generators stays one
+// plant. Plants are named per type in order of their position, west to east, and an isolated generator
+// is a plant of its own.
+func GroupPowerPlants(machines []models.Machine, radius float64) []models.PowerPlant {
+	byType := make(map[models.MachineType][]models.Machine)
+	for _, machine := range machines {
+		if machine.Category == models.MachineCategoryGenerator {
+			byType[machine.Type] = append(byType[machine.Type], machine)
+		}
+	}
+
+	result := make([]models.PowerPlant, 0)
+	for generatorType, generators := range byType {
+		plants := make([]models.PowerPlant, 0)
+		for _, cluster := range clusterByDistance(generators, radius) {
+			plants = append(plants, summarizePowerPlant(generatorType, cluster))
+		}
+		sort.Slice(plants, func(i, j int) bool {
+			if plants[i].X != plants[j].X {
+				return plants[i].X < plants[j].X
+			}
+			return plants[i].Y < plants[j].Y
+		})
+		for idx := range plants {
+			plants[idx].Name = fmt.Sprintf("%s Plant %s", generatorType, plantLabel(idx))
+		}
+		result = append(result, plants...)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Output != result[j].Output {
+			return result[i].Output > result[j].Output
+		}
+		return result[i].Name < result[j].Name
+	})
+
+	return result
+}
+
+// clusterByDistance groups machines transitively linked by being within radius of each other
+func clusterByDistance(machines []models.Machine, radius float64) [][]models.Machine {
+	visited := make([]bool, len(machines))
+	var clusters [][]models.Machine
+	for start := range machines {
+		if visited[start] {
+			continue
+		}
+		visited[start] = true
+		queue := []int{start}
+		var cluster []models.Machine
+		for len(queue) > 0 {
+			current := queue[0]
+			queue = queue[1:]
+			cluster = append(cluster, machines[current])
+			for next := range machines {
+				if !visited[next] && distance(machines[current].Location, machines[next].Location) <= radius {
+					visited[next] = true
+					queue = append(queue, next)
+				}
+			}
+		}
+		clusters = append(clusters, cluster)
+	}
+	return clusters
+}
+
+func summarizePowerPlant(generatorType models.MachineType, generators []models.Machine) models.PowerPlant {
+	plant := models.PowerPlant{
+		Type:       generatorType,
+		Generators: len(generators),
+		Fuel:       make([]models.PowerPlantFuel, 0),
+	}
+
+	fuelByName := make(map[string]float64)
+	for _, generator := range generators {
+		plant.X += generator.X / float64(len(generators))
+		plant.Y += generator.Y / float64(len(generators))
+		plant.Z += generator.Z / float64(len(generators))
+		for _, output := range generator.Output {
+			plant.Output += output.Current
+			plant.MaxOutput += output.Max
+		}
+		for _, input := range generator.Input {
+			fuelByName[input.Name] += input.Current
+		}
+	}
+
+	for name, perMinute := range fuelByName {
+		plant.Fuel = append(plant.Fuel, models.PowerPlantFuel{Name: name, PerMinute: perMinute})
+	}
+	sort.Slice(plant.Fuel, func(i, j int) bool {
+		return plant.Fuel[i].Name < plant.Fuel[j].Name
+	})
+
+	return plant
+}
+
+// plantLabel turns 0, 1, ..., 25, 26 into A, B, ..., Z, AA
+func plantLabel(idx int) string {
+	label := ""
+	for idx >= 0 {
+		label = string(rune('A'+idx%26)) + label
+		idx = idx/26 - 1
+	}
+	return label
+}
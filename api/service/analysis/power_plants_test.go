@@ -0,0 +1,56 @@
+package analysis
+
+import (
+	"api/models/models"
+	"testing"
+)
+
+func TestGroupPowerPlants(t *testing.T) {
+	generator := func(machineType models.MachineType, x, y float64, fuel string, fuelRate float64) models.Machine {
+		machine := machineAt("", models.MachineCategoryGenerator, x, y)
+		machine.Type = machineType
+		machine.Output = []models.MachineProdStats{{Current: 75e6, Max: 75e6}}
+		machine.Input = []models.MachineProdStats{{Name: fuel, Current: fuelRate}}
+		return machine
+	}
+	machines := []models.Machine{
+		// A row whose ends are further apart than the radius, linked through the generators between them
+		generator(models.MachineTypeCoalGenerator, 2000, 0, "Coal", 15),
+		generator(models.MachineTypeCoalGenerator, 0, 0, "Coal", 15),
+		generator(models.MachineTypeCoalGenerator, 4000, 0, "Compacted Coal", 7),
+		generator(models.MachineTypeCoalGenerator, 6000, 0, "Coal", 15),
+		generator(models.MachineTypeCoalGenerator, -50000, 0, "Coal", 15),  // Isolated, west of the row
+		generator(models.MachineTypeFuelGenerator, 2000, 2000, "Fuel", 20), // Next to the row, but another type
+		machineAt("constructor", models.MachineCategoryFactory, 3000, 0),
+	}
+
+	plants := GroupPowerPlants(machines, 2500)
+	if len(plants) != 3 {
+		t.Fatalf("got %d plants, want 3: %+v", len(plants), plants)
+	}
+
+	row := plants[0]
+	if row.Name != "coalGenerator Plant B" || row.Generators != 4 || row.Output != 300e6 || row.X != 3000 {
+		t.Errorf("got %+v, want plant B of 4 generators centered at x 3000", row)
+	}
+	wantFuel := []models.PowerPlantFuel{{Name: "Coal", PerMinute: 45}, {Name: "Compacted Coal", PerMinute: 7}}
+	if len(row.Fuel) != len(wantFuel) || row.Fuel[0] != wantFuel[0] || row.Fuel[1] != wantFuel[1] {
+		t.Errorf("got fuel %+v, want %+v", row.Fuel, wantFuel)
+	}
+
+	// Single generators are ordered by name when their output ties
+	if plants[1].Name != "coalGenerator Plant A" || plants[1].Generators != 1 || plants[1].X != -50000 {
+		t.Errorf("got %+v, want the isolated coal generator as plant A", plants[1])
+	}
+	if plants[2].Name != "fuelGenerator Plant A" || plants[2].Generators != 1 {
+		t.Errorf("got %+v, want the fuel generator as a plant of its own", plants[2])
+	}
+}
+
+func TestPlantLabel(t *testing.T) {
+	for idx, want := range map[int]string{0: "A", 25: "Z", 26: "AA", 27: "AB", 701: "ZZ", 702: "AAA"} {
+		if got := plantLabel(idx); got != want {
+			t.Errorf("plantLabel(%d) = %s, want %s", idx, got, want)
+		}
+	}
+}
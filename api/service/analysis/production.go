@@ -0,0 +1,154 @@
+package analysis
+
+import (
+	"api/models/models"
+	"sort"
+	"strings"
+)
+
+// FindOrphanedItems returns items that are produced but not consumed by any machine.
+// Each item is classified by where its output most likely ends up: a storage container,
+// a dimensional depot uploader, or the AWESOME sink. An item counts as sinked only if the
+// measured sink rate can absorb its whole output at its sink value; items without a known
+// sink value are reported as unknown while the sink is active. Only items with no plausible
+// destination are marked as wasted, since those back up their production line.
+func FindOrphanedItems(prodStats models.ProdStats, sinkStats models.SinkStats, storages []models.Storage) []models.OrphanedItem {
+	stored := map[string]bool{}
+	uploaded := map[string]bool{}
+	for _, storage := range storages {
+		for _, item := range storage.Inventory {
+			if item.Count <= 0 {
+				continue
+			}
+			if storage.Type == models.StorageTypeDimensionalDepotUploader {
+				uploaded[item.Name] = true
+			} else {
+				stored[item.Name] = true
+			}
+		}
+	}
+
+	result := make([]models.OrphanedItem, 0)
+	for _, item := range prodStats.Items {
+		if item.ProducedPerMinute <= 0 || item.ConsumedPerMinute > 0 {
+			continue
+		}
+
+		destination := models.OrphanedItemDestinationNone
+		switch {
+		case uploaded[item.Name]:
+			destination = models.OrphanedItemDestinationDimensionalDepot
+		case stored[item.Name]:
+			destination = models.OrphanedItemDestinationStorage
+		default:
+			destination = sinkDestination(item, sinkStats)
+		}
+
+		result = append(result, models.OrphanedItem{
+			Name:              item.Name,
+			ProducedPerMinute: item.ProducedPerMinute,
+			Destination:       destination,
+			Wasted:            destination == models.OrphanedItemDestinationNone,
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ProducedPerMinute > result[j].ProducedPerMinute
+	})
+
+	return result
+}
+
+// sinkDestination classifies an unconsumed item that is not stored by whether the sink can be receiving it
+func sinkDestination(item models.ItemProdStats, sinkStats models.SinkStats) models.OrphanedItemDestination {
+	if sinkStats.PointsPerMinute <= 0 {
+		return models.OrphanedItemDestinationNone
+	}
+	points, ok := sinkPoints[item.Name]
+	if !ok {
+		return models.OrphanedItemDestinationUnknown
+	}
+	if item.ProducedPerMinute*points > sinkStats.PointsPerMinute*sinkRateTolerance {
+		return models.OrphanedItemDestinationNone
+	}
+	return models.OrphanedItemDestinationSink
+}
+
+// ProdStatsFilter selects a subset of production stats. Zero values disable the corresponding filter.
+type ProdStatsFilter struct {
+	Form     models.ResourceForm
+	Category models.ItemCategory
+	Minable  *bool
+	MinRate  float64 // Minimum of the produced or consumed rate per minute
+}
+
+// FilterProdStats returns the items matching the filter, sorted by produced rate, highest first.
+func FilterProdStats(prodStats models.ProdStats, filter ProdStatsFilter) []models.ItemProdStats {
+	result := make([]models.ItemProdStats, 0)
+	for _, item := range prodStats.Items {
+		if filter.Form != "" && item.Form != filter.Form {
+			continue
+		}
+		if filter.Category != "" && item.Category != filter.Category {
+			continue
+		}
+		if filter.Minable != nil && item.Minable != *filter.Minable {
+			continue
+		}
+		if filter.MinRate > 0 && item.ProducedPerMinute < filter.MinRate && item.ConsumedPerMinute < filter.MinRate {
+			continue
+		}
+		result = append(result, item)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].ProducedPerMinute > result[j].ProducedPerMinute
+	})
+
+	return result
+}
+
+// itemAliases maps common shorthands, lowercased, to the in-game item name
+var itemAliases = map[string]string{
+	"rip": "Reinforced Iron Plate",
+	"mf":  "Modular Frame",
+	"hmf": "Heavy Modular Frame",
+	"fmf": "Fused Modular Frame",
+	"acu": "Adaptive Control Unit",
+	"sp":  "Smart Plating",
+	"vf":  "Versatile Framework",
+	"aw":  "Automated Wiring",
+	"hsc": "High-Speed Connector",
+}
+
+// resolveItemAlias resolves an alias to the item name, otherwise returns the trimmed name.
+// Class names are not resolved here; callers normalize them with frm_client.CanonicalItemName first.
+func resolveItemAlias(name string) string {
+	name = strings.TrimSpace(name)
+	if alias, ok := itemAliases[strings.ToLower(name)]; ok {
+		return alias
+	}
+	return name
+}
+
+// GetProdStatsForItems returns the stats of exactly the requested items, in the requested order.
+// Names are matched case-insensitively after resolving aliases, and items missing from the
+// prod stats get a zeroed entry so every requested item is present.
+func GetProdStatsForItems(prodStats models.ProdStats, names []string) []models.ItemProdStats {
+	result := make([]models.ItemProdStats, 0, len(names))
+	for _, name := range names {
+		canonical := resolveItemAlias(name)
+		found := false
+		for _, item := range prodStats.Items {
+			if itemNamesEqual(item.Name, canonical) {
+				result = append(result, item)
+				found = true
+				break
+			}
+		}
+		if !found {
+			result = append(result, models.ItemProdStats{ItemStats: models.ItemStats{Name: canonical}})
+		}
+	}
+	return result
+}
@@ -0,0 +1,129 @@
+package analysis
+
+import (
+	"api/models/models"
+	"strings"
+	"testing"
+)
+
+func prodItem(name string, produced, consumed float64) models.ItemProdStats {
+	return models.ItemProdStats{
+		ItemStats:         models.ItemStats{Name: name},
+		ProducedPerMinute: produced,
+		ConsumedPerMinute: consumed,
+	}
+}
+
+func TestFindOrphanedItems(t *testing.T) {
+	prodStats := models.ProdStats{Items: []models.ItemProdStats{
+		prodItem("Iron Plate", 60, 60),          // Consumed downstream
+		prodItem("Wire", 30, 0),                 // 180 points per minute fit the sink rate
+		prodItem("Computer", 10, 0),             // 172600 points per minute cannot all be sinked
+		prodItem("Mystery Part", 5, 0),          // No known sink value
+		prodItem("Rotor", 4, 0),                 // Accumulates in a container
+		prodItem("Modular Frame", 2, 0),         // Uploaded to the dimensional depot
+		prodItem("Iron Ore", 0, 0),              // Not produced
+		prodItem("Reinforced Iron Plate", 5, 2), // Partly consumed
+	}}
+	storages := []models.Storage{
+		{Type: models.StorageTypeIndustrialStorageContainer, Inventory: []models.ItemStats{{Name: "Rotor", Count: 100}}},
+		{Type: models.StorageTypeDimensionalDepotUploader, Inventory: []models.ItemStats{{Name: "Modular Frame", Count: 3}}},
+	}
+
+	tests := []struct {
+		name     string
+		sink     models.SinkStats
+		expected map[string]models.OrphanedItemDestination
+	}{
+		{
+			name: "sink active",
+			sink: models.SinkStats{PointsPerMinute: 200},
+			expected: map[string]models.OrphanedItemDestination{
+				"Wire":          models.OrphanedItemDestinationSink,
+				"Computer":      models.OrphanedItemDestinationNone,
+				"Mystery Part":  models.OrphanedItemDestinationUnknown,
+				"Rotor":         models.OrphanedItemDestinationStorage,
+				"Modular Frame": models.OrphanedItemDestinationDimensionalDepot,
+			},
+		},
+		{
+			name: "sink idle",
+			expected: map[string]models.OrphanedItemDestination{
+				"Wire":          models.OrphanedItemDestinationNone,
+				"Computer":      models.OrphanedItemDestinationNone,
+				"Mystery Part":  models.OrphanedItemDestinationNone,
+				"Rotor":         models.OrphanedItemDestinationStorage,
+				"Modular Frame": models.OrphanedItemDestinationDimensionalDepot,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			orphaned := FindOrphanedItems(prodStats, test.sink, storages)
+			if len(orphaned) != len(test.expected) {
+				t.Errorf("got %d orphaned items, want %d: %+v", len(orphaned), len(test.expected), orphaned)
+			}
+			for i, item := range orphaned {
+				want, ok := test.expected[item.Name]
+				if !ok {
+					t.Errorf("%s flagged as orphaned", item.Name)
+					continue
+				}
+				if item.Destination != want || item.Wasted != (want == models.OrphanedItemDestinationNone) {
+					t.Errorf("%s: got destination %s and wasted %v, want %s", item.Name, item.Destination, item.Wasted, want)
+				}
+				if i > 0 && item.ProducedPerMinute > orphaned[i-1].ProducedPerMinute {
+					t.Errorf("items not sorted by produced rate: %+v", orphaned)
+				}
+			}
+		})
+	}
+}
+
+func TestFilterProdStats(t *testing.T) {
+	item := func(name string, category models.ItemCategory, form models.ResourceForm, minable bool, produced, consumed float64) models.ItemProdStats {
+		return models.ItemProdStats{
+			ItemStats:         models.ItemStats{Name: name, Category: category, Form: form},
+			Minable:           minable,
+			ProducedPerMinute: produced,
+			ConsumedPerMinute: consumed,
+		}
+	}
+	prodStats := models.ProdStats{Items: []models.ItemProdStats{
+		item("Iron Ore", models.ItemCategoryOre, models.ResourceFormSolid, true, 480, 480),
+		item("Water", models.ItemCategoryFluid, models.ResourceFormLiquid, true, 600, 540),
+		item("Fuel", models.ItemCategoryFuel, models.ResourceFormLiquid, false, 120, 120),
+		item("Nitrogen Gas", models.ItemCategoryFluid, models.ResourceFormGas, true, 240, 0),
+		item("Iron Ingot", models.ItemCategoryIngot, models.ResourceFormSolid, false, 30, 2),
+		item("Copper Ingot", models.ItemCategoryIngot, models.ResourceFormSolid, false, 90, 90),
+	}}
+	minable := true
+
+	tests := []struct {
+		name     string
+		filter   ProdStatsFilter
+		expected []string
+	}{
+		{"no filter", ProdStatsFilter{}, []string{"Water", "Iron Ore", "Nitrogen Gas", "Fuel", "Copper Ingot", "Iron Ingot"}},
+		{"fluids only", ProdStatsFilter{Form: models.ResourceFormLiquid}, []string{"Water", "Fuel"}},
+		{"category", ProdStatsFilter{Category: models.ItemCategoryIngot}, []string{"Copper Ingot", "Iron Ingot"}},
+		{"form and category", ProdStatsFilter{Form: models.ResourceFormGas, Category: models.ItemCategoryFluid}, []string{"Nitrogen Gas"}},
+		{"minable", ProdStatsFilter{Minable: &minable}, []string{"Water", "Iron Ore", "Nitrogen Gas"}},
+		{"min rate matches produced or consumed", ProdStatsFilter{MinRate: 50}, []string{"Water", "Iron Ore", "Nitrogen Gas", "Fuel", "Copper Ingot"}},
+		{"no match", ProdStatsFilter{Category: models.ItemCategoryNuclear}, []string{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filtered := FilterProdStats(prodStats, test.filter)
+			got := make([]string, len(filtered))
+			for i, item := range filtered {
+				got[i] = item.Name
+			}
+			if strings.Join(got, ",") != strings.Join(test.expected, ",") {
+				t.Errorf("got %v, want %v", got, test.expected)
+			}
+		})
+	}
+}
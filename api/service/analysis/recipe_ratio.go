@@ -0,0 +1,82 @@
+package analysis
+
+import (
+	"api/models/models"
+	"math"
+	"sort"
+)
+
+const (
+	ratioUnderCapacity      = 0.95 // Machines at or above this productivity are healthy
+	ratioStarvedBuffer      = 0.1  // Minutes of buffer below which an input is starved
+	ratioOversuppliedBuffer = 0.5  // Minutes of buffer above which an input is oversupplied
+)
+
+// GetRecipeRatioIssues finds factory machines running below capacity where one input is the clear
+// limiter: its buffer is nearly empty while other inputs of the same recipe are well stocked.
+// Buffers are measured in minutes of consumption at full speed, since a machine consumes all
+// inputs in lockstep and only the stored amounts show which feed falls short.
+// Issues are ordered by productivity, lowest first.
+func GetRecipeRatioIssues(machines []models.Machine) []models.RecipeRatioIssue {
+	result := make([]models.RecipeRatioIssue, 0)
+	for _, machine := range machines {
+		if machine.Category != models.MachineCategoryFactory || machine.Status == models.MachineStatusPaused ||
+			machine.Productivity >= ratioUnderCapacity || len(machine.Output) == 0 {
+			continue
+		}
+
+		limiter, limiterBuffer := "", math.Inf(1)
+		buffers := make(map[string]float64)
+		for _, input := range machine.Input {
+			if input.Name == "Power" || input.Max <= 0 {
+				continue
+			}
+			buffer := input.Stored / input.Max
+			buffers[input.Name] = buffer
+			if buffer < limiterBuffer {
+				limiter, limiterBuffer = input.Name, buffer
+			}
+		}
+		if len(buffers) < 2 || limiterBuffer >= ratioStarvedBuffer {
+			continue
+		}
+
+		oversupplied := make([]string, 0)
+		for name, buffer := range buffers {
+			if name != limiter && buffer >= ratioOversuppliedBuffer {
+				oversupplied = append(oversupplied, name)
+			}
+		}
+		if len(oversupplied) == 0 {
+			continue
+		}
+		sort.Strings(oversupplied)
+
+		result = append(result, models.RecipeRatioIssue{
+			MachineType:   machine.Type,
+			Product:       primaryOutput(machine),
+			Productivity:  machine.Productivity,
+			Limiter:       limiter,
+			LimiterBuffer: limiterBuffer,
+			Oversupplied:  oversupplied,
+			Location:      machine.Location,
+		})
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Productivity < result[j].Productivity
+	})
+
+	return result
+}
+
+// primaryOutput returns the output with the highest max rate, the product of the machine's recipe
+func primaryOutput(machine models.Machine) string {
+	product, best := "", math.Inf(-1)
+	for _, output := range machine.Output {
+		if output.Max > best {
+			product, best = output.Name, output.Max
+		}
+	}
+	return product
+}
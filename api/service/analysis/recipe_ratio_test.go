@@ -0,0 +1,60 @@
+package analysis
+
+import (
+	"api/models/models"
+	"slices"
+	"testing"
+)
+
+func TestGetRecipeRatioIssues(t *testing.T) {
+	input := func(name string, stored, max float64) models.MachineProdStats {
+		return models.MachineProdStats{Name: name, Stored: stored, Max: max}
+	}
+	factory := func(machineType models.MachineType, productivity float64, product string, inputs ...models.MachineProdStats) models.Machine {
+		return models.Machine{
+			Type:         machineType,
+			Status:       models.MachineStatusOperating,
+			Category:     models.MachineCategoryFactory,
+			Productivity: productivity,
+			Input:        inputs,
+			Output:       []models.MachineProdStats{{Name: product, Max: 4}},
+		}
+	}
+
+	paused := factory(models.MachineTypeAssembler, 0, "Rotor", input("Iron Rod", 0, 20), input("Screw", 100, 100))
+	paused.Status = models.MachineStatusPaused
+	machines := []models.Machine{
+		// Rods run dry while screws pile up
+		factory(models.MachineTypeAssembler, 0.4, "Rotor", input("Iron Rod", 0.5, 20), input("Screw", 50, 100), input("Power", 0, 15)),
+		factory(models.MachineTypeManufacturer, 0.2, "Computer",
+			input("Circuit Board", 10, 10), input("Cable", 1, 20), input("Plastic", 40, 40), input("Screw", 20, 130)),
+		factory(models.MachineTypeAssembler, 0.97, "Rotor", input("Iron Rod", 0, 20), input("Screw", 100, 100)),
+		paused,
+		// Every input is short, so no single one limits the recipe
+		factory(models.MachineTypeAssembler, 0.3, "Reinforced Iron Plate", input("Iron Plate", 0, 30), input("Screw", 5, 60)),
+		factory(models.MachineTypeConstructor, 0.1, "Iron Rod", input("Iron Ingot", 0, 15)),
+	}
+
+	issues := GetRecipeRatioIssues(machines)
+	expected := []struct {
+		product      string
+		limiter      string
+		oversupplied []string
+	}{
+		{"Computer", "Cable", []string{"Circuit Board", "Plastic"}},
+		{"Rotor", "Iron Rod", []string{"Screw"}},
+	}
+	if len(issues) != len(expected) {
+		t.Fatalf("got %d issues, want %d: %+v", len(issues), len(expected), issues)
+	}
+	for i, want := range expected {
+		got := issues[i]
+		if got.Product != want.product || got.Limiter != want.limiter || !slices.Equal(got.Oversupplied, want.oversupplied) {
+			t.Errorf("issue %d: got %s limited by %s with %v oversupplied, want %s limited by %s with %v oversupplied",
+				i, got.Product, got.Limiter, got.Oversupplied, want.product, want.limiter, want.oversupplied)
+		}
+	}
+	if buffer := issues[1].LimiterBuffer; buffer != 0.025 {
+		t.Errorf("got rod buffer %v minutes, want 0.025", buffer)
+	}
+}
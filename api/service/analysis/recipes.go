@@ -0,0 +1,114 @@
+package analysis
+
+import (
+	"api/models/models"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// standardRecipeInputs lists the inputs of the standard recipe of common products. Machines making
+// one of these products from other inputs are running an alternate recipe.
+var standardRecipeInputs = map[string][]string{
+	"Iron Ingot":              {"Iron Ore"},
+	"Copper Ingot":            {"Copper Ore"},
+	"Caterium Ingot":          {"Caterium Ore"},
+	"Steel Ingot":             {"Coal", "Iron Ore"},
+	"Aluminum Ingot":          {"Aluminum Scrap", "Silica"},
+	"Iron Plate":              {"Iron Ingot"},
+	"Iron Rod":                {"Iron Ingot"},
+	"Screw":                   {"Iron Rod"},
+	"Wire":                    {"Copper Ingot"},
+	"Cable":                   {"Wire"},
+	"Copper Sheet":            {"Copper Ingot"},
+	"Concrete":                {"Limestone"},
+	"Quickwire":               {"Caterium Ingot"},
+	"Quartz Crystal":          {"Raw Quartz"},
+	"Silica":                  {"Raw Quartz"},
+	"Steel Beam":              {"Steel Ingot"},
+	"Steel Pipe":              {"Steel Ingot"},
+	"Plastic":                 {"Crude Oil"},
+	"Rubber":                  {"Crude Oil"},
+	"Fuel":                    {"Crude Oil"},
+	"Reinforced Iron Plate":   {"Iron Plate", "Screw"},
+	"Rotor":                   {"Iron Rod", "Screw"},
+	"Modular Frame":           {"Iron Rod", "Reinforced Iron Plate"},
+	"Smart Plating":           {"Reinforced Iron Plate", "Rotor"},
+	"Encased Industrial Beam": {"Concrete", "Steel Beam"},
+	"Stator":                  {"Steel Pipe", "Wire"},
+	"Motor":                   {"Rotor", "Stator"},
+	"Versatile Framework":     {"Modular Frame", "Steel Beam"},
+	"Automated Wiring":        {"Cable", "Stator"},
+	"Circuit Board":           {"Copper Sheet", "Plastic"},
+	"Computer":                {"Cable", "Circuit Board", "Plastic"},
+	"AI Limiter":              {"Copper Sheet", "Quickwire"},
+	"High-Speed Connector":    {"Cable", "Circuit Board", "Quickwire"},
+	"Alclad Aluminum Sheet":   {"Aluminum Ingot", "Copper Ingot"},
+	"Crystal Oscillator":      {"Cable", "Quartz Crystal", "Reinforced Iron Plate"},
+	"Heavy Modular Frame":     {"Encased Industrial Beam", "Modular Frame", "Screw", "Steel Pipe"},
+}
+
+// GetActiveRecipes infers the recipe of every configured factory machine from its input and
+// output items, and counts the machines running each. The product is the output with the highest
+// max rate. Recipes are ordered by product, standard recipes before alternates.
+func GetActiveRecipes(machines []models.Machine) []models.ActiveRecipe {
+	recipes := make(map[string]*models.ActiveRecipe)
+	for _, machine := range machines {
+		if machine.Category != models.MachineCategoryFactory || len(machine.Output) == 0 {
+			continue
+		}
+
+		inputs := make([]string, 0, len(machine.Input))
+		for _, input := range machine.Input {
+			if input.Name != "Power" {
+				inputs = append(inputs, input.Name)
+			}
+		}
+		sort.Strings(inputs)
+
+		outputs := slices.Clone(machine.Output)
+		sort.SliceStable(outputs, func(i, j int) bool { return outputs[i].Max > outputs[j].Max })
+		byproducts := make([]string, 0, len(outputs)-1)
+		for _, output := range outputs[1:] {
+			byproducts = append(byproducts, output.Name)
+		}
+		sort.Strings(byproducts)
+
+		product := outputs[0].Name
+		key := fmt.Sprintf("%s|%s|%s|%s", machine.Type, product, strings.Join(inputs, ","), strings.Join(byproducts, ","))
+		recipe, ok := recipes[key]
+		if !ok {
+			standard, known := standardRecipeInputs[product]
+			recipe = &models.ActiveRecipe{
+				Product:     product,
+				Byproducts:  byproducts,
+				Inputs:      inputs,
+				MachineType: machine.Type,
+				Alternate:   known && !slices.Equal(standard, inputs),
+				Known:       known,
+			}
+			recipes[key] = recipe
+		}
+		recipe.Machines++
+	}
+
+	result := make([]models.ActiveRecipe, 0, len(recipes))
+	for _, recipe := range recipes {
+		result = append(result, *recipe)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Product != result[j].Product {
+			return result[i].Product < result[j].Product
+		}
+		if result[i].Alternate != result[j].Alternate {
+			return !result[i].Alternate
+		}
+		if result[i].Machines != result[j].Machines {
+			return result[i].Machines > result[j].Machines
+		}
+		return strings.Join(result[i].Inputs, ",") < strings.Join(result[j].Inputs, ",")
+	})
+
+	return result
+}
@@ -0,0 +1,43 @@
+package analysis
+
+import (
+	"api/models/models"
+	"reflect"
+	"testing"
+)
+
+func TestGetActiveRecipes(t *testing.T) {
+	stats := func(names ...string) []models.MachineProdStats {
+		result := make([]models.MachineProdStats, len(names))
+		for i, name := range names {
+			result[i] = models.MachineProdStats{Name: name, Max: float64(len(names) - i)} // First listed has the highest rate
+		}
+		return result
+	}
+	machine := func(machineType models.MachineType, inputs, outputs []models.MachineProdStats) models.Machine {
+		return models.Machine{Type: machineType, Category: models.MachineCategoryFactory, Input: inputs, Output: outputs}
+	}
+	constructor := machine(models.MachineTypeConstructor, stats("Iron Ingot", "Power"), stats("Iron Plate"))
+	machines := []models.Machine{
+		constructor,
+		constructor,
+		machine(models.MachineTypeAssembler, stats("Steel Ingot", "Plastic"), stats("Iron Plate")), // Steel Coated Plate
+		machine(models.MachineTypeFoundry, stats("Iron Ore", "Coal"), stats("Steel Ingot")),        // Standard, inputs listed in another order
+		machine(models.MachineTypeRefinery, stats("Crude Oil"), stats("Plastic", "Heavy Oil Residue")),
+		machine(models.MachineTypeAssembler, stats("Wire"), stats("Mystery Part")),
+		machine(models.MachineTypeConstructor, nil, nil), // Unconfigured
+		{Type: models.MachineTypeMiner, Category: models.MachineCategoryExtractor, Output: stats("Iron Ore")},
+	}
+
+	want := []models.ActiveRecipe{
+		{Product: "Iron Plate", Byproducts: []string{}, Inputs: []string{"Iron Ingot"}, MachineType: models.MachineTypeConstructor, Machines: 2, Known: true},
+		{Product: "Iron Plate", Byproducts: []string{}, Inputs: []string{"Plastic", "Steel Ingot"}, MachineType: models.MachineTypeAssembler, Machines: 1, Alternate: true, Known: true},
+		{Product: "Mystery Part", Byproducts: []string{}, Inputs: []string{"Wire"}, MachineType: models.MachineTypeAssembler, Machines: 1},
+		{Product: "Plastic", Byproducts: []string{"Heavy Oil Residue"}, Inputs: []string{"Crude Oil"}, MachineType: models.MachineTypeRefinery, Machines: 1, Known: true},
+		{Product: "Steel Ingot", Byproducts: []string{}, Inputs: []string{"Coal", "Iron Ore"}, MachineType: models.MachineTypeFoundry, Machines: 1, Known: true},
+	}
+
+	if got := GetActiveRecipes(machines); !reflect.DeepEqual(got, want) {
+		t.Errorf("got recipes\n%+v\nwant\n%+v", got, want)
+	}
+}
@@ -0,0 +1,71 @@
+package analysis
+
+import (
+	"api/models/models"
+	"sort"
+)
+
+// SummarizeResourceNodes counts the nodes of each resource type by purity, split by whether they are exploited.
+// Nodes are gathered from all radar towers and the world node list, and nodes seen more than once,
+// e.g. in overlapping radar ranges, are counted once by ID. A node is exploited if any source says so.
+func SummarizeResourceNodes(towers []models.RadarTower, nodes []models.ResourceNode) []models.ResourceNodeSummary {
+	unique := map[string]models.ResourceNode{}
+	var unidentified []models.ResourceNode
+	add := func(node models.ResourceNode) {
+		if node.ID == "" {
+			unidentified = append(unidentified, node)
+			return
+		}
+		if existing, ok := unique[node.ID]; ok {
+			existing.Exploited = existing.Exploited || node.Exploited
+			unique[node.ID] = existing
+			return
+		}
+		unique[node.ID] = node
+	}
+	for _, node := range nodes {
+		add(node)
+	}
+	for _, tower := range towers {
+		for _, node := range tower.Nodes {
+			add(node)
+		}
+	}
+
+	summaries := map[models.ResourceType]*models.ResourceNodeSummary{}
+	count := func(node models.ResourceNode) {
+		summary, ok := summaries[node.ResourceType]
+		if !ok {
+			summary = &models.ResourceNodeSummary{ResourceType: node.ResourceType}
+			summaries[node.ResourceType] = summary
+		}
+		counts := &summary.Unexploited
+		if node.Exploited {
+			counts = &summary.Exploited
+		}
+		counts.Total++
+		switch node.Purity {
+		case models.ResourceNodePurityImpure:
+			counts.Impure++
+		case models.ResourceNodePurityNormal:
+			counts.Normal++
+		case models.ResourceNodePurityPure:
+			counts.Pure++
+		}
+	}
+	for _, node := range unique {
+		count(node)
+	}
+	for _, node := range unidentified {
+		count(node)
+	}
+
+	result := make([]models.ResourceNodeSummary, 0, len(summaries))
+	for _, summary := range summaries {
+		result = append(result, *summary)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ResourceType < result[j].ResourceType
+	})
+	return result
+}
@@ -0,0 +1,43 @@
+package analysis
+
+import (
+	"api/models/models"
+	"testing"
+)
+
+func TestSummarizeResourceNodes(t *testing.T) {
+	node := func(id string, resourceType models.ResourceType, purity models.ResourceNodePurity, exploited bool) models.ResourceNode {
+		return models.ResourceNode{ID: id, ResourceType: resourceType, Purity: purity, Exploited: exploited}
+	}
+	iron, copper, coal := models.ResourceTypeIronOre, models.ResourceTypeCopperOre, models.ResourceTypeCoal
+	impure, normal, pure := models.ResourceNodePurityImpure, models.ResourceNodePurityNormal, models.ResourceNodePurityPure
+
+	nodes := []models.ResourceNode{
+		node("iron-1", iron, pure, false),
+		node("iron-2", iron, normal, true),
+		node("coal-1", coal, impure, false),
+		node("", iron, impure, false),
+	}
+	// Overlapping radar towers report the same nodes again, one of them with a miner on iron-1
+	towers := []models.RadarTower{
+		{Nodes: []models.ResourceNode{node("iron-1", iron, pure, true), node("coal-1", coal, impure, false)}},
+		{Nodes: []models.ResourceNode{node("iron-1", iron, pure, false), node("copper-1", copper, normal, false), node("", iron, impure, false)}},
+	}
+
+	expected := []models.ResourceNodeSummary{
+		{ResourceType: coal, Unexploited: models.PurityCounts{Impure: 1, Total: 1}},
+		{ResourceType: copper, Unexploited: models.PurityCounts{Normal: 1, Total: 1}},
+		// Nodes without an ID cannot be matched, so each is counted
+		{ResourceType: iron, Exploited: models.PurityCounts{Normal: 1, Pure: 1, Total: 2}, Unexploited: models.PurityCounts{Impure: 2, Total: 2}},
+	}
+
+	summaries := SummarizeResourceNodes(towers, nodes)
+	if len(summaries) != len(expected) {
+		t.Fatalf("got %d summaries, want %d: %+v", len(summaries), len(expected), summaries)
+	}
+	for i, want := range expected {
+		if summaries[i] != want {
+			t.Errorf("summary %d: got %+v, want %+v", i, summaries[i], want)
+		}
+	}
+}
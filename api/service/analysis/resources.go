@@ -0,0 +1,108 @@
+package analysis
+
+import (
+	"api/models/models"
+	"sort"
+)
+
+const (
+	// oilExtractorRate is the output of an oil extractor on a normal node, independent of miner tier
+	oilExtractorRate = 120.0
+	// resourceWellExtractorRate is the output of a resource well extractor on a normal satellite node
+	resourceWellExtractorRate = 60.0
+)
+
+// minerRates is the output of a miner on a normal node per miner tier (Mk.1-3)
+var minerRates = map[int]float64{
+	1: 60,
+	2: 120,
+	3: 240,
+}
+
+var purityMultipliers = map[models.ResourceNodePurity]float64{
+	models.ResourceNodePurityImpure: 0.5,
+	models.ResourceNodePurityNormal: 1,
+	models.ResourceNodePurityPure:   2,
+}
+
+// IsValidMinerTier reports whether the tier has a known miner extraction rate
+func IsValidMinerTier(tier int) bool {
+	_, ok := minerRates[tier]
+	return ok
+}
+
+// EstimateResourceCapacity reports, per resource type, how many nodes are exploited and available,
+// the current extraction rate, and the additional rate if every available node was exploited.
+// Miners on solid nodes use the given miner tier. Geysers and fracking cores produce no items
+// and are left out.
+func EstimateResourceCapacity(nodes []models.ResourceNode, machines []models.Machine, minerTier int) []models.ResourceCapacity {
+	capacities := map[models.ResourceType]*models.ResourceCapacity{}
+	get := func(resourceType models.ResourceType) *models.ResourceCapacity {
+		capacity, ok := capacities[resourceType]
+		if !ok {
+			capacity = &models.ResourceCapacity{ResourceType: resourceType}
+			capacities[resourceType] = capacity
+		}
+		return capacity
+	}
+
+	for _, node := range nodes {
+		baseRate := nodeBaseRate(node, minerTier)
+		if baseRate == 0 {
+			continue
+		}
+
+		capacity := get(node.ResourceType)
+		if node.Exploited {
+			capacity.ExploitedNodes++
+			continue
+		}
+
+		capacity.AvailableNodes++
+		switch node.Purity {
+		case models.ResourceNodePurityImpure:
+			capacity.AvailableImpure++
+		case models.ResourceNodePurityNormal:
+			capacity.AvailableNormal++
+		case models.ResourceNodePurityPure:
+			capacity.AvailablePure++
+		}
+		capacity.AdditionalPerMinute += baseRate * purityMultipliers[node.Purity]
+	}
+
+	for _, machine := range machines {
+		if machine.Category != models.MachineCategoryExtractor {
+			continue
+		}
+		for _, output := range machine.Output {
+			if capacity, ok := capacities[models.ResourceType(output.Name)]; ok {
+				capacity.CurrentPerMinute += output.Current
+			}
+		}
+	}
+
+	result := make([]models.ResourceCapacity, 0, len(capacities))
+	for _, capacity := range capacities {
+		result = append(result, *capacity)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ResourceType < result[j].ResourceType
+	})
+
+	return result
+}
+
+// nodeBaseRate returns the extraction rate of a node at normal purity, or 0 if the node yields no items
+func nodeBaseRate(node models.ResourceNode, minerTier int) float64 {
+	switch node.NodeType {
+	case models.NodeTypeNode:
+		if node.ResourceType == models.ResourceTypeCrudeOil {
+			return oilExtractorRate
+		}
+		return minerRates[minerTier]
+	case models.NodeTypeFrackingSatellite:
+		return resourceWellExtractorRate
+	default:
+		return 0
+	}
+}
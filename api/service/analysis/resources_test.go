@@ -0,0 +1,62 @@
+package analysis
+
+import (
+	"api/models/models"
+	"testing"
+)
+
+func TestEstimateResourceCapacityMixedPurity(t *testing.T) {
+	node := func(resourceType models.ResourceType, nodeType models.NodeType, purity models.ResourceNodePurity, exploited bool) models.ResourceNode {
+		return models.ResourceNode{ResourceType: resourceType, NodeType: nodeType, Purity: purity, Exploited: exploited}
+	}
+	nodes := []models.ResourceNode{
+		node(models.ResourceTypeIronOre, models.NodeTypeNode, models.ResourceNodePurityImpure, false),
+		node(models.ResourceTypeIronOre, models.NodeTypeNode, models.ResourceNodePurityNormal, false),
+		node(models.ResourceTypeIronOre, models.NodeTypeNode, models.ResourceNodePurityPure, false),
+		node(models.ResourceTypeIronOre, models.NodeTypeNode, models.ResourceNodePurityPure, false),
+		node(models.ResourceTypeIronOre, models.NodeTypeNode, models.ResourceNodePurityNormal, true),
+		node(models.ResourceTypeCrudeOil, models.NodeTypeNode, models.ResourceNodePurityNormal, false), // Oil extractors ignore the miner tier
+		node(models.ResourceTypeGeyser, models.NodeTypeGeyser, models.ResourceNodePurityPure, false),   // Produces no items
+	}
+	miner := models.Machine{
+		Category: models.MachineCategoryExtractor,
+		Output:   []models.MachineProdStats{{Name: string(models.ResourceTypeIronOre), Current: 115}},
+	}
+
+	tests := []struct {
+		name       string
+		minerTier  int
+		additional float64
+	}{
+		{"mk1", 1, 330},
+		{"mk2", 2, 660},
+		{"mk3", 3, 1320},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			capacities := EstimateResourceCapacity(nodes, []models.Machine{miner}, test.minerTier)
+			if len(capacities) != 2 {
+				t.Fatalf("got %+v, want crude oil and iron ore only", capacities)
+			}
+
+			iron := capacities[1]
+			want := models.ResourceCapacity{
+				ResourceType:        models.ResourceTypeIronOre,
+				ExploitedNodes:      1,
+				AvailableNodes:      4,
+				AvailableImpure:     1,
+				AvailableNormal:     1,
+				AvailablePure:       2,
+				CurrentPerMinute:    115,
+				AdditionalPerMinute: test.additional,
+			}
+			if iron != want {
+				t.Errorf("got %+v, want %+v", iron, want)
+			}
+			if oil := capacities[0]; oil.ResourceType != models.ResourceTypeCrudeOil || oil.AdditionalPerMinute != oilExtractorRate {
+				t.Errorf("got %+v, want crude oil with %v additional", oil, oilExtractorRate)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package analysis
+
+import (
+	"api/models/models"
+	"sort"
+)
+
+// GetItemRunways returns for each stored or consumed item how many minutes its stored amount lasts at
+// the current consumption rate, ignoring production. Items below floorMinutes are flagged low, which
+// includes consumed items with nothing stored. Items nothing consumes have no runway and are never low.
+// Sorted by runway, shortest first, with unconsumed items last.
+func GetItemRunways(prodStats models.ProdStats, floorMinutes float64) []models.ItemRunway {
+	result := make([]models.ItemRunway, 0)
+	for _, item := range prodStats.Items {
+		if item.Count <= 0 && item.ConsumedPerMinute <= 0 {
+			continue
+		}
+
+		runway := models.ItemRunway{
+			Name:              item.Name,
+			Stored:            item.Count,
+			ConsumedPerMinute: item.ConsumedPerMinute,
+		}
+		if item.ConsumedPerMinute > 0 {
+			minutes := max(item.Count, 0) / item.ConsumedPerMinute
+			runway.RunwayMinutes = &minutes
+			runway.Low = minutes < floorMinutes
+		}
+		result = append(result, runway)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		a, b := result[i].RunwayMinutes, result[j].RunwayMinutes
+		if a == nil || b == nil {
+			return b == nil && a != nil
+		}
+		return *a < *b
+	})
+
+	return result
+}
@@ -0,0 +1,55 @@
+package analysis
+
+import (
+	"api/models/models"
+	"testing"
+)
+
+func TestGetItemRunways(t *testing.T) {
+	item := func(name string, stored, consumed float64) models.ItemProdStats {
+		return models.ItemProdStats{ItemStats: models.ItemStats{Name: name, Count: stored}, ConsumedPerMinute: consumed}
+	}
+	prodStats := models.ProdStats{Items: []models.ItemProdStats{
+		item("Concrete", 5000, 0),
+		item("Iron Plate", 1200, 60),
+		item("Screw", 0, 240),
+		item("Wire", 100, 50),
+		item("Copper Ore", 0, 0),
+		// Production is ignored, only the stored amount counts
+		{ItemStats: models.ItemStats{Name: "Iron Rod", Count: 450}, ProducedPerMinute: 1000, ConsumedPerMinute: 30},
+	}}
+
+	expected := []struct {
+		name    string
+		minutes float64 // -1 for no runway
+		low     bool
+	}{
+		{"Screw", 0, true},
+		{"Wire", 2, true},
+		{"Iron Rod", 15, false},
+		{"Iron Plate", 20, false},
+		{"Concrete", -1, false},
+	}
+
+	runways := GetItemRunways(prodStats, 10)
+	if len(runways) != len(expected) {
+		t.Fatalf("got %d runways, want %d: %+v", len(runways), len(expected), runways)
+	}
+	for i, want := range expected {
+		got := runways[i]
+		if got.Name != want.name || got.Low != want.low {
+			t.Errorf("runway %d: got %s low %v, want %s low %v", i, got.Name, got.Low, want.name, want.low)
+		}
+		switch {
+		case want.minutes < 0 && got.RunwayMinutes != nil:
+			t.Errorf("%s: got %v minutes, want no runway", want.name, *got.RunwayMinutes)
+		case want.minutes >= 0 && (got.RunwayMinutes == nil || *got.RunwayMinutes != want.minutes):
+			t.Errorf("%s: got %v minutes, want %v", want.name, got.RunwayMinutes, want.minutes)
+		}
+	}
+
+	// Exactly at the floor is not low
+	if runways := GetItemRunways(prodStats, 15); runways[2].Name != "Iron Rod" || runways[2].Low {
+		t.Errorf("got %+v, want Iron Rod at the floor not flagged", runways[2])
+	}
+}
@@ -0,0 +1,141 @@
+package analysis
+
+import (
+	"api/models/models"
+	"math"
+	"sort"
+)
+
+// sinkRateTolerance is how far an estimated sink rate may exceed the measured one and still be taken as a match
+const sinkRateTolerance = 1.25
+
+// sinkPoints are the AWESOME sink values of common items
+var sinkPoints = map[string]float64{
+	"Iron Ore":                    1,
+	"Copper Ore":                  3,
+	"Limestone":                   2,
+	"Coal":                        3,
+	"Iron Ingot":                  2,
+	"Copper Ingot":                6,
+	"Steel Ingot":                 8,
+	"Caterium Ingot":              42,
+	"Aluminum Ingot":              131,
+	"Iron Plate":                  6,
+	"Iron Rod":                    4,
+	"Screws":                      2,
+	"Wire":                        6,
+	"Cable":                       24,
+	"Copper Sheet":                24,
+	"Concrete":                    12,
+	"Quartz Crystal":              50,
+	"Silica":                      20,
+	"Quickwire":                   17,
+	"Steel Beam":                  64,
+	"Steel Pipe":                  24,
+	"Reinforced Iron Plate":       120,
+	"Rotor":                       140,
+	"Stator":                      240,
+	"Modular Frame":               408,
+	"Smart Plating":               520,
+	"Encased Industrial Beam":     528,
+	"Plastic":                     75,
+	"Rubber":                      60,
+	"Circuit Board":               696,
+	"AI Limiter":                  920,
+	"Versatile Framework":         1176,
+	"Automated Wiring":            1440,
+	"Motor":                       1520,
+	"High-Speed Connector":        3776,
+	"Modular Engine":              9960,
+	"Heavy Modular Frame":         10800,
+	"Computer":                    17260,
+	"Adaptive Control Unit":       76368,
+	"Supercomputer":               97352,
+	"Alclad Aluminum Sheet":       266,
+	"Aluminum Casing":             393,
+	"Biomass":                     12,
+	"Solid Biofuel":               48,
+	"Packaged Water":              130,
+	"Empty Canister":              60,
+	"Black Powder":                14,
+	"Compacted Coal":              28,
+	"Petroleum Coke":              20,
+	"Polymer Resin":               12,
+	"Fabric":                      140,
+	"Electromagnetic Control Rod": 2560,
+}
+
+// GetSinkComposition estimates which items feed the AWESOME sink. FRM reports only the total sink rate,
+// so the surplus of every solid item, produced minus consumed, is assumed to end up in the sink, weighted
+// by its sink value and scaled to the measured rate. Items without a known value are listed without a
+// contribution. Confidence reflects how close the unscaled estimate is to the measured rate.
+func GetSinkComposition(prodStats models.ProdStats, sinkStats models.SinkStats, storages []models.Storage) models.SinkComposition {
+	composition := models.SinkComposition{
+		PointsPerMinute: sinkStats.PointsPerMinute,
+		Confidence:      models.SinkCompositionConfidenceLow,
+		Items:           make([]models.SinkFeedItem, 0),
+	}
+	if sinkStats.PointsPerMinute <= 0 {
+		composition.Note = "The sink is not receiving anything"
+		return composition
+	}
+
+	stored := make(map[string]bool)
+	for _, storage := range storages {
+		for _, item := range storage.Inventory {
+			if item.Count > 0 {
+				stored[item.Name] = true
+			}
+		}
+	}
+
+	for _, item := range prodStats.Items {
+		surplus := item.ProducedPerMinute - item.ConsumedPerMinute
+		if surplus <= 0 || isFluid(item.Form) {
+			continue
+		}
+		feed := models.SinkFeedItem{
+			Name:             item.Name,
+			SurplusPerMinute: surplus,
+			Stored:           stored[item.Name],
+		}
+		if points, ok := sinkPoints[item.Name]; ok {
+			feed.PointsPerItem = &points
+			feed.PointsPerMinute = surplus * points
+			composition.EstimatedPointsPerMinute += feed.PointsPerMinute
+		}
+		composition.Items = append(composition.Items, feed)
+	}
+
+	if composition.EstimatedPointsPerMinute <= 0 {
+		composition.Note = "No surplus item with a known sink value explains the sink rate"
+		return composition
+	}
+
+	for idx := range composition.Items {
+		item := &composition.Items[idx]
+		item.Share = item.PointsPerMinute / composition.EstimatedPointsPerMinute
+		item.PointsPerMinute = item.Share * sinkStats.PointsPerMinute
+	}
+
+	ratio := composition.EstimatedPointsPerMinute / sinkStats.PointsPerMinute
+	deviation := math.Max(ratio, 1/ratio)
+	switch {
+	case deviation <= sinkRateTolerance:
+		composition.Confidence = models.SinkCompositionConfidenceHigh
+		composition.Note = "Surplus production matches the sink rate"
+	case deviation <= 2:
+		composition.Confidence = models.SinkCompositionConfidenceMedium
+		composition.Note = "Surplus production roughly matches the sink rate, some surplus may go to storage or some sinked items are unknown"
+	case ratio > 1:
+		composition.Note = "Surplus production far exceeds the sink rate, most of it likely goes to storage or backs up"
+	default:
+		composition.Note = "The sink receives far more than the surplus explains, e.g. from storage or items without a known value"
+	}
+
+	sort.SliceStable(composition.Items, func(i, j int) bool {
+		return composition.Items[i].PointsPerMinute > composition.Items[j].PointsPerMinute
+	})
+
+	return composition
+}
@@ -0,0 +1,84 @@
+package analysis
+
+import (
+	"api/models/models"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestGetSinkComposition(t *testing.T) {
+	item := func(name string, form models.ResourceForm, produced, consumed float64) models.ItemProdStats {
+		return models.ItemProdStats{ItemStats: models.ItemStats{Name: name, Form: form}, ProducedPerMinute: produced, ConsumedPerMinute: consumed}
+	}
+	prodStats := models.ProdStats{Items: []models.ItemProdStats{
+		item("Iron Plate", models.ResourceFormSolid, 150, 50), // 100 surplus at 6 points
+		item("Rotor", models.ResourceFormSolid, 20, 10),       // 10 surplus at 140 points
+		item("Mystery Part", models.ResourceFormSolid, 5, 0),
+		item("Water", models.ResourceFormLiquid, 300, 0),
+		item("Screws", models.ResourceFormSolid, 100, 200),
+	}}
+	storages := []models.Storage{{Inventory: []models.ItemStats{{Name: "Iron Plate", Count: 500}, {Name: "Rotor"}}}}
+
+	// The surplus is worth 2000 points per minute against 1800 measured
+	composition := GetSinkComposition(prodStats, models.SinkStats{PointsPerMinute: 1800}, storages)
+	if composition.EstimatedPointsPerMinute != 2000 || composition.Confidence != models.SinkCompositionConfidenceHigh {
+		t.Errorf("got estimate %v with %s confidence, want 2000 with high", composition.EstimatedPointsPerMinute, composition.Confidence)
+	}
+
+	expected := []struct {
+		name    string
+		points  float64
+		share   float64
+		known   bool
+		stored  bool
+		surplus float64
+	}{
+		{"Rotor", 1260, 0.7, true, false, 10},
+		{"Iron Plate", 540, 0.3, true, true, 100},
+		{"Mystery Part", 0, 0, false, false, 5},
+	}
+	if len(composition.Items) != len(expected) {
+		t.Fatalf("got %d items, want %d: %+v", len(composition.Items), len(expected), composition.Items)
+	}
+	for i, want := range expected {
+		got := composition.Items[i]
+		if got.Name != want.name || got.Stored != want.stored || got.SurplusPerMinute != want.surplus || (got.PointsPerItem != nil) != want.known {
+			t.Errorf("item %d: got %+v, want %+v", i, got, want)
+		}
+		if math.Abs(got.PointsPerMinute-want.points) > 1e-9 || math.Abs(got.Share-want.share) > 1e-9 {
+			t.Errorf("%s: got %v points at share %v, want %v at %v", want.name, got.PointsPerMinute, got.Share, want.points, want.share)
+		}
+	}
+}
+
+func TestGetSinkCompositionConfidence(t *testing.T) {
+	prodStats := models.ProdStats{Items: []models.ItemProdStats{
+		{ItemStats: models.ItemStats{Name: "Iron Plate", Form: models.ResourceFormSolid}, ProducedPerMinute: 100},
+	}}
+	unknownOnly := models.ProdStats{Items: []models.ItemProdStats{
+		{ItemStats: models.ItemStats{Name: "Mystery Part", Form: models.ResourceFormSolid}, ProducedPerMinute: 100},
+	}}
+
+	// The surplus is worth 600 points per minute
+	tests := []struct {
+		name       string
+		prodStats  models.ProdStats
+		measured   float64
+		confidence models.SinkCompositionConfidence
+		note       string
+	}{
+		{"matching", prodStats, 550, models.SinkCompositionConfidenceHigh, "matches"},
+		{"roughly", prodStats, 400, models.SinkCompositionConfidenceMedium, "roughly matches"},
+		{"surplus exceeds", prodStats, 100, models.SinkCompositionConfidenceLow, "far exceeds"},
+		{"sink exceeds", prodStats, 5000, models.SinkCompositionConfidenceLow, "far more"},
+		{"unknown values", unknownOnly, 600, models.SinkCompositionConfidenceLow, "No surplus item"},
+		{"idle sink", prodStats, 0, models.SinkCompositionConfidenceLow, "not receiving"},
+	}
+	for _, test := range tests {
+		composition := GetSinkComposition(test.prodStats, models.SinkStats{PointsPerMinute: test.measured}, nil)
+		if composition.Confidence != test.confidence || !strings.Contains(composition.Note, test.note) {
+			t.Errorf("%s: got %s confidence with note %q, want %s with %q", test.name, composition.Confidence, composition.Note, test.confidence, test.note)
+		}
+	}
+}
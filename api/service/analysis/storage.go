@@ -0,0 +1,65 @@
+package analysis
+
+import (
+	"api/models/models"
+	"math"
+	"sort"
+)
+
+// SummarizeStorages totals the stored amount and capacity of every item across all storage
+// containers, grouped by container type. An item spread over several slots or containers is
+// reported once per type with the combined totals. Items without a reported capacity count
+// towards the stored amount only.
+func SummarizeStorages(storages []models.Storage) []models.StorageSummary {
+	summaries := map[models.StorageType]*models.StorageSummary{}
+	itemIndex := map[models.StorageType]map[string]int{}
+
+	for _, storage := range storages {
+		summary, ok := summaries[storage.Type]
+		if !ok {
+			summary = &models.StorageSummary{Type: storage.Type, Items: []models.StorageItemTotal{}}
+			summaries[storage.Type] = summary
+			itemIndex[storage.Type] = map[string]int{}
+		}
+		summary.Containers++
+
+		counted := map[string]bool{}
+		for _, item := range storage.Inventory {
+			index, ok := itemIndex[storage.Type][item.Name]
+			if !ok {
+				index = len(summary.Items)
+				itemIndex[storage.Type][item.Name] = index
+				summary.Items = append(summary.Items, models.StorageItemTotal{Name: item.Name})
+			}
+			summary.Items[index].Stored += item.Count
+			// Capacities are summed per item of a container, so add them once however many slots it has
+			if !counted[item.Name] {
+				summary.Items[index].Capacity += storage.Capacities[item.Name]
+				counted[item.Name] = true
+			}
+		}
+	}
+
+	result := make([]models.StorageSummary, 0, len(summaries))
+	for _, summary := range summaries {
+		for i := range summary.Items {
+			item := &summary.Items[i]
+			item.FillPercent = fillRatio(item.Stored, item.Capacity)
+			summary.Stored += item.Stored
+			summary.Capacity += item.Capacity
+		}
+		summary.FillPercent = fillRatio(summary.Stored, summary.Capacity)
+		sort.Slice(summary.Items, func(a, b int) bool { return summary.Items[a].Name < summary.Items[b].Name })
+		result = append(result, *summary)
+	}
+	sort.Slice(result, func(a, b int) bool { return result[a].Type < result[b].Type })
+	return result
+}
+
+// fillRatio returns stored / capacity clamped to 0-1, or 0 when there is no known capacity
+func fillRatio(stored, capacity float64) float64 {
+	if capacity <= 0 {
+		return 0
+	}
+	return math.Max(0, math.Min(stored/capacity, 1))
+}
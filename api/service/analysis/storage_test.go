@@ -0,0 +1,65 @@
+package analysis
+
+import (
+	"api/models/models"
+	"math"
+	"testing"
+)
+
+func TestSummarizeStorages(t *testing.T) {
+	storages := []models.Storage{
+		{
+			Type: models.StorageTypeIndustrialStorageContainer,
+			Inventory: []models.ItemStats{
+				{Name: "Iron Plate", Count: 4700},
+				{Name: "Iron Plate", Count: 200},
+				{Name: "Screw", Count: 480},
+			},
+			Capacities: map[string]float64{"Iron Plate": 5000, "Screw": 500},
+		},
+		{
+			Type:       models.StorageTypeIndustrialStorageContainer,
+			Inventory:  []models.ItemStats{{Name: "Screw", Count: 20}},
+			Capacities: map[string]float64{"Screw": 500},
+		},
+		{Type: models.StorageTypePersonalStorageBox, Inventory: []models.ItemStats{}, Capacities: map[string]float64{}},
+		{Type: models.StorageTypeStorageContainer, Inventory: []models.ItemStats{{Name: "Wire", Count: 100}}},
+	}
+
+	summaries := SummarizeStorages(storages)
+
+	want := []models.StorageSummary{
+		{Type: models.StorageTypeIndustrialStorageContainer, Containers: 2, Stored: 5400, Capacity: 6000, FillPercent: 0.9, Items: []models.StorageItemTotal{
+			{Name: "Iron Plate", Stored: 4900, Capacity: 5000, FillPercent: 0.98},
+			{Name: "Screw", Stored: 500, Capacity: 1000, FillPercent: 0.5},
+		}},
+		{Type: models.StorageTypePersonalStorageBox, Containers: 1, Items: []models.StorageItemTotal{}},
+		// Without a reported capacity the fill is zero rather than a division by zero
+		{Type: models.StorageTypeStorageContainer, Containers: 1, Stored: 100, Items: []models.StorageItemTotal{
+			{Name: "Wire", Stored: 100},
+		}},
+	}
+
+	if len(summaries) != len(want) {
+		t.Fatalf("got %d summaries, want %d: %+v", len(summaries), len(want), summaries)
+	}
+	for i, summary := range summaries {
+		w := want[i]
+		t.Run(string(w.Type), func(t *testing.T) {
+			if summary.Type != w.Type || summary.Containers != w.Containers || summary.Stored != w.Stored ||
+				summary.Capacity != w.Capacity || math.Abs(summary.FillPercent-w.FillPercent) > 1e-9 {
+				t.Errorf("got %+v, want %+v", summary, w)
+			}
+			if len(summary.Items) != len(w.Items) {
+				t.Fatalf("got items %+v, want %+v", summary.Items, w.Items)
+			}
+			for j, item := range summary.Items {
+				wantItem := w.Items[j]
+				if item.Name != wantItem.Name || item.Stored != wantItem.Stored || item.Capacity != wantItem.Capacity ||
+					math.Abs(item.FillPercent-wantItem.FillPercent) > 1e-9 {
+					t.Errorf("got item %+v, want %+v", item, wantItem)
+				}
+			}
+		})
+	}
+}
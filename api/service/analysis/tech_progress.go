@@ -0,0 +1,52 @@
+package analysis
+
+import (
+	"api/models/models"
+	"sort"
+)
+
+// GetTechProgress groups the milestones by tier with the share purchased per tier, and lists
+// which are unlockable now. A milestone waiting for a space elevator phase is phase-locked even
+// if FRM also reports it as locked. Every list is sorted by tier then name.
+func GetTechProgress(schematics []models.Schematic) *models.TechProgress {
+	sorted := append([]models.Schematic{}, schematics...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Tier != sorted[j].Tier {
+			return sorted[i].Tier < sorted[j].Tier
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	progress := &models.TechProgress{
+		Tiers:       []models.TechTierProgress{},
+		Unlockable:  []models.Schematic{},
+		PhaseLocked: []models.Schematic{},
+		TierLocked:  []models.Schematic{},
+	}
+
+	for _, schematic := range sorted {
+		if len(progress.Tiers) == 0 || progress.Tiers[len(progress.Tiers)-1].Tier != schematic.Tier {
+			progress.Tiers = append(progress.Tiers, models.TechTierProgress{Tier: schematic.Tier})
+		}
+		tier := &progress.Tiers[len(progress.Tiers)-1]
+		tier.Total++
+		tier.Schematics = append(tier.Schematics, schematic)
+
+		switch {
+		case schematic.Purchased:
+			tier.Purchased++
+		case schematic.LockedPhase:
+			progress.PhaseLocked = append(progress.PhaseLocked, schematic)
+		case schematic.Locked:
+			progress.TierLocked = append(progress.TierLocked, schematic)
+		default:
+			progress.Unlockable = append(progress.Unlockable, schematic)
+		}
+	}
+
+	for i := range progress.Tiers {
+		tier := &progress.Tiers[i]
+		tier.CompletionPercent = float64(tier.Purchased) / float64(tier.Total)
+	}
+	return progress
+}
@@ -0,0 +1,83 @@
+package analysis
+
+import (
+	"api/models/models"
+	"reflect"
+	"testing"
+)
+
+func TestGetTechProgress(t *testing.T) {
+	schematics := []models.Schematic{
+		{Name: "Coal Power", Tier: 3, Purchased: true},
+		{Name: "Vehicular Transport", Tier: 3},
+		{Name: "Basic Steel Production", Tier: 3, Purchased: true},
+		{Name: "Logistics", Tier: 1, Purchased: true},
+		{Name: "Field Research", Tier: 1, Purchased: true},
+		{Name: "Base Building", Tier: 1, Purchased: true},
+		{Name: "Industrial Manufacturing", Tier: 5, Locked: true, LockedPhase: true},
+		{Name: "Jetpack", Tier: 5, LockedPhase: true},
+		{Name: "Alternative Fluid Transport", Tier: 4, Locked: true},
+		{Name: "Advanced Steel Production", Tier: 4},
+	}
+
+	progress := GetTechProgress(schematics)
+
+	names := func(schematics []models.Schematic) []string {
+		result := make([]string, 0, len(schematics))
+		for _, schematic := range schematics {
+			result = append(result, schematic.Name)
+		}
+		return result
+	}
+
+	wantTiers := []struct {
+		tier       int
+		total      int
+		purchased  int
+		completion float64
+		schematics []string
+	}{
+		{1, 3, 3, 1, []string{"Base Building", "Field Research", "Logistics"}},
+		{3, 3, 2, 2.0 / 3, []string{"Basic Steel Production", "Coal Power", "Vehicular Transport"}},
+		{4, 2, 0, 0, []string{"Advanced Steel Production", "Alternative Fluid Transport"}},
+		{5, 2, 0, 0, []string{"Industrial Manufacturing", "Jetpack"}},
+	}
+	if len(progress.Tiers) != len(wantTiers) {
+		t.Fatalf("got %d tiers, want %d", len(progress.Tiers), len(wantTiers))
+	}
+	for i, want := range wantTiers {
+		tier := progress.Tiers[i]
+		if tier.Tier != want.tier || tier.Total != want.total || tier.Purchased != want.purchased || tier.CompletionPercent != want.completion {
+			t.Errorf("tier %d: got %d/%d purchased (%v), want tier %d with %d/%d (%v)",
+				tier.Tier, tier.Purchased, tier.Total, tier.CompletionPercent, want.tier, want.purchased, want.total, want.completion)
+		}
+		if got := names(tier.Schematics); !reflect.DeepEqual(got, want.schematics) {
+			t.Errorf("tier %d: got schematics %v, want %v", tier.Tier, got, want.schematics)
+		}
+	}
+
+	lists := []struct {
+		name string
+		got  []models.Schematic
+		want []string
+	}{
+		{"unlockable", progress.Unlockable, []string{"Vehicular Transport", "Advanced Steel Production"}},
+		// Phase-locked even when FRM also reports the milestone as locked
+		{"phase locked", progress.PhaseLocked, []string{"Industrial Manufacturing", "Jetpack"}},
+		{"tier locked", progress.TierLocked, []string{"Alternative Fluid Transport"}},
+	}
+	for _, list := range lists {
+		t.Run(list.name, func(t *testing.T) {
+			if got := names(list.got); !reflect.DeepEqual(got, list.want) {
+				t.Errorf("got %v, want %v", got, list.want)
+			}
+		})
+	}
+}
+
+func TestGetTechProgressWithoutSchematics(t *testing.T) {
+	progress := GetTechProgress(nil)
+	if progress.Tiers == nil || progress.Unlockable == nil || progress.PhaseLocked == nil || progress.TierLocked == nil {
+		t.Errorf("got nil lists, want empty ones so they serialize as []: %+v", progress)
+	}
+}
@@ -0,0 +1,103 @@
+package analysis
+
+import (
+	"api/models/models"
+	"sort"
+)
+
+// GetConveyorThroughputByItem sums the flow on belts and pipes per item they carry, as opposed
+// to the production rate, to show whether conveyor capacity keeps up with production.
+// Belts carry the items resolved through the belt graph. Pipes carry the fluids handled by the
+// machines at their ends, since pipe junctions are not traced. Flow on a conveyor that may carry
+// several items is split evenly between them.
+func GetConveyorThroughputByItem(belts []models.Belt, splitterMergers []models.SplitterMerger, pipes []models.Pipe, machines []models.Machine, prodStats models.ProdStats) models.ConveyorThroughput {
+	forms := make(map[string]models.ResourceForm, len(prodStats.Items))
+	for _, item := range prodStats.Items {
+		forms[item.Name] = item.Form
+	}
+
+	totals := map[string]*models.ItemThroughput{}
+	add := func(items map[string]bool, perMinute float64) {
+		for name := range items {
+			total, ok := totals[name]
+			if !ok {
+				total = &models.ItemThroughput{Name: name, Form: forms[name]}
+				totals[name] = total
+			}
+			total.PerMinute += perMinute / float64(len(items))
+			total.Conveyors++
+		}
+	}
+
+	result := models.ConveyorThroughput{Items: make([]models.ItemThroughput, 0)}
+
+	carried := newBeltGraph(belts, splitterMergers, machines).carriedItems()
+	for idx, belt := range belts {
+		if len(carried[idx]) == 0 {
+			result.UnattributedBeltPerMinute += belt.ItemsPerMinute
+			continue
+		}
+		add(carried[idx], belt.ItemsPerMinute)
+	}
+
+	for _, pipe := range pipes {
+		fluids := pipeFluids(pipe, machines, forms)
+		if len(fluids) == 0 {
+			result.UnattributedPipePerMinute += pipe.ItemsPerMinute
+			continue
+		}
+		add(fluids, pipe.ItemsPerMinute)
+	}
+
+	for _, total := range totals {
+		result.Items = append(result.Items, *total)
+	}
+	sort.Slice(result.Items, func(i, j int) bool {
+		return result.Items[i].PerMinute > result.Items[j].PerMinute
+	})
+
+	return result
+}
+
+// pipeFluids returns the fluids a pipe may carry. When machines are attached at both ends,
+// only fluids both of them handle are kept.
+func pipeFluids(pipe models.Pipe, machines []models.Machine, forms map[string]models.ResourceForm) map[string]bool {
+	var ends []map[string]bool
+	if pipe.Connected0 {
+		if machine := findMachineAt(machines, pipe.Location0); machine != nil {
+			ends = append(ends, machineFluids(machine, forms))
+		}
+	}
+	if pipe.Connected1 {
+		if machine := findMachineAt(machines, pipe.Location1); machine != nil {
+			ends = append(ends, machineFluids(machine, forms))
+		}
+	}
+
+	switch len(ends) {
+	case 0:
+		return nil
+	case 1:
+		return ends[0]
+	}
+
+	shared := map[string]bool{}
+	for fluid := range ends[0] {
+		if ends[1][fluid] {
+			shared[fluid] = true
+		}
+	}
+	return shared
+}
+
+func machineFluids(machine *models.Machine, forms map[string]models.ResourceForm) map[string]bool {
+	fluids := map[string]bool{}
+	for _, stats := range [][]models.MachineProdStats{machine.Input, machine.Output} {
+		for _, item := range stats {
+			if isFluid(forms[item.Name]) {
+				fluids[item.Name] = true
+			}
+		}
+	}
+	return fluids
+}
@@ -0,0 +1,56 @@
+package analysis
+
+import (
+	"api/models/models"
+	"testing"
+)
+
+func TestGetConveyorThroughputSumsBeltsPerItem(t *testing.T) {
+	smelter := func(id string, y float64, output string) models.Machine {
+		machine := machineAt(id, models.MachineCategoryFactory, 0, y)
+		machine.Output = []models.MachineProdStats{{Name: output}}
+		return machine
+	}
+	machines := []models.Machine{
+		smelter("iron-1", 0, "Iron Ingot"),
+		smelter("iron-2", 5000, "Iron Ingot"),
+		smelter("copper", 10000, "Copper Ingot"),
+	}
+	belt := func(id string, y, perMinute float64) models.Belt {
+		return models.Belt{
+			ID:             id,
+			Location0:      models.Location{X: 500, Y: y},
+			Location1:      models.Location{X: 3000, Y: y},
+			Connected0:     true,
+			ItemsPerMinute: perMinute,
+		}
+	}
+	belts := []models.Belt{
+		belt("iron-1", 0, 60),
+		belt("iron-2", 5000, 45),
+		belt("copper", 10000, 30),
+		belt("detached", 20000, 15), // Not fed by any machine
+	}
+	prodStats := models.ProdStats{Items: []models.ItemProdStats{
+		{ItemStats: models.ItemStats{Name: "Iron Ingot", Form: models.ResourceFormSolid}},
+		{ItemStats: models.ItemStats{Name: "Copper Ingot", Form: models.ResourceFormSolid}},
+	}}
+
+	throughput := GetConveyorThroughputByItem(belts, nil, nil, machines, prodStats)
+
+	want := []models.ItemThroughput{
+		{Name: "Iron Ingot", Form: models.ResourceFormSolid, PerMinute: 105, Conveyors: 2},
+		{Name: "Copper Ingot", Form: models.ResourceFormSolid, PerMinute: 30, Conveyors: 1},
+	}
+	if len(throughput.Items) != len(want) {
+		t.Fatalf("got %+v, want %+v", throughput.Items, want)
+	}
+	for i := range want {
+		if throughput.Items[i] != want[i] {
+			t.Errorf("item %d: got %+v, want %+v", i, throughput.Items[i], want[i])
+		}
+	}
+	if throughput.UnattributedBeltPerMinute != 15 {
+		t.Errorf("got unattributed belt flow %v, want 15", throughput.UnattributedBeltPerMinute)
+	}
+}
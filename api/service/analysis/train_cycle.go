@@ -0,0 +1,190 @@
+package analysis
+
+import (
+	"api/models/models"
+	"container/heap"
+	"fmt"
+	"math"
+	"time"
+)
+
+const (
+	// trainCruiseSpeed is the typical speed of a train between stations, in m/s (120 km/h)
+	trainCruiseSpeed = 120 / 3.6
+	// trainDockingAllowance is the time spent per stop docking, transferring cargo and pulling away
+	trainDockingAllowance = 30 * time.Second
+	// railJoinPrecision is the grid in cm rail endpoints are snapped to when joining rails into a network
+	railJoinPrecision = 100.0
+	// maxStationRailDistance is how far in cm a station may be from the nearest rail endpoint to be reachable
+	maxStationRailDistance = 5000.0
+)
+
+// EstimateTrainCycle estimates how long a train takes for one loop of its timetable, from the rail distance
+// between consecutive stops at cruising speed plus a docking allowance per stop. Stops are matched to the
+// nearest rail endpoint. Returns an error if a stop is unknown or no rails connect two consecutive stops.
+func EstimateTrainCycle(train models.Train, rails []models.TrainRail) (time.Duration, error) {
+	cycle, _, err := estimateTrainCycle(train, newRailNetwork(rails))
+	return cycle, err
+}
+
+// estimateTrainCycle returns the cycle time and the loop distance in meters of a train on the network
+func estimateTrainCycle(train models.Train, network *railNetwork) (time.Duration, float64, error) {
+	if len(train.Timetable) < 2 {
+		return 0, 0, fmt.Errorf("timetable has fewer than two stops")
+	}
+
+	stops := make([]int, len(train.Timetable))
+	for i, entry := range train.Timetable {
+		if entry.Location == nil {
+			return 0, 0, fmt.Errorf("station %s does not exist", entry.Station)
+		}
+		vertex, ok := network.nearest(*entry.Location, maxStationRailDistance)
+		if !ok {
+			return 0, 0, fmt.Errorf("station %s is not reachable by rail", entry.Station)
+		}
+		stops[i] = vertex
+	}
+
+	distance := 0.0
+	for i, from := range stops {
+		to := stops[(i+1)%len(stops)]
+		leg, ok := network.shortestPath(from, to)
+		if !ok {
+			next := train.Timetable[(i+1)%len(stops)].Station
+			return 0, 0, fmt.Errorf("no rail connection from %s to %s", train.Timetable[i].Station, next)
+		}
+		distance += leg
+	}
+
+	travel := time.Duration(distance / trainCruiseSpeed * float64(time.Second))
+	return travel + time.Duration(len(stops))*trainDockingAllowance, distance, nil
+}
+
+// GetTrainCycles estimates the loop time of every train with a timetable
+func GetTrainCycles(trains []models.Train, rails []models.TrainRail) []models.TrainCycle {
+	network := newRailNetwork(rails)
+	cycles := make([]models.TrainCycle, 0, len(trains))
+	for _, train := range trains {
+		if len(train.Timetable) == 0 {
+			continue
+		}
+		cycle := models.TrainCycle{
+			TrainID:   train.ID,
+			TrainName: train.Name,
+			Stops:     len(train.Timetable),
+		}
+		duration, distance, err := estimateTrainCycle(train, network)
+		if err != nil {
+			cycle.Error = err.Error()
+		} else {
+			seconds := math.Round(duration.Seconds())
+			cycle.CycleSeconds = &seconds
+			cycle.DistanceMeters = math.Round(distance)
+		}
+		cycles = append(cycles, cycle)
+	}
+	return cycles
+}
+
+// railNetwork is a graph of rail endpoints connected by rails weighted by their length in meters
+type railNetwork struct {
+	vertices []models.Location
+	edges    [][]railEdge
+}
+
+type railEdge struct {
+	to     int
+	length float64
+}
+
+func newRailNetwork(rails []models.TrainRail) *railNetwork {
+	network := &railNetwork{}
+	index := map[[3]int64]int{}
+	vertex := func(location models.Location) int {
+		key := [3]int64{
+			int64(math.Round(location.X / railJoinPrecision)),
+			int64(math.Round(location.Y / railJoinPrecision)),
+			int64(math.Round(location.Z / railJoinPrecision)),
+		}
+		if id, ok := index[key]; ok {
+			return id
+		}
+		index[key] = len(network.vertices)
+		network.vertices = append(network.vertices, location)
+		network.edges = append(network.edges, nil)
+		return index[key]
+	}
+
+	for _, rail := range rails {
+		from, to := vertex(rail.Location0), vertex(rail.Location1)
+		length := rail.Length
+		if length <= 0 {
+			length = railDistance(rail.Location0, rail.Location1) / 100
+		}
+		network.edges[from] = append(network.edges[from], railEdge{to: to, length: length})
+		network.edges[to] = append(network.edges[to], railEdge{to: from, length: length})
+	}
+	return network
+}
+
+// nearest returns the vertex closest to the location if it lies within maxDistance
+func (network *railNetwork) nearest(location models.Location, maxDistance float64) (int, bool) {
+	best, bestDistance := -1, maxDistance
+	for id, vertex := range network.vertices {
+		if distance := railDistance(location, vertex); distance <= bestDistance {
+			best, bestDistance = id, distance
+		}
+	}
+	return best, best >= 0
+}
+
+// shortestPath returns the rail distance in meters between two vertices using Dijkstra's algorithm
+func (network *railNetwork) shortestPath(from, to int) (float64, bool) {
+	distances := make([]float64, len(network.vertices))
+	for i := range distances {
+		distances[i] = math.Inf(1)
+	}
+	distances[from] = 0
+
+	queue := &railQueue{{vertex: from}}
+	for queue.Len() > 0 {
+		current := heap.Pop(queue).(railQueueItem)
+		if current.vertex == to {
+			return current.distance, true
+		}
+		if current.distance > distances[current.vertex] {
+			continue
+		}
+		for _, edge := range network.edges[current.vertex] {
+			if distance := current.distance + edge.length; distance < distances[edge.to] {
+				distances[edge.to] = distance
+				heap.Push(queue, railQueueItem{vertex: edge.to, distance: distance})
+			}
+		}
+	}
+	return 0, false
+}
+
+func railDistance(a, b models.Location) float64 {
+	dx, dy, dz := a.X-b.X, a.Y-b.Y, a.Z-b.Z
+	return math.Sqrt(dx*dx + dy*dy + dz*dz)
+}
+
+type railQueueItem struct {
+	vertex   int
+	distance float64
+}
+
+// railQueue is a min-heap of vertices by distance
+type railQueue []railQueueItem
+
+func (queue railQueue) Len() int           { return len(queue) }
+func (queue railQueue) Less(i, j int) bool { return queue[i].distance < queue[j].distance }
+func (queue railQueue) Swap(i, j int)      { queue[i], queue[j] = queue[j], queue[i] }
+func (queue *railQueue) Push(item any)     { *queue = append(*queue, item.(railQueueItem)) }
+func (queue *railQueue) Pop() any {
+	old := *queue
+	item := old[len(old)-1]
+	*queue = old[:len(old)-1]
+	return item
+}
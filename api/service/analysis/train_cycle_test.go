@@ -0,0 +1,71 @@
+package analysis
+
+import (
+	"api/models/models"
+	"api/service/mock_client"
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEstimateTrainCycle(t *testing.T) {
+	client := mock_client.NewClient(mock_client.ScenarioFull)
+	trains, _ := client.ListTrains(context.Background())
+	rails, _ := client.ListTrainRails(context.Background())
+	train := trains[0]
+
+	// 400 m out and back at cruising speed, plus docking at both stops
+	want := time.Duration(800/trainCruiseSpeed*float64(time.Second)) + 2*trainDockingAllowance
+
+	cycle, err := EstimateTrainCycle(train, rails)
+	if err != nil {
+		t.Fatalf("failed to estimate cycle: %v", err)
+	}
+	if diff := cycle - want; diff < -time.Second || diff > time.Second {
+		t.Errorf("got cycle %s, want %s", cycle, want)
+	}
+	if cycle < time.Minute || cycle > 5*time.Minute {
+		t.Errorf("cycle %s is not plausible for a 400 m line", cycle)
+	}
+}
+
+func TestEstimateTrainCycleErrors(t *testing.T) {
+	client := mock_client.NewClient(mock_client.ScenarioFull)
+	trains, _ := client.ListTrains(context.Background())
+	rails, _ := client.ListTrainRails(context.Background())
+
+	withStop := func(entry models.TrainTimetableEntry) models.Train {
+		train := trains[0]
+		train.Timetable = append(append([]models.TrainTimetableEntry{}, train.Timetable...), entry)
+		return train
+	}
+	// A rail of its own far from the line, not connected to the mock rails
+	island := append(append([]models.TrainRail{}, rails...), models.TrainRail{
+		Location0: models.Location{X: 100000, Y: 100000, Z: 1000},
+		Location1: models.Location{X: 110000, Y: 100000, Z: 1000},
+		Length:    100,
+	})
+
+	tests := []struct {
+		name    string
+		train   models.Train
+		rails   []models.TrainRail
+		wantErr string
+	}{
+		{"single stop", models.Train{Timetable: trains[0].Timetable[:1]}, rails, "fewer than two stops"},
+		{"unknown station", withStop(models.TrainTimetableEntry{Station: "Gone"}), rails, "Gone does not exist"},
+		{"far from rails", withStop(models.TrainTimetableEntry{Station: "Outpost", Location: &models.Location{X: 0, Y: 90000, Z: 1000}}), rails, "Outpost is not reachable"},
+		{"no rails", trains[0], nil, "Iron Mine is not reachable"},
+		{"disconnected rails", withStop(models.TrainTimetableEntry{Station: "Island", Location: &models.Location{X: 100000, Y: 100000, Z: 1000}}), island, "no rail connection from Smeltery to Island"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := EstimateTrainCycle(test.train, test.rails)
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("got error %v, want one containing %q", err, test.wantErr)
+			}
+		})
+	}
+}
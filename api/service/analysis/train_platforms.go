@@ -0,0 +1,98 @@
+package analysis
+
+import (
+	"api/models/models"
+	"math"
+	"sort"
+)
+
+const (
+	trainStationDockTolerance = 2.0 // Distance outside a station or platform bounding box still counted as docked there
+	maxFreightPerLocomotive   = 8   // Freight cars per locomotive beyond which a loaded train cannot keep up speed
+)
+
+// FindTrainPlatformMismatches compares the cars of each docked train with the platforms of its station.
+// The station building lines up with the lead vehicle, so car i behind it lines up with platform i.
+// Docked trains whose cars and platforms line up one to one, with enough locomotives, are not reported.
+func FindTrainPlatformMismatches(trains []models.Train, stations []models.TrainStation) []models.TrainPlatformMismatch {
+	result := make([]models.TrainPlatformMismatch, 0)
+	for _, train := range trains {
+		if train.Status != models.TrainStatusDocking || len(train.Vehicles) == 0 {
+			continue
+		}
+		station := dockedTrainStation(train, stations)
+		if station == nil {
+			continue
+		}
+
+		mismatch := models.TrainPlatformMismatch{
+			TrainID:   train.ID,
+			TrainName: train.Name,
+			Station:   station.Name,
+			Platforms: len(station.Platforms),
+		}
+		for idx, vehicle := range train.Vehicles {
+			atPlatform := idx > 0 && idx <= len(station.Platforms)
+			switch vehicle.Type {
+			case models.TrainTypeLocomotive:
+				mismatch.Locomotives++
+				if atPlatform {
+					mismatch.LocomotivesAtPlatforms++
+				}
+			case models.TrainTypeFreight:
+				mismatch.FreightCars++
+				if !atPlatform {
+					mismatch.UnservedCars++
+				}
+			}
+		}
+		mismatch.UnservedPlatforms = max(len(station.Platforms)-(len(train.Vehicles)-1), 0)
+		mismatch.Underpowered = mismatch.FreightCars > mismatch.Locomotives*maxFreightPerLocomotive
+
+		if mismatch.UnservedPlatforms == 0 && mismatch.UnservedCars == 0 && mismatch.LocomotivesAtPlatforms == 0 && !mismatch.Underpowered {
+			continue
+		}
+		result = append(result, mismatch)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Station != result[j].Station {
+			return result[i].Station < result[j].Station
+		}
+		return result[i].TrainName < result[j].TrainName
+	})
+
+	return result
+}
+
+// dockedTrainStation returns the station whose building or platforms contain the train,
+// falling back to the nearest station named by the current timetable stop
+func dockedTrainStation(train models.Train, stations []models.TrainStation) *models.TrainStation {
+	for idx := range stations {
+		station := &stations[idx]
+		if station.BoundingBox.Contains(train.Location, trainStationDockTolerance) {
+			return station
+		}
+		for _, platform := range station.Platforms {
+			if platform.BoundingBox.Contains(train.Location, trainStationDockTolerance) {
+				return station
+			}
+		}
+	}
+
+	if train.TimetableIndex < 0 || train.TimetableIndex >= len(train.Timetable) {
+		return nil
+	}
+	name := train.Timetable[train.TimetableIndex].Station
+	var nearest *models.TrainStation
+	nearestDistance := math.Inf(1)
+	for idx := range stations {
+		if stations[idx].Name != name {
+			continue
+		}
+		if d := distance(train.Location, stations[idx].Location); d < nearestDistance {
+			nearest, nearestDistance = &stations[idx], d
+		}
+	}
+	return nearest
+}
@@ -0,0 +1,63 @@
+package analysis
+
+import (
+	"api/models/models"
+	"testing"
+)
+
+func TestFindTrainPlatformMismatches(t *testing.T) {
+	box := func(x float64) models.BoundingBox {
+		return models.BoundingBox{Min: models.Location{X: x - 10, Y: -10, Z: -10}, Max: models.Location{X: x + 10, Y: 10, Z: 10}}
+	}
+	station := func(name string, x float64, platforms int) models.TrainStation {
+		return models.TrainStation{Name: name, BoundingBox: box(x), Platforms: make([]models.TrainStationPlatform, platforms), Location: models.Location{X: x}}
+	}
+	stations := []models.TrainStation{station("North", 0, 3), station("South", 1000, 2), station("South", 5000, 4)}
+
+	train := func(name string, x float64, vehicles string) models.Train {
+		train := models.Train{ID: name, Name: name, Status: models.TrainStatusDocking, Location: models.Location{X: x}}
+		for _, vehicle := range vehicles {
+			vehicleType := models.TrainTypeFreight
+			if vehicle == 'L' {
+				vehicleType = models.TrainTypeLocomotive
+			}
+			train.Vehicles = append(train.Vehicles, models.TrainVehicle{Type: vehicleType})
+		}
+		return train
+	}
+	// Docked nowhere near a station box, so the station comes from its current timetable stop
+	fallback := train("fallback", 1100, "LF")
+	fallback.Timetable = []models.TrainTimetableEntry{{Station: "North"}, {Station: "South"}}
+	fallback.TimetableIndex = 1
+	moving := train("moving", 0, "LF")
+	moving.Status = models.TrainStatusSelfDriving
+
+	trains := []models.Train{
+		train("aligned", 0, "LFFF"),
+		train("short", 0, "LF"),
+		train("long", 1000, "LFFF"),
+		train("pusher", 0, "LFLF"),
+		train("heavy", 0, "LFFFFFFFFF"),
+		fallback,
+		moving,
+		train("lost", 3000, "LF"),
+	}
+
+	expected := []models.TrainPlatformMismatch{
+		{TrainID: "heavy", TrainName: "heavy", Station: "North", Locomotives: 1, FreightCars: 9, Platforms: 3, UnservedCars: 6, Underpowered: true},
+		{TrainID: "pusher", TrainName: "pusher", Station: "North", Locomotives: 2, FreightCars: 2, Platforms: 3, LocomotivesAtPlatforms: 1},
+		{TrainID: "short", TrainName: "short", Station: "North", Locomotives: 1, FreightCars: 1, Platforms: 3, UnservedPlatforms: 2},
+		{TrainID: "fallback", TrainName: "fallback", Station: "South", Locomotives: 1, FreightCars: 1, Platforms: 2, UnservedPlatforms: 1},
+		{TrainID: "long", TrainName: "long", Station: "South", Locomotives: 1, FreightCars: 3, Platforms: 2, UnservedCars: 1},
+	}
+
+	mismatches := FindTrainPlatformMismatches(trains, stations)
+	if len(mismatches) != len(expected) {
+		t.Fatalf("got %d mismatches, want %d: %+v", len(mismatches), len(expected), mismatches)
+	}
+	for i, want := range expected {
+		if mismatches[i] != want {
+			t.Errorf("mismatch %d: got %+v, want %+v", i, mismatches[i], want)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package analysis
+
+import (
+	"api/models/models"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// overTrainedBlockedTime is the average seconds a train may sit docked without transferring
+// before the route counts as over-trained
+const overTrainedBlockedTime = 30.0
+
+// GetTrainRoutes groups trains by their timetable station sequence and classifies each route's load
+// from the dock statistics of its stations. The same loop started at a different station is the same
+// route. A route is over-trained when docked trains mostly wait instead of transferring, and
+// under-trained when platforms stall on full exports or empty imports. Busiest routes come first.
+func GetTrainRoutes(trains []models.Train, stations []models.TrainStation) []models.TrainRoute {
+	stationsByName := make(map[string][]models.TrainStation)
+	for _, station := range stations {
+		stationsByName[station.Name] = append(stationsByName[station.Name], station)
+	}
+
+	routes := make(map[string]*models.TrainRoute)
+	for _, train := range trains {
+		if len(train.Timetable) == 0 {
+			continue
+		}
+		sequence := normalizeRoute(train.Timetable)
+		key := strings.Join(sequence, "\x00")
+		route, ok := routes[key]
+		if !ok {
+			route = &models.TrainRoute{Stations: sequence}
+			routes[key] = route
+		}
+		route.TrainIDs = append(route.TrainIDs, train.ID)
+		route.TrainCount++
+	}
+
+	result := make([]models.TrainRoute, 0, len(routes))
+	for _, route := range routes {
+		var totalDock, totalBlocked float64
+		seen := make(map[string]bool)
+		for _, name := range route.Stations {
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			for _, station := range stationsByName[name] {
+				dockStats := station.DockStats
+				route.DockCount += dockStats.DockCount
+				totalDock += dockStats.AverageDockTime * float64(dockStats.DockCount)
+				totalBlocked += dockStats.AverageBlockedTime * float64(dockStats.DockCount)
+				for _, platform := range station.Platforms {
+					if platform.Stalled {
+						route.StalledPlatforms++
+					}
+				}
+			}
+		}
+		if route.DockCount > 0 {
+			route.AverageDockTime = totalDock / float64(route.DockCount)
+			route.AverageBlockedTime = totalBlocked / float64(route.DockCount)
+		}
+
+		switch {
+		case route.DockCount > 0 && route.AverageBlockedTime >= overTrainedBlockedTime:
+			route.Load = models.TrainRouteLoadOverTrained
+		case route.StalledPlatforms > 0:
+			route.Load = models.TrainRouteLoadUnderTrained
+		default:
+			route.Load = models.TrainRouteLoadBalanced
+		}
+
+		sort.Strings(route.TrainIDs)
+		result = append(result, *route)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].TrainCount != result[j].TrainCount {
+			return result[i].TrainCount > result[j].TrainCount
+		}
+		return strings.Join(result[i].Stations, ",") < strings.Join(result[j].Stations, ",")
+	})
+
+	return result
+}
+
+// normalizeRoute rotates a timetable so it starts at its lexicographically smallest rotation,
+// since timetables loop and trains on the same loop may be heading to different stops
+func normalizeRoute(timetable []models.TrainTimetableEntry) []string {
+	names := make([]string, len(timetable))
+	for i, entry := range timetable {
+		names[i] = entry.Station
+	}
+
+	best := names
+	for offset := 1; offset < len(names); offset++ {
+		rotated := append(append([]string{}, names[offset:]...), names[:offset]...)
+		if slices.Compare(rotated, best) < 0 {
+			best = rotated
+		}
+	}
+	return best
+}
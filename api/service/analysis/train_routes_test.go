@@ -0,0 +1,51 @@
+package analysis
+
+import (
+	"api/models/models"
+	"reflect"
+	"testing"
+)
+
+func TestGetTrainRoutes(t *testing.T) {
+	train := func(id string, stops ...string) models.Train {
+		train := models.Train{ID: id}
+		for _, stop := range stops {
+			train.Timetable = append(train.Timetable, models.TrainTimetableEntry{Station: stop})
+		}
+		return train
+	}
+	trains := []models.Train{
+		train("t1", "Alpha", "Bravo", "Charlie"),
+		train("t2", "Bravo", "Charlie", "Alpha"), // Same loop, heading to a different stop
+		train("t3", "Charlie", "Bravo", "Alpha"), // Same stations in the opposite direction
+		train("t4", "Iron", "Steel"),
+		train("t5", "Coal", "Power"),
+		train("t6"), // No timetable
+	}
+	station := func(name string, dockCount int, averageDock, averageBlocked float64, stalled bool) models.TrainStation {
+		return models.TrainStation{
+			Name:      name,
+			DockStats: models.TrainStationDockStats{DockCount: dockCount, AverageDockTime: averageDock, AverageBlockedTime: averageBlocked},
+			Platforms: []models.TrainStationPlatform{{Stalled: stalled}},
+		}
+	}
+	stations := []models.TrainStation{
+		station("Alpha", 2, 60, 45, false),
+		station("Bravo", 2, 40, 35, false),
+		station("Iron", 3, 30, 5, false),
+		station("Steel", 1, 30, 5, true), // Export platform full
+		station("Coal", 2, 20, 4, false),
+		station("Power", 2, 20, 6, false),
+	}
+
+	want := []models.TrainRoute{
+		{Stations: []string{"Alpha", "Bravo", "Charlie"}, TrainIDs: []string{"t1", "t2"}, TrainCount: 2, DockCount: 4, AverageDockTime: 50, AverageBlockedTime: 40, Load: models.TrainRouteLoadOverTrained},
+		{Stations: []string{"Alpha", "Charlie", "Bravo"}, TrainIDs: []string{"t3"}, TrainCount: 1, DockCount: 4, AverageDockTime: 50, AverageBlockedTime: 40, Load: models.TrainRouteLoadOverTrained},
+		{Stations: []string{"Coal", "Power"}, TrainIDs: []string{"t5"}, TrainCount: 1, DockCount: 4, AverageDockTime: 20, AverageBlockedTime: 5, Load: models.TrainRouteLoadBalanced},
+		{Stations: []string{"Iron", "Steel"}, TrainIDs: []string{"t4"}, TrainCount: 1, DockCount: 4, AverageDockTime: 30, AverageBlockedTime: 5, StalledPlatforms: 1, Load: models.TrainRouteLoadUnderTrained},
+	}
+
+	if got := GetTrainRoutes(trains, stations); !reflect.DeepEqual(got, want) {
+		t.Errorf("got routes\n%+v\nwant\n%+v", got, want)
+	}
+}
@@ -0,0 +1,32 @@
+package analysis
+
+import (
+	"api/models/models"
+	"sort"
+)
+
+// FindDuplicateTrainStationNames returns every station name used by more than one station.
+// Timetables reference stations by name, so duplicates make train routing ambiguous.
+func FindDuplicateTrainStationNames(stations []models.TrainStation) []models.DuplicateTrainStationName {
+	locationsByName := make(map[string][]models.Location)
+	for _, station := range stations {
+		locationsByName[station.Name] = append(locationsByName[station.Name], station.Location)
+	}
+
+	result := make([]models.DuplicateTrainStationName, 0)
+	for name, locations := range locationsByName {
+		if len(locations) < 2 {
+			continue
+		}
+		result = append(result, models.DuplicateTrainStationName{
+			Name:      name,
+			Locations: locations,
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result
+}
@@ -0,0 +1,113 @@
+package analysis
+
+import "api/models/models"
+
+// itemStackSizes maps item names, as reported by FRM, to how many of them fit in one inventory stack
+var itemStackSizes = map[string]int{
+	// Ores and ingots
+	"Iron Ore":       100,
+	"Copper Ore":     100,
+	"Limestone":      100,
+	"Coal":           100,
+	"Caterium Ore":   100,
+	"Raw Quartz":     100,
+	"Sulfur":         100,
+	"Bauxite":        100,
+	"Uranium":        100,
+	"SAM":            100,
+	"Iron Ingot":     100,
+	"Copper Ingot":   100,
+	"Caterium Ingot": 100,
+	"Steel Ingot":    100,
+	"Aluminum Ingot": 100,
+	"Ficsite Ingot":  100,
+
+	// Minerals
+	"Concrete":         500,
+	"Quartz Crystal":   200,
+	"Silica":           200,
+	"Copper Powder":    500,
+	"Aluminum Scrap":   500,
+	"Compacted Coal":   100,
+	"Black Powder":     200,
+	"Smokeless Powder": 200,
+
+	// Standard parts
+	"Iron Plate":              200,
+	"Iron Rod":                200,
+	"Screws":                  500,
+	"Reinforced Iron Plate":   100,
+	"Modular Frame":           50,
+	"Heavy Modular Frame":     50,
+	"Fused Modular Frame":     50,
+	"Copper Sheet":            200,
+	"Steel Beam":              200,
+	"Steel Pipe":              200,
+	"Encased Industrial Beam": 100,
+	"Alclad Aluminum Sheet":   200,
+	"Aluminum Casing":         200,
+
+	// Oil derived
+	"Polymer Resin":  200,
+	"Petroleum Coke": 200,
+	"Plastic":        200,
+	"Rubber":         200,
+
+	// Industrial parts and electronics
+	"Rotor":                100,
+	"Stator":               100,
+	"Motor":                50,
+	"Heat Sink":            100,
+	"Cooling System":       100,
+	"Turbo Motor":          50,
+	"Battery":              200,
+	"Wire":                 500,
+	"Cable":                200,
+	"Quickwire":            500,
+	"Circuit Board":        200,
+	"AI Limiter":           100,
+	"High-Speed Connector": 100,
+	"Computer":             50,
+	"Supercomputer":        50,
+	"Radio Control Unit":   50,
+	"Crystal Oscillator":   100,
+
+	// Packaged fluids
+	"Empty Canister":   100,
+	"Empty Fluid Tank": 100,
+	"Packaged Water":   100,
+	"Packaged Oil":     100,
+	"Packaged Fuel":    100,
+
+	// Biomass
+	"Leaves":        500,
+	"Wood":          200,
+	"Mycelia":       200,
+	"Biomass":       200,
+	"Solid Biofuel": 200,
+	"Fabric":        100,
+}
+
+// StackSize returns how many of an item fit in one stack, and false if it is unknown
+func StackSize(itemName string) (int, bool) {
+	size, ok := itemStackSizes[itemName]
+	return size, ok
+}
+
+// ToItemsPerMinute normalizes a transfer rate of a single item to items/min, so rates reported in
+// different units (truck stations in stacks/sec, drone stations in items/min) can be compared.
+// Returns false if the rate is in stacks/sec and the item's stack size is unknown.
+func ToItemsPerMinute(rate float64, unit models.RateUnit, itemName string) (float64, bool) {
+	switch unit {
+	case models.RateUnitItemsPerMinute:
+		return rate, true
+	case models.RateUnitStacksPerSecond:
+		size, ok := StackSize(itemName)
+		if !ok {
+			return 0, false
+		}
+		return rate * float64(size) * 60, true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,42 @@
+package analysis
+
+import (
+	"api/models/models"
+	"testing"
+)
+
+func TestToItemsPerMinute(t *testing.T) {
+	tests := []struct {
+		name   string
+		rate   float64
+		unit   models.RateUnit
+		item   string
+		want   float64
+		wantOk bool
+	}{
+		{"items per minute unchanged", 120, models.RateUnitItemsPerMinute, "Iron Plate", 120, true},
+		{"items per minute of unknown item", 45, models.RateUnitItemsPerMinute, "Mystery Part", 45, true},
+		{"truck station stacks per second", 0.5, models.RateUnitStacksPerSecond, "Iron Plate", 6000, true},
+		{"stack size of 50", 0.1, models.RateUnitStacksPerSecond, "Computer", 300, true},
+		{"stacks of unknown item", 1, models.RateUnitStacksPerSecond, "Mystery Part", 0, false},
+		{"unknown unit", 1, models.RateUnit("stacks/min"), "Iron Plate", 0, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, ok := ToItemsPerMinute(test.rate, test.unit, test.item)
+			if got != test.want || ok != test.wantOk {
+				t.Errorf("got %v (ok %v), want %v (ok %v)", got, ok, test.want, test.wantOk)
+			}
+		})
+	}
+}
+
+func TestTruckAndDroneRatesAreComparable(t *testing.T) {
+	// A truck moving one stack of wire every 10 seconds matches a drone delivering 3000 wire per minute
+	truck, _ := ToItemsPerMinute(0.1, models.RateUnitStacksPerSecond, "Wire")
+	drone, _ := ToItemsPerMinute(3000, models.RateUnitItemsPerMinute, "Wire")
+	if truck != drone {
+		t.Errorf("got truck %v and drone %v items/min, want the same rate", truck, drone)
+	}
+}
@@ -0,0 +1,50 @@
+package analysis
+
+import (
+	"api/models/models"
+	"math"
+)
+
+const waterItemName = "Water"
+
+// GetWaterBalance compares the water made by all machines listing Water as an output with the
+// water consumed by all machines listing it as an input. On a deficit every consumer is assumed
+// to be starved by the same share, so the affected power is that share of the nuclear output.
+// Consumption is only known for machines that report their inputs.
+func GetWaterBalance(machines []models.Machine) models.WaterBalance {
+	balance := models.WaterBalance{}
+	nuclearOutput := 0.0
+	for _, machine := range machines {
+		for _, output := range machine.Output {
+			if output.Name == waterItemName {
+				balance.ProducedPerMinute += output.Current
+				balance.Producers++
+			}
+		}
+		for _, input := range machine.Input {
+			if input.Name != waterItemName {
+				continue
+			}
+			balance.ConsumedPerMinute += input.Current
+			balance.Consumers++
+			if machine.Type == models.MachineTypeNuclearPowerPlant {
+				balance.NuclearConsumedPerMinute += input.Current
+				for _, output := range machine.Output {
+					if output.Name == "Power" {
+						nuclearOutput += output.Current
+					}
+				}
+			}
+		}
+	}
+
+	balance.NetPerMinute = balance.ProducedPerMinute - balance.ConsumedPerMinute
+	if balance.NetPerMinute < 0 {
+		balance.Deficit = true
+		balance.NuclearThrottled = balance.NuclearConsumedPerMinute > 0
+		shortfall := math.Min(-balance.NetPerMinute/balance.ConsumedPerMinute, 1)
+		balance.AffectedPower = nuclearOutput * shortfall
+	}
+
+	return balance
+}
@@ -0,0 +1,73 @@
+package analysis
+
+import (
+	"api/models/models"
+	"testing"
+)
+
+func TestGetWaterBalance(t *testing.T) {
+	extractor := models.Machine{Type: models.MachineTypeWaterExtractor, Output: []models.MachineProdStats{{Name: "Water", Current: 120}}}
+	nuclear := models.Machine{
+		Type:   models.MachineTypeNuclearPowerPlant,
+		Input:  []models.MachineProdStats{{Name: "Uranium Fuel Rod", Current: 0.2}, {Name: "Water", Current: 240}},
+		Output: []models.MachineProdStats{{Name: "Power", Current: 2500e6}, {Name: "Uranium Waste", Current: 10}},
+	}
+	coal := models.Machine{
+		Type:   models.MachineTypeCoalGenerator,
+		Input:  []models.MachineProdStats{{Name: "Coal", Current: 15}, {Name: "Water", Current: 60}},
+		Output: []models.MachineProdStats{{Name: "Power", Current: 75e6}},
+	}
+	repeat := func(machine models.Machine, count int) []models.Machine {
+		machines := make([]models.Machine, count)
+		for idx := range machines {
+			machines[idx] = machine
+		}
+		return machines
+	}
+
+	tests := []struct {
+		name     string
+		machines []models.Machine
+		want     models.WaterBalance
+	}{
+		{
+			name:     "nuclear deficit",
+			machines: append(append(repeat(extractor, 3), repeat(nuclear, 2)...), repeat(coal, 2)...),
+			want: models.WaterBalance{
+				ProducedPerMinute: 360, ConsumedPerMinute: 600, NetPerMinute: -240, Producers: 3, Consumers: 4,
+				NuclearConsumedPerMinute: 480, Deficit: true, NuclearThrottled: true,
+				AffectedPower: 2000e6, // Consumers are starved by 40%
+			},
+		},
+		{
+			name:     "no water at all",
+			machines: repeat(nuclear, 1),
+			want: models.WaterBalance{
+				ConsumedPerMinute: 240, NetPerMinute: -240, Consumers: 1,
+				NuclearConsumedPerMinute: 240, Deficit: true, NuclearThrottled: true, AffectedPower: 2500e6,
+			},
+		},
+		{
+			name:     "deficit without nuclear",
+			machines: append(repeat(extractor, 1), repeat(coal, 3)...),
+			want: models.WaterBalance{
+				ProducedPerMinute: 120, ConsumedPerMinute: 180, NetPerMinute: -60, Producers: 1, Consumers: 3, Deficit: true,
+			},
+		},
+		{
+			name:     "surplus",
+			machines: append(repeat(extractor, 5), repeat(nuclear, 2)...),
+			want: models.WaterBalance{
+				ProducedPerMinute: 600, ConsumedPerMinute: 480, NetPerMinute: 120, Producers: 5, Consumers: 2, NuclearConsumedPerMinute: 480,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := GetWaterBalance(test.machines); got != test.want {
+				t.Errorf("got %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
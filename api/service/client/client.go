@@ -52,4 +52,11 @@ type Client interface {
 	GetFailureCount() int
 	IsDisconnected() bool
 	SetDisconnectedCallback(callback func())
+
+	// GetEndpointErrors returns the last error of every endpoint whose most recent fetch failed
+	GetEndpointErrors() []models.EndpointError
+
+	// Close releases the resources of the client, such as request workers. Call it when
+	// done with a client created for single requests.
+	Close()
 }
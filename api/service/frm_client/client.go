@@ -2,14 +2,19 @@ package frm_client
 
 import (
 	"api/models/models"
+	"api/pkg/config"
 	"api/pkg/log"
 	"api/service/frm_client/frm_models"
+	"bufio"
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
 	"net/http"
 	"net/url"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -19,23 +24,61 @@ const (
 	apiTimeout         = 10 * time.Second // Timeout for regular API calls
 	infraApiTimeout    = 20 * time.Second // Longer timeout for infrastructure endpoints (belts, pipes, etc.)
 	statusCheckTimeout = 2 * time.Second  // Timeout for the basic status check
+
+	defaultMaxConcurrentRequests = 4 // Max in-flight HTTP requests per client unless configured
+	defaultApiDownThreshold      = 3 // Consecutive failures before the API is reported down unless configured
+	defaultApiUpThreshold        = 2 // Consecutive successes before the API is reported up again unless configured
+
+	pausedHeartbeatInterval = 60 * time.Second // Polling cadence for most endpoints while the game is paused
+
+	defaultWarmUpRetries = 3                      // Retries of the initial fetch of each endpoint unless configured
+	warmUpInitialBackoff = 500 * time.Millisecond // Wait before the first warm-up retry, doubled for each further one
+
+	nonJSONSnippetLength = 200 // Bytes of a non-JSON response body included in the error
+)
+
+// pausedPolling describes how an endpoint is polled while the game is paused
+type pausedPolling int
+
+const (
+	pausedPollingHeartbeat pausedPolling = iota // Poll at pausedHeartbeatInterval
+	pausedPollingSkip                           // Do not poll, the data cannot change while paused
+	pausedPollingNormal                         // Keep the regular interval
 )
 
 // Client handles interactions with the Satisfactory Mod API
 type Client struct {
 	httpClient          *http.Client
 	apiIsUp             bool
+	apiStatusKnown      bool // False until the first status observation, which is applied directly
+	apiStatusStreak     int  // Consecutive observations disagreeing with apiIsUp
 	apiStatusLock       sync.RWMutex
 	apiUrl              string
+	headers             map[string]string // Sent with every request, values must never be logged
 	requestQueue        *RequestQueue
+	requestSlots        chan struct{} // Semaphore bounding concurrent in-flight HTTP requests, see maxConcurrentRequests
+	trainDocks          *trainDockTracker
 	consecutiveFailures int          // Counter for consecutive connection failures
 	failureLock         sync.RWMutex // Protects failure counter and disconnected state
 	onDisconnected      func()       // Callback triggered when failure threshold reached
 	wasDisconnected     bool         // Tracks previous disconnected state for logging
+
+	duplicateStationsLock sync.Mutex
+	lastDuplicateStations string // Names of the last logged duplicate train stations
+
+	gamePaused     bool // Last IsPaused reported by session info
+	gamePausedLock sync.RWMutex
+
+	endpointErrors     map[models.SatisfactoryEventType]models.EndpointError
+	endpointErrorsLock sync.RWMutex
+	errorSampler       *errorSampler
+	pollBudget         *pollBudget // Nil when no poll budget is configured
+	elevatorProgress   *elevatorProgress
 }
 
-// NewClientWithAddress creates a new Satisfactory API service instance with a custom URL
-func NewClientWithAddress(address string) *Client {
+// NewClientWithAddress creates a new Satisfactory API service instance with a custom URL,
+// sending the headers (e.g. Authorization for a reverse proxy) with every request
+func NewClientWithAddress(address string, headers map[string]string) *Client {
 	// Ensure the address has a protocol prefix
 	apiUrl := address
 	if !strings.HasPrefix(address, "http://") && !strings.HasPrefix(address, "https://") {
@@ -46,10 +89,86 @@ func NewClientWithAddress(address string) *Client {
 		httpClient: &http.Client{
 			Timeout: apiTimeout,
 		},
-		apiIsUp:      false,
-		apiUrl:       apiUrl,
-		requestQueue: NewRequestQueue(apiUrl),
+		apiIsUp:          false,
+		apiUrl:           apiUrl,
+		headers:          headers,
+		requestQueue:     NewRequestQueue(apiUrl, requestQueueConcurrency()),
+		requestSlots:     make(chan struct{}, maxConcurrentRequests()),
+		trainDocks:       newTrainDockTracker(time.Now),
+		endpointErrors:   make(map[models.SatisfactoryEventType]models.EndpointError),
+		errorSampler:     newErrorSampler(time.Now, errorLogInterval()),
+		pollBudget:       configuredPollBudget(),
+		elevatorProgress: newElevatorProgress(time.Now),
+	}
+}
+
+// requestQueueConcurrency returns the configured number of polls run at once per client.
+// A poll is one endpoint fetch, which may fan out into several HTTP requests, so this limits
+// how many endpoints are refreshed together rather than the load on the game server.
+func requestQueueConcurrency() int {
+	if config.Config != nil && config.Config.RequestQueueConcurrency > 0 {
+		return config.Config.RequestQueueConcurrency
+	}
+	return defaultRequestQueueConcurrency
+}
+
+// maxConcurrentRequests returns the configured limit of concurrent in-flight HTTP requests per client.
+// Unlike the request queue concurrency it counts individual requests, including those spawned by
+// fan-out fetchers, so it is the cap on data requests against the game server. Status checks bypass
+// it so they are never stuck behind slow fetches.
+func maxConcurrentRequests() int {
+	if config.Config != nil && config.Config.MaxConcurrentRequests > 0 {
+		return config.Config.MaxConcurrentRequests
+	}
+	return defaultMaxConcurrentRequests
+}
+
+// diagnosticsEvents reports whether a diagnostics event is emitted when an endpoint starts or stops failing
+func diagnosticsEvents() bool {
+	return config.Config != nil && config.Config.DiagnosticsEvents
+}
+
+// warmUpRetries returns the configured number of retries of the initial fetch of each endpoint
+func warmUpRetries() int {
+	if config.Config != nil && config.Config.WarmUpRetries != nil {
+		return *config.Config.WarmUpRetries
+	}
+	return defaultWarmUpRetries
+}
+
+// GetEndpointErrors returns the last error of every endpoint whose most recent fetch failed, by event type
+func (client *Client) GetEndpointErrors() []models.EndpointError {
+	client.endpointErrorsLock.RLock()
+	defer client.endpointErrorsLock.RUnlock()
+
+	result := make([]models.EndpointError, 0, len(client.endpointErrors))
+	for _, endpointError := range client.endpointErrors {
+		result = append(result, endpointError)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].EventType < result[j].EventType
+	})
+	return result
+}
+
+// observeEndpointResult records the error of a fetch, or clears the endpoint's last error on success.
+// Returns true if the set of failing endpoints changed.
+func (client *Client) observeEndpointResult(eventType models.SatisfactoryEventType, err error) bool {
+	client.endpointErrorsLock.Lock()
+	defer client.endpointErrorsLock.Unlock()
+
+	_, failing := client.endpointErrors[eventType]
+	if err == nil {
+		delete(client.endpointErrors, eventType)
+		return failing
+	}
+
+	client.endpointErrors[eventType] = models.EndpointError{
+		EventType: eventType,
+		Message:   err.Error(),
+		Timestamp: time.Now(),
 	}
+	return !failing
 }
 
 // GetAddress returns the API URL this client is connected to
@@ -63,17 +182,89 @@ func (client *Client) isApiUp() bool {
 	return client.apiIsUp
 }
 
-func (client *Client) setApiUp(isUp bool) {
+func (client *Client) isGamePaused() bool {
+	client.gamePausedLock.RLock()
+	defer client.gamePausedLock.RUnlock()
+	return client.gamePaused
+}
+
+func (client *Client) setGamePaused(paused bool) {
+	client.gamePausedLock.Lock()
+	defer client.gamePausedLock.Unlock()
+	if client.gamePaused != paused {
+		if paused {
+			log.Infof("Game paused on %s, slowing polling to %s", client.apiUrl, pausedHeartbeatInterval)
+		} else {
+			log.Infof("Game unpaused on %s, resuming normal polling", client.apiUrl)
+		}
+	}
+	client.gamePaused = paused
+}
+
+// pollDue reports whether an endpoint polled with the paused mode may be fetched, given its last fetch.
+// While the game is not paused every endpoint keeps its regular interval.
+func (client *Client) pollDue(whilePaused pausedPolling, lastFetch time.Time) bool {
+	if !client.isGamePaused() {
+		return true
+	}
+	switch whilePaused {
+	case pausedPollingSkip:
+		return false
+	case pausedPollingHeartbeat:
+		return time.Since(lastFetch) >= pausedHeartbeatInterval
+	default:
+		return true
+	}
+}
+
+// setApiUp records an observed API status and returns the debounced status.
+// The status only flips after apiDownThreshold consecutive failures or apiUpThreshold
+// consecutive successes, so a single dropped check does not toggle it.
+func (client *Client) setApiUp(isUp bool) bool {
 	client.apiStatusLock.Lock()
 	defer client.apiStatusLock.Unlock()
+
+	if isUp == client.apiIsUp && client.apiStatusKnown {
+		client.apiStatusStreak = 0
+		return client.apiIsUp
+	}
+
+	client.apiStatusStreak++
+	threshold := apiDownThreshold()
+	if isUp {
+		threshold = apiUpThreshold()
+	}
+	if client.apiStatusKnown && client.apiStatusStreak < threshold {
+		return client.apiIsUp
+	}
+
 	if client.apiIsUp != isUp {
 		isUpStr := fmt.Sprintf("%sdown%s", log.Red, log.Reset)
 		if isUp {
 			isUpStr = fmt.Sprintf("%sup%s", log.Green, log.Reset)
 		}
 		log.Printf("Satisfactory API status changed: %s", isUpStr)
-		client.apiIsUp = isUp
 	}
+	client.apiIsUp = isUp
+	client.apiStatusKnown = true
+	client.apiStatusStreak = 0
+	return client.apiIsUp
+}
+
+// apiDownThreshold returns the configured number of consecutive failures before the API is reported down
+func apiDownThreshold() int {
+	if config.Config != nil && config.Config.ApiDownThreshold > 0 {
+		return config.Config.ApiDownThreshold
+	}
+	return defaultApiDownThreshold
+}
+
+// apiUpThreshold returns the configured number of consecutive successes before the API is reported up
+func apiUpThreshold() int {
+	if config.Config != nil && config.Config.ApiUpThreshold > 0 {
+		return config.Config.ApiUpThreshold
+	}
+	return defaultApiUpThreshold
 }
 
 const failureThreshold = 5
@@ -134,13 +325,8 @@ func satisfactoryStatusToTrainStatus(trainData *frm_models.Train, relevantTrainS
 		return models.TrainStatusDerailed
 	}
 
-	// Check proximity to stations for Docking status
-	for _, station := range relevantTrainStations {
-		if math.Abs(trainData.Location.Z-station.Z) < 0.1 &&
-			math.Abs(trainData.Location.X-station.X) < 10 &&
-			math.Abs(trainData.Location.Y-station.Y) < 10 {
-			return models.TrainStatusDocking
-		}
+	if findDockedTrainStation(trainData, relevantTrainStations) != nil {
+		return models.TrainStatusDocking
 	}
 
 	switch trainData.Status {
@@ -156,6 +342,41 @@ func satisfactoryStatusToTrainStatus(trainData *frm_models.Train, relevantTrainS
 	}
 }
 
+// trainDockingTolerance is how far (in centimeters, the unit of FRM locations) outside a station or platform
+// bounding box a train may be and still count as docked
+const trainDockingTolerance = 200.0 // 2 m
+
+// findDockedTrainStation returns the station the train is docked at, or nil.
+// A train is docked when it is stationary inside the bounding box of the station or one of its platforms,
+// falling back to proximity to the station location when no bounding boxes are reported.
+func findDockedTrainStation(trainData *frm_models.Train, relevantTrainStations []models.TrainStation) *models.TrainStation {
+	if math.Abs(trainData.ForwardSpeed) > 1 {
+		return nil
+	}
+
+	location := parseLocation(trainData.Location)
+	for idx := range relevantTrainStations {
+		station := &relevantTrainStations[idx]
+
+		if station.BoundingBox.Contains(location, trainDockingTolerance) {
+			return station
+		}
+		for _, platform := range station.Platforms {
+			if platform.BoundingBox.Contains(location, trainDockingTolerance) {
+				return station
+			}
+		}
+
+		if math.Abs(trainData.Location.Z-station.Z) < 0.1 &&
+			math.Abs(trainData.Location.X-station.X) < 10 &&
+			math.Abs(trainData.Location.Y-station.Y) < 10 {
+			return station
+		}
+	}
+
+	return nil
+}
+
 // satisfactoryStatusToDroneStatus converts raw drone data to models.DroneStatus
 func satisfactoryStatusToDroneStatus(droneData *frm_models.Drone, relevantDroneStations []models.DroneStation) models.DroneStatus {
 	// Check proximity to stations for Docking status
@@ -175,14 +396,16 @@ func satisfactoryStatusToDroneStatus(droneData *frm_models.Drone, relevantDroneS
 // SetupEventStream starts polling endpoints and sends data via the callback
 func (client *Client) SetupEventStream(ctx context.Context, callback func(*models.SatisfactoryEvent)) error {
 	endpoints := []struct {
-		Type     models.SatisfactoryEventType
-		Endpoint func(context.Context) (interface{}, error)
-		Interval time.Duration
+		Type        models.SatisfactoryEventType
+		Endpoint    func(context.Context) (interface{}, error)
+		Interval    time.Duration
+		WhilePaused pausedPolling
 	}{
 		{
-			Type:     models.SatisfactoryEventApiStatus,
-			Endpoint: func(c context.Context) (interface{}, error) { return client.GetSatisfactoryApiStatus(c) },
-			Interval: 5 * time.Second,
+			Type:        models.SatisfactoryEventApiStatus,
+			Endpoint:    func(c context.Context) (interface{}, error) { return client.GetSatisfactoryApiStatus(c) },
+			Interval:    5 * time.Second,
+			WhilePaused: pausedPollingNormal,
 		},
 		{
 			Type:     models.SatisfactoryEventCircuits,
@@ -216,7 +439,7 @@ func (client *Client) SetupEventStream(ctx context.Context, callback func(*model
 		},
 		{
 			Type:     models.SatisfactoryEventMachines,
-			Endpoint: func(c context.Context) (interface{}, error) { return client.GetMachines(c) },
+			Endpoint: func(c context.Context) (interface{}, error) { return client.getMachines(c, false) },
 			Interval: 4 * time.Second,
 		},
 		{
@@ -230,24 +453,28 @@ func (client *Client) SetupEventStream(ctx context.Context, callback func(*model
 			Interval: 4 * time.Second,
 		},
 		{
-			Type:     models.SatisfactoryEventBelts,
-			Endpoint: func(c context.Context) (interface{}, error) { return client.GetBelts(c) },
-			Interval: 120 * time.Second,
+			Type:        models.SatisfactoryEventBelts,
+			Endpoint:    func(c context.Context) (interface{}, error) { return client.GetBelts(c) },
+			Interval:    120 * time.Second,
+			WhilePaused: pausedPollingSkip,
 		},
 		{
-			Type:     models.SatisfactoryEventPipes,
-			Endpoint: func(c context.Context) (interface{}, error) { return client.GetPipes(c) },
-			Interval: 120 * time.Second,
+			Type:        models.SatisfactoryEventPipes,
+			Endpoint:    func(c context.Context) (interface{}, error) { return client.GetPipes(c) },
+			Interval:    120 * time.Second,
+			WhilePaused: pausedPollingSkip,
 		},
 		{
-			Type:     models.SatisfactoryEventTrainRails,
-			Endpoint: func(c context.Context) (interface{}, error) { return client.ListTrainRails(c) },
-			Interval: 120 * time.Second,
+			Type:        models.SatisfactoryEventTrainRails,
+			Endpoint:    func(c context.Context) (interface{}, error) { return client.ListTrainRails(c) },
+			Interval:    120 * time.Second,
+			WhilePaused: pausedPollingSkip,
 		},
 		{
-			Type:     models.SatisfactoryEventCables,
-			Endpoint: func(c context.Context) (interface{}, error) { return client.ListCables(c) },
-			Interval: 120 * time.Second,
+			Type:        models.SatisfactoryEventCables,
+			Endpoint:    func(c context.Context) (interface{}, error) { return client.ListCables(c) },
+			Interval:    120 * time.Second,
+			WhilePaused: pausedPollingSkip,
 		},
 		{
 			Type:     models.SatisfactoryEventStorages,
@@ -290,14 +517,16 @@ func (client *Client) SetupEventStream(ctx context.Context, callback func(*model
 			Interval: 20 * time.Second,
 		},
 		{
-			Type:     models.SatisfactoryEventHypertubes,
-			Endpoint: func(c context.Context) (interface{}, error) { return client.GetHypertubes(c) },
-			Interval: 120 * time.Second,
+			Type:        models.SatisfactoryEventHypertubes,
+			Endpoint:    func(c context.Context) (interface{}, error) { return client.GetHypertubes(c) },
+			Interval:    120 * time.Second,
+			WhilePaused: pausedPollingSkip,
 		},
 		{
-			Type:     models.SatisfactoryEventSchematics,
-			Endpoint: func(c context.Context) (interface{}, error) { return client.ListSchematics(c) },
-			Interval: 30 * time.Second,
+			Type:        models.SatisfactoryEventSchematics,
+			Endpoint:    func(c context.Context) (interface{}, error) { return client.ListSchematics(c) },
+			Interval:    30 * time.Second,
+			WhilePaused: pausedPollingSkip,
 		},
 	}
 
@@ -309,38 +538,69 @@ func (client *Client) SetupEventStream(ctx context.Context, callback func(*model
 
 		log.Debugf("(%d/%d) Starting event listener for %s%s%s", idx+1, len(endpoints), log.Cyan, ep.Type, log.Reset)
 		go func(endpoint struct {
-			Type     models.SatisfactoryEventType
-			Endpoint func(context.Context) (interface{}, error)
-			Interval time.Duration
+			Type        models.SatisfactoryEventType
+			Endpoint    func(context.Context) (interface{}, error)
+			Interval    time.Duration
+			WhilePaused pausedPolling
 		}) {
 			defer wg.Done()
 			ticker := time.NewTicker(endpoint.Interval)
 			defer ticker.Stop()
 
-			// Fetch immediately on start, then continue with ticker
-			fetchData := func() {
+			var lastFetch time.Time
+
+			// Fetch immediately on start, then continue with ticker.
+			// Returns true if the fetch ran and failed.
+			fetchData := func() bool {
+				if !client.pollDue(endpoint.WhilePaused, lastFetch) {
+					return false
+				}
+				allowed := client.pollBudget.Allow(endpoint.Type)
+				if status, changed := client.pollBudget.Observe(); changed {
+					callback(&models.SatisfactoryEvent{Type: models.SatisfactoryEventPollBudget, Data: status})
+				}
+				if !allowed {
+					return false
+				}
+				lastFetch = time.Now()
+
 				endpointType := string(endpoint.Type)
-				executed, err := client.requestQueue.Enqueue(endpointType, func() error {
+				executed, err := client.requestQueue.Enqueue(endpointType, requestPriority(endpoint.Type), func() error {
 					data, fetchErr := endpoint.Endpoint(ctx)
+					if client.observeEndpointResult(endpoint.Type, fetchErr) && diagnosticsEvents() {
+						callback(&models.SatisfactoryEvent{Type: models.SatisfactoryEventDiagnostics, Data: client.GetEndpointErrors()})
+					}
+					var partialErr *models.PartialDataError
+					if errors.As(fetchErr, &partialErr) {
+						log.Warnf("Emitting partial %s data: %v", endpoint.Type, partialErr)
+						callback(&models.SatisfactoryEvent{Type: endpoint.Type, Data: data, Partial: true})
+						return nil
+					}
 					if fetchErr == nil {
 						callback(&models.SatisfactoryEvent{Type: endpoint.Type, Data: data})
 					}
 					return fetchErr
 				})
 
+				if executed {
+					client.logFetchResult(endpoint.Type, err)
+				}
 				if executed && err != nil {
-					log.PrettyError(fmt.Errorf("failed to fetch %s data. details: %w", endpoint.Type, err))
 					if endpoint.Type == models.SatisfactoryEventApiStatus {
 						callback(&models.SatisfactoryEvent{
 							Type: models.SatisfactoryEventApiStatus,
 							Data: &models.SatisfactoryApiStatus{Running: false},
 						})
 					}
+					return true
 				}
+				return false
 			}
 
-			// Execute immediately on start
-			fetchData()
+			// Execute immediately on start, retrying while the server warms up
+			if !warmUp(ctx, endpoint.Interval, warmUpRetries(), warmUpInitialBackoff, fetchData) {
+				return
+			}
 
 			for {
 				select {
@@ -363,6 +623,33 @@ func (client *Client) SetupEventStream(ctx context.Context, callback func(*model
 	return nil
 }
 
+// warmUp runs the first fetch of an endpoint. A server that just started often fails the first
+// requests, so a failed fetch is retried with a doubling backoff instead of waiting a full interval
+// for data. Retries stop before the first tick, which takes over from there.
+// Returns false if the context was cancelled while waiting for a retry.
+func warmUp(ctx context.Context, interval time.Duration, retries int, initialBackoff time.Duration, fetch func() bool) bool {
+	start := time.Now()
+	backoff := initialBackoff
+	for attempt := 0; fetch() && attempt < retries; attempt++ {
+		if time.Since(start)+backoff >= interval {
+			break
+		}
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return false
+		}
+		backoff *= 2
+	}
+	return true
+}
+
+// Close stops the request queue workers. Clients streaming events stop them when the stream's
+// context is cancelled, so this is only needed for clients created for single requests.
+func (client *Client) Close() {
+	client.requestQueue.Stop()
+}
+
 // SetupLightPolling polls only /getSessionInfo for disconnected sessions
 // This is a lightweight alternative to SetupEventStream when the server is offline
 func (client *Client) SetupLightPolling(ctx context.Context, callback func(*models.SatisfactoryEvent)) error {
@@ -430,10 +717,14 @@ func (client *Client) GetSatisfactoryApiStatus(ctx context.Context) (*models.Sat
 		client.setApiUp(false) // Should not happen, but good practice
 		return nil, models.NewSatisfactoryApiError("Failed to create request for API status check")
 	}
+	client.applyHeaders(req)
 
 	resp, err := client.httpClient.Do(req)
 	if err != nil {
-		client.setApiUp(false)
+		if client.setApiUp(false) {
+			// Transient failure, keep reporting up until the down threshold is reached
+			return &models.SatisfactoryApiStatus{Running: true}, nil
+		}
 		// Don't wrap error here, the caller (event loop) handles ApiError specifically
 		return nil, models.NewSatisfactoryApiError("API status check failed")
 	}
@@ -441,22 +732,41 @@ func (client *Client) GetSatisfactoryApiStatus(ctx context.Context) (*models.Sat
 
 	// Consider any 2xx status as "up" for this basic check
 	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
-		client.setApiUp(true)
-		return &models.SatisfactoryApiStatus{Running: true}, nil
+		// Still reported down while recovering until the up threshold is reached
+		return &models.SatisfactoryApiStatus{Running: client.setApiUp(true)}, nil
 	} else {
-		client.setApiUp(false)
+		if client.setApiUp(false) {
+			return &models.SatisfactoryApiStatus{Running: true}, nil
+		}
 		return nil, models.NewSatisfactoryApiError("API status check returned non-2xx status")
 	}
 }
 
+// applyHeaders sets the configured headers on an outgoing request
+func (client *Client) applyHeaders(req *http.Request) {
+	for name, value := range client.headers {
+		req.Header.Set(name, value)
+	}
+}
+
 // makeSatisfactoryCall performs a GET request and decodes the JSON response
-// It updates the API status based on success/failure.
+// It updates the failure count based on success/failure, the API status is left to the status check.
 func (client *Client) makeSatisfactoryCall(ctx context.Context, path string, target interface{}) error {
 	return client.makeSatisfactoryCallWithTimeout(ctx, path, target, client.httpClient.Timeout)
 }
 
 // makeSatisfactoryCallWithTimeout performs a GET request with a custom timeout
 func (client *Client) makeSatisfactoryCallWithTimeout(ctx context.Context, path string, target interface{}, timeout time.Duration) error {
+	// Fan-out fetchers spawn several calls at once, so bound the total in-flight
+	// requests against the game server independent of poll cadence
+	select {
+	case client.requestSlots <- struct{}{}:
+		defer func() { <-client.requestSlots }()
+	case <-ctx.Done():
+		return models.NewSatisfactoryApiError(fmt.Sprintf("Cancelled while waiting for a request slot for %s: %v", path, ctx.Err()))
+	}
+	client.pollBudget.Spend()
+
 	reqCtx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
@@ -468,9 +778,9 @@ func (client *Client) makeSatisfactoryCallWithTimeout(ctx context.Context, path
 
 	req, err := http.NewRequestWithContext(reqCtx, http.MethodGet, apiUrl, nil)
 	if err != nil {
-		client.setApiUp(false)
 		return models.NewSatisfactoryApiError(fmt.Sprintf("Failed to create request for %s: %v", path, err))
 	}
+	client.applyHeaders(req)
 
 	startTime := time.Now()
 	resp, err := client.httpClient.Do(req)
@@ -483,7 +793,8 @@ func (client *Client) makeSatisfactoryCallWithTimeout(ctx context.Context, path
 
 	if err != nil {
 		// NETWORK ERROR: Connection refused, timeout, DNS failure, etc.
-		client.setApiUp(false)
+		// The API status is only debounced from the status check, since a failing fan-out
+		// would otherwise reach the down threshold within a single poll
 		client.incrementFailureCount()
 		return models.NewSatisfactoryApiError(fmt.Sprintf("Failed to make request to %s: %v", path, err))
 	}
@@ -491,16 +802,23 @@ func (client *Client) makeSatisfactoryCallWithTimeout(ctx context.Context, path
 
 	if resp.StatusCode != http.StatusOK {
 		// HTTP ERROR: Server responded but with error status
+		// Don't increment failure count - server is reachable but returning errors
 		statusCode := resp.StatusCode
-		if statusCode == http.StatusServiceUnavailable || statusCode == http.StatusNotFound {
-			client.setApiUp(false)
-			// Don't increment failure count - server is reachable but returning errors
-		}
 		return models.NewSatisfactoryApiError(fmt.Sprintf("API call to %s failed with status code %d", path, statusCode))
 	}
 
+	// The mod's web server sometimes answers errors with an HTML page and a 200 status.
+	// Report that separately, without counting it as a connectivity failure. The body is
+	// checked rather than the content type, since some FRM builds serve JSON as text/plain.
+	body := bufio.NewReader(resp.Body)
+	peeked, _ := body.Peek(nonJSONSnippetLength)
+	trimmed := bytes.TrimSpace(peeked)
+	if bytes.HasPrefix(trimmed, []byte("<")) {
+		return models.NewNonJSONResponseError(path, resp.Header.Get("Content-Type"), string(trimmed))
+	}
+
 	// Decode JSON response
-	if err := json.NewDecoder(resp.Body).Decode(target); err != nil {
+	if err := json.NewDecoder(body).Decode(target); err != nil {
 		// API responded with OK, but body is invalid JSON or doesn't match target struct
 		// This is less likely an "API down" scenario, more likely a data or code issue.
 		// We don't necessarily setApiUp(false) here, as the endpoint might be partially functional.
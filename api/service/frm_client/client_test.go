@@ -0,0 +1,425 @@
+package frm_client
+
+import (
+	"api/models/models"
+	"api/pkg/log"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestMain(m *testing.M) {
+	if err := log.SetupLogger("test"); err != nil {
+		panic(err)
+	}
+	os.Exit(m.Run())
+}
+
+// observeWarnings captures the warnings logged until the end of the test
+func observeWarnings(t *testing.T) *observer.ObservedLogs {
+	t.Helper()
+	core, logs := observer.New(zap.WarnLevel)
+	previous := log.Logger
+	log.Logger = zap.New(core).Sugar()
+	t.Cleanup(func() { log.Logger = previous })
+	return logs
+}
+
+// newStubClient returns a client talking to a server answering each path with the JSON of its response
+func newStubClient(t *testing.T, responses map[string]any) *Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response, ok := responses[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClientWithAddress(server.URL, nil)
+	t.Cleanup(client.requestQueue.Stop)
+	return client
+}
+
+func TestApiStatusIsDebouncedFromStatusChecksOnly(t *testing.T) {
+	var down atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if down.Load() || r.URL.Path != "/" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClientWithAddress(server.URL, nil)
+	t.Cleanup(client.requestQueue.Stop)
+	ctx := context.Background()
+
+	running := func() bool {
+		status, err := client.GetSatisfactoryApiStatus(ctx)
+		return err == nil && status.Running
+	}
+
+	if !running() {
+		t.Fatal("API is down after a successful status check")
+	}
+
+	// A failing fan-out of data calls must not count towards the down threshold
+	for range defaultApiDownThreshold * 2 {
+		var target map[string]any
+		if err := client.makeSatisfactoryCall(ctx, "/getBelt", &target); err == nil {
+			t.Fatal("data call succeeded, want an error")
+		}
+	}
+	if !running() {
+		t.Fatal("failed data calls flipped the API status to down")
+	}
+
+	down.Store(true)
+	for i := 1; i < defaultApiDownThreshold; i++ {
+		if !running() {
+			t.Fatalf("API is down after %d failed status checks, want %d", i, defaultApiDownThreshold)
+		}
+	}
+	if running() {
+		t.Fatal("API is still up after sustained failed status checks")
+	}
+
+	down.Store(false)
+	for i := 1; i < defaultApiUpThreshold; i++ {
+		if running() {
+			t.Fatalf("API is up after %d successful status checks, want %d", i, defaultApiUpThreshold)
+		}
+	}
+	if !running() {
+		t.Fatal("API is still down after sustained successful status checks")
+	}
+}
+
+func TestNonJSONResponseIsNotAConnectivityFailure(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		wantNonJSON bool
+	}{
+		{"html error page", "text/html", "<html><body>Internal error</body></html>", true},
+		{"html served as json", "application/json", "  <!DOCTYPE html><html></html>", true},
+		{"json served as text/plain", "text/plain", `{"name": "ok"}`, false},
+		{"json", "application/json", `{"name": "ok"}`, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", test.contentType)
+				_, _ = w.Write([]byte(test.body))
+			}))
+			t.Cleanup(server.Close)
+
+			client := NewClientWithAddress(server.URL, nil)
+			t.Cleanup(client.requestQueue.Stop)
+			client.incrementFailureCount()
+
+			var target struct {
+				Name string `json:"name"`
+			}
+			err := client.makeSatisfactoryCall(context.Background(), "/getSessionInfo", &target)
+
+			var nonJSON *models.NonJSONResponseError
+			if test.wantNonJSON {
+				if !errors.As(err, &nonJSON) {
+					t.Fatalf("got error %v, want a NonJSONResponseError", err)
+				}
+				if nonJSON.Path != "/getSessionInfo" || nonJSON.ContentType != test.contentType {
+					t.Errorf("got %+v, want path /getSessionInfo and content type %q", nonJSON, test.contentType)
+				}
+				if failures := client.GetFailureCount(); failures != 1 {
+					t.Errorf("failure count %d after a non-JSON response, want it left at 1", failures)
+				}
+				return
+			}
+
+			if err != nil || target.Name != "ok" {
+				t.Fatalf("got %+v with error %v, want the decoded body", target, err)
+			}
+			if failures := client.GetFailureCount(); failures != 0 {
+				t.Errorf("failure count %d after a success, want 0", failures)
+			}
+		})
+	}
+}
+
+func TestPollingSlowsWhileGamePaused(t *testing.T) {
+	var paused atomic.Bool
+	var sessionInfoRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sessionInfoRequests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"SessionName": "test", "IsPaused": paused.Load()})
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClientWithAddress(server.URL, nil)
+	t.Cleanup(client.requestQueue.Stop)
+	fetchSessionInfo := func() {
+		t.Helper()
+		if _, err := client.GetSessionInfo(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	recent := time.Now().Add(-time.Second)
+	stale := time.Now().Add(-pausedHeartbeatInterval)
+	tests := []struct {
+		name        string
+		whilePaused pausedPolling
+		lastFetch   time.Time
+		wantRunning bool // Due while the game runs
+		wantPaused  bool // Due while the game is paused
+	}{
+		{"heartbeat fetched recently", pausedPollingHeartbeat, recent, true, false},
+		{"heartbeat interval passed", pausedPollingHeartbeat, stale, true, true},
+		{"skipped", pausedPollingSkip, stale, true, false},
+		{"normal", pausedPollingNormal, recent, true, true},
+	}
+	check := func(wantPaused bool) {
+		t.Helper()
+		for _, test := range tests {
+			want := test.wantRunning
+			if wantPaused {
+				want = test.wantPaused
+			}
+			if got := client.pollDue(test.whilePaused, test.lastFetch); got != want {
+				t.Errorf("%s: due %v with paused %v, want %v", test.name, got, wantPaused, want)
+			}
+		}
+	}
+
+	fetchSessionInfo()
+	check(false)
+
+	paused.Store(true)
+	fetchSessionInfo()
+	check(true)
+
+	// Session info keeps being polled while paused, so the unpause is noticed
+	paused.Store(false)
+	fetchSessionInfo()
+	check(false)
+	if requests := sessionInfoRequests.Load(); requests != 3 {
+		t.Errorf("got %d session info requests, want 3", requests)
+	}
+}
+
+func TestEndpointErrorsTrackLastFailure(t *testing.T) {
+	client := newFlakyClient(t, 1)
+	fetch := func() error {
+		var target map[string]any
+		err := client.makeSatisfactoryCall(context.Background(), "/getBelt", &target)
+		client.observeEndpointResult(models.SatisfactoryEventBelts, err)
+		return err
+	}
+
+	before := time.Now()
+	if err := fetch(); err == nil {
+		t.Fatal("first fetch succeeded, want a failure")
+	}
+	endpointErrors := client.GetEndpointErrors()
+	if len(endpointErrors) != 1 {
+		t.Fatalf("got %+v, want the belts error", endpointErrors)
+	}
+	got := endpointErrors[0]
+	if got.EventType != models.SatisfactoryEventBelts || !strings.Contains(got.Message, "503") {
+		t.Errorf("got %+v, want the belts error with its status code", got)
+	}
+	if got.Timestamp.Before(before) || got.Timestamp.After(time.Now()) {
+		t.Errorf("got timestamp %s, want the time of the failure", got.Timestamp)
+	}
+
+	if err := fetch(); err != nil {
+		t.Fatal(err)
+	}
+	if endpointErrors := client.GetEndpointErrors(); len(endpointErrors) != 0 {
+		t.Errorf("got %+v after a success, want the error cleared", endpointErrors)
+	}
+}
+
+func TestObserveEndpointResultReportsChanges(t *testing.T) {
+	client := NewClientWithAddress("http://localhost", nil)
+	t.Cleanup(client.requestQueue.Stop)
+	failure := errors.New("failed")
+
+	steps := []struct {
+		err         error
+		wantChanged bool
+	}{
+		{nil, false},
+		{failure, true},
+		{failure, false},
+		{nil, true},
+	}
+	for i, step := range steps {
+		if changed := client.observeEndpointResult(models.SatisfactoryEventBelts, step.err); changed != step.wantChanged {
+			t.Errorf("step %d: changed %v, want %v", i, changed, step.wantChanged)
+		}
+	}
+}
+
+func TestRequestSlotsBoundConcurrentRequests(t *testing.T) {
+	release := make(chan struct{})
+	var inFlight, peak atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			previous := peak.Load()
+			if current <= previous || peak.CompareAndSwap(previous, current) {
+				break
+			}
+		}
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte("{}"))
+	}))
+	t.Cleanup(server.Close)
+	client := NewClientWithAddress(server.URL, nil)
+	t.Cleanup(client.requestQueue.Stop)
+	limit := int32(cap(client.requestSlots))
+
+	requests := int(limit) * 3
+	var wg sync.WaitGroup
+	for range requests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var target map[string]any
+			if err := client.makeSatisfactoryCall(context.Background(), "/getFactory", &target); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+
+	// Wait until the slots are filled, then give the remaining callers a chance to get past them
+	deadline := time.Now().Add(2 * time.Second)
+	for inFlight.Load() < limit && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if got := inFlight.Load(); got != limit {
+		t.Errorf("got %d requests in flight, want %d", got, limit)
+	}
+	close(release)
+	wg.Wait()
+
+	if got := peak.Load(); got != limit {
+		t.Errorf("got peak of %d concurrent requests, want %d", got, limit)
+	}
+}
+
+func TestRequestSlotWaitIsCancellable(t *testing.T) {
+	client := NewClientWithAddress("http://127.0.0.1:1", nil)
+	t.Cleanup(client.requestQueue.Stop)
+	for range cap(client.requestSlots) {
+		client.requestSlots <- struct{}{}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	var target map[string]any
+	if err := client.makeSatisfactoryCall(ctx, "/getFactory", &target); err == nil {
+		t.Error("got no error while every slot was taken, want the wait to be cancelled")
+	}
+	if client.GetFailureCount() != 0 {
+		t.Errorf("got %d failures, want waiting for a slot not to count as a connection failure", client.GetFailureCount())
+	}
+}
+
+func TestConfiguredHeadersAreSent(t *testing.T) {
+	received := make(chan http.Header, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- r.Header.Clone()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte("[]"))
+	}))
+	t.Cleanup(server.Close)
+	headers := map[string]string{"Authorization": "Bearer secret", "X-Forwarded-User": "dashboard"}
+	client := NewClientWithAddress(server.URL, headers)
+	t.Cleanup(client.requestQueue.Stop)
+
+	if _, err := client.GetSatisfactoryApiStatus(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	var target []any
+	if err := client.makeSatisfactoryCall(context.Background(), "/getPower", &target); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, request := range []string{"status check", "data call"} {
+		got := <-received
+		for name, want := range headers {
+			if got.Get(name) != want {
+				t.Errorf("%s: got header %s %q, want %q", request, name, got.Get(name), want)
+			}
+		}
+	}
+}
+
+func TestWarmUpRetriesFirstFetch(t *testing.T) {
+	tests := []struct {
+		name      string
+		failures  int // Fetches failing before one succeeds
+		retries   int
+		interval  time.Duration
+		wantCalls int
+	}{
+		{"first fetch succeeds", 0, 3, time.Minute, 1},
+		{"succeeds on a retry", 2, 3, time.Minute, 3},
+		{"gives up after the retries", 10, 3, time.Minute, 4},
+		{"retries disabled", 10, 0, time.Minute, 1},
+		{"stops before the first tick", 10, 3, 5 * time.Millisecond, 2}, // Backoffs of 2 and 4 ms, the next one would pass the tick
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			calls := 0
+			fetch := func() bool {
+				calls++
+				return calls <= test.failures
+			}
+			if !warmUp(context.Background(), test.interval, test.retries, 2*time.Millisecond, fetch) {
+				t.Fatal("got cancelled, want the warm-up to finish")
+			}
+			if calls != test.wantCalls {
+				t.Errorf("got %d fetches, want %d", calls, test.wantCalls)
+			}
+		})
+	}
+}
+
+func TestWarmUpStopsWhenCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	calls := 0
+	if warmUp(ctx, time.Hour, 3, time.Minute, func() bool { calls++; return true }) {
+		t.Error("got finished, want the warm-up cancelled while waiting for a retry")
+	}
+	if calls != 1 {
+		t.Errorf("got %d fetches, want only the first", calls)
+	}
+}
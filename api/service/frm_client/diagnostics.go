@@ -0,0 +1,39 @@
+package frm_client
+
+import (
+	"api/models/models"
+	"context"
+)
+
+// GetClientDiagnostics returns a snapshot of the connection state, failing endpoints, backoffs,
+// poll budget and request queue timing. It only reads local state and makes no requests.
+func (client *Client) GetClientDiagnostics(ctx context.Context) (*models.ClientDiagnostics, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	backoffs := client.requestQueue.Backoffs()
+	backoffSeconds := make(map[models.SatisfactoryEventType]float64, len(backoffs))
+	for endpointType, backoff := range backoffs {
+		backoffSeconds[models.SatisfactoryEventType(endpointType)] = backoff.Seconds()
+	}
+
+	queueStats := client.requestQueue.QueueStats()
+	endpointStats := make(map[models.SatisfactoryEventType]models.EndpointStat, len(queueStats))
+	for endpointType, stat := range queueStats {
+		endpointStats[models.SatisfactoryEventType(endpointType)] = stat
+	}
+
+	return &models.ClientDiagnostics{
+		ApiUp:            client.isApiUp(),
+		GamePaused:       client.isGamePaused(),
+		FailureCount:     client.GetFailureCount(),
+		Disconnected:     client.IsDisconnected(),
+		EndpointErrors:   client.GetEndpointErrors(),
+		SuppressedErrors: client.errorSampler.Suppressed(),
+		PollBudget:       client.pollBudget.Status(),
+		BackoffSeconds:   backoffSeconds,
+		QueueDepth:       client.requestQueue.QueueDepth(),
+		QueueStats:       endpointStats,
+	}, nil
+}
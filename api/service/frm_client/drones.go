@@ -5,6 +5,7 @@ import (
 	"api/service/frm_client/frm_models"
 	"context"
 	"fmt"
+	"math"
 	"sync"
 )
 
@@ -78,12 +79,29 @@ func (client *Client) ListDrones(ctx context.Context) ([]models.Drone, error) {
 			Home:        homeStation,
 			Paired:      &pairedStation,
 			Destination: &destinationStation,
+			Fuel:        homeStation.Fuel,
 		}
+		drones[i].EstimatedArrival = droneArrivalEstimate(&drones[i])
 	}
 
 	return drones, nil
 }
 
+// droneArrivalEstimate returns the straight-line flight time in seconds to the destination at the
+// current speed (km/h, locations in cm). Drones that are not flying, e.g. docking at zero speed, or
+// have no known destination get no estimate.
+func droneArrivalEstimate(drone *models.Drone) float64 {
+	if drone.Status != models.DroneStatusFlying || drone.Speed <= 0 || drone.Destination == nil || drone.Destination.Name == "" {
+		return 0
+	}
+
+	dx := drone.Destination.X - drone.X
+	dy := drone.Destination.Y - drone.Y
+	dz := drone.Destination.Z - drone.Z
+	meters := math.Sqrt(dx*dx+dy*dy+dz*dz) / 100
+	return meters / (drone.Speed / 3.6)
+}
+
 // ListDroneStations fetches drone station data
 func (client *Client) ListDroneStations(ctx context.Context) ([]models.DroneStation, error) {
 	var rawStations []frm_models.DroneStation
@@ -123,19 +141,13 @@ func (client *Client) ListDroneStations(ctx context.Context) ([]models.DroneStat
 		// Parse InputInventory
 		inputInventory := make([]models.ItemStats, len(raw.InputInventory))
 		for j, item := range raw.InputInventory {
-			inputInventory[j] = models.ItemStats{
-				Name:  item.Name,
-				Count: item.Amount,
-			}
+			inputInventory[j] = parseItemStats(item.Name, item.Amount)
 		}
 
 		// Parse OutputInventory
 		outputInventory := make([]models.ItemStats, len(raw.OutputInventory))
 		for j, item := range raw.OutputInventory {
-			outputInventory[j] = models.ItemStats{
-				Name:  item.Name,
-				Count: item.Amount,
-			}
+			outputInventory[j] = parseItemStats(item.Name, item.Amount)
 		}
 
 		stations[i] = models.DroneStation{
@@ -146,6 +158,7 @@ func (client *Client) ListDroneStations(ctx context.Context) ([]models.DroneStat
 			Fuel:            fuel,
 			IncomingRate:    raw.AvgIncRate,
 			OutgoingRate:    raw.AvgOutRate,
+			RateUnit:        models.RateUnitItemsPerMinute,
 			InputInventory:  inputInventory,
 			OutputInventory: outputInventory,
 		}
@@ -0,0 +1,56 @@
+package frm_client
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestListDronesFuelAndArrival(t *testing.T) {
+	// Locations in cm; the destination is 1 km from the flying drone
+	client := newStubClient(t, map[string]any{
+		"/getDrone": json.RawMessage(`[
+			{"Name": "flying", "location": {"x": 0, "y": 0, "z": 5000}, "FlyingSpeed": 360, "HomeStation": "home", "TargetStation": "far", "DestinationStation": "far"},
+			{"Name": "docked", "location": {"x": 0, "y": 0, "z": 0}, "FlyingSpeed": 0, "HomeStation": "home", "TargetStation": "far", "DestinationStation": "far"},
+			{"Name": "lost", "location": {"x": 500, "y": 500, "z": 5000}, "FlyingSpeed": 360, "HomeStation": "unfueled"}
+		]`),
+		"/getDroneStation": json.RawMessage(`[
+			{"Name": "home", "location": {"x": 0, "y": 0, "z": 0}, "ActiveFuel": {"FuelName": "Battery"}, "FuelInventory": [{"Name": "Battery", "Amount": 40}]},
+			{"Name": "far", "location": {"x": 60000, "y": 80000, "z": 5000}, "ActiveFuel": {"FuelName": "N/A"}},
+			{"Name": "unfueled", "location": {"x": -5000, "y": 0, "z": 0}, "ActiveFuel": {"FuelName": "N/A"}}
+		]`),
+	})
+
+	drones, err := client.ListDrones(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		wantFuel    float64 // Zero for no fuel
+		wantArrival float64
+	}{
+		{"flying", 40, 10}, // 1000 m at 100 m/s
+		{"docked", 40, 0},
+		{"lost", 0, 0}, // No known destination
+	}
+	for i, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			drone := drones[i]
+			if drone.Name != test.name {
+				t.Fatalf("got %s, want %s", drone.Name, test.name)
+			}
+			if test.wantFuel == 0 && drone.Fuel != nil {
+				t.Errorf("got fuel %+v, want none", drone.Fuel)
+			}
+			if test.wantFuel != 0 && (drone.Fuel == nil || drone.Fuel.Amount != test.wantFuel) {
+				t.Errorf("got fuel %+v, want %v", drone.Fuel, test.wantFuel)
+			}
+			if math.Abs(drone.EstimatedArrival-test.wantArrival) > 1e-9 {
+				t.Errorf("got arrival in %v seconds, want %v", drone.EstimatedArrival, test.wantArrival)
+			}
+		})
+	}
+}
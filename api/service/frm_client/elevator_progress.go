@@ -0,0 +1,82 @@
+package frm_client
+
+import (
+	"api/models/models"
+	"sync"
+	"time"
+)
+
+// elevatorSample is the submitted amount of a phase objective seen at a poll
+type elevatorSample struct {
+	amount float64
+	at     time.Time
+}
+
+// elevatorProgress remembers the previous amount of every space elevator phase objective so
+// the delivery rate can be derived from the change between two GetSpaceElevator polls
+type elevatorProgress struct {
+	mu         sync.Mutex
+	now        func() time.Time
+	objectives map[string]elevatorSample // Objective name -> previous sample
+}
+
+func newElevatorProgress(now func() time.Time) *elevatorProgress {
+	return &elevatorProgress{
+		now:        now,
+		objectives: make(map[string]elevatorSample),
+	}
+}
+
+// apply fills in the delivery rate, ETA and stalled flag of every objective and returns the
+// ETA of the whole phase, in seconds. The phase ETA is the slowest objective's, and zero while any
+// unfinished objective has no measured progress. Objectives whose amount went down (a new
+// phase reusing the name) start over, and objectives no longer listed are forgotten.
+func (progress *elevatorProgress) apply(objectives []models.SpaceElevatorPhaseObjective) float64 {
+	progress.mu.Lock()
+	defer progress.mu.Unlock()
+
+	now := progress.now()
+	seen := make(map[string]bool, len(objectives))
+	var phaseETA float64
+	phaseKnown := true
+
+	for i := range objectives {
+		objective := &objectives[i]
+		seen[objective.Name] = true
+		previous, ok := progress.objectives[objective.Name]
+		progress.objectives[objective.Name] = elevatorSample{amount: objective.Amount, at: now}
+
+		remaining := objective.TotalCost - objective.Amount
+		if remaining <= 0 {
+			continue
+		}
+
+		elapsed := now.Sub(previous.at)
+		if !ok || elapsed <= 0 || objective.Amount < previous.amount {
+			phaseKnown = false
+			continue
+		}
+
+		delta := objective.Amount - previous.amount
+		if delta == 0 {
+			objective.Stalled = true
+			phaseKnown = false
+			continue
+		}
+
+		objective.DeliveryRate = delta / elapsed.Minutes()
+		objective.EstimatedCompletion = remaining / objective.DeliveryRate * 60
+		phaseETA = max(phaseETA, objective.EstimatedCompletion)
+	}
+
+	for name := range progress.objectives {
+		if !seen[name] {
+			delete(progress.objectives, name)
+		}
+	}
+
+	if !phaseKnown {
+		return 0
+	}
+	return phaseETA
+}
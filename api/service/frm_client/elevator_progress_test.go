@@ -0,0 +1,52 @@
+package frm_client
+
+import (
+	"api/models/models"
+	"testing"
+	"time"
+)
+
+func TestElevatorProgress(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	progress := newElevatorProgress(func() time.Time { return now })
+	phase := func(plates, frames float64) []models.SpaceElevatorPhaseObjective {
+		return []models.SpaceElevatorPhaseObjective{
+			{Name: "Smart Plating", Amount: plates, TotalCost: 1000},
+			{Name: "Versatile Framework", Amount: frames, TotalCost: 500},
+		}
+	}
+
+	first := phase(100, 400)
+	if eta := progress.apply(first); eta != 0 {
+		t.Errorf("got phase ETA %v on the first poll, want 0", eta)
+	}
+
+	now = now.Add(time.Minute)
+	second := phase(200, 450)
+	// 800 plates left at 100/min, 50 frames left at 50/min
+	if eta := progress.apply(second); eta != 480 {
+		t.Errorf("got phase ETA %v seconds, want the slowest objective's 480", eta)
+	}
+	if second[0].DeliveryRate != 100 || second[0].EstimatedCompletion != 480 {
+		t.Errorf("got rate %v and ETA %v, want 100/min and 480 seconds", second[0].DeliveryRate, second[0].EstimatedCompletion)
+	}
+	if second[1].EstimatedCompletion != 60 {
+		t.Errorf("got ETA %v, want 60 seconds", second[1].EstimatedCompletion)
+	}
+
+	now = now.Add(time.Minute)
+	third := phase(200, 500)
+	if eta := progress.apply(third); eta != 0 {
+		t.Errorf("got phase ETA %v with a stalled objective, want 0", eta)
+	}
+	if !third[0].Stalled || third[1].Stalled {
+		t.Errorf("got stalled %v and %v, want only the unfinished objective stalled", third[0].Stalled, third[1].Stalled)
+	}
+
+	// A new phase reusing the name starts over
+	now = now.Add(time.Minute)
+	fourth := phase(10, 0)
+	if eta := progress.apply(fourth); eta != 0 || fourth[0].DeliveryRate != 0 {
+		t.Errorf("got phase ETA %v and rate %v after a new phase, want 0", eta, fourth[0].DeliveryRate)
+	}
+}
@@ -0,0 +1,111 @@
+package frm_client
+
+import (
+	"api/models/models"
+	"api/pkg/config"
+	"api/pkg/log"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// defaultErrorLogInterval is how often a failing endpoint logs its error unless configured
+const defaultErrorLogInterval = 60 * time.Second
+
+// errorLogInterval returns the configured interval between logged errors of one failing endpoint
+func errorLogInterval() time.Duration {
+	if config.Config != nil && config.Config.ErrorLogIntervalSeconds > 0 {
+		return time.Duration(config.Config.ErrorLogIntervalSeconds) * time.Second
+	}
+	return defaultErrorLogInterval
+}
+
+// errorSampler limits fetch error logging per endpoint: the first failure is logged, then at most
+// one per interval with the number suppressed in between, so a sustained outage stays readable.
+// Recovery is logged with how many failures occurred.
+type errorSampler struct {
+	mu        sync.Mutex
+	now       func() time.Time
+	interval  time.Duration
+	endpoints map[models.SatisfactoryEventType]*sampledEndpoint
+}
+
+type sampledEndpoint struct {
+	failures   int // Since the endpoint started failing
+	suppressed int // Since the last logged failure
+	lastLogged time.Time
+}
+
+func newErrorSampler(now func() time.Time, interval time.Duration) *errorSampler {
+	return &errorSampler{
+		now:       now,
+		interval:  interval,
+		endpoints: make(map[models.SatisfactoryEventType]*sampledEndpoint),
+	}
+}
+
+// Failure records a failed fetch and returns the error to log, or nil if it is suppressed
+func (sampler *errorSampler) Failure(eventType models.SatisfactoryEventType, err error) error {
+	sampler.mu.Lock()
+	defer sampler.mu.Unlock()
+
+	now := sampler.now()
+	endpoint, failing := sampler.endpoints[eventType]
+	if !failing {
+		endpoint = &sampledEndpoint{}
+		sampler.endpoints[eventType] = endpoint
+	}
+	endpoint.failures++
+
+	if failing && now.Sub(endpoint.lastLogged) < sampler.interval {
+		endpoint.suppressed++
+		return nil
+	}
+
+	logged := fmt.Errorf("failed to fetch %s data. details: %w", eventType, err)
+	if endpoint.suppressed > 0 {
+		logged = fmt.Errorf("failed to fetch %s data (%d similar errors suppressed). details: %w", eventType, endpoint.suppressed, err)
+	}
+	endpoint.suppressed = 0
+	endpoint.lastLogged = now
+	return logged
+}
+
+// Recovered records a successful fetch and returns the recovery summary to log, or "" if the
+// endpoint was not failing
+func (sampler *errorSampler) Recovered(eventType models.SatisfactoryEventType) string {
+	sampler.mu.Lock()
+	defer sampler.mu.Unlock()
+
+	endpoint, failing := sampler.endpoints[eventType]
+	if !failing {
+		return ""
+	}
+	delete(sampler.endpoints, eventType)
+	return fmt.Sprintf("Fetching %s data recovered after %d failed attempts", eventType, endpoint.failures)
+}
+
+// Suppressed returns the failing endpoints with the number of errors not logged since their last logged one
+func (sampler *errorSampler) Suppressed() map[models.SatisfactoryEventType]int {
+	sampler.mu.Lock()
+	defer sampler.mu.Unlock()
+
+	suppressed := make(map[models.SatisfactoryEventType]int, len(sampler.endpoints))
+	for eventType, endpoint := range sampler.endpoints {
+		suppressed[eventType] = endpoint.suppressed
+	}
+	return suppressed
+}
+
+// logFetchResult logs a fetch error or recovery through the client's error sampler
+func (client *Client) logFetchResult(eventType models.SatisfactoryEventType, err error) {
+	if err == nil {
+		if summary := client.errorSampler.Recovered(eventType); summary != "" {
+			log.Infof("%s", summary)
+		}
+		return
+	}
+	if logged := client.errorSampler.Failure(eventType, err); logged != nil {
+		log.PrettyError(logged)
+	}
+}
@@ -0,0 +1,79 @@
+package frm_client
+
+import (
+	"api/models/models"
+	"api/pkg/config"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestErrorSamplerLogsOncePerInterval(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := start
+	sampler := newErrorSampler(func() time.Time { return now }, time.Minute)
+	timeout := errors.New("timeout")
+
+	steps := []struct {
+		after     time.Duration
+		eventType models.SatisfactoryEventType
+		logged    string // Empty when suppressed
+	}{
+		{0, models.SatisfactoryEventBelts, "failed to fetch belts data. details: timeout"},
+		{10 * time.Second, models.SatisfactoryEventBelts, ""},
+		// Endpoints are sampled separately
+		{15 * time.Second, models.SatisfactoryEventPipes, "failed to fetch pipes data. details: timeout"},
+		{50 * time.Second, models.SatisfactoryEventBelts, ""},
+		{60 * time.Second, models.SatisfactoryEventBelts, "failed to fetch belts data (2 similar errors suppressed). details: timeout"},
+		{90 * time.Second, models.SatisfactoryEventBelts, ""},
+	}
+
+	for idx, step := range steps {
+		now = start.Add(step.after)
+		logged := sampler.Failure(step.eventType, timeout)
+		if step.logged == "" {
+			if logged != nil {
+				t.Errorf("failure %d: got %q, want it suppressed", idx, logged)
+			}
+			continue
+		}
+		if logged == nil || logged.Error() != step.logged {
+			t.Errorf("failure %d: got %v, want %q", idx, logged, step.logged)
+		} else if !errors.Is(logged, timeout) {
+			t.Errorf("failure %d: got %v not wrapping the fetch error", idx, logged)
+		}
+	}
+
+	suppressed := sampler.Suppressed()
+	if len(suppressed) != 2 || suppressed[models.SatisfactoryEventBelts] != 1 || suppressed[models.SatisfactoryEventPipes] != 0 {
+		t.Errorf("got suppressed %v, want 1 for belts and 0 for pipes", suppressed)
+	}
+
+	if summary := sampler.Recovered(models.SatisfactoryEventBelts); !strings.Contains(summary, "after 5 failed attempts") {
+		t.Errorf("got recovery %q, want it to count the 5 failures", summary)
+	}
+	if summary := sampler.Recovered(models.SatisfactoryEventBelts); summary != "" {
+		t.Errorf("got recovery %q for an endpoint that is not failing, want none", summary)
+	}
+
+	// After recovery the next failure is logged right away
+	now = start.Add(100 * time.Second)
+	if logged := sampler.Failure(models.SatisfactoryEventBelts, timeout); logged == nil {
+		t.Error("got the first failure after recovery suppressed, want it logged")
+	}
+}
+
+func TestErrorLogInterval(t *testing.T) {
+	previous := config.Config
+	t.Cleanup(func() { config.Config = previous })
+
+	config.Config = &config.Type{}
+	if interval := errorLogInterval(); interval != defaultErrorLogInterval {
+		t.Errorf("got %v, want the default %v", interval, defaultErrorLogInterval)
+	}
+	config.Config = &config.Type{ErrorLogIntervalSeconds: 5}
+	if interval := errorLogInterval(); interval != 5*time.Second {
+		t.Errorf("got %v, want 5s", interval)
+	}
+}
@@ -41,6 +41,7 @@ type Ingredient struct {
 }
 
 type Extractor struct {
+	ID                  string       `json:"ID"`
 	Name                string       `json:"Name"`
 	IsProducing         bool         `json:"IsProducing"`
 	IsPaused            bool         `json:"IsPaused"`
@@ -56,6 +57,7 @@ type Extractor struct {
 }
 
 type FactoryMachine struct {
+	ID                  string       `json:"ID"`
 	Name                string       `json:"Name"`
 	IsProducing         bool         `json:"IsProducing"`
 	IsPaused            bool         `json:"IsPaused"`
@@ -73,13 +75,15 @@ type FactoryMachine struct {
 }
 
 type Generator struct {
-	Name                string      `json:"Name"`
-	Location            Location    `json:"location"`
-	BoundingBox         BoundingBox `json:"BoundingBox"`
-	BaseProd            float64     `json:"BaseProd"`            // Base power production (MW)
-	RegulatedDemandProd float64     `json:"RegulatedDemandProd"` // Current power production (MW) - used for most generators
-	ProductionCapacity  float64     `json:"ProductionCapacity"`  // Power capacity (MW) - used for geothermal
-	CircuitID           int         `json:"CircuitID"`
+	ID                  string       `json:"ID"`
+	Name                string       `json:"Name"`
+	Location            Location     `json:"location"`
+	BoundingBox         BoundingBox  `json:"BoundingBox"`
+	BaseProd            float64      `json:"BaseProd"`            // Base power production (MW)
+	RegulatedDemandProd float64      `json:"RegulatedDemandProd"` // Current power production (MW) - used for most generators
+	ProductionCapacity  float64      `json:"ProductionCapacity"`  // Power capacity (MW) - used for geothermal
+	CircuitID           int          `json:"CircuitID"`
+	FuelInventory       []Ingredient `json:"FuelInventory"` // Fuel being burned, empty for geothermal
 }
 
 type Circuit struct {
@@ -266,6 +270,8 @@ type Pipe struct {
 	SplineData     []Location `json:"SplineData"`
 	Length         float64    `json:"Length"`
 	ItemsPerMinute float64    `json:"ItemsPerMinute"`
+	FlowRate       float64    `json:"FlowRate"`    // Signed m³/min, negative when flowing from connection 1 to 0. Missing in older FRM builds
+	FillPercent    float64    `json:"FillPercent"` // 0-100. Missing in older FRM builds
 }
 
 type PipeJunction struct {
@@ -287,12 +293,21 @@ type TrainRail struct {
 	Length     float64    `json:"Length"`
 }
 
+// SplitterSortRule routes an item to one output of a smart or programmable splitter.
+// OutputIndex is 0 for the left, 1 for the center and 2 for the right output.
+type SplitterSortRule struct {
+	Name        string `json:"Name"`
+	ClassName   string `json:"ClassName"`
+	OutputIndex int    `json:"OutputIndex"`
+}
+
 type SplitterMerger struct {
-	ID          string      `json:"ID"`
-	Name        string      `json:"Name"`
-	ClassName   string      `json:"ClassName"`
-	Location    Location    `json:"location"`
-	BoundingBox BoundingBox `json:"BoundingBox"`
+	ID          string             `json:"ID"`
+	Name        string             `json:"Name"`
+	ClassName   string             `json:"ClassName"`
+	Location    Location           `json:"location"`
+	BoundingBox BoundingBox        `json:"BoundingBox"`
+	SortRules   []SplitterSortRule `json:"SortRules"` // Only reported for smart and programmable splitters
 }
 
 type Cable struct {
@@ -322,29 +337,9 @@ type Storage struct {
 	Inventory   []StorageInventoryItem `json:"Inventory"`
 }
 
-// Tractor has same structure as Truck
-type Tractor struct {
-	ID           string       `json:"ID"`
-	Name         string       `json:"Name"`
-	ForwardSpeed float64      `json:"ForwardSpeed"`
-	AutoPilot    bool         `json:"AutoPilot"`
-	Fuel         []TruckFuel  `json:"Fuel"`
-	Storage      []ItemAmount `json:"Storage"`
-	Driver       string       `json:"Driver"`
-	Location     Location     `json:"location"`
-}
-
-// Explorer has same structure as Truck
-type Explorer struct {
-	ID           string       `json:"ID"`
-	Name         string       `json:"Name"`
-	ForwardSpeed float64      `json:"ForwardSpeed"`
-	AutoPilot    bool         `json:"AutoPilot"`
-	Fuel         []TruckFuel  `json:"Fuel"`
-	Storage      []ItemAmount `json:"Storage"`
-	Driver       string       `json:"Driver"`
-	Location     Location     `json:"location"`
-}
+// Tractor and Explorer are reported in the same shape as Truck
+type Tractor = Truck
+type Explorer = Truck
 
 type VehiclePathVertex struct {
 	X float64 `json:"x"`
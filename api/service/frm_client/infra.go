@@ -2,9 +2,11 @@ package frm_client
 
 import (
 	"api/models/models"
+	"api/pkg/log"
 	"api/service/frm_client/frm_models"
 	"context"
 	"fmt"
+	"math"
 	"sync"
 )
 
@@ -77,24 +79,56 @@ func (client *Client) ListBelts(ctx context.Context) ([]models.Belt, error) {
 	for i, raw := range rawBelts {
 		splineData := make([]models.Location, len(raw.SplineData))
 		for j, pt := range raw.SplineData {
-			splineData[j] = models.Location{X: pt.X, Y: pt.Y, Z: pt.Z, Rotation: pt.Rotation}
+			splineData[j] = parseLocation(pt)
 		}
 
 		belts[i] = models.Belt{
 			ID:             raw.ID,
 			Name:           raw.Name,
-			Location0:      models.Location{X: raw.Location0.X, Y: raw.Location0.Y, Z: raw.Location0.Z, Rotation: raw.Location0.Rotation},
-			Location1:      models.Location{X: raw.Location1.X, Y: raw.Location1.Y, Z: raw.Location1.Z, Rotation: raw.Location1.Rotation},
+			Location0:      parseLocation(raw.Location0),
+			Location1:      parseLocation(raw.Location1),
 			Connected0:     raw.Connected0,
 			Connected1:     raw.Connected1,
-			SplineData:     splineData,
+			SplineData:     simplifySpline(splineData, splineTolerance()),
 			Length:         raw.Length / 100, // Convert cm to m
 			ItemsPerMinute: raw.ItemsPerMinute,
 		}
+		belts[i].MaxItemsPerMinute = beltCapacity(raw.ClassName)
+		belts[i].Saturation = math.Min(raw.ItemsPerMinute/belts[i].MaxItemsPerMinute, 1)
 	}
 	return belts, nil
 }
 
+// beltCapacities is the rated throughput in items per minute of each belt and lift mark
+var beltCapacities = map[string]float64{
+	"Build_ConveyorBeltMk1_C": 60,
+	"Build_ConveyorBeltMk2_C": 120,
+	"Build_ConveyorBeltMk3_C": 270,
+	"Build_ConveyorBeltMk4_C": 480,
+	"Build_ConveyorBeltMk5_C": 780,
+	"Build_ConveyorBeltMk6_C": 1200,
+	"Build_ConveyorLiftMk1_C": 60,
+	"Build_ConveyorLiftMk2_C": 120,
+	"Build_ConveyorLiftMk3_C": 270,
+	"Build_ConveyorLiftMk4_C": 480,
+	"Build_ConveyorLiftMk5_C": 780,
+	"Build_ConveyorLiftMk6_C": 1200,
+}
+
+// unknownBeltClasses holds the belt class names already warned about
+var unknownBeltClasses sync.Map
+
+// beltCapacity returns the rated throughput of a belt class, falling back to Mk.1 for unknown classes
+func beltCapacity(className string) float64 {
+	if capacity, ok := beltCapacities[className]; ok {
+		return capacity
+	}
+	if _, warned := unknownBeltClasses.LoadOrStore(className, true); !warned {
+		log.Warnf("Unknown belt class %q, assuming Mk.1 throughput", className)
+	}
+	return beltCapacities["Build_ConveyorBeltMk1_C"]
+}
+
 // GetPipes fetches pipe data and junctions
 func (client *Client) GetPipes(ctx context.Context) (models.Pipes, error) {
 	var pipes []models.Pipe
@@ -164,19 +198,21 @@ func (client *Client) ListPipes(ctx context.Context) ([]models.Pipe, error) {
 	for i, raw := range rawPipes {
 		splineData := make([]models.Location, len(raw.SplineData))
 		for j, pt := range raw.SplineData {
-			splineData[j] = models.Location{X: pt.X, Y: pt.Y, Z: pt.Z, Rotation: pt.Rotation}
+			splineData[j] = parseLocation(pt)
 		}
 
 		pipes[i] = models.Pipe{
 			ID:             raw.ID,
 			Name:           raw.Name,
-			Location0:      models.Location{X: raw.Location0.X, Y: raw.Location0.Y, Z: raw.Location0.Z, Rotation: raw.Location0.Rotation},
-			Location1:      models.Location{X: raw.Location1.X, Y: raw.Location1.Y, Z: raw.Location1.Z, Rotation: raw.Location1.Rotation},
+			Location0:      parseLocation(raw.Location0),
+			Location1:      parseLocation(raw.Location1),
 			Connected0:     raw.Connected0,
 			Connected1:     raw.Connected1,
-			SplineData:     splineData,
+			SplineData:     simplifySpline(splineData, splineTolerance()),
 			Length:         raw.Length / 100, // Convert cm to m
 			ItemsPerMinute: raw.ItemsPerMinute,
+			FlowRate:       raw.FlowRate,
+			FillPercent:    math.Max(0, math.Min(raw.FillPercent/100, 1)),
 		}
 	}
 	return pipes, nil
@@ -216,11 +252,40 @@ func (client *Client) ListSplitterMergers(ctx context.Context) ([]models.Splitte
 			Type:        models.SplitterMergerType(raw.Name),
 			Location:    parseLocation(raw.Location),
 			BoundingBox: parseBoundingBox(raw.BoundingBox),
+			Filters:     parseSplitterFilters(raw.SortRules),
 		}
 	}
 	return splitterMergers, nil
 }
 
+// splitterOutputs maps FRM sort rule output indices to splitter output directions
+var splitterOutputs = []models.SplitterOutput{
+	models.SplitterOutputLeft,
+	models.SplitterOutputCenter,
+	models.SplitterOutputRight,
+}
+
+// parseSplitterFilters converts the sort rules of a smart or programmable splitter, skipping rules
+// with an unknown output index
+func parseSplitterFilters(rules []frm_models.SplitterSortRule) []models.SplitterOutputFilter {
+	filters := make([]models.SplitterOutputFilter, 0, len(rules))
+	for _, rule := range rules {
+		if rule.OutputIndex < 0 || rule.OutputIndex >= len(splitterOutputs) {
+			continue
+		}
+		item := rule.Name
+		if item == "" {
+			item = CanonicalItemName(rule.ClassName)
+		}
+		filters = append(filters, models.SplitterOutputFilter{
+			Output:    splitterOutputs[rule.OutputIndex],
+			Item:      item,
+			ItemClass: rule.ClassName,
+		})
+	}
+	return filters
+}
+
 // ListTrainRails fetches train rail data
 // NOTE: FRM /getTrainRails endpoint is currently broken, returning empty list
 func (client *Client) ListTrainRails(ctx context.Context) ([]models.TrainRail, error) {
@@ -323,14 +388,14 @@ func (client *Client) ListHypertubes(ctx context.Context) ([]models.Hypertube, e
 	for i, raw := range rawHypertubes {
 		splineData := make([]models.Location, len(raw.SplineData))
 		for j, pt := range raw.SplineData {
-			splineData[j] = models.Location{X: pt.X, Y: pt.Y, Z: pt.Z, Rotation: pt.Rotation}
+			splineData[j] = parseLocation(pt)
 		}
 
 		hypertubes[i] = models.Hypertube{
 			ID:          raw.ID,
-			Location0:   models.Location{X: raw.Location0.X, Y: raw.Location0.Y, Z: raw.Location0.Z, Rotation: raw.Location0.Rotation},
-			Location1:   models.Location{X: raw.Location1.X, Y: raw.Location1.Y, Z: raw.Location1.Z, Rotation: raw.Location1.Rotation},
-			SplineData:  splineData,
+			Location0:   parseLocation(raw.Location0),
+			Location1:   parseLocation(raw.Location1),
+			SplineData:  simplifySpline(splineData, splineTolerance()),
 			BoundingBox: parseBoundingBox(raw.BoundingBox),
 		}
 	}
@@ -0,0 +1,133 @@
+package frm_client
+
+import (
+	"api/models/models"
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestListSplitterMergersFilters(t *testing.T) {
+	// Shaped like the FRM response, so the field names are checked too
+	client := newStubClient(t, map[string]any{
+		"/getSplitterMerger": json.RawMessage(`[
+			{"ID": "smart", "Name": "Smart Splitter", "ClassName": "Build_ConveyorAttachmentSplitterSmart_C", "SortRules": [
+				{"Name": "Iron Plate", "ClassName": "Desc_IronPlate_C", "OutputIndex": 0},
+				{"Name": "", "ClassName": "Desc_Wire_C", "OutputIndex": 1},
+				{"Name": "Overflow", "ClassName": "", "OutputIndex": 2},
+				{"Name": "Screw", "ClassName": "Desc_IronScrew_C", "OutputIndex": 3}
+			]},
+			{"ID": "plain", "Name": "Conveyor Splitter", "ClassName": "Build_ConveyorAttachmentSplitter_C"},
+			{"ID": "merger", "Name": "Conveyor Merger", "ClassName": "Build_ConveyorAttachmentMerger_C", "SortRules": []}
+		]`),
+	})
+
+	splitterMergers, err := client.ListSplitterMergers(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		id          string
+		wantFilters []models.SplitterOutputFilter
+	}{
+		{"smart", []models.SplitterOutputFilter{
+			{Output: models.SplitterOutputLeft, Item: "Iron Plate", ItemClass: "Desc_IronPlate_C"},
+			{Output: models.SplitterOutputCenter, Item: "Wire", ItemClass: "Desc_Wire_C"}, // Named from the class name
+			{Output: models.SplitterOutputRight, Item: "Overflow"},
+			// The rule with an unknown output index is skipped
+		}},
+		{"plain", []models.SplitterOutputFilter{}},
+		{"merger", []models.SplitterOutputFilter{}},
+	}
+	for i, test := range tests {
+		t.Run(test.id, func(t *testing.T) {
+			splitterMerger := splitterMergers[i]
+			if splitterMerger.ID != test.id {
+				t.Fatalf("got %s, want %s", splitterMerger.ID, test.id)
+			}
+			if !reflect.DeepEqual(splitterMerger.Filters, test.wantFilters) {
+				t.Errorf("got filters %+v, want %+v", splitterMerger.Filters, test.wantFilters)
+			}
+		})
+	}
+}
+
+func TestListBeltsSaturation(t *testing.T) {
+	client := newStubClient(t, map[string]any{
+		"/getBelts": json.RawMessage(`[
+			{"ID": "mk1", "ClassName": "Build_ConveyorBeltMk1_C", "ItemsPerMinute": 30},
+			{"ID": "mk5", "ClassName": "Build_ConveyorBeltMk5_C", "ItemsPerMinute": 780},
+			{"ID": "lift", "ClassName": "Build_ConveyorLiftMk3_C", "ItemsPerMinute": 0},
+			{"ID": "over", "ClassName": "Build_ConveyorBeltMk2_C", "ItemsPerMinute": 150},
+			{"ID": "unknown", "ClassName": "Build_ConveyorBeltMk9_C", "ItemsPerMinute": 45}
+		]`),
+	})
+
+	belts, err := client.ListBelts(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		id             string
+		wantCapacity   float64
+		wantSaturation float64
+	}{
+		{"mk1", 60, 0.5},
+		{"mk5", 780, 1},
+		{"lift", 270, 0},
+		{"over", 120, 1}, // Capped at the rated capacity
+		{"unknown", 60, 0.75},
+	}
+	for i, test := range tests {
+		t.Run(test.id, func(t *testing.T) {
+			belt := belts[i]
+			if belt.ID != test.id {
+				t.Fatalf("got %s, want %s", belt.ID, test.id)
+			}
+			if belt.MaxItemsPerMinute != test.wantCapacity || belt.Saturation != test.wantSaturation {
+				t.Errorf("got capacity %v and saturation %v, want %v and %v", belt.MaxItemsPerMinute, belt.Saturation, test.wantCapacity, test.wantSaturation)
+			}
+		})
+	}
+}
+
+func TestListPipesFlow(t *testing.T) {
+	client := newStubClient(t, map[string]any{
+		"/getPipes": json.RawMessage(`[
+			{"ID": "forward", "FlowRate": 300, "FillPercent": 45.5},
+			{"ID": "backward", "FlowRate": -120.5, "FillPercent": 100},
+			{"ID": "overfull", "FlowRate": 600, "FillPercent": 104},
+			{"ID": "old-frm", "ItemsPerMinute": 0}
+		]`),
+	})
+
+	pipes, err := client.ListPipes(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		id       string
+		wantFlow float64
+		wantFill float64
+	}{
+		{"forward", 300, 0.455},
+		{"backward", -120.5, 1},
+		{"overfull", 600, 1}, // Clamped to 0-1
+		{"old-frm", 0, 0},    // Fields missing in older FRM builds
+	}
+	for i, test := range tests {
+		t.Run(test.id, func(t *testing.T) {
+			pipe := pipes[i]
+			if pipe.ID != test.id {
+				t.Fatalf("got %s, want %s", pipe.ID, test.id)
+			}
+			if pipe.FlowRate != test.wantFlow || pipe.FillPercent != test.wantFill {
+				t.Errorf("got flow %v and fill %v, want %v and %v", pipe.FlowRate, pipe.FillPercent, test.wantFlow, test.wantFill)
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package frm_client
+
+import "strings"
+
+// itemClassNames maps FRM item class names to the canonical display names used in itemMetadataTable.
+// Some endpoints report class names where others report display names, so both are normalized
+// to the display name before joining them.
+var itemClassNames = map[string]string{
+	// Ores
+	"Desc_OreIron_C":    "Iron Ore",
+	"Desc_OreCopper_C":  "Copper Ore",
+	"Desc_Stone_C":      "Limestone",
+	"Desc_Coal_C":       "Coal",
+	"Desc_OreGold_C":    "Caterium Ore",
+	"Desc_RawQuartz_C":  "Raw Quartz",
+	"Desc_Sulfur_C":     "Sulfur",
+	"Desc_OreBauxite_C": "Bauxite",
+	"Desc_OreUranium_C": "Uranium",
+	"Desc_SAM_C":        "SAM",
+
+	// Ingots
+	"Desc_IronIngot_C":     "Iron Ingot",
+	"Desc_CopperIngot_C":   "Copper Ingot",
+	"Desc_GoldIngot_C":     "Caterium Ingot",
+	"Desc_SteelIngot_C":    "Steel Ingot",
+	"Desc_AluminumIngot_C": "Aluminum Ingot",
+	"Desc_FicsiteIngot_C":  "Ficsite Ingot",
+
+	// Minerals
+	"Desc_Cement_C":        "Concrete",
+	"Desc_QuartzCrystal_C": "Quartz Crystal",
+	"Desc_Silica_C":        "Silica",
+	"Desc_CopperDust_C":    "Copper Powder",
+	"Desc_AluminumScrap_C": "Aluminum Scrap",
+	"Desc_CompactedCoal_C": "Compacted Coal",
+	"Desc_SAMIngot_C":      "Reanimated SAM",
+	"Desc_Gunpowder_C":     "Black Powder",
+	"Desc_GunpowderMK2_C":  "Smokeless Powder",
+	"Desc_DarkMatter_C":    "Dark Matter Crystal",
+
+	// Standard parts
+	"Desc_IronPlate_C":            "Iron Plate",
+	"Desc_IronRod_C":              "Iron Rod",
+	"Desc_IronScrew_C":            "Screws",
+	"Desc_IronPlateReinforced_C":  "Reinforced Iron Plate",
+	"Desc_ModularFrame_C":         "Modular Frame",
+	"Desc_ModularFrameHeavy_C":    "Heavy Modular Frame",
+	"Desc_ModularFrameFused_C":    "Fused Modular Frame",
+	"Desc_CopperSheet_C":          "Copper Sheet",
+	"Desc_SteelPlate_C":           "Steel Beam",
+	"Desc_SteelPipe_C":            "Steel Pipe",
+	"Desc_SteelPlateReinforced_C": "Encased Industrial Beam",
+	"Desc_AluminumPlate_C":        "Alclad Aluminum Sheet",
+	"Desc_AluminumCasing_C":       "Aluminum Casing",
+	"Desc_FicsiteMesh_C":          "Ficsite Trigon",
+
+	// Oil derived
+	"Desc_PolymerResin_C":  "Polymer Resin",
+	"Desc_PetroleumCoke_C": "Petroleum Coke",
+	"Desc_Plastic_C":       "Plastic",
+	"Desc_Rubber_C":        "Rubber",
+
+	// Industrial parts
+	"Desc_Rotor_C":                     "Rotor",
+	"Desc_Stator_C":                    "Stator",
+	"Desc_Motor_C":                     "Motor",
+	"Desc_AluminumPlateReinforced_C":   "Heat Sink",
+	"Desc_CoolingSystem_C":             "Cooling System",
+	"Desc_MotorLightweight_C":          "Turbo Motor",
+	"Desc_Battery_C":                   "Battery",
+	"Desc_ElectromagneticControlRod_C": "Electromagnetic Control Rod",
+	"Desc_PressureConversionCube_C":    "Pressure Conversion Cube",
+
+	// Electronics
+	"Desc_Wire_C":                    "Wire",
+	"Desc_Cable_C":                   "Cable",
+	"Desc_HighSpeedWire_C":           "Quickwire",
+	"Desc_CircuitBoard_C":            "Circuit Board",
+	"Desc_CircuitBoardHighSpeed_C":   "AI Limiter",
+	"Desc_HighSpeedConnector_C":      "High-Speed Connector",
+	"Desc_Computer_C":                "Computer",
+	"Desc_ComputerSuper_C":           "Supercomputer",
+	"Desc_ModularFrameLightweight_C": "Radio Control Unit",
+	"Desc_CrystalOscillator_C":       "Crystal Oscillator",
+
+	// Fluids
+	"Desc_Water_C":           "Water",
+	"Desc_LiquidOil_C":       "Crude Oil",
+	"Desc_HeavyOilResidue_C": "Heavy Oil Residue",
+	"Desc_LiquidFuel_C":      "Fuel",
+	"Desc_LiquidBiofuel_C":   "Liquid Biofuel",
+	"Desc_LiquidTurboFuel_C": "Turbofuel",
+	"Desc_RocketFuel_C":      "Rocket Fuel",
+	"Desc_IonizedFuel_C":     "Ionized Fuel",
+	"Desc_AluminaSolution_C": "Alumina Solution",
+	"Desc_SulfuricAcid_C":    "Sulfuric Acid",
+	"Desc_NitrogenGas_C":     "Nitrogen Gas",
+	"Desc_NitricAcid_C":      "Nitric Acid",
+	"Desc_DissolvedSilica_C": "Dissolved Silica",
+	"Desc_QuantumEnergy_C":   "Excited Photonic Matter",
+	"Desc_DarkEnergy_C":      "Dark Matter Residue",
+
+	// Packaged fluids
+	"Desc_FluidCanister_C":        "Empty Canister",
+	"Desc_GasTank_C":              "Empty Fluid Tank",
+	"Desc_PackagedWater_C":        "Packaged Water",
+	"Desc_PackagedOil_C":          "Packaged Oil",
+	"Desc_PackagedOilResidue_C":   "Packaged Heavy Oil Residue",
+	"Desc_Fuel_C":                 "Packaged Fuel",
+	"Desc_PackagedBiofuel_C":      "Packaged Liquid Biofuel",
+	"Desc_TurboFuel_C":            "Packaged Turbofuel",
+	"Desc_PackagedRocketFuel_C":   "Packaged Rocket Fuel",
+	"Desc_PackagedIonizedFuel_C":  "Packaged Ionized Fuel",
+	"Desc_PackagedAlumina_C":      "Packaged Alumina Solution",
+	"Desc_PackagedSulfuricAcid_C": "Packaged Sulfuric Acid",
+	"Desc_PackagedNitrogenGas_C":  "Packaged Nitrogen Gas",
+	"Desc_PackagedNitricAcid_C":   "Packaged Nitric Acid",
+
+	// Biomass
+	"Desc_Leaves_C":          "Leaves",
+	"Desc_Wood_C":            "Wood",
+	"Desc_Mycelia_C":         "Mycelia",
+	"Desc_GenericBiomass_C":  "Biomass",
+	"Desc_Biofuel_C":         "Solid Biofuel",
+	"Desc_Fabric_C":          "Fabric",
+	"Desc_AlienProtein_C":    "Alien Protein",
+	"Desc_AlienDNACapsule_C": "Alien DNA Capsule",
+
+	// Nuclear
+	"Desc_UraniumCell_C":        "Encased Uranium Cell",
+	"Desc_NuclearFuelRod_C":     "Uranium Fuel Rod",
+	"Desc_NuclearWaste_C":       "Uranium Waste",
+	"Desc_NonFissibleUranium_C": "Non-Fissile Uranium",
+	"Desc_PlutoniumPellet_C":    "Plutonium Pellet",
+	"Desc_PlutoniumCell_C":      "Encased Plutonium Cell",
+	"Desc_PlutoniumFuelRod_C":   "Plutonium Fuel Rod",
+	"Desc_PlutoniumWaste_C":     "Plutonium Waste",
+	"Desc_Ficsonium_C":          "Ficsonium",
+	"Desc_FicsoniumFuelRod_C":   "Ficsonium Fuel Rod",
+
+	// Quantum
+	"Desc_TimeCrystal_C":       "Time Crystal",
+	"Desc_Diamond_C":           "Diamonds",
+	"Desc_QuantumOscillator_C": "Superposition Oscillator",
+	"Desc_TemporalProcessor_C": "Neural-Quantum Processor",
+	"Desc_SingularityCell_C":   "Singularity Cell",
+	"Desc_SAMFluctuator_C":     "SAM Fluctuator",
+	"Desc_AlienPowerFuel_C":    "Alien Power Matrix",
+
+	// Space elevator parts
+	"Desc_SpaceElevatorPart_1_C": "Smart Plating",
+	"Desc_SpaceElevatorPart_2_C": "Versatile Framework",
+	"Desc_SpaceElevatorPart_3_C": "Automated Wiring",
+	"Desc_SpaceElevatorPart_4_C": "Modular Engine",
+	"Desc_SpaceElevatorPart_5_C": "Adaptive Control Unit",
+	"Desc_SpaceElevatorPart_6_C": "Magnetic Field Generator",
+	"Desc_SpaceElevatorPart_7_C": "Assembly Director System",
+	"Desc_SpaceElevatorPart_8_C": "Thermal Propulsion Rocket",
+	"Desc_SpaceElevatorPart_9_C": "Nuclear Pasta",
+}
+
+// itemDisplayNames is the reverse of itemClassNames
+var itemDisplayNames = func() map[string]string {
+	names := make(map[string]string, len(itemClassNames))
+	for className, displayName := range itemClassNames {
+		names[displayName] = className
+	}
+	return names
+}()
+
+// CanonicalItemName returns the display name of an item given either its display name or class name.
+// Names that are not known are returned trimmed but otherwise unchanged.
+func CanonicalItemName(name string) string {
+	name = strings.TrimSpace(name)
+	if displayName, ok := itemClassNames[name]; ok {
+		return displayName
+	}
+	return name
+}
+
+// ItemClassName returns the class name of an item given either its display name or class name,
+// and whether it is known.
+func ItemClassName(name string) (string, bool) {
+	name = strings.TrimSpace(name)
+	if _, ok := itemClassNames[name]; ok {
+		return name, true
+	}
+	className, ok := itemDisplayNames[name]
+	return className, ok
+}
@@ -0,0 +1,68 @@
+package frm_client
+
+import "testing"
+
+func TestCanonicalItemName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Desc_IronPlate_C", "Iron Plate"},
+		{"Iron Plate", "Iron Plate"},
+		{"  Desc_Wire_C ", "Wire"},
+		{"Desc_IronPlateReinforced_C", "Reinforced Iron Plate"},
+		{"Desc_SpaceElevatorPart_1_C", "Smart Plating"},
+		{"Desc_Unknown_C", "Desc_Unknown_C"},
+		{"iron plate", "iron plate"}, // Case is left to the caller
+		{"", ""},
+	}
+	for _, test := range tests {
+		if got := CanonicalItemName(test.name); got != test.want {
+			t.Errorf("CanonicalItemName(%q) = %q, want %q", test.name, got, test.want)
+		}
+	}
+}
+
+func TestCanonicalItemNameJoinsMismatchedNames(t *testing.T) {
+	// Pairs of names different endpoints report for the same item
+	pairs := [][2]string{
+		{"Desc_IronIngot_C", "Iron Ingot"},
+		{"Desc_Wire_C", " Wire"},
+		{"Desc_SpaceElevatorPart_9_C", "Nuclear Pasta"},
+	}
+	for _, pair := range pairs {
+		if a, b := CanonicalItemName(pair[0]), CanonicalItemName(pair[1]); a != b {
+			t.Errorf("%q and %q normalize to %q and %q, want the same name", pair[0], pair[1], a, b)
+		}
+	}
+
+	// Lookalike names of different items must stay apart
+	distinct := [][2]string{
+		{"Desc_IronPlate_C", "Reinforced Iron Plate"},
+		{"Desc_IronPlateReinforced_C", "Iron Plate"},
+	}
+	for _, pair := range distinct {
+		if CanonicalItemName(pair[0]) == CanonicalItemName(pair[1]) {
+			t.Errorf("%q and %q normalize to the same name", pair[0], pair[1])
+		}
+	}
+}
+
+func TestItemClassName(t *testing.T) {
+	tests := []struct {
+		name      string
+		wantClass string
+		wantKnown bool
+	}{
+		{"Iron Plate", "Desc_IronPlate_C", true},
+		{"Desc_IronPlate_C", "Desc_IronPlate_C", true},
+		{" Wire ", "Desc_Wire_C", true},
+		{"Unobtainium", "", false},
+	}
+	for _, test := range tests {
+		className, known := ItemClassName(test.name)
+		if className != test.wantClass || known != test.wantKnown {
+			t.Errorf("ItemClassName(%q) = %q, %v, want %q, %v", test.name, className, known, test.wantClass, test.wantKnown)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package frm_client
+
+import "api/models/models"
+
+type itemMetadata struct {
+	Category models.ItemCategory
+	Tier     int
+}
+
+// itemMetadataTable maps canonical item names, as reported by FRM, to their category
+// and the tier they are first unlocked in.
+var itemMetadataTable = map[string]itemMetadata{
+	// Ores
+	"Iron Ore":     {models.ItemCategoryOre, 0},
+	"Copper Ore":   {models.ItemCategoryOre, 0},
+	"Limestone":    {models.ItemCategoryOre, 0},
+	"Coal":         {models.ItemCategoryOre, 3},
+	"Caterium Ore": {models.ItemCategoryOre, 2},
+	"Raw Quartz":   {models.ItemCategoryOre, 2},
+	"Sulfur":       {models.ItemCategoryOre, 2},
+	"Bauxite":      {models.ItemCategoryOre, 7},
+	"Uranium":      {models.ItemCategoryOre, 8},
+	"SAM":          {models.ItemCategoryOre, 0},
+
+	// Ingots
+	"Iron Ingot":     {models.ItemCategoryIngot, 0},
+	"Copper Ingot":   {models.ItemCategoryIngot, 0},
+	"Caterium Ingot": {models.ItemCategoryIngot, 2},
+	"Steel Ingot":    {models.ItemCategoryIngot, 3},
+	"Aluminum Ingot": {models.ItemCategoryIngot, 7},
+	"Ficsite Ingot":  {models.ItemCategoryIngot, 9},
+
+	// Minerals
+	"Concrete":            {models.ItemCategoryMineral, 0},
+	"Quartz Crystal":      {models.ItemCategoryMineral, 2},
+	"Silica":              {models.ItemCategoryMineral, 2},
+	"Copper Powder":       {models.ItemCategoryMineral, 8},
+	"Aluminum Scrap":      {models.ItemCategoryMineral, 7},
+	"Compacted Coal":      {models.ItemCategoryMineral, 5},
+	"Reanimated SAM":      {models.ItemCategoryMineral, 0},
+	"Black Powder":        {models.ItemCategoryAmmunition, 2},
+	"Smokeless Powder":    {models.ItemCategoryAmmunition, 5},
+	"Dark Matter Crystal": {models.ItemCategoryQuantum, 9},
+
+	// Standard parts
+	"Iron Plate":              {models.ItemCategoryStandardPart, 0},
+	"Iron Rod":                {models.ItemCategoryStandardPart, 0},
+	"Screws":                  {models.ItemCategoryStandardPart, 0},
+	"Reinforced Iron Plate":   {models.ItemCategoryStandardPart, 0},
+	"Modular Frame":           {models.ItemCategoryStandardPart, 2},
+	"Heavy Modular Frame":     {models.ItemCategoryStandardPart, 4},
+	"Fused Modular Frame":     {models.ItemCategoryStandardPart, 8},
+	"Copper Sheet":            {models.ItemCategoryStandardPart, 2},
+	"Steel Beam":              {models.ItemCategoryStandardPart, 3},
+	"Steel Pipe":              {models.ItemCategoryStandardPart, 3},
+	"Encased Industrial Beam": {models.ItemCategoryStandardPart, 4},
+	"Alclad Aluminum Sheet":   {models.ItemCategoryStandardPart, 7},
+	"Aluminum Casing":         {models.ItemCategoryStandardPart, 7},
+	"Ficsite Trigon":          {models.ItemCategoryStandardPart, 9},
+
+	// Oil derived
+	"Polymer Resin":  {models.ItemCategoryOilDerived, 5},
+	"Petroleum Coke": {models.ItemCategoryOilDerived, 5},
+	"Plastic":        {models.ItemCategoryOilDerived, 5},
+	"Rubber":         {models.ItemCategoryOilDerived, 5},
+
+	// Industrial parts
+	"Rotor":                       {models.ItemCategoryIndustrial, 2},
+	"Stator":                      {models.ItemCategoryIndustrial, 4},
+	"Motor":                       {models.ItemCategoryIndustrial, 4},
+	"Heat Sink":                   {models.ItemCategoryIndustrial, 7},
+	"Cooling System":              {models.ItemCategoryIndustrial, 7},
+	"Turbo Motor":                 {models.ItemCategoryIndustrial, 8},
+	"Battery":                     {models.ItemCategoryIndustrial, 7},
+	"Electromagnetic Control Rod": {models.ItemCategoryNuclear, 8},
+
+	// Electronics
+	"Wire":                 {models.ItemCategoryElectronic, 0},
+	"Cable":                {models.ItemCategoryElectronic, 0},
+	"Quickwire":            {models.ItemCategoryElectronic, 2},
+	"Circuit Board":        {models.ItemCategoryElectronic, 5},
+	"AI Limiter":           {models.ItemCategoryElectronic, 5},
+	"High-Speed Connector": {models.ItemCategoryElectronic, 5},
+	"Computer":             {models.ItemCategoryCommunication, 5},
+	"Supercomputer":        {models.ItemCategoryCommunication, 8},
+	"Radio Control Unit":   {models.ItemCategoryCommunication, 7},
+	"Crystal Oscillator":   {models.ItemCategoryCommunication, 6},
+
+	// Fluids
+	"Water":                   {models.ItemCategoryFluid, 0},
+	"Crude Oil":               {models.ItemCategoryFluid, 5},
+	"Heavy Oil Residue":       {models.ItemCategoryFluid, 5},
+	"Fuel":                    {models.ItemCategoryFuel, 5},
+	"Liquid Biofuel":          {models.ItemCategoryFuel, 5},
+	"Turbofuel":               {models.ItemCategoryFuel, 6},
+	"Rocket Fuel":             {models.ItemCategoryFuel, 8},
+	"Ionized Fuel":            {models.ItemCategoryFuel, 9},
+	"Alumina Solution":        {models.ItemCategoryFluid, 7},
+	"Sulfuric Acid":           {models.ItemCategoryFluid, 7},
+	"Nitrogen Gas":            {models.ItemCategoryFluid, 8},
+	"Nitric Acid":             {models.ItemCategoryFluid, 8},
+	"Dissolved Silica":        {models.ItemCategoryFluid, 8},
+	"Excited Photonic Matter": {models.ItemCategoryQuantum, 9},
+	"Dark Matter Residue":     {models.ItemCategoryQuantum, 9},
+
+	// Packaged fluids
+	"Empty Canister":             {models.ItemCategoryPackaged, 5},
+	"Empty Fluid Tank":           {models.ItemCategoryPackaged, 7},
+	"Packaged Water":             {models.ItemCategoryPackaged, 5},
+	"Packaged Oil":               {models.ItemCategoryPackaged, 5},
+	"Packaged Heavy Oil Residue": {models.ItemCategoryPackaged, 5},
+	"Packaged Fuel":              {models.ItemCategoryPackaged, 5},
+	"Packaged Liquid Biofuel":    {models.ItemCategoryPackaged, 5},
+	"Packaged Turbofuel":         {models.ItemCategoryPackaged, 6},
+	"Packaged Rocket Fuel":       {models.ItemCategoryPackaged, 8},
+	"Packaged Ionized Fuel":      {models.ItemCategoryPackaged, 9},
+	"Packaged Alumina Solution":  {models.ItemCategoryPackaged, 7},
+	"Packaged Sulfuric Acid":     {models.ItemCategoryPackaged, 7},
+	"Packaged Nitrogen Gas":      {models.ItemCategoryPackaged, 8},
+	"Packaged Nitric Acid":       {models.ItemCategoryPackaged, 8},
+
+	// Biomass
+	"Leaves":            {models.ItemCategoryBiomass, 0},
+	"Wood":              {models.ItemCategoryBiomass, 0},
+	"Mycelia":           {models.ItemCategoryBiomass, 0},
+	"Biomass":           {models.ItemCategoryBiomass, 0},
+	"Solid Biofuel":     {models.ItemCategoryBiomass, 0},
+	"Fabric":            {models.ItemCategoryBiomass, 5},
+	"Alien Protein":     {models.ItemCategoryBiomass, 0},
+	"Alien DNA Capsule": {models.ItemCategoryBiomass, 0},
+
+	// Nuclear
+	"Encased Uranium Cell":   {models.ItemCategoryNuclear, 8},
+	"Uranium Fuel Rod":       {models.ItemCategoryNuclear, 8},
+	"Uranium Waste":          {models.ItemCategoryNuclear, 8},
+	"Non-Fissile Uranium":    {models.ItemCategoryNuclear, 8},
+	"Plutonium Pellet":       {models.ItemCategoryNuclear, 8},
+	"Encased Plutonium Cell": {models.ItemCategoryNuclear, 8},
+	"Plutonium Fuel Rod":     {models.ItemCategoryNuclear, 8},
+	"Plutonium Waste":        {models.ItemCategoryNuclear, 8},
+	"Ficsonium":              {models.ItemCategoryNuclear, 9},
+	"Ficsonium Fuel Rod":     {models.ItemCategoryNuclear, 9},
+
+	// Quantum
+	"Time Crystal":             {models.ItemCategoryQuantum, 9},
+	"Diamonds":                 {models.ItemCategoryQuantum, 9},
+	"Superposition Oscillator": {models.ItemCategoryQuantum, 9},
+	"Neural-Quantum Processor": {models.ItemCategoryQuantum, 9},
+	"AI Expansion Server":      {models.ItemCategoryQuantum, 9},
+	"Singularity Cell":         {models.ItemCategoryQuantum, 9},
+	"SAM Fluctuator":           {models.ItemCategoryQuantum, 9},
+	"Alien Power Matrix":       {models.ItemCategoryQuantum, 9},
+
+	// Space elevator parts
+	"Smart Plating":             {models.ItemCategorySpaceElevator, 2},
+	"Versatile Framework":       {models.ItemCategorySpaceElevator, 4},
+	"Automated Wiring":          {models.ItemCategorySpaceElevator, 4},
+	"Modular Engine":            {models.ItemCategorySpaceElevator, 6},
+	"Adaptive Control Unit":     {models.ItemCategorySpaceElevator, 6},
+	"Assembly Director System":  {models.ItemCategorySpaceElevator, 8},
+	"Magnetic Field Generator":  {models.ItemCategorySpaceElevator, 8},
+	"Thermal Propulsion Rocket": {models.ItemCategorySpaceElevator, 8},
+	"Nuclear Pasta":             {models.ItemCategorySpaceElevator, 8},
+	"Biochemical Sculptor":      {models.ItemCategorySpaceElevator, 9},
+	"Ballistic Warp Drive":      {models.ItemCategorySpaceElevator, 9},
+	"Pressure Conversion Cube":  {models.ItemCategoryIndustrial, 8},
+}
+
+// lookupItemMetadata returns the metadata for an item, falling back to the
+// "other" category for items missing from the table.
+func lookupItemMetadata(name string) itemMetadata {
+	if metadata, ok := itemMetadataTable[name]; ok {
+		return metadata
+	}
+	return itemMetadata{Category: models.ItemCategoryOther}
+}
+
+// gasItems lists the fluids that are gases rather than liquids
+var gasItems = map[string]bool{
+	"Nitrogen Gas":            true,
+	"Excited Photonic Matter": true,
+	"Dark Matter Residue":     true,
+}
+
+// itemForm returns whether an item is a solid, liquid or gas
+func itemForm(name string, category models.ItemCategory) models.ResourceForm {
+	if gasItems[name] {
+		return models.ResourceFormGas
+	}
+	if category == models.ItemCategoryFluid || category == models.ItemCategoryFuel {
+		return models.ResourceFormLiquid
+	}
+	return models.ResourceFormSolid
+}
@@ -0,0 +1,38 @@
+package frm_client
+
+import (
+	"api/models/models"
+	"testing"
+)
+
+func TestParseItemStatsMetadata(t *testing.T) {
+	tests := []struct {
+		name     string
+		category models.ItemCategory
+		form     models.ResourceForm
+		tier     int
+	}{
+		{"Iron Ore", models.ItemCategoryOre, models.ResourceFormSolid, 0},
+		{"Steel Ingot", models.ItemCategoryIngot, models.ResourceFormSolid, 3},
+		{"Computer", models.ItemCategoryCommunication, models.ResourceFormSolid, 5},
+		{"Water", models.ItemCategoryFluid, models.ResourceFormLiquid, 0},
+		{"Fuel", models.ItemCategoryFuel, models.ResourceFormLiquid, 5},
+		{"Packaged Fuel", models.ItemCategoryPackaged, models.ResourceFormSolid, 5},
+		{"Nitrogen Gas", models.ItemCategoryFluid, models.ResourceFormGas, 8},
+		{"Dark Matter Residue", models.ItemCategoryQuantum, models.ResourceFormGas, 9}, // Gas outside the fluid category
+		{"Mystery Part", models.ItemCategoryOther, models.ResourceFormSolid, 0},        // Missing from the table
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stats := parseItemStats(test.name, 12)
+			if stats.Name != test.name || stats.Count != 12 {
+				t.Errorf("got %s x%v, want %s x12", stats.Name, stats.Count, test.name)
+			}
+			if stats.Category != test.category || stats.Form != test.form || stats.Tier != test.tier {
+				t.Errorf("got category %s, form %s, tier %d, want %s, %s, %d",
+					stats.Category, stats.Form, stats.Tier, test.category, test.form, test.tier)
+			}
+		})
+	}
+}
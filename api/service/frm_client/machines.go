@@ -8,12 +8,32 @@ import (
 	"sync"
 )
 
-// GetMachines fetches all machines: factory, extractors, and generators
+// machineSources is the number of FRM endpoints GetMachines combines
+const machineSources = 3
+
+// GetMachines fetches all machines: factory, extractors, and generators.
+// It is all-or-nothing, failing if any of the sub-fetches fails.
 func (client *Client) GetMachines(ctx context.Context) ([]models.Machine, error) {
+	return client.getMachines(ctx, true)
+}
+
+// getMachines fetches all machines. Unless strict, the machines of the successful sub-fetches are
+// returned together with a *models.PartialDataError naming the failed ones, as long as one succeeded.
+func (client *Client) getMachines(ctx context.Context, strict bool) ([]models.Machine, error) {
 	var machines []models.Machine
 	var wg sync.WaitGroup
 	var mu sync.Mutex
 	var firstError error
+	var failed []string
+
+	fail := func(source string, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if firstError == nil {
+			firstError = fmt.Errorf("failed to get %s. details: %w", source, err)
+		}
+		failed = append(failed, source)
+	}
 
 	// Helper to determine machine status based on FRM API boolean flags
 	machineStatus := func(isConfigured, isProducing, isPaused bool) models.MachineStatus {
@@ -36,11 +56,7 @@ func (client *Client) GetMachines(ctx context.Context) ([]models.Machine, error)
 		var rawExtractors []frm_models.Extractor
 		err := client.makeSatisfactoryCall(ctx, "/getExtractor", &rawExtractors)
 		if err != nil {
-			mu.Lock()
-			if firstError == nil {
-				firstError = fmt.Errorf("failed to get extractors. details: %w", err)
-			}
-			mu.Unlock()
+			fail("extractors", err)
 			return
 		}
 
@@ -53,6 +69,7 @@ func (client *Client) GetMachines(ctx context.Context) ([]models.Machine, error)
 			}
 
 			machine := models.Machine{
+				ID:           machineID(raw.ID, raw.Name, raw.Location),
 				Type:         models.MachineType(raw.Name),
 				Category:     models.MachineCategoryExtractor,
 				Status:       machineStatus(raw.IsConfigured, raw.IsProducing, raw.IsPaused),
@@ -85,11 +102,7 @@ func (client *Client) GetMachines(ctx context.Context) ([]models.Machine, error)
 		var rawFactories []frm_models.FactoryMachine
 		err := client.makeSatisfactoryCall(ctx, "/getFactory", &rawFactories)
 		if err != nil {
-			mu.Lock()
-			if firstError == nil {
-				firstError = fmt.Errorf("failed to get factory machines. details: %w", err)
-			}
-			mu.Unlock()
+			fail("factory machines", err)
 			return
 		}
 
@@ -99,6 +112,7 @@ func (client *Client) GetMachines(ctx context.Context) ([]models.Machine, error)
 			status := machineStatus(raw.IsConfigured, raw.IsProducing, raw.IsPaused)
 
 			machine := models.Machine{
+				ID:           machineID(raw.ID, raw.Name, raw.Location),
 				Type:         models.MachineType(raw.Name),
 				Category:     models.MachineCategoryFactory,
 				Status:       status,
@@ -150,11 +164,7 @@ func (client *Client) GetMachines(ctx context.Context) ([]models.Machine, error)
 		var rawGenerators []frm_models.Generator
 		err := client.makeSatisfactoryCall(ctx, "/getGenerators", &rawGenerators)
 		if err != nil {
-			mu.Lock()
-			if firstError == nil {
-				firstError = fmt.Errorf("failed to get generators. details: %w", err)
-			}
-			mu.Unlock()
+			fail("generators", err)
 			return
 		}
 
@@ -189,6 +199,7 @@ func (client *Client) GetMachines(ctx context.Context) ([]models.Machine, error)
 			maxPower := maxPowerByType(&raw, genType)
 
 			machine := models.Machine{
+				ID:           machineID(raw.ID, raw.Name, raw.Location),
 				Type:         models.MachineType(raw.Name),
 				Category:     models.MachineCategoryGenerator,
 				Status:       generatorStatus(power, maxPower),
@@ -196,7 +207,7 @@ func (client *Client) GetMachines(ctx context.Context) ([]models.Machine, error)
 				Location:     parseLocation(raw.Location),
 				BoundingBox:  parseBoundingBox(raw.BoundingBox),
 				CircuitIDs:   parseCircuitIDs(raw.CircuitID),
-				Input:        []models.MachineProdStats{},
+				Input:        make([]models.MachineProdStats, 0, len(raw.FuelInventory)),
 				Output: []models.MachineProdStats{
 					{
 						Name:    "Power",
@@ -205,6 +216,15 @@ func (client *Client) GetMachines(ctx context.Context) ([]models.Machine, error)
 					},
 				},
 			}
+			for _, fuel := range raw.FuelInventory {
+				machine.Input = append(machine.Input, models.MachineProdStats{
+					Name:       fuel.Name,
+					Stored:     fuel.Amount,
+					Current:    fuel.CurrentConsumed,
+					Max:        fuel.MaxConsumed,
+					Efficiency: fuel.ConsPercent / 100.0,
+				})
+			}
 			machines = append(machines, machine)
 		}
 	}()
@@ -212,7 +232,10 @@ func (client *Client) GetMachines(ctx context.Context) ([]models.Machine, error)
 	wg.Wait()
 
 	if firstError != nil {
-		return nil, firstError
+		if strict || len(failed) == machineSources {
+			return nil, firstError
+		}
+		return machines, models.NewPartialDataError(failed, firstError)
 	}
 
 	return machines, nil
@@ -0,0 +1,118 @@
+package frm_client
+
+import (
+	"api/models/models"
+	"api/service/frm_client/frm_models"
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestGetMachinesPartialWhenExtractorsFail(t *testing.T) {
+	// The stub answers /getExtractor with a 404
+	client := newStubClient(t, map[string]any{
+		"/getFactory": []frm_models.FactoryMachine{
+			{ID: "1", Name: "Constructor", IsConfigured: true, IsProducing: true},
+			{ID: "2", Name: "Assembler", IsConfigured: true},
+		},
+		"/getGenerators": []frm_models.Generator{},
+	})
+
+	machines, err := client.getMachines(context.Background(), false)
+	var partialErr *models.PartialDataError
+	if !errors.As(err, &partialErr) {
+		t.Fatalf("got error %v, want a PartialDataError", err)
+	}
+	if !reflect.DeepEqual(partialErr.Failed, []string{"extractors"}) {
+		t.Errorf("got failed sources %v, want [extractors]", partialErr.Failed)
+	}
+	if len(machines) != 2 {
+		t.Errorf("got %d machines, want the 2 factory machines", len(machines))
+	}
+
+	// The event stream emits the machines that could be fetched, flagged as partial
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	events := make(chan *models.SatisfactoryEvent, 1)
+	err = client.SetupEventStream(ctx, func(event *models.SatisfactoryEvent) {
+		if event.Type != models.SatisfactoryEventMachines {
+			return
+		}
+		select {
+		case events <- event:
+		default:
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case event := <-events:
+		if data, _ := event.Data.([]models.Machine); !event.Partial || len(data) != 2 {
+			t.Errorf("got a machines event with %d machines, partial %v, want 2 machines flagged partial", len(data), event.Partial)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no machines event emitted")
+	}
+	cancel()
+
+	machines, err = client.GetMachines(context.Background())
+	if err == nil || errors.As(err, &partialErr) || machines != nil {
+		t.Errorf("strict mode got %d machines with error %v, want none and a plain error", len(machines), err)
+	}
+}
+
+func TestGetMachinesFailsWhenAllSourcesFail(t *testing.T) {
+	client := newStubClient(t, map[string]any{})
+
+	machines, err := client.getMachines(context.Background(), false)
+	var partialErr *models.PartialDataError
+	if err == nil || errors.As(err, &partialErr) || machines != nil {
+		t.Errorf("got %d machines with error %v, want none and a plain error", len(machines), err)
+	}
+}
+
+func TestGetMachinesGeneratorFuelAsInput(t *testing.T) {
+	client := newStubClient(t, map[string]any{
+		"/getFactory":   []frm_models.FactoryMachine{},
+		"/getExtractor": []frm_models.Extractor{},
+		"/getGenerators": []frm_models.Generator{
+			{ID: "coal", Name: "Coal-Powered Generator", BaseProd: 75, RegulatedDemandProd: 60, FuelInventory: []frm_models.Ingredient{
+				{Name: "Coal", Amount: 40, CurrentConsumed: 12, MaxConsumed: 15, ConsPercent: 80},
+				{Name: "Water", Amount: 10, CurrentConsumed: 36, MaxConsumed: 45, ConsPercent: 80},
+			}},
+			{ID: "geo", Name: "Geothermal Generator", ProductionCapacity: 200},
+		},
+	})
+
+	machines, err := client.getMachines(context.Background(), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(machines) != 2 {
+		t.Fatalf("got %d machines, want both generators", len(machines))
+	}
+	byID := make(map[string]models.Machine)
+	for _, machine := range machines {
+		byID[machine.ID] = machine
+	}
+
+	coal := byID["coal"]
+	expected := []models.MachineProdStats{
+		{Name: "Coal", Stored: 40, Current: 12, Max: 15, Efficiency: 0.8},
+		{Name: "Water", Stored: 10, Current: 36, Max: 45, Efficiency: 0.8},
+	}
+	if !reflect.DeepEqual(coal.Input, expected) {
+		t.Errorf("got inputs %+v, want %+v", coal.Input, expected)
+	}
+	if coal.Category != models.MachineCategoryGenerator || len(coal.Output) != 1 || coal.Output[0].Name != "Power" {
+		t.Errorf("got %+v, want a generator producing power", coal)
+	}
+
+	// Geothermal burns nothing, but still reports an empty input list
+	if geo := byID["geo"]; geo.Input == nil || len(geo.Input) != 0 {
+		t.Errorf("got inputs %#v, want an empty list", geo.Input)
+	}
+}
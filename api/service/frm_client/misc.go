@@ -5,6 +5,7 @@ import (
 	"api/service/frm_client/frm_models"
 	"context"
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 	"time"
@@ -21,11 +22,13 @@ func (client *Client) ListStorageContainers(ctx context.Context) ([]models.Stora
 	storages := make([]models.Storage, len(rawStorages))
 	for i, raw := range rawStorages {
 		inventory := make([]models.ItemStats, len(raw.Inventory))
+		capacities := make(map[string]float64, len(raw.Inventory))
+		var stored, capacity float64
 		for j, item := range raw.Inventory {
-			inventory[j] = models.ItemStats{
-				Name:  item.Name,
-				Count: float64(item.Amount),
-			}
+			inventory[j] = parseItemStats(item.Name, float64(item.Amount))
+			capacities[item.Name] += float64(max(item.MaxAmount, 0))
+			stored += float64(item.Amount)
+			capacity += float64(max(item.MaxAmount, 0))
 		}
 
 		storages[i] = models.Storage{
@@ -34,11 +37,21 @@ func (client *Client) ListStorageContainers(ctx context.Context) ([]models.Stora
 			Location:    parseLocation(raw.Location),
 			BoundingBox: parseBoundingBox(raw.BoundingBox),
 			Inventory:   inventory,
+			FillPercent: fillRatio(stored, capacity),
+			Capacities:  capacities,
 		}
 	}
 	return storages, nil
 }
 
+// fillRatio returns stored / capacity clamped to 0-1, or 0 when there is no known capacity
+func fillRatio(stored, capacity float64) float64 {
+	if capacity <= 0 {
+		return 0
+	}
+	return math.Max(0, math.Min(stored/capacity, 1))
+}
+
 // GetSpaceElevator fetches space elevator data (single object, not array)
 func (client *Client) GetSpaceElevator(ctx context.Context) (*models.SpaceElevator, error) {
 	var rawList []frm_models.SpaceElevator
@@ -67,13 +80,14 @@ func (client *Client) GetSpaceElevator(ctx context.Context) (*models.SpaceElevat
 	}
 
 	return &models.SpaceElevator{
-		ID:            raw.ID,
-		Name:          raw.Name,
-		Location:      parseLocation(raw.Location),
-		BoundingBox:   parseBoundingBox(raw.BoundingBox),
-		CurrentPhase:  phases,
-		FullyUpgraded: raw.FullyUpgraded,
-		UpgradeReady:  raw.UpgradeReady,
+		ID:                  raw.ID,
+		Name:                raw.Name,
+		Location:            parseLocation(raw.Location),
+		BoundingBox:         parseBoundingBox(raw.BoundingBox),
+		CurrentPhase:        phases,
+		FullyUpgraded:       raw.FullyUpgraded,
+		UpgradeReady:        raw.UpgradeReady,
+		EstimatedCompletion: client.elevatorProgress.apply(phases),
 	}, nil
 }
 
@@ -113,26 +127,55 @@ func (client *Client) GetHub(ctx context.Context) (*models.Hub, error) {
 	}
 
 	// Only include milestone data if there's an active milestone
-	if raw.HasActiveMilestone && raw.ActiveMilestone.Type != "No Milestone Selected" {
-		costs := make([]models.HubMilestoneCost, len(raw.ActiveMilestone.Cost))
-		for i, c := range raw.ActiveMilestone.Cost {
-			// Bug in FRM api, Amount is same as TotalCost, so we use RemainingCost instead
-			costs[i] = models.HubMilestoneCost{
-				Name:          c.Name,
-				Amount:        c.TotalCost - c.RemainingCost,
-				RemainingCost: c.RemainingCost,
-				TotalCost:     c.TotalCost,
-			}
+	if raw.HasActiveMilestone {
+		hub.ActiveMilestone = parseHubMilestone(raw.ActiveMilestone)
+	}
+
+	return hub, nil
+}
+
+// parseHubMilestone converts the active milestone, returning nil for "No Milestone Selected".
+// ProgressPercent is the mean of the per-item progress, so every item weighs the same
+// regardless of how many of it the milestone needs.
+func parseHubMilestone(raw frm_models.HubTerminalMilestone) *models.HubMilestone {
+	if raw.Type == "No Milestone Selected" {
+		return nil
+	}
+
+	costs := make([]models.HubMilestoneCost, len(raw.Cost))
+	var progress float64
+	completable := true
+	for i, c := range raw.Cost {
+		// Bug in FRM api, Amount is same as TotalCost, so we use RemainingCost instead
+		remaining := max(c.RemainingCost, 0)
+		costs[i] = models.HubMilestoneCost{
+			Name:          c.Name,
+			Amount:        c.TotalCost - remaining,
+			RemainingCost: remaining,
+			TotalCost:     c.TotalCost,
 		}
-		hub.ActiveMilestone = &models.HubMilestone{
-			Name:     raw.ActiveMilestone.Name,
-			TechTier: raw.ActiveMilestone.TechTier,
-			Type:     raw.ActiveMilestone.Type,
-			Cost:     costs,
+
+		if c.TotalCost > 0 {
+			progress += fillRatio(c.TotalCost-remaining, c.TotalCost)
+		} else {
+			progress++
+		}
+		if remaining > 0 {
+			completable = false
 		}
 	}
 
-	return hub, nil
+	milestone := &models.HubMilestone{
+		Name:        raw.Name,
+		TechTier:    raw.TechTier,
+		Type:        raw.Type,
+		Cost:        costs,
+		Completable: completable,
+	}
+	if len(costs) > 0 {
+		milestone.ProgressPercent = progress / float64(len(costs))
+	}
+	return milestone
 }
 
 // parseDuration parses a duration string in "HH:MM:SS" format and returns a time.Duration
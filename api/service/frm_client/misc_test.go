@@ -0,0 +1,128 @@
+package frm_client
+
+import (
+	"api/models/models"
+	"api/service/frm_client/frm_models"
+	"context"
+	"math"
+	"testing"
+)
+
+func TestListStorageContainersFillPercent(t *testing.T) {
+	client := newStubClient(t, map[string]any{
+		"/getStorageInv": []frm_models.Storage{
+			{ID: "industrial", Name: string(models.StorageTypeIndustrialStorageContainer), Inventory: []frm_models.StorageInventoryItem{
+				{Name: "Iron Plate", Amount: 4700, MaxAmount: 4800},
+				{Name: "Iron Plate", Amount: 200, MaxAmount: 200},
+				{Name: "Screw", Amount: 480, MaxAmount: 500},
+			}},
+			{ID: "personal", Name: string(models.StorageTypePersonalStorageBox), Inventory: []frm_models.StorageInventoryItem{}},
+			{ID: "unknown", Name: string(models.StorageTypeStorageContainer), Inventory: []frm_models.StorageInventoryItem{
+				{Name: "Wire", Amount: 100},
+			}},
+		},
+	})
+
+	storages, err := client.ListStorageContainers(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		id             string
+		wantFill       float64
+		wantCapacities map[string]float64
+	}{
+		{"industrial", 5380.0 / 5500, map[string]float64{"Iron Plate": 5000, "Screw": 500}},
+		{"personal", 0, map[string]float64{}},
+		{"unknown", 0, map[string]float64{"Wire": 0}}, // No capacity reported
+	}
+	for i, test := range tests {
+		t.Run(test.id, func(t *testing.T) {
+			storage := storages[i]
+			if storage.ID != test.id {
+				t.Fatalf("got storage %s, want %s", storage.ID, test.id)
+			}
+			if math.Abs(storage.FillPercent-test.wantFill) > 1e-9 {
+				t.Errorf("got fill %v, want %v", storage.FillPercent, test.wantFill)
+			}
+			if len(storage.Capacities) != len(test.wantCapacities) {
+				t.Errorf("got capacities %v, want %v", storage.Capacities, test.wantCapacities)
+			}
+			for name, want := range test.wantCapacities {
+				if got, ok := storage.Capacities[name]; !ok || got != want {
+					t.Errorf("got capacity %v of %s, want %v", got, name, want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseHubMilestone(t *testing.T) {
+	tests := []struct {
+		name            string
+		raw             frm_models.HubTerminalMilestone
+		wantNil         bool
+		wantProgress    float64
+		wantCompletable bool
+	}{
+		{
+			name:    "no milestone selected",
+			raw:     frm_models.HubTerminalMilestone{Type: "No Milestone Selected"},
+			wantNil: true,
+		},
+		{
+			name: "items weigh the same regardless of their cost",
+			raw: frm_models.HubTerminalMilestone{Type: "Milestone", Cost: []frm_models.HubTerminalCostItem{
+				{Name: "Iron Plate", TotalCost: 1000, RemainingCost: 0},
+				{Name: "Rotor", TotalCost: 10, RemainingCost: 10},
+			}},
+			wantProgress: 0.5,
+		},
+		{
+			name: "fully submitted",
+			raw: frm_models.HubTerminalMilestone{Type: "Milestone", Cost: []frm_models.HubTerminalCostItem{
+				{Name: "Iron Plate", TotalCost: 100, RemainingCost: 0},
+				{Name: "Rotor", TotalCost: 10, RemainingCost: -2}, // Oversubmitted
+			}},
+			wantProgress:    1,
+			wantCompletable: true,
+		},
+		{
+			name: "items without a cost count as done",
+			raw: frm_models.HubTerminalMilestone{Type: "Milestone", Cost: []frm_models.HubTerminalCostItem{
+				{Name: "Wire", TotalCost: 0},
+				{Name: "Cable", TotalCost: 100, RemainingCost: 75},
+			}},
+			wantProgress: 0.625,
+		},
+		{
+			name:            "no cost items",
+			raw:             frm_models.HubTerminalMilestone{Type: "Milestone"},
+			wantCompletable: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			milestone := parseHubMilestone(test.raw)
+			if test.wantNil {
+				if milestone != nil {
+					t.Errorf("got %+v, want no milestone", milestone)
+				}
+				return
+			}
+			if milestone == nil {
+				t.Fatal("got no milestone")
+			}
+			if math.Abs(milestone.ProgressPercent-test.wantProgress) > 1e-9 || milestone.Completable != test.wantCompletable {
+				t.Errorf("got progress %v and completable %v, want %v and %v", milestone.ProgressPercent, milestone.Completable, test.wantProgress, test.wantCompletable)
+			}
+			for _, cost := range milestone.Cost {
+				if cost.RemainingCost < 0 || cost.Amount > cost.TotalCost {
+					t.Errorf("cost %+v submitted beyond its total", cost)
+				}
+			}
+		})
+	}
+}
@@ -25,10 +25,7 @@ func (client *Client) ListPlayers(ctx context.Context) ([]models.Player, error)
 
 		playerItems := make([]models.ItemStats, len(raw.Inventory))
 		for i, item := range raw.Inventory {
-			playerItems[i] = models.ItemStats{
-				Name:  item.Name,
-				Count: item.Amount,
-			}
+			playerItems[i] = parseItemStats(item.Name, item.Amount)
 		}
 		// Sort items by count descending
 		sort.Slice(playerItems, func(i, j int) bool {
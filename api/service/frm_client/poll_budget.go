@@ -0,0 +1,184 @@
+package frm_client
+
+import (
+	"api/models/models"
+	"api/pkg/config"
+	"api/pkg/log"
+	"sync"
+	"time"
+)
+
+const pollBudgetWindow = time.Minute
+
+type pollPriority int
+
+const (
+	pollPriorityLow pollPriority = iota
+	pollPriorityNormal
+	pollPriorityCritical
+)
+
+// pollPriorities ranks the endpoints shed first when the poll budget runs low, unlisted ones are normal.
+// Critical endpoints feed the API status and the history, low ones change slowly.
+var pollPriorities = map[models.SatisfactoryEventType]pollPriority{
+	models.SatisfactoryEventApiStatus:      pollPriorityCritical,
+	models.SatisfactoryEventCircuits:       pollPriorityCritical,
+	models.SatisfactoryEventFactoryStats:   pollPriorityCritical,
+	models.SatisfactoryEventProdStats:      pollPriorityCritical,
+	models.SatisfactoryEventGeneratorStats: pollPriorityCritical,
+	models.SatisfactoryEventSinkStats:      pollPriorityCritical,
+	models.SatisfactoryEventBelts:          pollPriorityLow,
+	models.SatisfactoryEventPipes:          pollPriorityLow,
+	models.SatisfactoryEventTrainRails:     pollPriorityLow,
+	models.SatisfactoryEventCables:         pollPriorityLow,
+	models.SatisfactoryEventHypertubes:     pollPriorityLow,
+	models.SatisfactoryEventStorages:       pollPriorityLow,
+	models.SatisfactoryEventVehiclePaths:   pollPriorityLow,
+	models.SatisfactoryEventSpaceElevator:  pollPriorityLow,
+	models.SatisfactoryEventHub:            pollPriorityLow,
+	models.SatisfactoryEventResourceNodes:  pollPriorityLow,
+	models.SatisfactoryEventSchematics:     pollPriorityLow,
+}
+
+// requestPriority maps the poll priority of an endpoint to its request queue lane, so slow
+// low priority endpoints such as the infrastructure ones never delay the others
+func requestPriority(eventType models.SatisfactoryEventType) RequestPriority {
+	if pollPriorities[eventType] == pollPriorityLow {
+		return PriorityLow
+	}
+	return PriorityHigh
+}
+
+// pollPriorityShares is the share of the budget that may be used before endpoints of a priority are skipped
+var pollPriorityShares = map[pollPriority]float64{
+	pollPriorityLow:      0.5,
+	pollPriorityNormal:   0.8,
+	pollPriorityCritical: 1,
+}
+
+// pollBudget caps the requests a client makes per window. As the budget runs low, polls of the
+// least critical endpoints are skipped first, which lengthens their effective interval until the
+// window resets. Fan-out endpoints make several requests per poll, so a window can overshoot the
+// limit by the requests of polls already allowed.
+type pollBudget struct {
+	mu          sync.Mutex
+	now         func() time.Time
+	limit       int
+	window      time.Duration
+	windowStart time.Time
+	used        int
+	reported    *models.PollBudget // Last status returned by Observe
+}
+
+// newPollBudget returns a budget of limit requests per window, or nil if limit is 0
+func newPollBudget(now func() time.Time, limit int, window time.Duration) *pollBudget {
+	if limit <= 0 {
+		return nil
+	}
+	return &pollBudget{
+		now:         now,
+		limit:       limit,
+		window:      window,
+		windowStart: now(),
+	}
+}
+
+// configuredPollBudget returns the budget configured per minute, or nil if none is
+func configuredPollBudget() *pollBudget {
+	if config.Config == nil {
+		return nil
+	}
+	return newPollBudget(time.Now, config.Config.PollBudgetPerMinute, pollBudgetWindow)
+}
+
+// Allow returns whether an endpoint may be polled now. A nil budget allows everything.
+func (budget *pollBudget) Allow(eventType models.SatisfactoryEventType) bool {
+	if budget == nil {
+		return true
+	}
+	budget.mu.Lock()
+	defer budget.mu.Unlock()
+
+	budget.resetIfElapsed()
+	priority, ok := pollPriorities[eventType]
+	if !ok {
+		priority = pollPriorityNormal
+	}
+	return float64(budget.used) < pollPriorityShares[priority]*float64(budget.limit)
+}
+
+// Spend records a request made to the game server
+func (budget *pollBudget) Spend() {
+	if budget == nil {
+		return
+	}
+	budget.mu.Lock()
+	defer budget.mu.Unlock()
+
+	budget.resetIfElapsed()
+	budget.used++
+}
+
+// Observe returns the budget status when its window reset or its throttle changed since the last call
+func (budget *pollBudget) Observe() (*models.PollBudget, bool) {
+	if budget == nil {
+		return nil, false
+	}
+	budget.mu.Lock()
+	defer budget.mu.Unlock()
+
+	budget.resetIfElapsed()
+	status := budget.status()
+	previous := budget.reported
+	if previous != nil && previous.ResetsAt.Equal(status.ResetsAt) && previous.Throttle == status.Throttle {
+		return nil, false
+	}
+	budget.reported = &status
+	if previous == nil || previous.Throttle != status.Throttle {
+		log.Infof("Poll budget throttle is now %s (%d/%d requests used)", status.Throttle, status.Used, status.Limit)
+	}
+	return &status, true
+}
+
+// Status returns the current budget status, or nil for a nil budget
+func (budget *pollBudget) Status() *models.PollBudget {
+	if budget == nil {
+		return nil
+	}
+	budget.mu.Lock()
+	defer budget.mu.Unlock()
+
+	budget.resetIfElapsed()
+	status := budget.status()
+	return &status
+}
+
+func (budget *pollBudget) resetIfElapsed() {
+	now := budget.now()
+	if now.Sub(budget.windowStart) < budget.window {
+		return
+	}
+	budget.windowStart = now
+	budget.used = 0
+}
+
+func (budget *pollBudget) status() models.PollBudget {
+	used := float64(budget.used)
+	limit := float64(budget.limit)
+	throttle := models.PollBudgetThrottleNone
+	switch {
+	case used >= pollPriorityShares[pollPriorityCritical]*limit:
+		throttle = models.PollBudgetThrottleAll
+	case used >= pollPriorityShares[pollPriorityNormal]*limit:
+		throttle = models.PollBudgetThrottleNormal
+	case used >= pollPriorityShares[pollPriorityLow]*limit:
+		throttle = models.PollBudgetThrottleLow
+	}
+	return models.PollBudget{
+		Limit:     budget.limit,
+		Used:      budget.used,
+		Remaining: max(budget.limit-budget.used, 0),
+		ResetsAt:  budget.windowStart.Add(budget.window),
+		Throttle:  throttle,
+	}
+}
@@ -0,0 +1,82 @@
+package frm_client
+
+import (
+	"api/models/models"
+	"testing"
+	"time"
+)
+
+func TestPollBudgetShedsLowPriorityFirst(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	budget := newPollBudget(func() time.Time { return now }, 10, time.Minute)
+
+	tests := []struct {
+		spent        int
+		wantLow      bool
+		wantNormal   bool
+		wantCritical bool
+		wantThrottle models.PollBudgetThrottle
+	}{
+		{0, true, true, true, models.PollBudgetThrottleNone},
+		{5, false, true, true, models.PollBudgetThrottleLow},
+		{8, false, false, true, models.PollBudgetThrottleNormal},
+		{10, false, false, false, models.PollBudgetThrottleAll},
+	}
+	spent := 0
+	for _, test := range tests {
+		for ; spent < test.spent; spent++ {
+			budget.Spend()
+		}
+		got := [3]bool{
+			budget.Allow(models.SatisfactoryEventBelts),
+			budget.Allow(models.SatisfactoryEventPlayers),
+			budget.Allow(models.SatisfactoryEventProdStats),
+		}
+		if want := [3]bool{test.wantLow, test.wantNormal, test.wantCritical}; got != want {
+			t.Errorf("after %d requests: low, normal, critical allowed %v, want %v", spent, got, want)
+		}
+		if status := budget.Status(); status.Throttle != test.wantThrottle || status.Used != spent {
+			t.Errorf("after %d requests: got %+v, want throttle %s", spent, status, test.wantThrottle)
+		}
+	}
+
+	now = now.Add(time.Minute)
+	if !budget.Allow(models.SatisfactoryEventBelts) {
+		t.Error("low priority endpoint refused after the window reset")
+	}
+	if status := budget.Status(); status.Used != 0 || !status.ResetsAt.Equal(now.Add(time.Minute)) {
+		t.Errorf("got %+v after the window reset, want an empty budget resetting a minute later", status)
+	}
+}
+
+func TestPollBudgetObserveReportsChanges(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	budget := newPollBudget(func() time.Time { return now }, 4, time.Minute)
+
+	if _, changed := budget.Observe(); !changed {
+		t.Fatal("first observation not reported")
+	}
+	budget.Spend()
+	if _, changed := budget.Observe(); changed {
+		t.Error("reported a change while the throttle stayed the same")
+	}
+	budget.Spend()
+	if status, changed := budget.Observe(); !changed || status.Throttle != models.PollBudgetThrottleLow {
+		t.Errorf("got %+v changed %v, want the low throttle reported", status, changed)
+	}
+	now = now.Add(time.Minute)
+	if status, changed := budget.Observe(); !changed || status.Throttle != models.PollBudgetThrottleNone {
+		t.Errorf("got %+v changed %v, want the window reset reported", status, changed)
+	}
+}
+
+func TestPollBudgetDisabled(t *testing.T) {
+	budget := newPollBudget(time.Now, 0, time.Minute)
+	if budget != nil {
+		t.Fatalf("got %+v, want no budget for a zero limit", budget)
+	}
+	budget.Spend()
+	if !budget.Allow(models.SatisfactoryEventBelts) || budget.Status() != nil {
+		t.Error("a nil budget must allow every poll and report no status")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"api/service/frm_client/frm_models"
 	"context"
 	"fmt"
+	"math"
 	"sort"
 	"strconv"
 	"strings"
@@ -37,18 +38,25 @@ func (client *Client) ListCircuits(ctx context.Context) ([]models.Circuit, error
 
 		secondsToFull := parseTime(raw.BatteryTimeFull)
 		secondsToEmpty := parseTime(raw.BatteryTimeEmpty)
+		if secondsToFull == 0 && secondsToEmpty == 0 {
+			secondsToFull, secondsToEmpty = batteryTimes(raw.BatteryCapacity, raw.BatteryPercent, raw.BatteryDifferential)
+		}
 
 		circuit := models.Circuit{
 			ID: raw.CircuitID,
 			Consumption: models.CircuitConsumption{
-				Total: raw.PowerConsumed * 1_000_000,    // MW to W
-				Max:   raw.PowerMaxConsumed * 1_000_000, // MW to W
+				Total:          raw.PowerConsumed * 1_000_000,    // MW to W
+				Max:            raw.PowerMaxConsumed * 1_000_000, // MW to W
+				TotalMegawatts: dualUnit(raw.PowerConsumed),
+				MaxMegawatts:   dualUnit(raw.PowerMaxConsumed),
 			},
 			Production: models.CircuitProduction{
-				Total: raw.PowerProduction * 1_000_000, // MW to W
+				Total:          raw.PowerProduction * 1_000_000, // MW to W
+				TotalMegawatts: dualUnit(raw.PowerProduction),
 			},
 			Capacity: models.CircuitCapacity{
-				Total: raw.PowerCapacity * 1_000_000, // MW to W
+				Total:          raw.PowerCapacity * 1_000_000, // MW to W
+				TotalMegawatts: dualUnit(raw.PowerCapacity),
 			},
 			Battery: models.CircuitBattery{
 				Percentage:   raw.BatteryPercent,
@@ -56,14 +64,15 @@ func (client *Client) ListCircuits(ctx context.Context) ([]models.Circuit, error
 				Differential: raw.BatteryDifferential * 1_000_000, // MW to W
 				UntilFull:    secondsToFull,
 				UntilEmpty:   secondsToEmpty,
+
+				CapacityMegawattHours: dualUnit(raw.BatteryCapacity),
+				DifferentialMegawatts: dualUnit(raw.BatteryDifferential),
 			},
 			FuseTriggered: raw.FuseTriggered,
 		}
 
-		// Filter out circuits with no production (consistent with TS)
-		if circuit.Production.Total > 0 {
-			circuits = append(circuits, circuit)
-		}
+		// Circuits without production are kept, a triggered fuse reports zero production
+		circuits = append(circuits, circuit)
 	}
 
 	// Sort by largest production (consistent with TS)
@@ -74,6 +83,23 @@ func (client *Client) ListCircuits(ctx context.Context) ([]models.Circuit, error
 	return circuits, nil
 }
 
+// batteryDifferentialEpsilon is the differential in MW below which the batteries are considered balanced
+const batteryDifferentialEpsilon = 1e-6
+
+// batteryTimes estimates the seconds until the batteries are full and empty from the capacity in MWh,
+// the charge percentage (0-100) and the differential in MW. Only one of them is non-zero at a time.
+func batteryTimes(capacity, percentage, differential float64) (untilFull, untilEmpty float64) {
+	stored := capacity * percentage / 100
+	switch {
+	case differential > batteryDifferentialEpsilon:
+		return math.Max(capacity-stored, 0) / differential * 3600, 0
+	case differential < -batteryDifferentialEpsilon:
+		return 0, stored / -differential * 3600
+	default:
+		return 0, 0
+	}
+}
+
 // ListCables fetches power cable data for map visualization
 func (client *Client) ListCables(ctx context.Context) ([]models.Cable, error) {
 	var rawCables []frm_models.Cable
@@ -87,8 +113,8 @@ func (client *Client) ListCables(ctx context.Context) ([]models.Cable, error) {
 		cables[i] = models.Cable{
 			ID:         raw.ID,
 			Name:       raw.Name,
-			Location0:  models.Location{X: raw.Location0.X, Y: raw.Location0.Y, Z: raw.Location0.Z, Rotation: raw.Location0.Rotation},
-			Location1:  models.Location{X: raw.Location1.X, Y: raw.Location1.Y, Z: raw.Location1.Z, Rotation: raw.Location1.Rotation},
+			Location0:  parseLocation(raw.Location0),
+			Location1:  parseLocation(raw.Location1),
 			Connected0: raw.Connected0,
 			Connected1: raw.Connected1,
 			Length:     raw.Length / 100, // Convert cm to m
@@ -0,0 +1,87 @@
+package frm_client
+
+import (
+	"api/service/frm_client/frm_models"
+	"context"
+	"testing"
+)
+
+func TestListCircuitsKeepsCircuitsWithoutProduction(t *testing.T) {
+	client := newStubClient(t, map[string]any{
+		"/getPower": []frm_models.Circuit{
+			{CircuitID: "1", PowerProduction: 100, PowerConsumed: 80},
+			{CircuitID: "2", PowerConsumed: 40, FuseTriggered: true},
+			{CircuitID: "3", BatteryCapacity: 100, BatteryPercent: 50, BatteryDifferential: -10},
+		},
+	})
+
+	circuits, err := client.ListCircuits(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(circuits) != 3 {
+		t.Fatalf("got %d circuits, want 3", len(circuits))
+	}
+
+	byID := map[string]bool{}
+	for _, circuit := range circuits {
+		byID[circuit.ID] = circuit.FuseTriggered
+	}
+	if tripped, ok := byID["2"]; !ok || !tripped {
+		t.Errorf("tripped circuit without production missing or not tripped: %v", byID)
+	}
+	if _, ok := byID["3"]; !ok {
+		t.Errorf("battery-only circuit missing: %v", byID)
+	}
+	if circuits[0].ID != "1" {
+		t.Errorf("circuits not sorted by production, first is %s", circuits[0].ID)
+	}
+}
+
+func TestBatteryTimes(t *testing.T) {
+	tests := []struct {
+		name         string
+		capacity     float64 // MWh
+		percentage   float64
+		differential float64 // MW
+		untilFull    float64
+		untilEmpty   float64
+	}{
+		{"charging", 100, 25, 50, 5400, 0},     // 75 MWh missing at 50 MW
+		{"discharging", 100, 25, -10, 0, 9000}, // 25 MWh stored at 10 MW
+		{"balanced", 100, 25, 1e-9, 0, 0},
+		{"already full", 100, 100, 50, 0, 0},
+		{"no batteries", 0, 0, -10, 0, 0},
+	}
+	for _, test := range tests {
+		untilFull, untilEmpty := batteryTimes(test.capacity, test.percentage, test.differential)
+		if untilFull != test.untilFull || untilEmpty != test.untilEmpty {
+			t.Errorf("%s: got %v until full and %v until empty, want %v and %v", test.name, untilFull, untilEmpty, test.untilFull, test.untilEmpty)
+		}
+	}
+}
+
+func TestListCircuitsBatteryTimes(t *testing.T) {
+	reported := "01:02:03"
+	client := newStubClient(t, map[string]any{
+		"/getPower": []frm_models.Circuit{
+			{CircuitID: "reported", PowerProduction: 2, BatteryCapacity: 100, BatteryPercent: 50, BatteryDifferential: 10, BatteryTimeFull: &reported},
+			{CircuitID: "estimated", PowerProduction: 1, BatteryCapacity: 100, BatteryPercent: 50, BatteryDifferential: -10},
+		},
+	})
+
+	circuits, err := client.ListCircuits(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(circuits) != 2 {
+		t.Fatalf("got %d circuits, want 2", len(circuits))
+	}
+	// Times reported by FRM win over the estimate
+	if battery := circuits[0].Battery; battery.UntilFull != 3723 || battery.UntilEmpty != 0 {
+		t.Errorf("got %v until full and %v until empty, want the reported 3723 and 0", battery.UntilFull, battery.UntilEmpty)
+	}
+	if battery := circuits[1].Battery; battery.UntilFull != 0 || battery.UntilEmpty != 18000 {
+		t.Errorf("got %v until full and %v until empty, want the estimated 0 and 18000", battery.UntilFull, battery.UntilEmpty)
+	}
+}
@@ -1,17 +1,48 @@
 package frm_client
 
 import (
+	"api/models/models"
 	"api/pkg/log"
 	"context"
 	"sync"
+	"time"
 )
 
-// RequestQueue serializes requests to the FRM API and prevents duplicate requests
-// from piling up when the API is slow to respond.
+const (
+	// backoffThreshold is the number of consecutive failures of an endpoint before its requests are backed off
+	backoffThreshold = 5
+	// backoffBaseDelay is the delay after the threshold is reached, doubled with every further failure
+	backoffBaseDelay = 2 * time.Second
+	// backoffMaxDelay caps the delay between attempts of a failing endpoint
+	backoffMaxDelay = 60 * time.Second
+	// defaultRequestQueueConcurrency is the number of queued requests run at once unless configured
+	defaultRequestQueueConcurrency = 2
+	// statusEndpointType is never backed off, so the status check notices a recovered API on its next poll
+	statusEndpointType = string(models.SatisfactoryEventApiStatus)
+	// durationAverageWeight is the weight of the latest duration in the rolling average of an endpoint
+	durationAverageWeight = 0.2
+)
+
+// RequestPriority selects the lane of a queued request. Workers always take high priority
+// requests first, and low priority requests never occupy every worker.
+type RequestPriority int
+
+const (
+	PriorityLow RequestPriority = iota
+	PriorityHigh
+)
+
+// RequestQueue runs requests to the FRM API on a fixed number of workers and prevents
+// duplicate requests from piling up when the API is slow to respond.
 type RequestQueue struct {
 	mu            sync.Mutex
-	pendingTypes  map[string]bool     // Tracks which endpoint types have pending requests
-	requestChan   chan *queuedRequest // Channel for serializing request execution
+	pendingTypes  map[string]bool      // Tracks which endpoint types have pending requests
+	failures      map[string]int       // Consecutive failures per endpoint type
+	retryAt       map[string]time.Time // Endpoint types backed off until the given time
+	stats         map[string]*models.EndpointStat
+	highChan      chan *queuedRequest
+	lowChan       chan *queuedRequest
+	lowSlots      chan struct{} // Held by workers running a low priority request
 	workerCtx     context.Context
 	workerCancel  context.CancelFunc
 	clientAddress string // For logging purposes
@@ -23,48 +54,170 @@ type queuedRequest struct {
 	done         chan error
 }
 
-// NewRequestQueue creates a new request queue for serializing FRM API requests
-func NewRequestQueue(clientAddress string) *RequestQueue {
+// NewRequestQueue creates a new request queue for FRM API requests running up to concurrency
+// requests at once. With more than one worker, one is always kept free of low priority requests.
+func NewRequestQueue(clientAddress string, concurrency int) *RequestQueue {
+	concurrency = max(concurrency, 1)
 	ctx, cancel := context.WithCancel(context.Background())
 	q := &RequestQueue{
 		pendingTypes:  make(map[string]bool),
-		requestChan:   make(chan *queuedRequest, 100), // Buffer for pending requests
+		failures:      make(map[string]int),
+		retryAt:       make(map[string]time.Time),
+		stats:         make(map[string]*models.EndpointStat),
+		highChan:      make(chan *queuedRequest, 100), // Buffer for pending requests
+		lowChan:       make(chan *queuedRequest, 100),
+		lowSlots:      make(chan struct{}, max(concurrency-1, 1)),
 		workerCtx:     ctx,
 		workerCancel:  cancel,
 		clientAddress: clientAddress,
 	}
-	go q.worker()
+	for range concurrency {
+		go q.worker()
+	}
 	return q
 }
 
-// worker processes requests sequentially
+// worker processes requests, preferring the high priority lane. It only takes low priority
+// requests while holding a low slot, otherwise it waits for high priority ones only.
 func (q *RequestQueue) worker() {
 	for {
 		select {
-		case req := <-q.requestChan:
-			// Execute the request
-			err := req.execute()
-
-			// Mark this endpoint type as no longer pending
-			q.mu.Lock()
-			delete(q.pendingTypes, req.endpointType)
-			q.mu.Unlock()
+		case req := <-q.highChan:
+			q.run(req)
+			continue
+		case <-q.workerCtx.Done():
+			return
+		default:
+		}
 
-			// Send result back
-			req.done <- err
-			close(req.done)
+		lowChan := q.lowChan
+		select {
+		case q.lowSlots <- struct{}{}:
+		default:
+			lowChan = nil
+		}
 
+		select {
+		case req := <-q.highChan:
+			q.releaseLowSlot(lowChan)
+			q.run(req)
+		case req := <-lowChan:
+			q.run(req)
+			q.releaseLowSlot(lowChan)
 		case <-q.workerCtx.Done():
+			q.releaseLowSlot(lowChan)
 			return
 		}
 	}
 }
 
-// Enqueue adds a request to the queue. If a request for this endpoint type is already
-// pending, it returns immediately with (false, nil) and logs a warning.
-// Returns (true, error) when the request completes.
-func (q *RequestQueue) Enqueue(endpointType string, execute func() error) (bool, error) {
+// releaseLowSlot gives back the low slot taken by a worker, lowChan is nil if it took none
+func (q *RequestQueue) releaseLowSlot(lowChan chan *queuedRequest) {
+	if lowChan != nil {
+		<-q.lowSlots
+	}
+}
+
+func (q *RequestQueue) run(req *queuedRequest) {
+	start := time.Now()
+	err := req.execute()
+	duration := time.Since(start)
+
+	// Mark this endpoint type as no longer pending
 	q.mu.Lock()
+	delete(q.pendingTypes, req.endpointType)
+	q.recordResultLocked(req.endpointType, err)
+	q.recordTimingLocked(req.endpointType, duration, err)
+	q.mu.Unlock()
+
+	// Send result back
+	req.done <- err
+	close(req.done)
+}
+
+// recordResultLocked tracks consecutive failures of an endpoint type and backs it off
+// exponentially once they reach the threshold. A success resets the backoff. Caller must hold q.mu.
+func (q *RequestQueue) recordResultLocked(endpointType string, err error) {
+	if err == nil || endpointType == statusEndpointType {
+		delete(q.failures, endpointType)
+		delete(q.retryAt, endpointType)
+		return
+	}
+
+	q.failures[endpointType]++
+	failures := q.failures[endpointType]
+	if failures < backoffThreshold {
+		return
+	}
+
+	delay := backoffMaxDelay
+	if shift := failures - backoffThreshold; shift < 16 {
+		delay = min(backoffBaseDelay<<shift, backoffMaxDelay)
+	}
+	q.retryAt[endpointType] = time.Now().Add(delay)
+	log.Debugf("Backing off endpoint '%s' on %s for %s after %d consecutive failures",
+		endpointType, q.clientAddress, delay, failures)
+}
+
+// recordTimingLocked updates the duration and result counts of an endpoint type. The average is
+// exponentially weighted so it follows recent slowdowns. Caller must hold q.mu.
+func (q *RequestQueue) recordTimingLocked(endpointType string, duration time.Duration, err error) {
+	stat, ok := q.stats[endpointType]
+	if !ok {
+		stat = &models.EndpointStat{AverageDurationSeconds: duration.Seconds()}
+		q.stats[endpointType] = stat
+	}
+
+	stat.LastDurationSeconds = duration.Seconds()
+	stat.AverageDurationSeconds += durationAverageWeight * (stat.LastDurationSeconds - stat.AverageDurationSeconds)
+	if err != nil {
+		stat.Failures++
+	} else {
+		stat.Successes++
+	}
+}
+
+// QueueStats returns the timing and result counts of every endpoint type that has completed a request
+func (q *RequestQueue) QueueStats() map[string]models.EndpointStat {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	stats := make(map[string]models.EndpointStat, len(q.stats))
+	for endpointType, stat := range q.stats {
+		stats[endpointType] = *stat
+	}
+	return stats
+}
+
+// QueueDepth returns the number of requests waiting for a worker
+func (q *RequestQueue) QueueDepth() int {
+	return len(q.highChan) + len(q.lowChan)
+}
+
+// Backoffs returns the remaining backoff of every endpoint type that is currently backed off
+func (q *RequestQueue) Backoffs() map[string]time.Duration {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	backoffs := make(map[string]time.Duration, len(q.retryAt))
+	for endpointType, retryAt := range q.retryAt {
+		if remaining := time.Until(retryAt); remaining > 0 {
+			backoffs[endpointType] = remaining
+		}
+	}
+	return backoffs
+}
+
+// Enqueue adds a request to the lane of the priority. If a request for this endpoint type is
+// already pending, or the endpoint type is backed off after repeated failures, it returns
+// immediately with (false, nil). Returns (true, error) when the request completes.
+func (q *RequestQueue) Enqueue(endpointType string, priority RequestPriority, execute func() error) (bool, error) {
+	q.mu.Lock()
+
+	if time.Now().Before(q.retryAt[endpointType]) {
+		q.mu.Unlock()
+		return false, nil
+	}
 
 	// Check if this endpoint type already has a pending request
 	if q.pendingTypes[endpointType] {
@@ -86,9 +239,14 @@ func (q *RequestQueue) Enqueue(endpointType string, execute func() error) (bool,
 		done:         make(chan error, 1),
 	}
 
+	lane := q.lowChan
+	if priority == PriorityHigh {
+		lane = q.highChan
+	}
+
 	// Send to worker
 	select {
-	case q.requestChan <- req:
+	case lane <- req:
 		// Wait for completion
 		err := <-req.done
 		return true, err
@@ -0,0 +1,319 @@
+package frm_client
+
+import (
+	"api/models/models"
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newFlakyClient returns a client talking to a server that fails the first failures requests
+func newFlakyClient(t *testing.T, failures int32) *Client {
+	t.Helper()
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClientWithAddress(server.URL, nil)
+	t.Cleanup(client.requestQueue.Stop)
+	return client
+}
+
+// skipBackoff lets the next request of the endpoint type through, as if its backoff had passed
+func skipBackoff(q *RequestQueue, endpointType string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	delete(q.retryAt, endpointType)
+}
+
+func TestRequestQueueBacksOffFailingEndpoints(t *testing.T) {
+	const failures = backoffThreshold + 3
+	client := newFlakyClient(t, failures)
+	queue := client.requestQueue
+	fetch := func() error {
+		var target map[string]any
+		return client.makeSatisfactoryCall(context.Background(), "/getBelt", &target)
+	}
+
+	for attempt := 1; attempt <= failures; attempt++ {
+		executed, err := queue.Enqueue("belts", PriorityLow, fetch)
+		if !executed || err == nil {
+			t.Fatalf("attempt %d: executed %v with error %v, want a failed request", attempt, executed, err)
+		}
+
+		backoff := queue.Backoffs()["belts"]
+		if attempt < backoffThreshold {
+			if backoff != 0 {
+				t.Fatalf("attempt %d: backed off for %s before the threshold", attempt, backoff)
+			}
+			continue
+		}
+
+		want := backoffBaseDelay << (attempt - backoffThreshold)
+		if backoff > want || backoff < want-time.Second {
+			t.Fatalf("attempt %d: backed off for %s, want %s", attempt, backoff, want)
+		}
+		if executed, _ := queue.Enqueue("belts", PriorityLow, fetch); executed {
+			t.Fatalf("attempt %d: request ran during the backoff", attempt)
+		}
+		skipBackoff(queue, "belts")
+	}
+
+	if executed, err := queue.Enqueue("belts", PriorityLow, fetch); !executed || err != nil {
+		t.Fatalf("executed %v with error %v, want a successful request", executed, err)
+	}
+	if backoff, ok := queue.Backoffs()["belts"]; ok {
+		t.Errorf("still backed off for %s after a success", backoff)
+	}
+	queue.mu.Lock()
+	defer queue.mu.Unlock()
+	if queue.failures["belts"] != 0 {
+		t.Errorf("%d failures remembered after a success, want 0", queue.failures["belts"])
+	}
+}
+
+func TestRequestQueueBackoffIsCapped(t *testing.T) {
+	queue := NewRequestQueue("test", 1)
+	t.Cleanup(queue.Stop)
+
+	queue.mu.Lock()
+	for range backoffThreshold + 20 {
+		queue.recordResultLocked("belts", context.DeadlineExceeded)
+	}
+	queue.mu.Unlock()
+
+	if backoff := queue.Backoffs()["belts"]; backoff > backoffMaxDelay || backoff < backoffMaxDelay-time.Second {
+		t.Errorf("backed off for %s, want the cap of %s", backoff, backoffMaxDelay)
+	}
+}
+
+func TestRequestQueueNeverBacksOffStatusCheck(t *testing.T) {
+	client := newFlakyClient(t, backoffThreshold*2)
+	queue := client.requestQueue
+
+	for attempt := 1; attempt <= backoffThreshold*2; attempt++ {
+		executed, err := queue.Enqueue(statusEndpointType, PriorityHigh, func() error {
+			_, err := client.GetSatisfactoryApiStatus(context.Background())
+			return err
+		})
+		if !executed {
+			t.Fatalf("attempt %d: status check was skipped", attempt)
+		}
+		if err == nil && attempt > defaultApiDownThreshold {
+			t.Fatalf("attempt %d: status check succeeded against a failing server", attempt)
+		}
+	}
+	if backoff, ok := queue.Backoffs()[statusEndpointType]; ok {
+		t.Errorf("status check backed off for %s", backoff)
+	}
+}
+
+// enqueueBlocked enqueues a request that runs until release is closed, and waits for it to start.
+// The returned channel receives the result of Enqueue.
+func enqueueBlocked(t *testing.T, q *RequestQueue, endpointType string, priority RequestPriority, release <-chan struct{}) <-chan error {
+	t.Helper()
+	started := make(chan struct{})
+	result := make(chan error, 1)
+	go func() {
+		_, err := q.Enqueue(endpointType, priority, func() error {
+			close(started)
+			<-release
+			return nil
+		})
+		result <- err
+	}()
+
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		t.Fatalf("%s request did not start", endpointType)
+	}
+	return result
+}
+
+func TestRequestQueueHighPriorityPassesSlowLowPriority(t *testing.T) {
+	queue := NewRequestQueue("test", 2)
+	t.Cleanup(queue.Stop)
+
+	release := make(chan struct{})
+	slow := enqueueBlocked(t, queue, "belts", PriorityLow, release)
+
+	// A second low priority request must wait, so a worker stays free for high priority ones
+	var pipesRan atomic.Bool
+	pipes := make(chan struct{})
+	go func() {
+		_, _ = queue.Enqueue("pipes", PriorityLow, func() error {
+			pipesRan.Store(true)
+			return nil
+		})
+		close(pipes)
+	}()
+
+	status := make(chan bool, 1)
+	go func() {
+		executed, err := queue.Enqueue(statusEndpointType, PriorityHigh, func() error { return nil })
+		status <- executed && err == nil
+	}()
+
+	select {
+	case ok := <-status:
+		if !ok {
+			t.Fatal("high priority request did not run successfully")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("high priority request is blocked behind a slow low priority one")
+	}
+	if pipesRan.Load() {
+		t.Error("second low priority request ran while the first one held the low slot")
+	}
+
+	close(release)
+	if err := <-slow; err != nil {
+		t.Errorf("slow request failed: %v", err)
+	}
+	select {
+	case <-pipes:
+	case <-time.After(time.Second):
+		t.Fatal("second low priority request did not run after the slot was released")
+	}
+}
+
+func TestRequestQueueDeduplicatesPendingEndpoints(t *testing.T) {
+	queue := NewRequestQueue("test", 2)
+	t.Cleanup(queue.Stop)
+
+	release := make(chan struct{})
+	first := enqueueBlocked(t, queue, "belts", PriorityLow, release)
+
+	var duplicateRan atomic.Bool
+	executed, err := queue.Enqueue("belts", PriorityLow, func() error {
+		duplicateRan.Store(true)
+		return nil
+	})
+	if executed || err != nil || duplicateRan.Load() {
+		t.Fatalf("duplicate request executed %v with error %v, want it skipped", executed, err)
+	}
+
+	// Other endpoint types are not affected by the pending request
+	if executed, err := queue.Enqueue("pipes", PriorityHigh, func() error { return nil }); !executed || err != nil {
+		t.Fatalf("other endpoint executed %v with error %v, want it run", executed, err)
+	}
+
+	close(release)
+	if err := <-first; err != nil {
+		t.Fatalf("first request failed: %v", err)
+	}
+	if executed, err := queue.Enqueue("belts", PriorityLow, func() error { return nil }); !executed || err != nil {
+		t.Errorf("request after completion executed %v with error %v, want it run", executed, err)
+	}
+}
+
+func TestRequestQueueRecordsEndpointTiming(t *testing.T) {
+	queue := NewRequestQueue("test", 1)
+	t.Cleanup(queue.Stop)
+
+	delays := []struct {
+		delay time.Duration
+		err   error
+	}{
+		{20 * time.Millisecond, nil},
+		{60 * time.Millisecond, nil},
+		{10 * time.Millisecond, errors.New("failed")},
+	}
+	for _, step := range delays {
+		_, _ = queue.Enqueue("belts", PriorityHigh, func() error {
+			time.Sleep(step.delay)
+			return step.err
+		})
+	}
+
+	stat, ok := queue.QueueStats()["belts"]
+	if !ok {
+		t.Fatal("no stats recorded for belts")
+	}
+	if stat.Successes != 2 || stat.Failures != 1 {
+		t.Errorf("got %d successes and %d failures, want 2 and 1", stat.Successes, stat.Failures)
+	}
+	if last := stat.LastDurationSeconds; last < 0.01 || last >= 0.06 {
+		t.Errorf("got last duration %vs, want about the 10ms of the last request", last)
+	}
+	// The average starts at the first duration and moves a fifth of the way towards every later one,
+	// 20ms, then 28ms, then 24.4ms
+	if avg := stat.AverageDurationSeconds; avg < 0.02 || avg > 0.04 {
+		t.Errorf("got average duration %vs, want about 24ms", avg)
+	}
+}
+
+func TestRequestQueueTimingAverage(t *testing.T) {
+	queue := NewRequestQueue("test", 1)
+	t.Cleanup(queue.Stop)
+
+	queue.mu.Lock()
+	queue.recordTimingLocked("belts", 10*time.Second, nil)
+	queue.recordTimingLocked("belts", 20*time.Second, nil)
+	queue.recordTimingLocked("belts", 20*time.Second, context.DeadlineExceeded)
+	queue.mu.Unlock()
+
+	stat := queue.QueueStats()["belts"]
+	// 10, then 10 + 0.2 * (20 - 10) = 12, then 12 + 0.2 * (20 - 12) = 13.6
+	if want := 13.6; math.Abs(stat.AverageDurationSeconds-want) > 1e-9 {
+		t.Errorf("got average %v, want %v", stat.AverageDurationSeconds, want)
+	}
+	if stat.LastDurationSeconds != 20 || stat.Successes != 2 || stat.Failures != 1 {
+		t.Errorf("got %+v, want last duration 20 with 2 successes and 1 failure", stat)
+	}
+}
+
+func TestClientDiagnosticsReportsQueue(t *testing.T) {
+	client := NewClientWithAddress("http://localhost", nil)
+	t.Cleanup(client.requestQueue.Stop)
+	queue := client.requestQueue
+
+	// Both default workers are busy, so the next requests wait in the queue
+	release := make(chan struct{})
+	blocked := enqueueBlocked(t, queue, "belts", PriorityHigh, release)
+	blockedStorages := enqueueBlocked(t, queue, "storages", PriorityHigh, release)
+	for _, endpointType := range []string{"pipes", "cables"} {
+		go func() {
+			_, _ = queue.Enqueue(endpointType, PriorityHigh, func() error { return nil })
+		}()
+	}
+	deadline := time.Now().Add(time.Second)
+	for queue.QueueDepth() < 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	diagnostics, err := client.GetClientDiagnostics(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diagnostics.QueueDepth != 2 {
+		t.Errorf("got queue depth %d, want 2", diagnostics.QueueDepth)
+	}
+
+	close(release)
+	for _, done := range []<-chan error{blocked, blockedStorages} {
+		if err := <-done; err != nil {
+			t.Fatal(err)
+		}
+	}
+	diagnostics, err = client.GetClientDiagnostics(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stat, ok := diagnostics.QueueStats[models.SatisfactoryEventType("belts")]; !ok || stat.Successes != 1 {
+		t.Errorf("got belts stats %+v, want one success", stat)
+	}
+}
@@ -13,5 +13,6 @@ func (client *Client) GetSessionInfo(ctx context.Context) (*models.SessionInfo,
 	if err != nil {
 		return nil, fmt.Errorf("failed to get session info: %w", err)
 	}
+	client.setGamePaused(raw.IsPaused)
 	return raw.ToDTO(), nil
 }
@@ -0,0 +1,115 @@
+package frm_client
+
+import (
+	"api/models/models"
+	"context"
+	"sync"
+	"time"
+)
+
+// Section names of a world snapshot, also used as keys of its errors
+const (
+	SnapshotSectionMachines        = "machines"
+	SnapshotSectionCircuits        = "circuits"
+	SnapshotSectionProdStats       = "prodStats"
+	SnapshotSectionVehicles        = "vehicles"
+	SnapshotSectionVehicleStations = "vehicleStations"
+	SnapshotSectionBelts           = "belts"
+	SnapshotSectionPipes           = "pipes"
+	SnapshotSectionStorages        = "storages"
+	SnapshotSectionRadarTowers     = "radarTowers"
+	SnapshotSectionSpaceElevator   = "spaceElevator"
+	SnapshotSectionHub             = "hub"
+	SnapshotSectionSchematics      = "schematics"
+)
+
+// SnapshotAll concurrently fetches every section of the world into one snapshot. A failing section
+// is recorded in the snapshot's errors instead of failing the whole snapshot. Only a cancelled
+// context returns an error, as soon as it is cancelled.
+func (client *Client) SnapshotAll(ctx context.Context) (models.WorldSnapshot, error) {
+	snapshot := models.WorldSnapshot{
+		TakenAt: time.Now(),
+		Errors:  make(map[string]string),
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	fetch := func(section string, get func() error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := get(); err != nil {
+				mu.Lock()
+				snapshot.Errors[section] = err.Error()
+				mu.Unlock()
+			}
+		}()
+	}
+
+	// Every goroutine writes its own field, only the errors are shared
+	fetch(SnapshotSectionMachines, func() (err error) {
+		snapshot.Machines, err = client.GetMachines(ctx)
+		return err
+	})
+	fetch(SnapshotSectionCircuits, func() (err error) {
+		snapshot.Circuits, err = client.ListCircuits(ctx)
+		return err
+	})
+	fetch(SnapshotSectionProdStats, func() (err error) {
+		snapshot.ProdStats, err = client.GetProdStats(ctx)
+		return err
+	})
+	fetch(SnapshotSectionVehicles, func() (err error) {
+		snapshot.Vehicles, err = client.GetVehicles(ctx)
+		return err
+	})
+	fetch(SnapshotSectionVehicleStations, func() (err error) {
+		snapshot.VehicleStations, err = client.GetVehicleStations(ctx)
+		return err
+	})
+	fetch(SnapshotSectionBelts, func() (err error) {
+		snapshot.Belts, err = client.GetBelts(ctx)
+		return err
+	})
+	fetch(SnapshotSectionPipes, func() (err error) {
+		snapshot.Pipes, err = client.GetPipes(ctx)
+		return err
+	})
+	fetch(SnapshotSectionStorages, func() (err error) {
+		snapshot.Storages, err = client.ListStorageContainers(ctx)
+		return err
+	})
+	fetch(SnapshotSectionRadarTowers, func() (err error) {
+		snapshot.RadarTowers, err = client.ListRadarTowers(ctx)
+		return err
+	})
+	fetch(SnapshotSectionSpaceElevator, func() (err error) {
+		snapshot.SpaceElevator, err = client.GetSpaceElevator(ctx)
+		return err
+	})
+	fetch(SnapshotSectionHub, func() (err error) {
+		snapshot.Hub, err = client.GetHub(ctx)
+		return err
+	})
+	fetch(SnapshotSectionSchematics, func() (err error) {
+		snapshot.Schematics, err = client.ListSchematics(ctx)
+		return err
+	})
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-ctx.Done():
+		return models.WorldSnapshot{}, ctx.Err()
+	case <-done:
+	}
+
+	if len(snapshot.Errors) == 0 {
+		snapshot.Errors = nil
+	}
+	return snapshot, nil
+}
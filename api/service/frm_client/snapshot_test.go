@@ -0,0 +1,81 @@
+package frm_client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSnapshotAllPopulatesEverySection(t *testing.T) {
+	// Every endpoint answers with one entity, which is enough for each section to keep it
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"ID":"1","Name":"Thing","ClassName":"Build_Thing_C","Type":"Milestone"}]`))
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClientWithAddress(server.URL, nil)
+	t.Cleanup(client.Close)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	snapshot, err := client.SnapshotAll(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshot.Errors) > 0 {
+		t.Fatalf("sections failed: %v", snapshot.Errors)
+	}
+	if snapshot.TakenAt.IsZero() {
+		t.Error("snapshot has no time")
+	}
+
+	populated := map[string]bool{
+		SnapshotSectionMachines:        len(snapshot.Machines) > 0,
+		SnapshotSectionCircuits:        len(snapshot.Circuits) > 0,
+		SnapshotSectionProdStats:       snapshot.ProdStats != nil,
+		SnapshotSectionVehicles:        len(snapshot.Vehicles.Trains) > 0 && len(snapshot.Vehicles.Drones) > 0,
+		SnapshotSectionVehicleStations: len(snapshot.VehicleStations.TrainStations) > 0,
+		SnapshotSectionBelts:           len(snapshot.Belts.Belts) > 0,
+		SnapshotSectionPipes:           len(snapshot.Pipes.Pipes) > 0,
+		SnapshotSectionStorages:        len(snapshot.Storages) > 0,
+		SnapshotSectionRadarTowers:     len(snapshot.RadarTowers) > 0,
+		SnapshotSectionSpaceElevator:   snapshot.SpaceElevator != nil,
+		SnapshotSectionHub:             snapshot.Hub != nil,
+		SnapshotSectionSchematics:      len(snapshot.Schematics) > 0,
+	}
+	for section, ok := range populated {
+		if !ok {
+			t.Errorf("section %s is empty", section)
+		}
+	}
+}
+
+func TestSnapshotAllAbortsOnCancelledContext(t *testing.T) {
+	// The server never answers, so only the cancellation can end the snapshot
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	t.Cleanup(server.Close)
+	t.Cleanup(func() { close(release) })
+
+	client := NewClientWithAddress(server.URL, nil)
+	t.Cleanup(client.Close)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	if _, err := client.SnapshotAll(ctx); err == nil {
+		t.Fatal("cancelled snapshot returned no error")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("cancelled snapshot took %s to return", elapsed)
+	}
+}
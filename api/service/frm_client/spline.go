@@ -0,0 +1,68 @@
+package frm_client
+
+import (
+	"api/models/models"
+	"api/pkg/config"
+	"math"
+)
+
+// splineTolerance returns the configured max deviation of simplified splines, 0 keeps every point
+func splineTolerance() float64 {
+	if config.Config != nil {
+		return config.Config.SplineTolerance
+	}
+	return 0
+}
+
+// simplifySpline reduces a polyline with Douglas-Peucker, keeping every point that deviates more than
+// tolerance from the simplified line. The end points are always kept.
+func simplifySpline(points []models.Location, tolerance float64) []models.Location {
+	if tolerance <= 0 || len(points) <= 2 {
+		return points
+	}
+
+	keep := make([]bool, len(points))
+	keep[0] = true
+	keep[len(points)-1] = true
+
+	type span struct{ first, last int }
+	stack := []span{{0, len(points) - 1}}
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		farthest, farthestDistance := -1, tolerance
+		for i := current.first + 1; i < current.last; i++ {
+			if d := segmentDistance(points[i], points[current.first], points[current.last]); d > farthestDistance {
+				farthest, farthestDistance = i, d
+			}
+		}
+		if farthest < 0 {
+			continue
+		}
+		keep[farthest] = true
+		stack = append(stack, span{current.first, farthest}, span{farthest, current.last})
+	}
+
+	simplified := make([]models.Location, 0)
+	for i, point := range points {
+		if keep[i] {
+			simplified = append(simplified, point)
+		}
+	}
+	return simplified
+}
+
+// segmentDistance is the distance from p to the segment a-b
+func segmentDistance(p, a, b models.Location) float64 {
+	abX, abY, abZ := b.X-a.X, b.Y-a.Y, b.Z-a.Z
+	apX, apY, apZ := p.X-a.X, p.Y-a.Y, p.Z-a.Z
+
+	lengthSquared := abX*abX + abY*abY + abZ*abZ
+	t := 0.0
+	if lengthSquared > 0 {
+		t = math.Max(0, math.Min(1, (apX*abX+apY*abY+apZ*abZ)/lengthSquared))
+	}
+	dX, dY, dZ := apX-t*abX, apY-t*abY, apZ-t*abZ
+	return math.Sqrt(dX*dX + dY*dY + dZ*dZ)
+}
@@ -0,0 +1,66 @@
+package frm_client
+
+import (
+	"api/models/models"
+	"math"
+	"testing"
+)
+
+func TestSimplifySplineTolerance(t *testing.T) {
+	// An L-shaped spline with slight wobbles along both legs
+	points := []models.Location{
+		{X: 0, Y: 0}, {X: 100, Y: 2}, {X: 200, Y: 0}, {X: 300, Y: 0},
+		{X: 300, Y: 100}, {X: 301, Y: 200}, {X: 300, Y: 300},
+	}
+
+	tests := []struct {
+		name      string
+		tolerance float64
+		kept      []int
+	}{
+		{"disabled", 0, []int{0, 1, 2, 3, 4, 5, 6}},
+		{"wobbles smoothed", 5, []int{0, 3, 6}},
+		{"larger wobble kept", 1.5, []int{0, 1, 3, 6}},
+		{"end points only", 1000, []int{0, 6}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			simplified := simplifySpline(points, test.tolerance)
+			if len(simplified) != len(test.kept) {
+				t.Fatalf("got %+v, want points %v", simplified, test.kept)
+			}
+			for i, idx := range test.kept {
+				if simplified[i] != points[idx] {
+					t.Errorf("point %d: got %+v, want %+v", i, simplified[i], points[idx])
+				}
+			}
+		})
+	}
+
+	short := points[:2]
+	if simplified := simplifySpline(short, 1000); len(simplified) != 2 {
+		t.Errorf("got %+v, want a two point spline unchanged", simplified)
+	}
+}
+
+func TestSegmentDistance(t *testing.T) {
+	a, b := models.Location{X: 0}, models.Location{X: 100}
+	tests := []struct {
+		name     string
+		point    models.Location
+		a, b     models.Location
+		expected float64
+	}{
+		{"beside", models.Location{X: 50, Y: 30}, a, b, 30},
+		{"above", models.Location{X: 50, Z: 40}, a, b, 40},
+		{"before the start", models.Location{X: -30, Y: 40}, a, b, 50},
+		{"past the end", models.Location{X: 130, Y: -40}, a, b, 50},
+		{"degenerate segment", models.Location{X: 3, Y: 4}, a, a, 5},
+	}
+	for _, test := range tests {
+		if got := segmentDistance(test.point, test.a, test.b); math.Abs(got-test.expected) > 1e-9 {
+			t.Errorf("%s: got %v, want %v", test.name, got, test.expected)
+		}
+	}
+}
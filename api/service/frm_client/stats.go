@@ -55,6 +55,7 @@ func (client *Client) GetFactoryStats(ctx context.Context) (*models.FactoryStats
 			stats.Efficiency.MachinesUnknown++
 		}
 	}
+	stats.Efficiency.ComputeOverallEfficiency()
 
 	return &stats, nil
 }
@@ -71,8 +72,8 @@ func (client *Client) GetProdStats(ctx context.Context) (*models.ProdStats, erro
 	var rawProdData []frm_models.ProdStatItem
 	var rawInvData []frm_models.WorldInvItem
 	var rawCloudInvData []frm_models.CloudInvItem
-	itemMap := make(map[string]int)      // Map Name -> Amount (world inventory)
-	cloudItemMap := make(map[string]int) // Map Name -> Amount (cloud inventory)
+	itemMap := make(map[string]int)      // Map canonical name -> Amount (world inventory)
+	cloudItemMap := make(map[string]int) // Map canonical name -> Amount (cloud inventory)
 
 	// Fetch Production Stats
 	wg.Add(1)
@@ -105,7 +106,7 @@ func (client *Client) GetProdStats(ctx context.Context) (*models.ProdStats, erro
 		mu.Lock()
 		defer mu.Unlock()
 		for _, item := range rawInvData {
-			itemMap[item.Name] = item.Amount
+			itemMap[CanonicalItemName(item.Name)] += item.Amount
 		}
 	}()
 
@@ -123,7 +124,11 @@ func (client *Client) GetProdStats(ctx context.Context) (*models.ProdStats, erro
 		mu.Lock()
 		defer mu.Unlock()
 		for _, item := range rawCloudInvData {
-			cloudItemMap[item.Name] = item.Amount
+			name := item.Name
+			if name == "" {
+				name = item.ClassName
+			}
+			cloudItemMap[CanonicalItemName(name)] += item.Amount
 		}
 	}()
 
@@ -135,9 +140,10 @@ func (client *Client) GetProdStats(ctx context.Context) (*models.ProdStats, erro
 
 	// Process fetched data
 	for _, item := range rawProdData {
-		minable := client.isMinableResource(item.Name)
-		count := itemMap[item.Name]           // Defaults to 0 if not found
-		cloudCount := cloudItemMap[item.Name] // Defaults to 0 if not found
+		name := CanonicalItemName(item.Name)
+		minable := client.isMinableResource(name)
+		count := itemMap[name]           // Defaults to 0 if not found
+		cloudCount := cloudItemMap[name] // Defaults to 0 if not found
 
 		if minable {
 			prodStats.MinableProducedPerMinute += item.CurrentProd
@@ -148,10 +154,7 @@ func (client *Client) GetProdStats(ctx context.Context) (*models.ProdStats, erro
 		}
 
 		prodStats.Items = append(prodStats.Items, models.ItemProdStats{
-			ItemStats: models.ItemStats{
-				Name:  item.Name,
-				Count: float64(count), // Assuming count should be float64 in the model
-			},
+			ItemStats:           parseItemStats(name, float64(count)),
 			ProducedPerMinute:   item.CurrentProd,
 			MaxProducePerMinute: item.MaxProd,
 			ProduceEfficiency:   item.ProdPercent / 100.0,
@@ -217,6 +220,15 @@ func (client *Client) GetGeneratorStats(ctx context.Context) (*models.GeneratorS
 		}
 	}
 
+	// The balance is best-effort, generator stats are still useful without it
+	if circuits, err := client.ListCircuits(ctx); err == nil {
+		for _, circuit := range circuits {
+			stats.TotalProduction += circuit.Production.Total
+			stats.TotalConsumption += circuit.Consumption.Total
+		}
+		stats.NetBalance = stats.TotalProduction - stats.TotalConsumption
+	}
+
 	return &stats, nil
 }
 
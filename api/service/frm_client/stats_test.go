@@ -0,0 +1,116 @@
+package frm_client
+
+import (
+	"api/models/models"
+	"api/service/frm_client/frm_models"
+	"context"
+	"testing"
+)
+
+func TestGetFactoryStatsOverallEfficiency(t *testing.T) {
+	client := newStubClient(t, map[string]any{
+		"/getFactory": []frm_models.FactoryMachine{
+			{IsConfigured: true, IsProducing: true},
+			{IsConfigured: true, IsProducing: true},
+			{IsConfigured: true, IsProducing: true},
+			{IsConfigured: true},
+			{IsConfigured: true, IsPaused: true},
+			{IsConfigured: false},
+		},
+	})
+
+	stats, err := client.GetFactoryStats(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.TotalMachines != 6 || stats.Efficiency.MachinesUnconfigured != 1 {
+		t.Errorf("got %d machines with %d unconfigured, want 6 with 1", stats.TotalMachines, stats.Efficiency.MachinesUnconfigured)
+	}
+	if want := 3.0 / 5; stats.Efficiency.OverallEfficiency != want {
+		t.Errorf("got overall efficiency %v, want %v", stats.Efficiency.OverallEfficiency, want)
+	}
+}
+
+func TestGetProdStatsJoinsClassAndDisplayNames(t *testing.T) {
+	client := newStubClient(t, map[string]any{
+		"/getProdStats": []frm_models.ProdStatItem{
+			{Name: "Iron Plate", CurrentProd: 60},
+			{Name: "Desc_Wire_C", CurrentProd: 30},
+		},
+		"/getWorldInv": []frm_models.WorldInvItem{
+			{Name: "Desc_IronPlate_C", Amount: 100},
+			{Name: "Iron Plate", Amount: 50},
+			{Name: "Wire", Amount: 20},
+		},
+		"/getCloudInv": []frm_models.CloudInvItem{
+			{ClassName: "Desc_IronPlate_C", Amount: 7},
+			{Name: "Wire", ClassName: "Desc_Wire_C", Amount: 3},
+		},
+	})
+
+	stats, err := client.GetProdStats(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		wantCount float64
+		wantCloud float64
+	}{
+		{"Iron Plate", 150, 7},
+		{"Wire", 20, 3},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for _, item := range stats.Items {
+				if item.Name != test.name {
+					continue
+				}
+				if item.Count != test.wantCount || item.CloudCount != test.wantCloud {
+					t.Errorf("got count %v and cloud count %v, want %v and %v", item.Count, item.CloudCount, test.wantCount, test.wantCloud)
+				}
+				return
+			}
+			t.Errorf("item missing from %+v", stats.Items)
+		})
+	}
+}
+
+func TestGetGeneratorStatsPowerBalance(t *testing.T) {
+	generators := []frm_models.Generator{
+		{Name: "Coal-Powered Generator", RegulatedDemandProd: 75},
+		{Name: "Coal-Powered Generator", RegulatedDemandProd: 50},
+		{Name: "Geothermal Generator", ProductionCapacity: 200},
+	}
+	client := newStubClient(t, map[string]any{
+		"/getGenerators": generators,
+		// The balance spans every circuit, including one that only consumes
+		"/getPower": []frm_models.Circuit{
+			{CircuitID: "1", PowerProduction: 325, PowerConsumed: 250},
+			{CircuitID: "2", PowerConsumed: 100},
+		},
+	})
+
+	stats, err := client.GetGeneratorStats(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if coal := stats.Sources[models.PowerTypeCoal]; coal.Count != 2 || coal.TotalProduction != 125_000_000 {
+		t.Errorf("got coal %+v, want 2 generators producing 125 MW", coal)
+	}
+	if stats.TotalProduction != 325_000_000 || stats.TotalConsumption != 350_000_000 || stats.NetBalance != -25_000_000 {
+		t.Errorf("got production %v, consumption %v and balance %v, want 325, 350 and -25 MW",
+			stats.TotalProduction, stats.TotalConsumption, stats.NetBalance)
+	}
+
+	// Without circuits the sources are still reported, with no balance
+	client = newStubClient(t, map[string]any{"/getGenerators": generators})
+	stats, err = client.GetGeneratorStats(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stats.Sources) != 2 || stats.TotalProduction != 0 || stats.NetBalance != 0 {
+		t.Errorf("got %+v, want both sources without a balance", stats)
+	}
+}
@@ -0,0 +1,130 @@
+package frm_client
+
+import (
+	"api/models/models"
+	"sync"
+	"time"
+)
+
+// trainDockObservation is what a single ListTrains poll saw for one train
+type trainDockObservation struct {
+	Station string // Empty when the train is not docked
+	Loading bool   // True when a platform at the station is transferring cargo
+}
+
+type trainDockVisit struct {
+	station     string
+	start       time.Time
+	lastSeen    time.Time
+	lastLoading bool
+	loading     time.Duration
+	blocked     time.Duration
+}
+
+type trainStationDockTotals struct {
+	count   int
+	total   time.Duration
+	loading time.Duration
+	blocked time.Duration
+	last    time.Duration
+}
+
+// trainDockTracker measures how long trains stay docked at each station across polls.
+// Time between two polls is attributed to the state seen at the earlier poll, so the
+// resolution is bounded by the train polling interval.
+type trainDockTracker struct {
+	mu       sync.Mutex
+	now      func() time.Time
+	visits   map[string]*trainDockVisit // Train ID -> ongoing visit
+	stations map[string]*trainStationDockTotals
+}
+
+func newTrainDockTracker(now func() time.Time) *trainDockTracker {
+	return &trainDockTracker{
+		now:      now,
+		visits:   make(map[string]*trainDockVisit),
+		stations: make(map[string]*trainStationDockTotals),
+	}
+}
+
+// observe records one poll worth of train docking state, keyed by train ID.
+// Trains missing from the observations are treated as having left their station.
+func (tracker *trainDockTracker) observe(observations map[string]trainDockObservation) {
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+
+	now := tracker.now()
+
+	for trainID, visit := range tracker.visits {
+		tracker.accumulate(visit, now)
+
+		observation, present := observations[trainID]
+		if !present || observation.Station != visit.station {
+			tracker.finish(visit)
+			delete(tracker.visits, trainID)
+			continue
+		}
+
+		visit.lastLoading = observation.Loading
+	}
+
+	for trainID, observation := range observations {
+		if observation.Station == "" {
+			continue
+		}
+		if _, ongoing := tracker.visits[trainID]; ongoing {
+			continue
+		}
+		tracker.visits[trainID] = &trainDockVisit{
+			station:     observation.Station,
+			start:       now,
+			lastSeen:    now,
+			lastLoading: observation.Loading,
+		}
+	}
+}
+
+// stats returns the dock statistics of completed visits at a station
+func (tracker *trainDockTracker) stats(station string) models.TrainStationDockStats {
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+
+	totals, ok := tracker.stations[station]
+	if !ok || totals.count == 0 {
+		return models.TrainStationDockStats{}
+	}
+
+	count := float64(totals.count)
+	return models.TrainStationDockStats{
+		DockCount:          totals.count,
+		AverageDockTime:    totals.total.Seconds() / count,
+		LastDockTime:       totals.last.Seconds(),
+		AverageLoadingTime: totals.loading.Seconds() / count,
+		AverageBlockedTime: totals.blocked.Seconds() / count,
+	}
+}
+
+func (tracker *trainDockTracker) accumulate(visit *trainDockVisit, now time.Time) {
+	elapsed := now.Sub(visit.lastSeen)
+	if visit.lastLoading {
+		visit.loading += elapsed
+	} else {
+		visit.blocked += elapsed
+	}
+	visit.lastSeen = now
+}
+
+func (tracker *trainDockTracker) finish(visit *trainDockVisit) {
+	totals, ok := tracker.stations[visit.station]
+	if !ok {
+		totals = &trainStationDockTotals{}
+		tracker.stations[visit.station] = totals
+	}
+
+	duration := visit.lastSeen.Sub(visit.start)
+	totals.count++
+	totals.total += duration
+	totals.loading += visit.loading
+	totals.blocked += visit.blocked
+	totals.last = duration
+}
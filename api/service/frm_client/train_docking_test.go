@@ -0,0 +1,45 @@
+package frm_client
+
+import (
+	"api/models/models"
+	"testing"
+	"time"
+)
+
+func TestTrainDockTrackerMeasuresVisits(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	tracker := newTrainDockTracker(func() time.Time { return now })
+	poll := func(at time.Duration, observations map[string]trainDockObservation) {
+		now = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC).Add(at)
+		tracker.observe(observations)
+	}
+
+	poll(0, map[string]trainDockObservation{"a": {Station: "Iron", Loading: true}})
+	poll(10*time.Second, map[string]trainDockObservation{"a": {Station: "Iron", Loading: true}})
+	poll(20*time.Second, map[string]trainDockObservation{"a": {Station: "Iron"}}) // Done loading, waiting to leave
+	poll(30*time.Second, map[string]trainDockObservation{"a": {}})
+	poll(40*time.Second, map[string]trainDockObservation{
+		"a": {Station: "Iron", Loading: true},
+		"b": {Station: "Iron", Loading: true},
+	})
+	// Train a moves on to the next station between two polls, b stays docked
+	poll(50*time.Second, map[string]trainDockObservation{
+		"a": {Station: "Copper"},
+		"b": {Station: "Iron", Loading: true},
+	})
+	poll(60*time.Second, map[string]trainDockObservation{"b": {Station: "Iron", Loading: true}}) // Train a is gone
+
+	tests := []struct {
+		station string
+		want    models.TrainStationDockStats
+	}{
+		{"Iron", models.TrainStationDockStats{DockCount: 2, AverageDockTime: 20, LastDockTime: 10, AverageLoadingTime: 15, AverageBlockedTime: 5}},
+		{"Copper", models.TrainStationDockStats{DockCount: 1, AverageDockTime: 10, LastDockTime: 10, AverageBlockedTime: 10}},
+		{"Unvisited", models.TrainStationDockStats{}},
+	}
+	for _, test := range tests {
+		if got := tracker.stats(test.station); got != test.want {
+			t.Errorf("%s: got %+v, want %+v", test.station, got, test.want)
+		}
+	}
+}
@@ -2,9 +2,12 @@ package frm_client
 
 import (
 	"api/models/models"
+	"api/pkg/log"
+	"api/service/analysis"
 	"api/service/frm_client/frm_models"
 	"context"
 	"fmt"
+	"math"
 	"sort"
 	"strings"
 	"sync"
@@ -75,43 +78,7 @@ func (client *Client) ListTrains(ctx context.Context) ([]models.Train, error) {
 				continue
 			}
 
-			platformType := models.TrainStationPlatformTypeFreight
-			if rawPlatform.ClassName == "Build_TrainDockingStationLiquid_C" {
-				platformType = models.TrainStationPlatformTypeFluidFreight
-			}
-
-			// Determine platform mode from LoadingMode
-			platformMode := models.TrainStationPlatformModeImport
-			if rawPlatform.LoadingMode == "Unloading" {
-				platformMode = models.TrainStationPlatformModeExport
-			}
-
-			// Determine platform status from LoadingStatus
-			platformStatus := models.TrainStationPlatformStatusIdle
-			if rawPlatform.LoadingStatus != "Idle" && rawPlatform.LoadingStatus != "" {
-				platformStatus = models.TrainStationPlatformStatusDocking
-			}
-
-			inventory := make([]models.ItemStats, len(rawPlatform.Inventory))
-			for k, item := range rawPlatform.Inventory {
-				inventory[k] = models.ItemStats{
-					Name:  item.Name,
-					Count: item.Amount,
-				}
-			}
-
-			platforms = append(platforms, models.TrainStationPlatform{
-				ID:           rawPlatform.ID,
-				Type:         platformType,
-				Mode:         platformMode,
-				Status:       platformStatus,
-				Location:     parseLocation(rawPlatform.Location),
-				BoundingBox:  parseBoundingBox(rawPlatform.BoundingBox),
-				Inventory:    inventory,
-				TransferRate: rawPlatform.TransferRate,
-				InflowRate:   rawPlatform.InflowRate,
-				OutflowRate:  rawPlatform.OutflowRate,
-			})
+			platforms = append(platforms, parseTrainStationPlatform(rawPlatform))
 		}
 
 		modelStations[i] = models.TrainStation{
@@ -122,12 +89,34 @@ func (client *Client) ListTrains(ctx context.Context) ([]models.Train, error) {
 		}
 	}
 
+	// Station names are not unique, so keep every candidate and resolve the nearest per train
+	stationsByName := make(map[string][]models.TrainStation)
+	for _, station := range modelStations {
+		stationsByName[station.Name] = append(stationsByName[station.Name], station)
+	}
+
 	trains := make([]models.Train, 0, len(rawTrains))
+	dockObservations := make(map[string]trainDockObservation, len(rawTrains))
 	for _, raw := range rawTrains {
+		observation := trainDockObservation{}
+		if !raw.Derailed {
+			if station := findDockedTrainStation(&raw, modelStations); station != nil {
+				observation.Station = station.Name
+				for _, platform := range station.Platforms {
+					if platform.Status == models.TrainStationPlatformStatusDocking {
+						observation.Loading = true
+						break
+					}
+				}
+			}
+		}
+		dockObservations[raw.ID] = observation
+
 		timetable := make([]models.TrainTimetableEntry, len(raw.TimeTable))
 		for i, stop := range raw.TimeTable {
 			timetable[i] = models.TrainTimetableEntry{
-				Station: stop.StationName,
+				Station:  stop.StationName,
+				Location: nearestTrainStationLocation(stationsByName[stop.StationName], parseLocation(raw.Location)),
 			}
 		}
 
@@ -135,10 +124,7 @@ func (client *Client) ListTrains(ctx context.Context) ([]models.Train, error) {
 		for i, v := range raw.Vehicles {
 			inventory := make([]models.ItemStats, len(v.Inventory))
 			for j, item := range v.Inventory {
-				inventory[j] = models.ItemStats{
-					Name:  item.Name,
-					Count: item.Amount,
-				}
+				inventory[j] = parseItemStats(item.Name, item.Amount)
 			}
 			vehicles[i] = models.TrainVehicle{
 				Type:      classNameToTrainType(v.ClassName),
@@ -161,6 +147,8 @@ func (client *Client) ListTrains(ctx context.Context) ([]models.Train, error) {
 		})
 	}
 
+	client.trainDocks.observe(dockObservations)
+
 	return trains, nil
 }
 
@@ -229,48 +217,7 @@ func (client *Client) ListTrainStations(ctx context.Context) ([]models.TrainStat
 		// Convert assigned platforms to model platforms
 		platforms := make([]models.TrainStationPlatform, len(stationPlatforms[i]))
 		for j, assignment := range stationPlatforms[i] {
-			rawPlatform := assignment.rawPlatform
-
-			// Determine platform type from ClassName
-			platformType := models.TrainStationPlatformTypeFreight
-			if rawPlatform.ClassName == "Build_TrainDockingStationLiquid_C" {
-				platformType = models.TrainStationPlatformTypeFluidFreight
-			}
-
-			// Determine platform mode from LoadingMode
-			platformMode := models.TrainStationPlatformModeImport
-			if rawPlatform.LoadingMode == "Loading" {
-				platformMode = models.TrainStationPlatformModeExport
-			}
-
-			// Determine platform status from LoadingStatus
-			// "Idle" means idle, anything else (Loading/Unloading) means docking
-			platformStatus := models.TrainStationPlatformStatusIdle
-			if rawPlatform.LoadingStatus != "Idle" && rawPlatform.LoadingStatus != "" {
-				platformStatus = models.TrainStationPlatformStatusDocking
-			}
-
-			// Parse inventory
-			inventory := make([]models.ItemStats, len(rawPlatform.Inventory))
-			for k, item := range rawPlatform.Inventory {
-				inventory[k] = models.ItemStats{
-					Name:  item.Name,
-					Count: item.Amount,
-				}
-			}
-
-			platforms[j] = models.TrainStationPlatform{
-				ID:           rawPlatform.ID,
-				Type:         platformType,
-				Mode:         platformMode,
-				Status:       platformStatus,
-				Location:     parseLocation(rawPlatform.Location),
-				BoundingBox:  parseBoundingBox(rawPlatform.BoundingBox),
-				Inventory:    inventory,
-				TransferRate: rawPlatform.TransferRate,
-				InflowRate:   rawPlatform.InflowRate,
-				OutflowRate:  rawPlatform.OutflowRate,
-			}
+			platforms[j] = parseTrainStationPlatform(assignment.rawPlatform)
 		}
 
 		stations[i] = models.TrainStation{
@@ -279,7 +226,123 @@ func (client *Client) ListTrainStations(ctx context.Context) ([]models.TrainStat
 			BoundingBox: parseBoundingBox(raw.BoundingBox),
 			CircuitIDs:  parseCircuitIDsFromPowerInfo(raw.PowerInfo),
 			Platforms:   platforms,
+			DockStats:   client.trainDocks.stats(raw.Name),
 		}
 	}
+
+	client.warnDuplicateTrainStations(stations)
+
 	return stations, nil
 }
+
+// nearestTrainStationLocation returns a copy of the location of the candidate station closest to the
+// given location, so timetable stops do not alias the stations they were matched to
+func nearestTrainStationLocation(candidates []models.TrainStation, location models.Location) *models.Location {
+	nearest := -1
+	nearestDistance := math.Inf(1)
+	for idx := range candidates {
+		dx := candidates[idx].X - location.X
+		dy := candidates[idx].Y - location.Y
+		dz := candidates[idx].Z - location.Z
+		distance := dx*dx + dy*dy + dz*dz
+		if distance < nearestDistance {
+			nearestDistance = distance
+			nearest = idx
+		}
+	}
+	if nearest < 0 {
+		return nil
+	}
+	stationLocation := candidates[nearest].Location
+	return &stationLocation
+}
+
+// warnDuplicateTrainStations logs stations sharing a name, once per distinct set of duplicates
+func (client *Client) warnDuplicateTrainStations(stations []models.TrainStation) {
+	duplicates := analysis.FindDuplicateTrainStationNames(stations)
+
+	names := make([]string, len(duplicates))
+	for i, duplicate := range duplicates {
+		names[i] = duplicate.Name
+	}
+	key := strings.Join(names, "\x00")
+
+	client.duplicateStationsLock.Lock()
+	defer client.duplicateStationsLock.Unlock()
+	if key == client.lastDuplicateStations {
+		return
+	}
+	client.lastDuplicateStations = key
+
+	for _, duplicate := range duplicates {
+		locations := make([]string, len(duplicate.Locations))
+		for i, location := range duplicate.Locations {
+			locations[i] = fmt.Sprintf("(%.0f, %.0f, %.0f)", location.X, location.Y, location.Z)
+		}
+		log.Warnf("Duplicate train station name %q on %s at %s, timetables using it are ambiguous", duplicate.Name, client.apiUrl, strings.Join(locations, ", "))
+	}
+}
+
+// fluidPlatformCapacity is the tank capacity of a fluid freight platform, used when FRM reports no MaxAmount
+const fluidPlatformCapacity = 2400.0
+
+// parseTrainStationPlatform converts a raw cargo platform, including its fill level and stall state
+func parseTrainStationPlatform(rawPlatform frm_models.TrainStationPlatform) models.TrainStationPlatform {
+	// Determine platform type from ClassName
+	platformType := models.TrainStationPlatformTypeFreight
+	if rawPlatform.ClassName == "Build_TrainDockingStationLiquid_C" {
+		platformType = models.TrainStationPlatformTypeFluidFreight
+	}
+
+	// Determine platform mode from LoadingMode. A loading platform fills trains with its cargo,
+	// exporting it from the station, and an unloading one imports the train's cargo
+	platformMode := models.TrainStationPlatformModeImport
+	if rawPlatform.LoadingMode == "Loading" {
+		platformMode = models.TrainStationPlatformModeExport
+	}
+
+	// Determine platform status from LoadingStatus
+	// "Idle" means idle, anything else (Loading/Unloading) means docking
+	platformStatus := models.TrainStationPlatformStatusIdle
+	if rawPlatform.LoadingStatus != "Idle" && rawPlatform.LoadingStatus != "" {
+		platformStatus = models.TrainStationPlatformStatusDocking
+	}
+
+	// Parse inventory and sum up the fill level
+	inventory := make([]models.ItemStats, len(rawPlatform.Inventory))
+	amount := 0.0
+	capacity := 0.0
+	for k, item := range rawPlatform.Inventory {
+		inventory[k] = parseItemStats(item.Name, item.Amount)
+		amount += item.Amount
+		capacity += item.MaxAmount
+	}
+	if capacity == 0 && platformType == models.TrainStationPlatformTypeFluidFreight {
+		capacity = fluidPlatformCapacity
+	}
+
+	fill := 0.0
+	if capacity > 0 {
+		fill = math.Min(amount/capacity, 1)
+	}
+
+	// A full export platform or an empty import platform blocks trains from completing their transfer
+	stalled := (platformMode == models.TrainStationPlatformModeExport && capacity > 0 && fill >= 1) ||
+		(platformMode == models.TrainStationPlatformModeImport && amount == 0)
+
+	return models.TrainStationPlatform{
+		ID:           rawPlatform.ID,
+		Type:         platformType,
+		Mode:         platformMode,
+		Status:       platformStatus,
+		Location:     parseLocation(rawPlatform.Location),
+		BoundingBox:  parseBoundingBox(rawPlatform.BoundingBox),
+		Inventory:    inventory,
+		Capacity:     capacity,
+		Fill:         fill,
+		Stalled:      stalled,
+		TransferRate: rawPlatform.TransferRate,
+		InflowRate:   rawPlatform.InflowRate,
+		OutflowRate:  rawPlatform.OutflowRate,
+	}
+}
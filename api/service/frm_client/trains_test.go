@@ -0,0 +1,133 @@
+package frm_client
+
+import (
+	"api/models/models"
+	"api/service/frm_client/frm_models"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestParseTrainStationPlatform(t *testing.T) {
+	tests := []struct {
+		name        string
+		raw         frm_models.TrainStationPlatform
+		wantMode    models.TrainStationPlatformMode
+		wantStatus  models.TrainStationPlatformStatus
+		wantFill    float64
+		wantStalled bool
+	}{
+		{
+			name: "loading platform exports",
+			raw: frm_models.TrainStationPlatform{LoadingMode: "Loading", LoadingStatus: "Loading", Inventory: []frm_models.InventoryItem{
+				{Name: "Iron Plate", Amount: 1200, MaxAmount: 2400},
+			}},
+			wantMode:   models.TrainStationPlatformModeExport,
+			wantStatus: models.TrainStationPlatformStatusDocking,
+			wantFill:   0.5,
+		},
+		{
+			name: "unloading platform imports",
+			raw: frm_models.TrainStationPlatform{LoadingMode: "Unloading", LoadingStatus: "Idle", Inventory: []frm_models.InventoryItem{
+				{Name: "Iron Plate", Amount: 600, MaxAmount: 2400},
+			}},
+			wantMode:   models.TrainStationPlatformModeImport,
+			wantStatus: models.TrainStationPlatformStatusIdle,
+			wantFill:   0.25,
+		},
+		{
+			name: "full export platform stalls",
+			raw: frm_models.TrainStationPlatform{LoadingMode: "Loading", Inventory: []frm_models.InventoryItem{
+				{Name: "Iron Plate", Amount: 2400, MaxAmount: 2400},
+			}},
+			wantMode:    models.TrainStationPlatformModeExport,
+			wantStatus:  models.TrainStationPlatformStatusIdle,
+			wantFill:    1,
+			wantStalled: true,
+		},
+		{
+			name:        "empty import platform stalls",
+			raw:         frm_models.TrainStationPlatform{LoadingMode: "Unloading", LoadingStatus: "Unloading"},
+			wantMode:    models.TrainStationPlatformModeImport,
+			wantStatus:  models.TrainStationPlatformStatusDocking,
+			wantStalled: true,
+		},
+		{
+			name: "fluid platform without max amount uses the tank capacity",
+			raw: frm_models.TrainStationPlatform{ClassName: "Build_TrainDockingStationLiquid_C", LoadingMode: "Unloading", Inventory: []frm_models.InventoryItem{
+				{Name: "Water", Amount: 600},
+			}},
+			wantMode:   models.TrainStationPlatformModeImport,
+			wantStatus: models.TrainStationPlatformStatusIdle,
+			wantFill:   600 / fluidPlatformCapacity,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			platform := parseTrainStationPlatform(test.raw)
+			if platform.Mode != test.wantMode || platform.Status != test.wantStatus {
+				t.Errorf("got mode %s and status %s, want %s and %s", platform.Mode, platform.Status, test.wantMode, test.wantStatus)
+			}
+			if platform.Fill != test.wantFill || platform.Stalled != test.wantStalled {
+				t.Errorf("got fill %v and stalled %v, want %v and %v", platform.Fill, platform.Stalled, test.wantFill, test.wantStalled)
+			}
+		})
+	}
+}
+
+func TestNearestTrainStationLocation(t *testing.T) {
+	stations := []models.TrainStation{
+		{Name: "Iron", Location: models.Location{X: 0, Y: 0}},
+		{Name: "Iron", Location: models.Location{X: 10000, Y: 0}},
+	}
+
+	nearest := nearestTrainStationLocation(stations, models.Location{X: 9000, Y: 500})
+	if nearest == nil || nearest.X != 10000 {
+		t.Fatalf("got %+v, want the station at x 10000", nearest)
+	}
+
+	nearest.X = 42
+	if stations[1].X != 10000 {
+		t.Errorf("changing the returned location moved the station to x %v", stations[1].X)
+	}
+
+	if nearest := nearestTrainStationLocation(nil, models.Location{}); nearest != nil {
+		t.Errorf("got %+v without candidates, want nil", nearest)
+	}
+}
+
+func TestWarnDuplicateTrainStations(t *testing.T) {
+	logs := observeWarnings(t)
+	client := NewClientWithAddress("http://localhost", nil)
+	t.Cleanup(client.requestQueue.Stop)
+
+	station := func(name string, x float64) models.TrainStation {
+		return models.TrainStation{Name: name, Location: models.Location{X: x}}
+	}
+	steps := []struct {
+		name         string
+		stations     []models.TrainStation
+		wantWarnings []string // Station names warned about in this step
+	}{
+		{"unique names", []models.TrainStation{station("Iron", 0), station("Copper", 100)}, nil},
+		{"duplicate name", []models.TrainStation{station("Iron", 0), station("Iron", 100), station("Copper", 200)}, []string{"Iron"}},
+		{"same duplicates again", []models.TrainStation{station("Iron", 0), station("Iron", 100)}, nil},
+		{"new duplicate", []models.TrainStation{station("Iron", 0), station("Iron", 100), station("Copper", 0), station("Copper", 1)}, []string{"Copper", "Iron"}},
+	}
+
+	for _, step := range steps {
+		t.Run(step.name, func(t *testing.T) {
+			client.warnDuplicateTrainStations(step.stations)
+			entries := logs.TakeAll()
+			if len(entries) != len(step.wantWarnings) {
+				t.Fatalf("got %d warnings, want %d: %v", len(entries), len(step.wantWarnings), entries)
+			}
+			for i, name := range step.wantWarnings {
+				if !strings.Contains(entries[i].Message, fmt.Sprintf("%q", name)) {
+					t.Errorf("warning %q does not name station %s", entries[i].Message, name)
+				}
+			}
+		})
+	}
+}
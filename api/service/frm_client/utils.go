@@ -2,25 +2,54 @@ package frm_client
 
 import (
 	"api/models/models"
+	"api/pkg/config"
 	"api/service/frm_client/frm_models"
+	"fmt"
 )
 
 func parseBoundingBox(box frm_models.BoundingBox) models.BoundingBox {
 	return models.BoundingBox{
-		Min: models.Location{X: box.Min.X, Y: box.Min.Y, Z: box.Min.Z},
-		Max: models.Location{X: box.Max.X, Y: box.Max.Y, Z: box.Max.Z},
+		Min: parseLocation(frm_models.Location{X: box.Min.X, Y: box.Min.Y, Z: box.Min.Z}),
+		Max: parseLocation(frm_models.Location{X: box.Max.X, Y: box.Max.Y, Z: box.Max.Z}),
 	}
 }
 
+// machineID returns the building ID FRM reports for a machine, or one derived from its type and location
+// rounded to whole centimeters so floating-point jitter between polls does not change it. Grid-snapped
+// buildings sit on whole centimeters, far from the rounding boundaries. Only a free-placed building
+// with a coordinate within the jitter of a half centimeter can still change ID between polls.
+func machineID(buildingID, name string, loc frm_models.Location) string {
+	if buildingID != "" {
+		return buildingID
+	}
+	return fmt.Sprintf("%s@%.0f,%.0f,%.0f", name, loc.X, loc.Y, loc.Z)
+}
+
 func parseLocation(loc frm_models.Location) models.Location {
 	return models.Location{
 		X:        loc.X,
 		Y:        loc.Y,
 		Z:        loc.Z,
 		Rotation: loc.Rotation,
+		XMeters:  dualUnit(loc.X / 100), // cm to m
+		YMeters:  dualUnit(loc.Y / 100), // cm to m
+		ZMeters:  dualUnit(loc.Z / 100), // cm to m
 	}
 }
 
+// dualUnitsEnabled reports whether normalized-unit fields should be emitted next to the raw ones
+func dualUnitsEnabled() bool {
+	return config.Config != nil && config.Config.DualUnits
+}
+
+// dualUnit returns the value for an alternate-unit field, or nil when dual units are disabled
+func dualUnit(value float64) *float64 {
+	if !dualUnitsEnabled() {
+		return nil
+	}
+	return &value
+}
+
 func parseCircuitIDsFromPowerInfo(powerInfo frm_models.PowerInfo) models.CircuitIDs {
 	return models.CircuitIDs{
 		CircuitID:      powerInfo.CircuitID,
@@ -42,3 +71,14 @@ func parsePowerInfo(powerInfo frm_models.PowerInfo) models.PowerInfo {
 		MaxPowerConsumed: powerInfo.MaxPowerConsumed,
 	}
 }
+
+func parseItemStats(name string, count float64) models.ItemStats {
+	metadata := lookupItemMetadata(name)
+	return models.ItemStats{
+		Name:     name,
+		Count:    count,
+		Category: metadata.Category,
+		Form:     itemForm(name, metadata.Category),
+		Tier:     metadata.Tier,
+	}
+}
@@ -0,0 +1,83 @@
+package frm_client
+
+import (
+	"api/pkg/config"
+	"api/service/frm_client/frm_models"
+	"testing"
+)
+
+func TestMachineIDStableAcrossPolls(t *testing.T) {
+	tests := []struct {
+		name       string
+		buildingID string
+		first      frm_models.Location
+		second     frm_models.Location
+		wantSame   bool
+	}{
+		{
+			name:     "same machine with jitter",
+			first:    frm_models.Location{X: 12350.0001, Y: -800.4, Z: 100},
+			second:   frm_models.Location{X: 12349.9998, Y: -800.3999, Z: 100.0002},
+			wantSame: true,
+		},
+		{
+			name:     "neighbouring machines",
+			first:    frm_models.Location{X: 12350, Y: -800, Z: 100},
+			second:   frm_models.Location{X: 12450, Y: -800, Z: 100},
+			wantSame: false,
+		},
+		{
+			name:       "building ID wins over the location",
+			buildingID: "Build_ConstructorMk1_C_1",
+			first:      frm_models.Location{X: 12350},
+			second:     frm_models.Location{X: 99999},
+			wantSame:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			first := machineID(test.buildingID, "Constructor", test.first)
+			second := machineID(test.buildingID, "Constructor", test.second)
+			if (first == second) != test.wantSame {
+				t.Errorf("got IDs %q and %q, want same %v", first, second, test.wantSame)
+			}
+		})
+	}
+
+	if constructor, assembler := machineID("", "Constructor", frm_models.Location{}), machineID("", "Assembler", frm_models.Location{}); constructor == assembler {
+		t.Errorf("machines of different types at one location share the ID %q", constructor)
+	}
+}
+
+func TestParseLocationDualUnits(t *testing.T) {
+	previous := config.Config
+	t.Cleanup(func() { config.Config = previous })
+	raw := frm_models.Location{X: 12345, Y: -250, Z: 0, Rotation: 90}
+
+	config.Config = &config.Type{}
+	location := parseLocation(raw)
+	if location.XMeters != nil || location.YMeters != nil || location.ZMeters != nil {
+		t.Errorf("got meter fields %+v with dual units disabled, want none", location)
+	}
+
+	config.Config = &config.Type{DualUnits: true}
+	location = parseLocation(raw)
+	if location.X != 12345 || location.Y != -250 || location.Rotation != 90 {
+		t.Errorf("got raw location %+v, want the centimeter values kept", location)
+	}
+	axes := []struct {
+		name string
+		got  *float64
+		want float64
+	}{
+		{"x", location.XMeters, 123.45},
+		{"y", location.YMeters, -2.5},
+		{"z", location.ZMeters, 0},
+	}
+	for _, axis := range axes {
+		if axis.got == nil || *axis.got != axis.want {
+			t.Errorf("%s: got %v meters, want %v", axis.name, axis.got, axis.want)
+		}
+	}
+}
@@ -110,52 +110,24 @@ func (client *Client) GetVehicles(ctx context.Context) (models.Vehicles, error)
 	return vehicles, nil
 }
 
+// ListTrucks fetches truck data
 func (client *Client) ListTrucks(ctx context.Context) ([]models.Truck, error) {
-	var rawTrucks []frm_models.Truck
-	err := client.makeSatisfactoryCall(ctx, "/getTruck", &rawTrucks)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get trucks. details: %w", err)
+	statuses := groundVehicleStatuses[models.TruckStatus]{
+		selfDriving:   models.TruckStatusSelfDriving,
+		manualDriving: models.TruckStatusManualDriving,
+		parked:        models.TruckStatusParked,
 	}
-
-	trucks := make([]models.Truck, len(rawTrucks))
-	for i, raw := range rawTrucks {
-		inventory := make([]models.ItemStats, 0)
-		for _, item := range raw.Storage {
-			inventory = append(inventory, models.ItemStats{
-				Name:  item.Name,
-				Count: item.Amount,
-			})
-		}
-
-		var status models.TruckStatus
-		if raw.AutoPilot {
-			status = models.TruckStatusSelfDriving
-		} else if raw.Driver != "" {
-			status = models.TruckStatusManualDriving
-		} else {
-			status = models.TruckStatusParked
-		}
-
-		// Pick first fuel entry as primary fuel
-		var fuel *models.Fuel
-		if len(raw.Fuel) > 0 {
-			fuel = &models.Fuel{
-				Name:   raw.Fuel[0].FuelName,
-				Amount: raw.Fuel[0].Amount,
-			}
-		}
-
-		trucks[i] = models.Truck{
-			ID:        raw.ID,
-			Name:      raw.Name,
-			Speed:     raw.ForwardSpeed,
-			Status:    status,
-			Fuel:      fuel,
-			Inventory: inventory,
-			Location:  parseLocation(raw.Location),
+	return listGroundVehicles(ctx, client, "/getTruck", "trucks", statuses, func(vehicle groundVehicle[models.TruckStatus]) models.Truck {
+		return models.Truck{
+			ID:        vehicle.ID,
+			Name:      vehicle.Name,
+			Speed:     vehicle.Speed,
+			Status:    vehicle.Status,
+			Fuel:      vehicle.Fuel,
+			Inventory: vehicle.Inventory,
+			Location:  vehicle.Location,
 		}
-	}
-	return trucks, nil
+	})
 }
 
 // GetVehicleStations fetches all vehicle station data: train, drone, and truck stations
@@ -239,20 +211,18 @@ func (client *Client) ListTruckStations(ctx context.Context) ([]models.TruckStat
 		// Parse Inventory
 		inventory := make([]models.ItemStats, len(raw.Inventory))
 		for j, item := range raw.Inventory {
-			inventory[j] = models.ItemStats{
-				Name:  item.Name,
-				Count: item.Amount,
-			}
+			inventory[j] = parseItemStats(item.Name, item.Amount)
 		}
 
 		stations[i] = models.TruckStation{
-			Name:            raw.Name,
-			Location:        parseLocation(raw.Location),
-			BoundingBox:     parseBoundingBox(raw.BoundingBox),
-			CircuitIDs:      parseCircuitIDsFromPowerInfo(raw.PowerInfo),
-			TransferRate:    raw.TransferRate,
-			MaxTransferRate: raw.MaxTransferRate,
-			Inventory:       inventory,
+			Name:             raw.Name,
+			Location:         parseLocation(raw.Location),
+			BoundingBox:      parseBoundingBox(raw.BoundingBox),
+			CircuitIDs:       parseCircuitIDsFromPowerInfo(raw.PowerInfo),
+			TransferRate:     raw.TransferRate,
+			MaxTransferRate:  raw.MaxTransferRate,
+			TransferRateUnit: models.RateUnitStacksPerSecond,
+			Inventory:        inventory,
 		}
 	}
 	return stations, nil
@@ -260,100 +230,109 @@ func (client *Client) ListTruckStations(ctx context.Context) ([]models.TruckStat
 
 // ListTractors fetches tractor data
 func (client *Client) ListTractors(ctx context.Context) ([]models.Tractor, error) {
-	var rawTractors []frm_models.Tractor
-	err := client.makeSatisfactoryCall(ctx, "/getTractor", &rawTractors)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get tractors. details: %w", err)
+	statuses := groundVehicleStatuses[models.TractorStatus]{
+		selfDriving:   models.TractorStatusSelfDriving,
+		manualDriving: models.TractorStatusManualDriving,
+		parked:        models.TractorStatusParked,
 	}
-
-	tractors := make([]models.Tractor, len(rawTractors))
-	for i, raw := range rawTractors {
-		inventory := make([]models.ItemStats, 0)
-		for _, item := range raw.Storage {
-			inventory = append(inventory, models.ItemStats{
-				Name:  item.Name,
-				Count: item.Amount,
-			})
+	return listGroundVehicles(ctx, client, "/getTractor", "tractors", statuses, func(vehicle groundVehicle[models.TractorStatus]) models.Tractor {
+		return models.Tractor{
+			ID:        vehicle.ID,
+			Name:      vehicle.Name,
+			Speed:     vehicle.Speed,
+			Status:    vehicle.Status,
+			Fuel:      vehicle.Fuel,
+			Inventory: vehicle.Inventory,
+			Location:  vehicle.Location,
 		}
+	})
+}
 
-		var status models.TractorStatus
-		if raw.AutoPilot {
-			status = models.TractorStatusSelfDriving
-		} else if raw.Driver != "" {
-			status = models.TractorStatusManualDriving
-		} else {
-			status = models.TractorStatusParked
+// ListExplorers fetches explorer data
+func (client *Client) ListExplorers(ctx context.Context) ([]models.Explorer, error) {
+	statuses := groundVehicleStatuses[models.ExplorerStatus]{
+		selfDriving:   models.ExplorerStatusSelfDriving,
+		manualDriving: models.ExplorerStatusManualDriving,
+		parked:        models.ExplorerStatusParked,
+	}
+	return listGroundVehicles(ctx, client, "/getExplorer", "explorers", statuses, func(vehicle groundVehicle[models.ExplorerStatus]) models.Explorer {
+		return models.Explorer{
+			ID:        vehicle.ID,
+			Name:      vehicle.Name,
+			Speed:     vehicle.Speed,
+			Status:    vehicle.Status,
+			Fuel:      vehicle.Fuel,
+			Inventory: vehicle.Inventory,
+			Location:  vehicle.Location,
 		}
+	})
+}
 
-		// Pick first fuel entry as primary fuel
-		var fuel *models.Fuel
-		if len(raw.Fuel) > 0 {
-			fuel = &models.Fuel{
-				Name:   raw.Fuel[0].FuelName,
-				Amount: raw.Fuel[0].Amount,
-			}
-		}
+// groundVehicleStatuses are the status values of one ground vehicle type
+type groundVehicleStatuses[S ~string] struct {
+	selfDriving   S
+	manualDriving S
+	parked        S
+}
 
-		tractors[i] = models.Tractor{
-			ID:        raw.ID,
-			Name:      raw.Name,
-			Speed:     raw.ForwardSpeed,
-			Status:    status,
-			Fuel:      fuel,
-			Inventory: inventory,
-			Location:  parseLocation(raw.Location),
-		}
-	}
-	return tractors, nil
+// groundVehicle holds the converted fields shared by trucks, tractors and explorers
+type groundVehicle[S ~string] struct {
+	ID        string
+	Name      string
+	Speed     float64
+	Status    S
+	Fuel      *models.Fuel
+	Inventory []models.ItemStats
+	Location  models.Location
 }
 
-// ListExplorers fetches explorer data
-func (client *Client) ListExplorers(ctx context.Context) ([]models.Explorer, error) {
-	var rawExplorers []frm_models.Explorer
-	err := client.makeSatisfactoryCall(ctx, "/getExplorer", &rawExplorers)
+// listGroundVehicles fetches trucks, tractors or explorers, which FRM reports in the same shape,
+// and converts each through the shared conversion before build maps it to its model type
+func listGroundVehicles[T any, S ~string](ctx context.Context, client *Client, path, kind string, statuses groundVehicleStatuses[S], build func(groundVehicle[S]) T) ([]T, error) {
+	var rawVehicles []frm_models.Truck
+	err := client.makeSatisfactoryCall(ctx, path, &rawVehicles)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get explorers. details: %w", err)
+		return nil, fmt.Errorf("failed to get %s. details: %w", kind, err)
 	}
 
-	explorers := make([]models.Explorer, len(rawExplorers))
-	for i, raw := range rawExplorers {
-		inventory := make([]models.ItemStats, 0)
-		for _, item := range raw.Storage {
-			inventory = append(inventory, models.ItemStats{
-				Name:  item.Name,
-				Count: item.Amount,
-			})
-		}
+	vehicles := make([]T, len(rawVehicles))
+	for i, raw := range rawVehicles {
+		vehicles[i] = build(convertGroundVehicle(raw, statuses))
+	}
+	return vehicles, nil
+}
 
-		var status models.ExplorerStatus
-		if raw.AutoPilot {
-			status = models.ExplorerStatusSelfDriving
-		} else if raw.Driver != "" {
-			status = models.ExplorerStatusManualDriving
-		} else {
-			status = models.ExplorerStatusParked
-		}
+func convertGroundVehicle[S ~string](raw frm_models.Truck, statuses groundVehicleStatuses[S]) groundVehicle[S] {
+	inventory := make([]models.ItemStats, 0)
+	for _, item := range raw.Storage {
+		inventory = append(inventory, parseItemStats(item.Name, item.Amount))
+	}
 
-		// Pick first fuel entry as primary fuel
-		var fuel *models.Fuel
-		if len(raw.Fuel) > 0 {
-			fuel = &models.Fuel{
-				Name:   raw.Fuel[0].FuelName,
-				Amount: raw.Fuel[0].Amount,
-			}
-		}
+	status := statuses.parked
+	if raw.AutoPilot {
+		status = statuses.selfDriving
+	} else if raw.Driver != "" {
+		status = statuses.manualDriving
+	}
 
-		explorers[i] = models.Explorer{
-			ID:        raw.ID,
-			Name:      raw.Name,
-			Speed:     raw.ForwardSpeed,
-			Status:    status,
-			Fuel:      fuel,
-			Inventory: inventory,
-			Location:  parseLocation(raw.Location),
+	// Pick first fuel entry as primary fuel
+	var fuel *models.Fuel
+	if len(raw.Fuel) > 0 {
+		fuel = &models.Fuel{
+			Name:   raw.Fuel[0].FuelName,
+			Amount: raw.Fuel[0].Amount,
 		}
 	}
-	return explorers, nil
+
+	return groundVehicle[S]{
+		ID:        raw.ID,
+		Name:      raw.Name,
+		Speed:     raw.ForwardSpeed,
+		Status:    status,
+		Fuel:      fuel,
+		Inventory: inventory,
+		Location:  parseLocation(raw.Location),
+	}
 }
 
 // ListVehiclePaths fetches vehicle path data
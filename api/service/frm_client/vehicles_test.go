@@ -0,0 +1,79 @@
+package frm_client
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestGroundVehiclesConvertAlike(t *testing.T) {
+	raw := json.RawMessage(`[
+		{"ID": "1", "Name": "auto", "ForwardSpeed": 45, "AutoPilot": true, "Driver": "someone",
+			"Fuel": [{"FuelName": "Packaged Fuel", "Amount": 20}, {"FuelName": "Battery", "Amount": 5}],
+			"Storage": [{"Name": "Iron Plate", "Amount": 100}], "location": {"x": 1000, "y": 2000, "z": 300, "rotation": 90}},
+		{"ID": "2", "Name": "driven", "ForwardSpeed": 12, "Driver": "someone", "location": {"x": -500, "y": 0, "z": 0}},
+		{"ID": "3", "Name": "parked", "location": {"x": 0, "y": 0, "z": 0}}
+	]`)
+	client := newStubClient(t, map[string]any{"/getTruck": raw, "/getTractor": raw, "/getExplorer": raw})
+	ctx := context.Background()
+
+	trucks, err := client.ListTrucks(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tractors, err := client.ListTractors(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	explorers, err := client.ListExplorers(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Reduce every type to the shared fields, with the status as a plain string
+	converted := make(map[string][]groundVehicle[string])
+	for _, truck := range trucks {
+		converted["trucks"] = append(converted["trucks"], groundVehicle[string]{truck.ID, truck.Name, truck.Speed, string(truck.Status), truck.Fuel, truck.Inventory, truck.Location})
+	}
+	for _, tractor := range tractors {
+		converted["tractors"] = append(converted["tractors"], groundVehicle[string]{tractor.ID, tractor.Name, tractor.Speed, string(tractor.Status), tractor.Fuel, tractor.Inventory, tractor.Location})
+	}
+	for _, explorer := range explorers {
+		converted["explorers"] = append(converted["explorers"], groundVehicle[string]{explorer.ID, explorer.Name, explorer.Speed, string(explorer.Status), explorer.Fuel, explorer.Inventory, explorer.Location})
+	}
+
+	for _, kind := range []string{"tractors", "explorers"} {
+		if !reflect.DeepEqual(converted[kind], converted["trucks"]) {
+			t.Errorf("got %s %+v, want them converted like trucks %+v", kind, converted[kind], converted["trucks"])
+		}
+	}
+
+	vehicles := converted["trucks"]
+	if len(vehicles) != 3 {
+		t.Fatalf("got %d vehicles, want 3", len(vehicles))
+	}
+	expected := []struct {
+		status string
+		fuel   string
+	}{
+		{"selfDriving", "Packaged Fuel"}, // Autopilot wins over a driver, the first fuel is primary
+		{"manualDriving", ""},
+		{"parked", ""},
+	}
+	for i, want := range expected {
+		vehicle := vehicles[i]
+		if vehicle.Status != want.status {
+			t.Errorf("%s: got status %s, want %s", vehicle.Name, vehicle.Status, want.status)
+		}
+		if (want.fuel == "") != (vehicle.Fuel == nil) || (vehicle.Fuel != nil && vehicle.Fuel.Name != want.fuel) {
+			t.Errorf("%s: got fuel %+v, want %q", vehicle.Name, vehicle.Fuel, want.fuel)
+		}
+		if vehicle.Inventory == nil {
+			t.Errorf("%s: got a nil inventory, want an empty list", vehicle.Name)
+		}
+	}
+	if auto := vehicles[0]; auto.Speed != 45 || len(auto.Inventory) != 1 || auto.Inventory[0].Count != 100 || auto.Location.X == 0 {
+		t.Errorf("got %+v, want speed, inventory and location converted", auto)
+	}
+}
@@ -25,6 +25,7 @@ const nodeKeyPrefix = "poll:node:"
 type HeartbeatData struct {
 	Status      string    `json:"status"`       // Current status: "init" or "online"
 	StartupTime time.Time `json:"startup_time"` // When this instance started (for tracking)
+	Weight      int       `json:"weight"`       // Relative capacity used by weighted rendezvous hashing
 }
 
 // GenerateInstanceID creates a unique identifier for this API instance.
@@ -61,11 +62,12 @@ func nodeKey(instanceID string) string {
 // RegisterHeartbeat registers this instance in Redis by setting the heartbeat key with TTL.
 // This should be called once when the instance starts with "init" status.
 // The heartbeat key pattern is poll:node:{instanceID} with heartbeat data as JSON value.
-func RegisterHeartbeat(ctx context.Context, client *key_value.Client, instanceID string, ttl time.Duration) error {
+func RegisterHeartbeat(ctx context.Context, client *key_value.Client, instanceID string, weight int, ttl time.Duration) error {
 	key := nodeKey(instanceID)
 	data := HeartbeatData{
 		Status:      "init",
 		StartupTime: time.Now(),
+		Weight:      weight,
 	}
 	jsonData, err := json.Marshal(data)
 	if err != nil {
@@ -76,12 +78,13 @@ func RegisterHeartbeat(ctx context.Context, client *key_value.Client, instanceID
 
 // RefreshHeartbeat refreshes the TTL on an existing heartbeat key with the current status.
 // This should be called periodically (every HeartbeatInterval) to maintain presence.
-// The node self-reports its current status ("init" or "online").
-func RefreshHeartbeat(ctx context.Context, client *key_value.Client, instanceID string, status string, startupTime time.Time, ttl time.Duration) error {
+// The node self-reports its current status ("init" or "online") and its weight.
+func RefreshHeartbeat(ctx context.Context, client *key_value.Client, instanceID string, status string, startupTime time.Time, weight int, ttl time.Duration) error {
 	key := nodeKey(instanceID)
 	data := HeartbeatData{
 		Status:      status,
 		StartupTime: startupTime,
+		Weight:      weight,
 	}
 	jsonData, err := json.Marshal(data)
 	if err != nil {
@@ -139,3 +142,35 @@ func GetNodeStatus(ctx context.Context, client *key_value.Client, instanceID str
 
 	return data.Status, nil
 }
+
+// GetNodeWeights reads the self-reported weight of each node from its heartbeat.
+// Nodes whose heartbeat is missing, unparsable or reports no weight get the default weight.
+func GetNodeWeights(ctx context.Context, client *key_value.Client, instanceIDs []string) (map[string]int, error) {
+	weights := make(map[string]int, len(instanceIDs))
+	if len(instanceIDs) == 0 {
+		return weights, nil
+	}
+
+	keys := make([]string, len(instanceIDs))
+	for i, instanceID := range instanceIDs {
+		keys[i] = nodeKey(instanceID)
+	}
+	values, err := client.RedisClient.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("get heartbeats: %w", err)
+	}
+
+	for i, instanceID := range instanceIDs {
+		weights[instanceID] = defaultNodeWeight
+		raw, ok := values[i].(string)
+		if !ok {
+			continue
+		}
+		var data HeartbeatData
+		if err := json.Unmarshal([]byte(raw), &data); err == nil && data.Weight > 0 {
+			weights[instanceID] = data.Weight
+		}
+	}
+
+	return weights, nil
+}
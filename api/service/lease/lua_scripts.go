@@ -8,12 +8,14 @@ import "github.com/redis/go-redis/v9"
 // ARGV[1] = expected owner (instanceID)
 // ARGV[2] = TTL in milliseconds
 // ARGV[3] = new lease value (JSON)
-// Returns: 1 if renewed successfully, 0 if caller is not the owner
+// ARGV[4] = fencing token of the caller's acquisition
+// Returns: 1 if renewed successfully, 0 if caller is not the owner or its token is stale
 var renewScript = redis.NewScript(`
 local key = KEYS[1]
 local ownerID = ARGV[1]
 local ttlMs = tonumber(ARGV[2])
 local jsonValue = ARGV[3]
+local token = tonumber(ARGV[4])
 
 local currentValue = redis.call('GET', key)
 if not currentValue then
@@ -22,7 +24,7 @@ end
 
 -- Parse JSON lease value
 local parsed = cjson.decode(currentValue)
-if parsed.owner_id == ownerID then
+if parsed.owner_id == ownerID and tonumber(parsed.token) == token then
   redis.call('SET', key, jsonValue)
   redis.call('PEXPIRE', key, ttlMs)
   return 1
@@ -52,3 +54,108 @@ else
   return 0
 end
 `)
+
+// handoffClaimScript atomically takes over a lease handed off to the caller. The claim succeeds when
+// the handoff marker names the caller and the lease is unowned or still held by the handing-off owner.
+// KEYS[1] = lease key (poll:lease:{sessionID})
+// KEYS[2] = handoff key (poll:handoff:{sessionID})
+// KEYS[3] = fencing key (poll:fence:{sessionID})
+// ARGV[1] = claiming owner (instanceID)
+// ARGV[2] = TTL in milliseconds
+// ARGV[3] = new lease value (JSON), its token is set by the script
+// Returns: the new fencing token if claimed and the marker removed, 0 otherwise
+var handoffClaimScript = redis.NewScript(`
+local leaseKey = KEYS[1]
+local handoffKey = KEYS[2]
+local fenceKey = KEYS[3]
+local ownerID = ARGV[1]
+local ttlMs = tonumber(ARGV[2])
+local jsonValue = ARGV[3]
+
+local marker = redis.call('GET', handoffKey)
+if not marker then
+  return 0
+end
+
+local handoff = cjson.decode(marker)
+if handoff.to ~= ownerID then
+  return 0
+end
+
+local currentValue = redis.call('GET', leaseKey)
+if currentValue then
+  local parsed = cjson.decode(currentValue)
+  if parsed.owner_id ~= handoff.from and parsed.owner_id ~= ownerID then
+    return 0
+  end
+end
+
+local token = redis.call('INCR', fenceKey)
+local value = cjson.decode(jsonValue)
+value.token = token
+redis.call('SET', leaseKey, cjson.encode(value), 'PX', ttlMs)
+redis.call('DEL', handoffKey)
+return token
+`)
+
+// acquireScript atomically acquires an unowned lease with a new fencing token.
+// KEYS[1] = lease key (poll:lease:{sessionID})
+// KEYS[2] = fencing key (poll:fence:{sessionID})
+// ARGV[1] = TTL in milliseconds
+// ARGV[2] = new lease value (JSON), its token is set by the script
+// Returns: the new fencing token if acquired, 0 if the lease is already held
+var acquireScript = redis.NewScript(`
+local leaseKey = KEYS[1]
+local fenceKey = KEYS[2]
+local ttlMs = tonumber(ARGV[1])
+local jsonValue = ARGV[2]
+
+if redis.call('EXISTS', leaseKey) == 1 then
+  return 0
+end
+
+local token = redis.call('INCR', fenceKey)
+local value = cjson.decode(jsonValue)
+value.token = token
+redis.call('SET', leaseKey, cjson.encode(value), 'PX', ttlMs)
+return token
+`)
+
+// reclaimScript atomically takes over a lease whose owner is dead. The takeover only succeeds
+// while the stored owner is still the dead instance and its heartbeat key is still missing, so a
+// legitimate owner that renewed or came back in the meantime keeps its lease.
+// KEYS[1] = lease key (poll:lease:{sessionID})
+// KEYS[2] = fencing key (poll:fence:{sessionID})
+// KEYS[3] = heartbeat key of the dead owner (poll:node:{instanceID})
+// ARGV[1] = dead owner (instanceID)
+// ARGV[2] = TTL in milliseconds
+// ARGV[3] = new lease value (JSON), its token is set by the script
+// Returns: the new fencing token if reclaimed, 0 otherwise
+var reclaimScript = redis.NewScript(`
+local leaseKey = KEYS[1]
+local fenceKey = KEYS[2]
+local nodeKey = KEYS[3]
+local deadOwnerID = ARGV[1]
+local ttlMs = tonumber(ARGV[2])
+local jsonValue = ARGV[3]
+
+if redis.call('EXISTS', nodeKey) == 1 then
+  return 0
+end
+
+local currentValue = redis.call('GET', leaseKey)
+if not currentValue then
+  return 0
+end
+
+local parsed = cjson.decode(currentValue)
+if parsed.owner_id ~= deadOwnerID then
+  return 0
+end
+
+local token = redis.call('INCR', fenceKey)
+local value = cjson.decode(jsonValue)
+value.token = token
+redis.call('SET', leaseKey, cjson.encode(value), 'PX', ttlMs)
+return token
+`)
@@ -5,11 +5,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
 	"api/pkg/db/key_value"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
 )
@@ -89,11 +91,37 @@ type LeaseManager interface {
 	// Returns RedisLeaseValue with owner ID and timestamps, or error if not found.
 	// Expects JSON format as returned by RedisLeaseValue.Marshal().
 	GetLeaseValue(ctx context.Context, sessionID string) (RedisLeaseValue, error)
+
+	// ClusterSnapshot gathers every lease visible in Redis with its recorded owner,
+	// timestamps, preferred owner, and whether this instance's cached state agrees.
+	// Inconsistencies are returned explicitly to help debug split-brain conditions.
+	ClusterSnapshot(ctx context.Context) (*ClusterSnapshot, error)
+
+	// SetLeaseLostCallback sets a handler called when reconciliation finds that a lease
+	// this instance believed it owned is actually owned by another instance or gone.
+	SetLeaseLostCallback(callback func(sessionID string))
+
+	// Events returns a channel of lease lifecycle events (acquired, released, uncertain, reacquired).
+	// Emission never blocks: when the buffer is full the oldest event is dropped.
+	Events() <-chan LeaseEvent
+
+	// RegisterMetrics registers the Prometheus metrics of this manager with the given registry.
+	RegisterMetrics(reg prometheus.Registerer) error
 }
 
 // leaseKeyPrefix is the Redis key prefix for session lease keys.
 const leaseKeyPrefix = "poll:lease:"
 
+// handoffKeyPrefix is the Redis key prefix for rebalancing handoff markers.
+const handoffKeyPrefix = "poll:handoff:"
+
+// fenceKeyPrefix is the Redis key prefix for the per-session fencing token counters.
+// Counters have no TTL so tokens keep increasing across lease expiries.
+const fenceKeyPrefix = "poll:fence:"
+
+// errLeaseHandedOff is returned by renewLease when the lease was claimed by its handoff target.
+var errLeaseHandedOff = errors.New("lease handed off to preferred owner")
+
 // leaseManager is the concrete implementation of LeaseManager.
 type leaseManager struct {
 	instanceID string
@@ -111,11 +139,30 @@ type leaseManager struct {
 	ownedLeases map[string]LeaseInfo
 	mu          sync.RWMutex
 
-	// cachedNodes is the periodically refreshed list of live node IDs.
+	// handoffs tracks when a handoff was offered for owned leases awaiting their preferred owner.
+	// Protected by mu.
+	handoffs map[string]time.Time
+
+	// cachedNodes is the periodically refreshed list of live node IDs and their weights.
 	// Used by rendezvous hashing to avoid querying Redis on every call.
 	cachedNodes   []string
+	cachedWeights map[string]int
 	cachedNodesMu sync.RWMutex
 
+	// Reconciliation of cached ownership against Redis
+	lastReconcile time.Time
+	onLeaseLost   func(sessionID string)
+	callbackMu    sync.RWMutex
+
+	// lastOrphanSweep is when leases of dead instances were last looked for
+	lastOrphanSweep time.Time
+
+	// events buffers lease lifecycle events for observers
+	events chan LeaseEvent
+
+	// metrics are registered by the caller with RegisterMetrics
+	metrics *leaseMetrics
+
 	// Lifecycle management
 	ctx    context.Context
 	cancel context.CancelFunc
@@ -135,6 +182,9 @@ func NewLeaseManager(client *key_value.Client, config LeaseConfig, logger *zap.L
 		status:      "init",
 		startupTime: time.Now(),
 		ownedLeases: make(map[string]LeaseInfo),
+		handoffs:    make(map[string]time.Time),
+		events:      make(chan LeaseEvent, leaseEventBuffer),
+		metrics:     newLeaseMetrics(),
 	}
 }
 
@@ -149,6 +199,9 @@ func NewLeaseManagerWithID(instanceID string, client *key_value.Client, config L
 		status:      "init",
 		startupTime: time.Now(),
 		ownedLeases: make(map[string]LeaseInfo),
+		handoffs:    make(map[string]time.Time),
+		events:      make(chan LeaseEvent, leaseEventBuffer),
+		metrics:     newLeaseMetrics(),
 	}
 }
 
@@ -157,6 +210,34 @@ func (m *leaseManager) InstanceID() string {
 	return m.instanceID
 }
 
+// Events returns the channel lease lifecycle events are published on.
+func (m *leaseManager) Events() <-chan LeaseEvent {
+	return m.events
+}
+
+// emitEvent publishes a lease event without blocking. If the buffer is full,
+// the oldest event is dropped to make room, so the renewal loop never stalls.
+func (m *leaseManager) emitEvent(eventType LeaseEventType, sessionID, reason string) {
+	event := LeaseEvent{
+		Type:       eventType,
+		SessionID:  sessionID,
+		InstanceID: m.instanceID,
+		Reason:     reason,
+		Timestamp:  time.Now(),
+	}
+	for {
+		select {
+		case m.events <- event:
+			return
+		default:
+		}
+		select {
+		case <-m.events:
+		default:
+		}
+	}
+}
+
 // IsReady returns true if this instance is in "online" status and ready
 // to accept new leases and participate in rebalancing.
 func (m *leaseManager) IsReady() bool {
@@ -239,16 +320,26 @@ func leaseKey(sessionID string) string {
 	return leaseKeyPrefix + sessionID
 }
 
+// handoffKey returns the Redis key of the handoff marker for a session.
+func handoffKey(sessionID string) string {
+	return handoffKeyPrefix + sessionID
+}
+
+// fenceKey returns the Redis key of the fencing token counter for a session.
+func fenceKey(sessionID string) string {
+	return fenceKeyPrefix + sessionID
+}
+
 // Start begins the heartbeat and lease management background loops.
 // It registers the initial heartbeat, performs initial node discovery, and starts four background goroutines:
-// 1. Status transition loop: transitions from "init" to "online" after 10 seconds
+// 1. Status transition loop: transitions from "init" to "online" after the status grace period
 // 2. Heartbeat loop: refreshes this instance's presence in Redis
 // 3. Renewal loop: renews all owned leases to prevent TTL expiry
 // 4. Node discovery loop: refreshes the cached list of live nodes for rendezvous hashing
 // Must be called before any lease operations. Returns error if initial heartbeat fails.
 func (m *leaseManager) Start(ctx context.Context) error {
 	// Register initial heartbeat with "init" status
-	if err := RegisterHeartbeat(ctx, m.client, m.instanceID, m.config.HeartbeatTTL); err != nil {
+	if err := RegisterHeartbeat(ctx, m.client, m.instanceID, m.config.weight(), m.config.HeartbeatTTL); err != nil {
 		return fmt.Errorf("register initial heartbeat: %w", err)
 	}
 
@@ -266,6 +357,9 @@ func (m *leaseManager) Start(ctx context.Context) error {
 		zap.Duration("heartbeat_interval", m.config.HeartbeatInterval),
 		zap.Duration("renewal_interval", m.config.RenewalInterval),
 		zap.Duration("node_discovery_interval", m.config.NodeDiscoveryInterval),
+		zap.Duration("orphan_sweep_interval", m.config.OrphanSweepInterval),
+		zap.Duration("status_grace_period", m.config.statusGracePeriod()),
+		zap.Int("weight", m.config.weight()),
 	)
 
 	// Start status transition loop first
@@ -281,15 +375,14 @@ func (m *leaseManager) Start(ctx context.Context) error {
 	return nil
 }
 
-// statusTransitionLoop waits for the grace period (10 seconds) then transitions
+// statusTransitionLoop waits for the status grace period (default 10 seconds) then transitions
 // this instance from "init" to "online" status. During "init" phase, the node
 // keeps existing leases but cannot acquire new ones. After transition to "online",
 // the node can participate fully in lease acquisition and rebalancing.
 func (m *leaseManager) statusTransitionLoop() {
 	defer m.wg.Done()
 
-	// Wait for 10 seconds grace period
-	gracePeriod := 10 * time.Second
+	gracePeriod := m.config.statusGracePeriod()
 	timer := time.NewTimer(gracePeriod)
 	defer timer.Stop()
 
@@ -328,7 +421,7 @@ func (m *leaseManager) heartbeatLoop() {
 			m.statusMu.RUnlock()
 
 			// Refresh heartbeat with current status
-			if err := RefreshHeartbeat(m.ctx, m.client, m.instanceID, currentStatus, m.startupTime, m.config.HeartbeatTTL); err != nil {
+			if err := RefreshHeartbeat(m.ctx, m.client, m.instanceID, currentStatus, m.startupTime, m.config.weight(), m.config.HeartbeatTTL); err != nil {
 				m.logger.Warn("heartbeat refresh failed",
 					zap.String("instance_id", m.instanceID),
 					zap.Error(err),
@@ -362,15 +455,16 @@ func (m *leaseManager) nodeDiscoveryLoop() {
 	}
 }
 
-// refreshCachedNodes queries Redis for live nodes and updates the cache.
+// refreshCachedNodes queries Redis for live nodes and their weights and updates the cache.
 func (m *leaseManager) refreshCachedNodes() error {
-	nodes, err := GetLiveNodes(m.ctx, m.client)
+	nodes, weights, err := m.liveNodeWeights(m.ctx)
 	if err != nil {
 		return err
 	}
 
 	m.cachedNodesMu.Lock()
 	m.cachedNodes = nodes
+	m.cachedWeights = weights
 	m.cachedNodesMu.Unlock()
 
 	m.logger.Debug("node discovery refreshed",
@@ -382,18 +476,32 @@ func (m *leaseManager) refreshCachedNodes() error {
 	return nil
 }
 
-// getCachedNodes returns the current cached list of live nodes.
+// getCachedNodes returns the current cached list of live nodes and their weights.
 // Falls back to querying Redis if cache is empty.
-func (m *leaseManager) getCachedNodes(ctx context.Context) ([]string, error) {
+func (m *leaseManager) getCachedNodes(ctx context.Context) ([]string, map[string]int, error) {
 	m.cachedNodesMu.RLock()
 	nodes := m.cachedNodes
+	weights := m.cachedWeights
 	m.cachedNodesMu.RUnlock()
 
 	if len(nodes) > 0 {
-		return nodes, nil
+		return nodes, weights, nil
 	}
 
-	return GetLiveNodes(ctx, m.client)
+	return m.liveNodeWeights(ctx)
+}
+
+// liveNodeWeights queries Redis for the live nodes and the weights reported in their heartbeats.
+func (m *leaseManager) liveNodeWeights(ctx context.Context) ([]string, map[string]int, error) {
+	nodes, err := GetLiveNodes(ctx, m.client)
+	if err != nil {
+		return nil, nil, err
+	}
+	weights, err := GetNodeWeights(ctx, m.client, nodes)
+	if err != nil {
+		return nil, nil, err
+	}
+	return nodes, weights, nil
 }
 
 // renewalLoop periodically renews all leases owned by this instance,
@@ -410,9 +518,91 @@ func (m *leaseManager) renewalLoop() {
 		case <-m.ctx.Done():
 			return
 		case <-ticker.C:
+			m.reconcileOwnedLeases()
 			m.renewOwnedLeases()
 			m.reacquireUncertainLeases()
 			m.releaseNonPreferredLeases()
+			m.reclaimOrphanedLeases()
+		}
+	}
+}
+
+// SetLeaseLostCallback sets a handler called when reconciliation drops a lease
+// that turned out to be owned by another instance or no longer exists.
+func (m *leaseManager) SetLeaseLostCallback(callback func(sessionID string)) {
+	m.callbackMu.Lock()
+	defer m.callbackMu.Unlock()
+	m.onLeaseLost = callback
+}
+
+// reconcileOwnedLeases verifies cached ownership against Redis and drops leases
+// that are actually owned by others, preventing dual polling when the cache is stale.
+// Runs at most once per ReconcileInterval and checks at most ReconcileBatchSize leases,
+// least recently verified first, so every lease is eventually checked.
+// A lease found owned elsewhere after a renewal race (split brain) is demoted with a
+// taken over event, and the renew script's fencing token check keeps the loser from extending it.
+func (m *leaseManager) reconcileOwnedLeases() {
+	if m.config.ReconcileInterval <= 0 || time.Since(m.lastReconcile) < m.config.ReconcileInterval {
+		return
+	}
+	m.lastReconcile = time.Now()
+
+	m.mu.RLock()
+	candidates := make([]LeaseInfo, 0, len(m.ownedLeases))
+	for _, info := range m.ownedLeases {
+		if info.State == LeaseStateOwned {
+			candidates = append(candidates, info)
+		}
+	}
+	m.mu.RUnlock()
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].LastVerifiedAt.Before(candidates[j].LastVerifiedAt)
+	})
+	if m.config.ReconcileBatchSize > 0 && len(candidates) > m.config.ReconcileBatchSize {
+		candidates = candidates[:m.config.ReconcileBatchSize]
+	}
+
+	for _, candidate := range candidates {
+		owned, err := m.IsOwnedStrict(m.ctx, candidate.SessionID)
+		if err != nil {
+			m.logger.Warn("failed to verify lease ownership during reconciliation",
+				zap.String("session_id", candidate.SessionID),
+				zap.String("instance_id", m.instanceID),
+				zap.Error(err),
+			)
+			continue
+		}
+
+		m.mu.Lock()
+		info, exists := m.ownedLeases[candidate.SessionID]
+		if !exists {
+			m.mu.Unlock()
+			continue
+		}
+		if owned {
+			info.LastVerifiedAt = time.Now()
+			m.ownedLeases[candidate.SessionID] = info
+			m.mu.Unlock()
+			continue
+		}
+		delete(m.ownedLeases, candidate.SessionID)
+		delete(m.handoffs, candidate.SessionID)
+		m.updateLeaseGaugesLocked()
+		m.mu.Unlock()
+
+		m.logger.Warn("lease released",
+			zap.String("session_id", candidate.SessionID),
+			zap.String("instance_id", m.instanceID),
+			zap.String("reason", "stale_cache"),
+		)
+		m.emitEvent(LeaseEventTakenOver, candidate.SessionID, "stale_cache")
+
+		m.callbackMu.RLock()
+		callback := m.onLeaseLost
+		m.callbackMu.RUnlock()
+		if callback != nil {
+			callback(candidate.SessionID)
 		}
 	}
 }
@@ -427,7 +617,17 @@ func (m *leaseManager) renewOwnedLeases() {
 	m.mu.RUnlock()
 
 	for _, sessionID := range sessionIDs {
-		if err := m.renewLease(sessionID); err != nil {
+		err := m.renewLease(sessionID)
+		if errors.Is(err, errLeaseHandedOff) {
+			m.callbackMu.RLock()
+			callback := m.onLeaseLost
+			m.callbackMu.RUnlock()
+			if callback != nil {
+				callback(sessionID)
+			}
+			continue
+		}
+		if err != nil {
 			m.logger.Warn("lease renewal failed",
 				zap.String("session_id", sessionID),
 				zap.String("instance_id", m.instanceID),
@@ -484,18 +684,18 @@ func (m *leaseManager) tryReacquireLease(sessionID string) {
 		return
 	}
 
-	if leaseValue.OwnerID == m.instanceID {
-		// Get current acquired time (preserve it across renewals)
-		m.mu.RLock()
-		acquiredAt := m.ownedLeases[sessionID].AcquiredAt
-		m.mu.RUnlock()
+	m.mu.RLock()
+	owned := m.ownedLeases[sessionID]
+	m.mu.RUnlock()
 
-		// Create JSON lease value with updated lastRenewedAt
+	if leaseValue.OwnerID == m.instanceID && leaseValue.Token == owned.Token {
+		// Create JSON lease value with updated lastRenewedAt, preserving acquired time and token
 		now := time.Now()
 		newLeaseValue := RedisLeaseValue{
 			OwnerID:       m.instanceID,
-			AcquiredAt:    acquiredAt,
+			AcquiredAt:    owned.AcquiredAt,
 			LastRenewedAt: now,
+			Token:         owned.Token,
 		}
 		valueStr, err := newLeaseValue.Marshal()
 		if err != nil {
@@ -508,7 +708,7 @@ func (m *leaseManager) tryReacquireLease(sessionID string) {
 		}
 
 		ttlMs := m.config.LeaseTTL.Milliseconds()
-		result, err := renewScript.Run(m.ctx, m.client.RedisClient, []string{key}, m.instanceID, ttlMs, valueStr).Int()
+		result, err := renewScript.Run(m.ctx, m.client.RedisClient, []string{key}, m.instanceID, ttlMs, valueStr, owned.Token).Int()
 		if err != nil {
 			m.logger.Warn("failed to renew lease during re-acquisition",
 				zap.String("session_id", sessionID),
@@ -527,11 +727,14 @@ func (m *leaseManager) tryReacquireLease(sessionID string) {
 				info.LastRenewedAt = now
 				info.UncertainSince = time.Time{}
 				m.ownedLeases[sessionID] = info
+				m.updateLeaseGaugesLocked()
+				m.metrics.reacquired.WithLabelValues(m.instanceID).Inc()
 
 				m.logger.Info("lease re-acquired from uncertain state",
 					zap.String("session_id", sessionID),
 					zap.String("instance_id", m.instanceID),
 				)
+				m.emitEvent(LeaseEventReacquired, sessionID, "renewed")
 			}
 			m.mu.Unlock()
 		} else {
@@ -542,10 +745,11 @@ func (m *leaseManager) tryReacquireLease(sessionID string) {
 	}
 }
 
-// releaseNonPreferredLeases voluntarily releases leases where this instance
-// is no longer the preferred owner. This enables faster rebalancing when
-// new instances join the cluster. Per FR-015, non-preferred owners should
-// release leases so the preferred owner can acquire them promptly.
+// releaseNonPreferredLeases hands off leases where this instance is no longer
+// the preferred owner. This enables faster rebalancing when new instances join
+// the cluster. Per FR-015, non-preferred owners give up leases so the preferred
+// owner can acquire them promptly; the handoff keeps polling until it does and
+// falls back to a plain release when it is not claimed within HandoffTimeout.
 func (m *leaseManager) releaseNonPreferredLeases() {
 	m.mu.RLock()
 	sessionIDs := make([]string, 0, len(m.ownedLeases))
@@ -596,7 +800,27 @@ func (m *leaseManager) releaseNonPreferredLeases() {
 				continue
 			}
 
-			// Preferred owner is online, release this lease
+			// Preferred owner is online, hand the lease off and keep polling until it is claimed
+			m.mu.RLock()
+			offeredAt, handingOff := m.handoffs[sessionID]
+			m.mu.RUnlock()
+
+			if !handingOff {
+				if err := m.offerHandoff(sessionID, preferredOwner); err != nil {
+					m.logger.Warn("failed to offer lease handoff",
+						zap.String("session_id", sessionID),
+						zap.String("instance_id", m.instanceID),
+						zap.String("preferred_owner", preferredOwner),
+						zap.Error(err),
+					)
+				}
+				continue
+			}
+			if time.Since(offeredAt) < m.config.handoffTimeout() {
+				continue
+			}
+
+			// The handoff was not claimed in time, fall back to releasing the lease
 			if err := m.Release(m.ctx, sessionID); err != nil {
 				m.logger.Warn("failed to voluntarily release non-preferred lease",
 					zap.String("session_id", sessionID),
@@ -610,12 +834,180 @@ func (m *leaseManager) releaseNonPreferredLeases() {
 				zap.String("session_id", sessionID),
 				zap.String("instance_id", m.instanceID),
 				zap.String("preferred_owner", preferredOwner),
-				zap.String("reason", "not_preferred_owner"),
+				zap.String("reason", "handoff_timeout"),
 			)
 		}
 	}
 }
 
+// reclaimOrphanedLeases takes over leases held by instances whose heartbeat expired, for sessions
+// this instance is the preferred owner of, instead of waiting for the lease TTL after a crash.
+// Runs at most once per OrphanSweepInterval since it scans every lease key.
+// The liveness check is repeated atomically in the reclaim script.
+func (m *leaseManager) reclaimOrphanedLeases() {
+	if !m.IsReady() || m.config.OrphanSweepInterval <= 0 || time.Since(m.lastOrphanSweep) < m.config.OrphanSweepInterval {
+		return
+	}
+	m.lastOrphanSweep = time.Now()
+
+	liveNodes, weights, err := m.liveNodeWeights(m.ctx)
+	if err != nil {
+		m.logger.Warn("failed to get live nodes for orphaned lease sweep",
+			zap.String("instance_id", m.instanceID),
+			zap.Error(err),
+		)
+		return
+	}
+	live := make(map[string]bool, len(liveNodes))
+	for _, node := range liveNodes {
+		live[node] = true
+	}
+
+	iter := m.client.RedisClient.Scan(m.ctx, 0, leaseKeyPrefix+"*", 0).Iterator()
+	for iter.Next(m.ctx) {
+		sessionID := iter.Val()[len(leaseKeyPrefix):]
+		if ComputePreferredOwner(sessionID, liveNodes, weights) != m.instanceID {
+			continue
+		}
+
+		value, err := m.GetLeaseValue(m.ctx, sessionID)
+		if err != nil || value.OwnerID == "" || value.OwnerID == m.instanceID || live[value.OwnerID] {
+			continue
+		}
+
+		if err := m.reclaimLease(sessionID, value.OwnerID); err != nil {
+			m.logger.Warn("failed to reclaim orphaned lease",
+				zap.String("session_id", sessionID),
+				zap.String("instance_id", m.instanceID),
+				zap.String("dead_owner", value.OwnerID),
+				zap.Error(err),
+			)
+		}
+	}
+	if err := iter.Err(); err != nil {
+		m.logger.Warn("failed to scan leases for orphaned lease sweep",
+			zap.String("instance_id", m.instanceID),
+			zap.Error(err),
+		)
+	}
+}
+
+// reclaimLease force-acquires a lease from a dead owner. Does nothing if the owner changed
+// or its heartbeat reappeared before the script ran.
+func (m *leaseManager) reclaimLease(sessionID, deadOwner string) error {
+	now := time.Now()
+	leaseValue := RedisLeaseValue{
+		OwnerID:       m.instanceID,
+		AcquiredAt:    now,
+		LastRenewedAt: now,
+	}
+	valueStr, err := leaseValue.Marshal()
+	if err != nil {
+		return fmt.Errorf("marshal lease value: %w", err)
+	}
+
+	keys := []string{leaseKey(sessionID), fenceKey(sessionID), nodeKey(deadOwner)}
+	ttlMs := m.config.LeaseTTL.Milliseconds()
+	token, err := reclaimScript.Run(m.ctx, m.client.RedisClient, keys, deadOwner, ttlMs, valueStr).Int64()
+	if err != nil {
+		return fmt.Errorf("run reclaim script: %w", err)
+	}
+	if token == 0 {
+		return nil
+	}
+
+	m.mu.Lock()
+	m.ownedLeases[sessionID] = LeaseInfo{
+		SessionID:     sessionID,
+		OwnerID:       m.instanceID,
+		State:         LeaseStateOwned,
+		AcquiredAt:    now,
+		LastRenewedAt: now,
+		Token:         token,
+	}
+	m.updateLeaseGaugesLocked()
+	m.mu.Unlock()
+
+	m.logger.Info("lease acquired",
+		zap.String("session_id", sessionID),
+		zap.String("instance_id", m.instanceID),
+		zap.String("dead_owner", deadOwner),
+		zap.String("reason", "orphan_reclaim"),
+	)
+	m.emitEvent(LeaseEventAcquired, sessionID, "orphan_reclaim")
+	return nil
+}
+
+// offerHandoff writes a handoff marker naming the preferred owner, which lets it take over the lease
+// on its next acquisition attempt while this instance keeps polling, so the session is never unpolled.
+// The marker expires with the handoff timeout.
+func (m *leaseManager) offerHandoff(sessionID, preferredOwner string) error {
+	now := time.Now()
+	value, err := RedisHandoffValue{From: m.instanceID, To: preferredOwner, OfferedAt: now}.Marshal()
+	if err != nil {
+		return fmt.Errorf("marshal handoff value: %w", err)
+	}
+	if err := m.client.RedisClient.Set(m.ctx, handoffKey(sessionID), value, m.config.handoffTimeout()).Err(); err != nil {
+		return fmt.Errorf("write handoff marker: %w", err)
+	}
+
+	m.mu.Lock()
+	m.handoffs[sessionID] = now
+	m.mu.Unlock()
+
+	m.logger.Info("lease handoff offered",
+		zap.String("session_id", sessionID),
+		zap.String("instance_id", m.instanceID),
+		zap.String("preferred_owner", preferredOwner),
+	)
+	return nil
+}
+
+// claimHandoff takes over a lease whose handoff marker names this instance.
+// Returns (false, nil) when no handoff to this instance is pending.
+func (m *leaseManager) claimHandoff(ctx context.Context, sessionID string) (bool, error) {
+	now := time.Now()
+	leaseValue := RedisLeaseValue{
+		OwnerID:       m.instanceID,
+		AcquiredAt:    now,
+		LastRenewedAt: now,
+	}
+	valueStr, err := leaseValue.Marshal()
+	if err != nil {
+		return false, fmt.Errorf("marshal lease value: %w", err)
+	}
+
+	keys := []string{leaseKey(sessionID), handoffKey(sessionID), fenceKey(sessionID)}
+	ttlMs := m.config.LeaseTTL.Milliseconds()
+	token, err := handoffClaimScript.Run(ctx, m.client.RedisClient, keys, m.instanceID, ttlMs, valueStr).Int64()
+	if err != nil {
+		return false, fmt.Errorf("run handoff claim script: %w", err)
+	}
+	if token == 0 {
+		return false, nil
+	}
+
+	m.mu.Lock()
+	m.ownedLeases[sessionID] = LeaseInfo{
+		SessionID:     sessionID,
+		OwnerID:       m.instanceID,
+		State:         LeaseStateOwned,
+		AcquiredAt:    now,
+		LastRenewedAt: now,
+		Token:         token,
+	}
+	m.updateLeaseGaugesLocked()
+	m.mu.Unlock()
+
+	m.logger.Info("lease acquired",
+		zap.String("session_id", sessionID),
+		zap.String("instance_id", m.instanceID),
+		zap.String("reason", "handoff"),
+	)
+	m.emitEvent(LeaseEventAcquired, sessionID, "handoff")
+	return true, nil
+}
+
 // removeLeaseLocked removes a lease from tracking when it's no longer owned.
 // This is called when a lease expired via TTL or was taken by another instance.
 // Logs the removal for observability per FR-021.
@@ -625,11 +1017,14 @@ func (m *leaseManager) removeLeaseLocked(sessionID string) {
 
 	if _, exists := m.ownedLeases[sessionID]; exists {
 		delete(m.ownedLeases, sessionID)
+		delete(m.handoffs, sessionID)
+		m.updateLeaseGaugesLocked()
 		m.logger.Info("lease released",
 			zap.String("session_id", sessionID),
 			zap.String("instance_id", m.instanceID),
 			zap.String("reason", "ttl_expiry"),
 		)
+		m.emitEvent(LeaseEventReleased, sessionID, "ttl_expiry")
 	}
 }
 
@@ -641,24 +1036,26 @@ func (m *leaseManager) renewLease(sessionID string) error {
 	ttlMs := m.config.LeaseTTL.Milliseconds()
 	now := time.Now()
 
-	// Get current acquired time (preserve it across renewals)
+	// Get current acquired time and token (preserve them across renewals)
 	m.mu.RLock()
-	acquiredAt := m.ownedLeases[sessionID].AcquiredAt
+	owned := m.ownedLeases[sessionID]
 	m.mu.RUnlock()
 
 	// Create JSON lease value with updated lastRenewedAt
 	leaseValue := RedisLeaseValue{
 		OwnerID:       m.instanceID,
-		AcquiredAt:    acquiredAt,
+		AcquiredAt:    owned.AcquiredAt,
 		LastRenewedAt: now,
+		Token:         owned.Token,
 	}
 	valueStr, err := leaseValue.Marshal()
 	if err != nil {
 		return fmt.Errorf("marshal lease value: %w", err)
 	}
 
-	result, err := renewScript.Run(m.ctx, m.client.RedisClient, []string{key}, m.instanceID, ttlMs, valueStr).Int()
+	result, err := renewScript.Run(m.ctx, m.client.RedisClient, []string{key}, m.instanceID, ttlMs, valueStr, owned.Token).Int()
 	if err != nil {
+		m.metrics.renewalFailures.WithLabelValues(m.instanceID).Inc()
 		m.markLeaseUncertain(sessionID)
 		return fmt.Errorf("run renew script: %w", err)
 	}
@@ -677,13 +1074,28 @@ func (m *leaseManager) renewLease(sessionID string) error {
 		info.State = LeaseStateOwned
 		info.UncertainSince = time.Time{}
 		m.ownedLeases[sessionID] = info
+		m.updateLeaseGaugesLocked()
+		m.metrics.renewals.WithLabelValues(m.instanceID).Inc()
 
 		m.logger.Debug("lease renewed",
 			zap.String("session_id", sessionID),
 			zap.String("instance_id", m.instanceID),
 			zap.Duration("ttl", m.config.LeaseTTL),
 		)
+	} else if _, handingOff := m.handoffs[sessionID]; handingOff {
+		// The preferred owner claimed the lease, so no renewal failure is recorded
+		delete(m.ownedLeases, sessionID)
+		delete(m.handoffs, sessionID)
+		m.updateLeaseGaugesLocked()
+		m.logger.Info("lease released",
+			zap.String("session_id", sessionID),
+			zap.String("instance_id", m.instanceID),
+			zap.String("reason", "handoff"),
+		)
+		m.emitEvent(LeaseEventReleased, sessionID, "handoff")
+		return errLeaseHandedOff
 	} else {
+		m.metrics.renewalFailures.WithLabelValues(m.instanceID).Inc()
 		m.markLeaseUncertainLocked(sessionID, &info)
 		return fmt.Errorf("lease no longer owned by this instance")
 	}
@@ -709,9 +1121,11 @@ func (m *leaseManager) markLeaseUncertain(sessionID string) {
 func (m *leaseManager) markLeaseUncertainLocked(sessionID string, info *LeaseInfo) {
 	if info.State != LeaseStateUncertain {
 		info.UncertainSince = time.Now()
+		m.emitEvent(LeaseEventUncertain, sessionID, "renewal_failed")
 	}
 	info.State = LeaseStateUncertain
 	m.ownedLeases[sessionID] = *info
+	m.updateLeaseGaugesLocked()
 
 	m.logger.Warn("lease state uncertain",
 		zap.String("session_id", sessionID),
@@ -751,11 +1165,14 @@ func (m *leaseManager) Stop() error {
 				zap.String("instance_id", m.instanceID),
 				zap.String("reason", "shutdown"),
 			)
+			m.emitEvent(LeaseEventReleased, sessionID, "shutdown")
 		}
 	}
 
 	m.mu.Lock()
 	m.ownedLeases = make(map[string]LeaseInfo)
+	m.handoffs = make(map[string]time.Time)
+	m.updateLeaseGaugesLocked()
 	m.mu.Unlock()
 
 	if err := RemoveHeartbeat(ctx, m.client, m.instanceID); err != nil {
@@ -805,6 +1222,19 @@ func (m *leaseManager) TryAcquire(ctx context.Context, sessionID string) (bool,
 		return false, nil
 	}
 
+	// A lease handed off to this instance is claimed right away, before its TTL expires
+	claimed, err := m.claimHandoff(ctx, sessionID)
+	if err != nil {
+		m.logger.Warn("failed to claim lease handoff",
+			zap.String("session_id", sessionID),
+			zap.String("instance_id", m.instanceID),
+			zap.Error(err),
+		)
+	}
+	if claimed {
+		return true, nil
+	}
+
 	isPreferred, err := m.IsPreferredOwner(ctx, sessionID)
 	if err != nil {
 		m.logger.Warn("failed to check preferred owner status",
@@ -845,7 +1275,8 @@ func (m *leaseManager) TryAcquire(ctx context.Context, sessionID string) (bool,
 		return false, fmt.Errorf("marshal lease value: %w", err)
 	}
 
-	acquired, err := m.client.RedisClient.SetNX(ctx, key, valueStr, m.config.LeaseTTL).Result()
+	keys := []string{key, fenceKey(sessionID)}
+	token, err := acquireScript.Run(ctx, m.client.RedisClient, keys, m.config.LeaseTTL.Milliseconds(), valueStr).Int64()
 	if err != nil {
 		m.logger.Warn("lease acquire failed",
 			zap.String("session_id", sessionID),
@@ -855,7 +1286,7 @@ func (m *leaseManager) TryAcquire(ctx context.Context, sessionID string) (bool,
 		return false, fmt.Errorf("acquire lease: %w", err)
 	}
 
-	if acquired {
+	if token > 0 {
 		m.mu.Lock()
 		m.ownedLeases[sessionID] = LeaseInfo{
 			SessionID:     sessionID,
@@ -863,7 +1294,9 @@ func (m *leaseManager) TryAcquire(ctx context.Context, sessionID string) (bool,
 			State:         LeaseStateOwned,
 			AcquiredAt:    now,
 			LastRenewedAt: now,
+			Token:         token,
 		}
+		m.updateLeaseGaugesLocked()
 		m.mu.Unlock()
 
 		m.logger.Info("lease acquired",
@@ -871,6 +1304,11 @@ func (m *leaseManager) TryAcquire(ctx context.Context, sessionID string) (bool,
 			zap.String("instance_id", m.instanceID),
 			zap.Bool("is_preferred_owner", isPreferred),
 		)
+		reason := "fallback"
+		if isPreferred {
+			reason = "preferred_owner"
+		}
+		m.emitEvent(LeaseEventAcquired, sessionID, reason)
 		return true, nil
 	}
 
@@ -895,6 +1333,8 @@ func (m *leaseManager) Release(ctx context.Context, sessionID string) error {
 
 	m.mu.Lock()
 	delete(m.ownedLeases, sessionID)
+	delete(m.handoffs, sessionID)
+	m.updateLeaseGaugesLocked()
 	m.mu.Unlock()
 
 	if result == 1 {
@@ -903,6 +1343,7 @@ func (m *leaseManager) Release(ctx context.Context, sessionID string) error {
 			zap.String("instance_id", m.instanceID),
 			zap.String("reason", "voluntary"),
 		)
+		m.emitEvent(LeaseEventReleased, sessionID, "voluntary")
 	}
 
 	return nil
@@ -969,7 +1410,8 @@ func (m *leaseManager) UncertainLeases() []LeaseInfo {
 // IsOwnedStrict checks lease ownership by querying Redis directly.
 // Use before critical operations when cached state might be stale.
 // Returns (true, nil) if this instance owns the lease in Redis.
-// Returns (false, nil) if the lease doesn't exist or is owned by another instance.
+// Returns (false, nil) if the lease doesn't exist, is owned by another instance, or carries a
+// different fencing token than the acquisition cached by this instance.
 // Returns (false, error) if Redis operation fails.
 func (m *leaseManager) IsOwnedStrict(ctx context.Context, sessionID string) (bool, error) {
 	key := leaseKey(sessionID)
@@ -987,8 +1429,15 @@ func (m *leaseManager) IsOwnedStrict(ctx context.Context, sessionID string) (boo
 	if err != nil {
 		return false, fmt.Errorf("parse lease value: %w", err)
 	}
+	if leaseValue.OwnerID != m.instanceID {
+		return false, nil
+	}
 
-	return leaseValue.OwnerID == m.instanceID, nil
+	// A different token means the lease was re-acquired since the acquisition cached here
+	m.mu.RLock()
+	info, cached := m.ownedLeases[sessionID]
+	m.mu.RUnlock()
+	return !cached || info.Token == leaseValue.Token, nil
 }
 
 // GetLiveNodes returns instance IDs of all instances with active heartbeats.
@@ -1002,12 +1451,12 @@ func (m *leaseManager) GetLiveNodes(ctx context.Context) ([]string, error) {
 // Uses the cached node list for performance; falls back to Redis if cache is empty.
 // Returns empty string if no live nodes are found.
 func (m *leaseManager) PreferredOwner(ctx context.Context, sessionID string) (string, error) {
-	nodes, err := m.getCachedNodes(ctx)
+	nodes, weights, err := m.getCachedNodes(ctx)
 	if err != nil {
 		return "", fmt.Errorf("get live nodes: %w", err)
 	}
 
-	return ComputePreferredOwner(sessionID, nodes), nil
+	return ComputePreferredOwner(sessionID, nodes, weights), nil
 }
 
 // IsPreferredOwner returns true if this instance is the preferred owner
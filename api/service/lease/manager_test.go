@@ -0,0 +1,529 @@
+package lease
+
+import (
+	"api/pkg/db/key_value"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// newTestRedis returns an in-memory Redis and a client connected to it
+func newTestRedis(t *testing.T) (*miniredis.Miniredis, *key_value.Client) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	redisClient := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = redisClient.Close() })
+	return server, &key_value.Client{RedisClient: redisClient}
+}
+
+// newTestManager returns an online lease manager without its background loops, which tests drive by hand
+func newTestManager(t *testing.T, client *key_value.Client, instanceID string, config LeaseConfig) *leaseManager {
+	t.Helper()
+	m := NewLeaseManagerWithID(instanceID, client, config, zap.NewNop()).(*leaseManager)
+	m.status = "online"
+	m.ctx, m.cancel = context.WithCancel(context.Background())
+	t.Cleanup(m.cancel)
+	return m
+}
+
+// mustAcquire acquires the session's lease or fails the test
+func mustAcquire(t *testing.T, m *leaseManager, sessionID string) {
+	t.Helper()
+	acquired, err := m.TryAcquire(context.Background(), sessionID)
+	if err != nil || !acquired {
+		t.Fatalf("%s: acquired %v with error %v, want the lease", m.instanceID, acquired, err)
+	}
+}
+
+// goOnline publishes an online heartbeat for the instance
+func goOnline(t *testing.T, client *key_value.Client, instanceID string) {
+	t.Helper()
+	if err := RefreshHeartbeat(context.Background(), client, instanceID, "online", time.Now(), defaultNodeWeight, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// sessionPreferring returns a session ID with the prefix the instance is the preferred owner of among the nodes
+func sessionPreferring(t *testing.T, prefix, instanceID string, nodes ...string) string {
+	t.Helper()
+	for i := range 1000 {
+		sessionID := fmt.Sprintf("%s-%d", prefix, i)
+		if ComputePreferredOwner(sessionID, nodes, nil) == instanceID {
+			return sessionID
+		}
+	}
+	t.Fatalf("no session preferring %s", instanceID)
+	return ""
+}
+
+func TestAcquireScriptBumpsFencingToken(t *testing.T) {
+	server, client := newTestRedis(t)
+	config := DefaultLeaseConfig()
+	first := newTestManager(t, client, "node-a", config)
+	second := newTestManager(t, client, "node-b", config)
+
+	mustAcquire(t, first, "session")
+	if acquired, _ := second.TryAcquire(context.Background(), "session"); acquired {
+		t.Fatal("second instance acquired a held lease")
+	}
+
+	server.FastForward(config.LeaseTTL)
+	mustAcquire(t, second, "session")
+
+	firstToken := first.GetLeaseInfo("session").Token
+	secondToken := second.GetLeaseInfo("session").Token
+	if firstToken <= 0 || secondToken <= firstToken {
+		t.Errorf("got tokens %d then %d, want increasing positive tokens", firstToken, secondToken)
+	}
+}
+
+func TestRenewalWithStaleTokenFails(t *testing.T) {
+	server, client := newTestRedis(t)
+	config := DefaultLeaseConfig()
+	// The same node name restarted, so both acquisitions carry the same owner ID
+	stale := newTestManager(t, client, "node-a", config)
+	current := newTestManager(t, client, "node-a", config)
+
+	mustAcquire(t, stale, "session")
+	server.FastForward(config.LeaseTTL)
+	mustAcquire(t, current, "session")
+
+	if err := stale.renewLease("session"); err == nil {
+		t.Fatal("renewal with a stale token succeeded")
+	}
+	if !stale.IsUncertain("session") {
+		t.Error("stale lease not marked uncertain after a rejected renewal")
+	}
+
+	value, err := current.GetLeaseValue(context.Background(), "session")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value.Token != current.GetLeaseInfo("session").Token {
+		t.Errorf("stale renewal overwrote the token: got %d, want %d", value.Token, current.GetLeaseInfo("session").Token)
+	}
+	if err := current.renewLease("session"); err != nil {
+		t.Errorf("renewal by the current owner failed: %v", err)
+	}
+}
+
+func TestIsOwnedStrictRejectsAfterTakeover(t *testing.T) {
+	tests := []struct {
+		name      string
+		takerID   string
+		wantOwner string
+	}{
+		{"other instance", "node-b", "node-b"},
+		{"same instance id", "node-a", "node-a"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server, client := newTestRedis(t)
+			config := DefaultLeaseConfig()
+			original := newTestManager(t, client, "node-a", config)
+			taker := newTestManager(t, client, test.takerID, config)
+
+			mustAcquire(t, original, "session")
+			if owned, err := original.IsOwnedStrict(context.Background(), "session"); err != nil || !owned {
+				t.Fatalf("owned %v with error %v before the takeover, want owned", owned, err)
+			}
+
+			server.FastForward(config.LeaseTTL)
+			mustAcquire(t, taker, "session")
+
+			owned, err := original.IsOwnedStrict(context.Background(), "session")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if owned {
+				t.Error("original owner still strictly owns the lease after the takeover")
+			}
+			if !original.IsOwned("session") {
+				t.Error("cached ownership changed without reconciliation")
+			}
+			if owner, _ := original.GetLeaseOwner(context.Background(), "session"); owner != test.wantOwner {
+				t.Errorf("got owner %q, want %q", owner, test.wantOwner)
+			}
+		})
+	}
+}
+
+func TestGracefulHandoff(t *testing.T) {
+	_, client := newTestRedis(t)
+	config := DefaultLeaseConfig()
+	oldOwner := newTestManager(t, client, "node-a", config)
+	newOwner := newTestManager(t, client, "node-b", config)
+	sessionID := sessionPreferring(t, "session", "node-b", "node-a", "node-b")
+
+	// node-a polls the session alone, then node-b joins and is preferred
+	mustAcquire(t, oldOwner, sessionID)
+	goOnline(t, client, "node-a")
+	goOnline(t, client, "node-b")
+
+	var lost []string
+	oldOwner.SetLeaseLostCallback(func(sessionID string) { lost = append(lost, sessionID) })
+
+	oldOwner.releaseNonPreferredLeases()
+	marker, err := client.RedisClient.Get(context.Background(), handoffKey(sessionID)).Result()
+	if err != nil {
+		t.Fatalf("no handoff marker offered: %v", err)
+	}
+	var handoff RedisHandoffValue
+	if err := json.Unmarshal([]byte(marker), &handoff); err != nil || handoff.From != "node-a" || handoff.To != "node-b" {
+		t.Errorf("got marker %s, want one from node-a to node-b", marker)
+	}
+	if !oldOwner.IsOwned(sessionID) {
+		t.Fatal("old owner stopped polling before the handoff was claimed")
+	}
+
+	mustAcquire(t, newOwner, sessionID)
+	if owner, _ := newOwner.GetLeaseOwner(context.Background(), sessionID); owner != "node-b" {
+		t.Errorf("got owner %q after the claim, want node-b", owner)
+	}
+	if exists, _ := client.IsSet(handoffKey(sessionID)); exists {
+		t.Error("handoff marker left after the claim")
+	}
+	if newOwner.GetLeaseInfo(sessionID).Token <= oldOwner.GetLeaseInfo(sessionID).Token {
+		t.Error("claimed lease did not get a newer fencing token")
+	}
+
+	// The old owner learns of the handoff on its next renewal and gives the session up
+	oldOwner.renewOwnedLeases()
+	if oldOwner.GetLeaseInfo(sessionID) != nil {
+		t.Error("old owner still tracks the handed off lease")
+	}
+	if len(lost) != 1 || lost[0] != sessionID {
+		t.Errorf("lease lost callback called for %v, want [%s]", lost, sessionID)
+	}
+	if owned, _ := oldOwner.IsOwnedStrict(context.Background(), sessionID); owned {
+		t.Error("old owner strictly owns the handed off lease")
+	}
+	if err := newOwner.renewLease(sessionID); err != nil {
+		t.Errorf("new owner failed to renew: %v", err)
+	}
+}
+
+func TestRenewalAfterHandoffReportsHandedOff(t *testing.T) {
+	_, client := newTestRedis(t)
+	config := DefaultLeaseConfig()
+	oldOwner := newTestManager(t, client, "node-a", config)
+	newOwner := newTestManager(t, client, "node-b", config)
+	sessionID := sessionPreferring(t, "session", "node-b", "node-a", "node-b")
+
+	mustAcquire(t, oldOwner, sessionID)
+	goOnline(t, client, "node-a")
+	goOnline(t, client, "node-b")
+	oldOwner.releaseNonPreferredLeases()
+	mustAcquire(t, newOwner, sessionID)
+
+	if err := oldOwner.renewLease(sessionID); !errors.Is(err, errLeaseHandedOff) {
+		t.Errorf("got error %v, want errLeaseHandedOff", err)
+	}
+	if oldOwner.IsUncertain(sessionID) {
+		t.Error("handed off lease marked uncertain instead of released")
+	}
+}
+
+func TestHandoffClaimRejected(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(t *testing.T, client *key_value.Client, sessionID string)
+	}{
+		{"no marker", func(t *testing.T, client *key_value.Client, sessionID string) {}},
+		{"marker names another instance", func(t *testing.T, client *key_value.Client, sessionID string) {
+			value, _ := RedisHandoffValue{From: "node-a", To: "node-c"}.Marshal()
+			client.RedisClient.Set(context.Background(), handoffKey(sessionID), value, time.Minute)
+		}},
+		{"lease taken by a third instance", func(t *testing.T, client *key_value.Client, sessionID string) {
+			value, _ := RedisHandoffValue{From: "node-a", To: "node-b"}.Marshal()
+			client.RedisClient.Set(context.Background(), handoffKey(sessionID), value, time.Minute)
+			lease, _ := RedisLeaseValue{OwnerID: "node-c", Token: 7}.Marshal()
+			client.RedisClient.Set(context.Background(), leaseKey(sessionID), lease, time.Minute)
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, client := newTestRedis(t)
+			claimer := newTestManager(t, client, "node-b", DefaultLeaseConfig())
+			test.setup(t, client, "session")
+
+			claimed, err := claimer.claimHandoff(context.Background(), "session")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if claimed || claimer.IsOwned("session") {
+				t.Error("claimed a lease not handed off to this instance")
+			}
+		})
+	}
+}
+
+func TestUnclaimedHandoffFallsBackToRelease(t *testing.T) {
+	_, client := newTestRedis(t)
+	config := DefaultLeaseConfig()
+	config.HandoffTimeout = time.Minute
+	oldOwner := newTestManager(t, client, "node-a", config)
+	sessionID := sessionPreferring(t, "session", "node-b", "node-a", "node-b")
+
+	mustAcquire(t, oldOwner, sessionID)
+	goOnline(t, client, "node-a")
+	goOnline(t, client, "node-b")
+
+	oldOwner.releaseNonPreferredLeases()
+	oldOwner.releaseNonPreferredLeases()
+	if !oldOwner.IsOwned(sessionID) {
+		t.Fatal("lease released before the handoff timed out")
+	}
+
+	oldOwner.mu.Lock()
+	oldOwner.handoffs[sessionID] = time.Now().Add(-config.HandoffTimeout)
+	oldOwner.mu.Unlock()
+	oldOwner.releaseNonPreferredLeases()
+
+	if oldOwner.IsOwned(sessionID) {
+		t.Error("lease kept after the handoff timed out")
+	}
+	if owner, _ := oldOwner.GetLeaseOwner(context.Background(), sessionID); owner != "" {
+		t.Errorf("got owner %q after the fallback release, want none", owner)
+	}
+}
+
+// orphanLease stores a lease held by an instance without a heartbeat
+func orphanLease(t *testing.T, client *key_value.Client, sessionID, deadOwner string) {
+	t.Helper()
+	value, _ := RedisLeaseValue{OwnerID: deadOwner, Token: 1}.Marshal()
+	if err := client.RedisClient.Set(context.Background(), leaseKey(sessionID), value, time.Minute).Err(); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.RedisClient.Set(context.Background(), fenceKey(sessionID), 1, 0).Err(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReclaimOrphanedLeases(t *testing.T) {
+	_, client := newTestRedis(t)
+	sweeper := newTestManager(t, client, "node-b", DefaultLeaseConfig())
+	goOnline(t, client, "node-a")
+	goOnline(t, client, "node-b")
+
+	orphaned := sessionPreferring(t, "orphaned", "node-b", "node-a", "node-b")
+	orphanLease(t, client, orphaned, "node-dead")
+	notPreferred := sessionPreferring(t, "not-preferred", "node-a", "node-a", "node-b")
+	orphanLease(t, client, notPreferred, "node-dead")
+	liveOwner := sessionPreferring(t, "live-owner", "node-b", "node-a", "node-b")
+	orphanLease(t, client, liveOwner, "node-a")
+
+	sweeper.reclaimOrphanedLeases()
+
+	tests := []struct {
+		sessionID string
+		wantOwner string
+	}{
+		{orphaned, "node-b"},
+		{notPreferred, "node-dead"},
+		{liveOwner, "node-a"},
+	}
+	for _, test := range tests {
+		t.Run(test.sessionID, func(t *testing.T) {
+			if owner, _ := sweeper.GetLeaseOwner(context.Background(), test.sessionID); owner != test.wantOwner {
+				t.Errorf("got owner %q, want %q", owner, test.wantOwner)
+			}
+		})
+	}
+	if info := sweeper.GetLeaseInfo(orphaned); info == nil || info.Token <= 1 {
+		t.Errorf("reclaimed lease tracked as %+v, want a newer fencing token", info)
+	}
+}
+
+func TestOrphanSweepIsThrottled(t *testing.T) {
+	_, client := newTestRedis(t)
+	config := DefaultLeaseConfig()
+	sweeper := newTestManager(t, client, "node-b", config)
+	goOnline(t, client, "node-b")
+
+	// The first sweep finds nothing, the orphan appears right after it
+	sweeper.reclaimOrphanedLeases()
+	orphanLease(t, client, "session", "node-dead")
+
+	sweeper.reclaimOrphanedLeases()
+	if sweeper.IsOwned("session") {
+		t.Fatal("swept again within the sweep interval")
+	}
+
+	sweeper.lastOrphanSweep = time.Now().Add(-config.OrphanSweepInterval)
+	sweeper.reclaimOrphanedLeases()
+	if !sweeper.IsOwned("session") {
+		t.Error("orphaned lease not reclaimed once the sweep interval passed")
+	}
+}
+
+func TestOrphanSweepDisabled(t *testing.T) {
+	_, client := newTestRedis(t)
+	config := DefaultLeaseConfig()
+	config.OrphanSweepInterval = 0
+	sweeper := newTestManager(t, client, "node-b", config)
+	goOnline(t, client, "node-b")
+	orphanLease(t, client, "session", "node-dead")
+
+	sweeper.reclaimOrphanedLeases()
+	if sweeper.IsOwned("session") {
+		t.Error("reclaimed an orphaned lease with the sweep disabled")
+	}
+}
+
+func TestReconcileDropsLeaseOwnedElsewhere(t *testing.T) {
+	server, client := newTestRedis(t)
+	config := DefaultLeaseConfig()
+	stale := newTestManager(t, client, "node-a", config)
+	taker := newTestManager(t, client, "node-b", config)
+
+	var lost []string
+	stale.SetLeaseLostCallback(func(sessionID string) { lost = append(lost, sessionID) })
+
+	mustAcquire(t, stale, "session")
+	stale.reconcileOwnedLeases()
+	if !stale.IsOwned("session") || len(lost) > 0 {
+		t.Fatal("reconciliation dropped a lease owned in Redis")
+	}
+
+	// Redis names another owner while the cache still claims the lease
+	server.FastForward(config.LeaseTTL)
+	mustAcquire(t, taker, "session")
+	drainEvents(stale)
+
+	stale.reconcileOwnedLeases()
+	if !stale.IsOwned("session") || len(lost) > 0 {
+		t.Fatal("reconciled again within the reconcile interval")
+	}
+
+	stale.lastReconcile = time.Now().Add(-config.ReconcileInterval)
+	stale.reconcileOwnedLeases()
+	if info := stale.GetLeaseInfo("session"); info != nil {
+		t.Errorf("lease still cached as %+v, want it dropped", info)
+	}
+	if len(lost) != 1 || lost[0] != "session" {
+		t.Errorf("lease lost callback called for %v, want [session]", lost)
+	}
+	events := drainEvents(stale)
+	if len(events) != 1 || events[0].Type != LeaseEventTakenOver || events[0].Reason != "stale_cache" {
+		t.Errorf("got events %+v, want a stale_cache takeover", events)
+	}
+	if owner, _ := taker.GetLeaseOwner(context.Background(), "session"); owner != "node-b" {
+		t.Errorf("got owner %q, want the new owner node-b", owner)
+	}
+}
+
+func TestStatusGracePeriod(t *testing.T) {
+	_, client := newTestRedis(t)
+	config := DefaultLeaseConfig()
+	config.StatusGracePeriod = 200 * time.Millisecond
+	m := NewLeaseManagerWithID("node-a", client, config, zap.NewNop()).(*leaseManager)
+	if err := m.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = m.Stop() })
+
+	if status, _ := GetNodeStatus(context.Background(), client, "node-a"); status != "init" {
+		t.Errorf("got heartbeat status %q right after start, want init", status)
+	}
+	if acquired, err := m.TryAcquire(context.Background(), "session"); err != nil || acquired {
+		t.Fatalf("acquired %v with error %v during the grace period, want no lease", acquired, err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !m.IsReady() {
+		if time.Now().After(deadline) {
+			t.Fatal("still in init long after the grace period")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if elapsed := time.Since(m.startupTime); elapsed < config.StatusGracePeriod {
+		t.Errorf("went online after %s, before the grace period of %s", elapsed, config.StatusGracePeriod)
+	}
+	mustAcquire(t, m, "session")
+}
+
+func TestStatusGracePeriodDefault(t *testing.T) {
+	tests := []struct {
+		configured time.Duration
+		want       time.Duration
+	}{
+		{0, defaultStatusGracePeriod},
+		{-time.Second, defaultStatusGracePeriod},
+		{3 * time.Second, 3 * time.Second},
+	}
+	for _, test := range tests {
+		t.Run(test.configured.String(), func(t *testing.T) {
+			if got := (LeaseConfig{StatusGracePeriod: test.configured}).statusGracePeriod(); got != test.want {
+				t.Errorf("got %s, want %s", got, test.want)
+			}
+		})
+	}
+}
+
+// drainEvents returns the events buffered on the manager's channel, oldest first
+func drainEvents(m *leaseManager) []LeaseEvent {
+	var events []LeaseEvent
+	for {
+		select {
+		case event := <-m.Events():
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+func TestEventsReportLeaseLifecycle(t *testing.T) {
+	_, client := newTestRedis(t)
+	m := newTestManager(t, client, "node-a", DefaultLeaseConfig())
+
+	mustAcquire(t, m, "session")
+	if err := m.Release(context.Background(), "session"); err != nil {
+		t.Fatal(err)
+	}
+
+	events := drainEvents(m)
+	want := []LeaseEventType{LeaseEventAcquired, LeaseEventReleased}
+	if len(events) != len(want) {
+		t.Fatalf("got %d events, want %d: %+v", len(events), len(want), events)
+	}
+	for i, event := range events {
+		if event.Type != want[i] || event.SessionID != "session" || event.InstanceID != "node-a" {
+			t.Errorf("event %d: got %+v, want %s of session by node-a", i, event, want[i])
+		}
+	}
+	if events[1].Reason != "voluntary" {
+		t.Errorf("release reason %q, want voluntary", events[1].Reason)
+	}
+}
+
+func TestEventsDropOldestWhenFull(t *testing.T) {
+	_, client := newTestRedis(t)
+	m := newTestManager(t, client, "node-a", DefaultLeaseConfig())
+
+	const overflow = 10
+	for i := range leaseEventBuffer + overflow {
+		m.emitEvent(LeaseEventRenewed, fmt.Sprintf("session-%d", i), "test")
+	}
+
+	events := drainEvents(m)
+	if len(events) != leaseEventBuffer {
+		t.Fatalf("got %d events, want the buffer size %d", len(events), leaseEventBuffer)
+	}
+	if first := events[0].SessionID; first != fmt.Sprintf("session-%d", overflow) {
+		t.Errorf("oldest kept event is for %s, want session-%d", first, overflow)
+	}
+	if last := events[len(events)-1].SessionID; last != fmt.Sprintf("session-%d", leaseEventBuffer+overflow-1) {
+		t.Errorf("newest event is for %s, want session-%d", last, leaseEventBuffer+overflow-1)
+	}
+}
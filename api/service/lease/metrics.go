@@ -0,0 +1,69 @@
+package lease
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// leaseMetrics are the Prometheus metrics of one lease manager.
+// They are always recorded; registering only controls where they are exposed.
+type leaseMetrics struct {
+	owned           *prometheus.GaugeVec
+	uncertain       *prometheus.GaugeVec
+	renewals        *prometheus.CounterVec
+	renewalFailures *prometheus.CounterVec
+	reacquired      *prometheus.CounterVec
+}
+
+func newLeaseMetrics() *leaseMetrics {
+	return &leaseMetrics{
+		owned: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "lease_owned_total",
+			Help: "Number of session leases currently held by the instance.",
+		}, []string{"instance_id"}),
+		uncertain: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "lease_uncertain_current",
+			Help: "Number of held session leases whose ownership could not be confirmed.",
+		}, []string{"instance_id"}),
+		renewals: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "lease_renewals_total",
+			Help: "Number of successful lease renewals.",
+		}, []string{"instance_id"}),
+		renewalFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "lease_renewal_failures_total",
+			Help: "Number of failed lease renewals.",
+		}, []string{"instance_id"}),
+		reacquired: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "lease_reacquired_total",
+			Help: "Number of uncertain leases confirmed and renewed again.",
+		}, []string{"instance_id"}),
+	}
+}
+
+// RegisterMetrics registers the lease metrics of this manager with the given registry.
+func (m *leaseManager) RegisterMetrics(reg prometheus.Registerer) error {
+	collectors := []prometheus.Collector{
+		m.metrics.owned,
+		m.metrics.uncertain,
+		m.metrics.renewals,
+		m.metrics.renewalFailures,
+		m.metrics.reacquired,
+	}
+	for _, collector := range collectors {
+		if err := reg.Register(collector); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// updateLeaseGaugesLocked sets the ownership gauges from the tracked leases. Caller must hold m.mu lock.
+func (m *leaseManager) updateLeaseGaugesLocked() {
+	uncertain := 0
+	for _, info := range m.ownedLeases {
+		if info.State == LeaseStateUncertain {
+			uncertain++
+		}
+	}
+	m.metrics.owned.WithLabelValues(m.instanceID).Set(float64(len(m.ownedLeases)))
+	m.metrics.uncertain.WithLabelValues(m.instanceID).Set(float64(uncertain))
+}
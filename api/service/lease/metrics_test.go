@@ -0,0 +1,78 @@
+package lease
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestLeaseMetrics(t *testing.T) {
+	server, client := newTestRedis(t)
+	config := DefaultLeaseConfig()
+	m := newTestManager(t, client, "node-a", config)
+	registry := prometheus.NewRegistry()
+	if err := m.RegisterMetrics(registry); err != nil {
+		t.Fatal(err)
+	}
+
+	mustAcquire(t, m, "first")
+	mustAcquire(t, m, "second")
+	if err := m.renewLease("first"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Once the leases expired, renewing the first fails and turns it uncertain
+	server.FastForward(config.LeaseTTL)
+	if err := m.renewLease("first"); err == nil {
+		t.Fatal("renewal of an expired lease succeeded")
+	}
+
+	tests := []struct {
+		name      string
+		collector prometheus.Collector
+		want      float64
+	}{
+		{"owned", m.metrics.owned, 2},
+		{"uncertain", m.metrics.uncertain, 1},
+		{"renewals", m.metrics.renewals, 1},
+		{"renewal failures", m.metrics.renewalFailures, 1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := testutil.ToFloat64(test.collector); got != test.want {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+
+	if count, err := testutil.GatherAndCount(registry); err != nil || count != 4 {
+		t.Errorf("gathered %d series with error %v, want 4", count, err)
+	}
+	if err := m.Release(context.Background(), "first"); err != nil {
+		t.Fatal(err)
+	}
+	if got := testutil.ToFloat64(m.metrics.owned); got != 1 {
+		t.Errorf("owned %v after a release, want 1", got)
+	}
+}
+
+func TestLeaseMetricsArePerManager(t *testing.T) {
+	_, client := newTestRedis(t)
+	first := newTestManager(t, client, "node-a", DefaultLeaseConfig())
+	second := newTestManager(t, client, "node-b", DefaultLeaseConfig())
+
+	// Each manager can be registered with its own registry, and the default registry is left alone
+	if err := first.RegisterMetrics(prometheus.NewRegistry()); err != nil {
+		t.Fatal(err)
+	}
+	if err := second.RegisterMetrics(prometheus.NewRegistry()); err != nil {
+		t.Fatal(err)
+	}
+
+	mustAcquire(t, first, "session")
+	if got := testutil.ToFloat64(second.metrics.owned.WithLabelValues("node-b")); got != 0 {
+		t.Errorf("second manager reports %v owned leases, want 0", got)
+	}
+}
@@ -3,6 +3,7 @@ package lease
 
 import (
 	"hash/fnv"
+	"math"
 )
 
 // computeWeight calculates the rendezvous hash weight for a node-session pair.
@@ -15,25 +16,72 @@ func computeWeight(nodeID, sessionID string) uint64 {
 	return h.Sum64()
 }
 
+// mixHash applies the splitmix64 finalizer. FNV-1a leaves the high bits of short, similar
+// inputs poorly mixed, which skews the share of sessions each node wins.
+func mixHash(h uint64) uint64 {
+	h ^= h >> 30
+	h *= 0xbf58476d1ce4e5b9
+	h ^= h >> 27
+	h *= 0x94d049bb133111eb
+	h ^= h >> 31
+	return h
+}
+
+// computeScore scales the rendezvous hash of a node-session pair by the node's capacity weight.
+// The mixed hash is mapped to a uniform value u in (0, 1) and scored as -weight / ln(u), so each
+// node wins a share of sessions proportional to its weight.
+func computeScore(nodeID, sessionID string, weight int) float64 {
+	u := (float64(mixHash(computeWeight(nodeID, sessionID))>>11) + 0.5) / (1 << 53)
+	return -float64(weight) / math.Log(u)
+}
+
 // ComputePreferredOwner determines which node should own the given session
-// using rendezvous (Highest Random Weight) hashing.
-// Returns the nodeID with the highest weight for the given sessionID.
+// using weighted rendezvous (Highest Random Weight) hashing.
+// Nodes missing from weights, or with a non-positive weight, count as weight 1.
+// When every node has the same weight the plain FNV-1a hashes are compared, as before weights were
+// introduced, so rolling out weights does not move any session until an operator sets one.
+// Returns the nodeID with the highest score for the given sessionID.
 // Returns empty string if nodes slice is empty.
-func ComputePreferredOwner(sessionID string, nodes []string) string {
+func ComputePreferredOwner(sessionID string, nodes []string, weights map[string]int) string {
 	if len(nodes) == 0 {
 		return ""
 	}
 
+	nodeWeight := func(nodeID string) int {
+		if weight := weights[nodeID]; weight > 0 {
+			return weight
+		}
+		return defaultNodeWeight
+	}
+
+	uniform := true
+	for _, nodeID := range nodes {
+		if nodeWeight(nodeID) != nodeWeight(nodes[0]) {
+			uniform = false
+			break
+		}
+	}
+
 	var bestNode string
-	var bestWeight uint64
+	if uniform {
+		var bestHash uint64
+		for _, nodeID := range nodes {
+			hash := computeWeight(nodeID, sessionID)
+			if hash > bestHash || bestNode == "" {
+				bestHash = hash
+				bestNode = nodeID
+			}
+		}
+		return bestNode
+	}
 
+	var bestScore float64
 	for _, nodeID := range nodes {
-		weight := computeWeight(nodeID, sessionID)
-		if weight > bestWeight || bestNode == "" {
-			bestWeight = weight
+		score := computeScore(nodeID, sessionID, nodeWeight(nodeID))
+		if score > bestScore || bestNode == "" {
+			bestScore = score
 			bestNode = nodeID
 		}
 	}
-
 	return bestNode
 }
@@ -0,0 +1,85 @@
+package lease
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+// baselineOwner is the preferred owner as computed before weights existed
+func baselineOwner(sessionID string, nodes []string) string {
+	var bestNode string
+	var bestHash uint64
+	for _, nodeID := range nodes {
+		if hash := computeWeight(nodeID, sessionID); hash > bestHash || bestNode == "" {
+			bestHash, bestNode = hash, nodeID
+		}
+	}
+	return bestNode
+}
+
+func TestComputePreferredOwnerKeepsAssignmentsForEqualWeights(t *testing.T) {
+	nodes := []string{"node-a", "node-b", "node-c"}
+	tests := []struct {
+		name    string
+		weights map[string]int
+	}{
+		{"no weights", nil},
+		{"default weights", map[string]int{"node-a": 1, "node-b": 1, "node-c": 1}},
+		{"equal custom weights", map[string]int{"node-a": 4, "node-b": 4, "node-c": 4}},
+		{"missing and non-positive weights", map[string]int{"node-a": 1, "node-b": 0}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for i := range 1000 {
+				sessionID := fmt.Sprintf("session-%d", i)
+				if got, want := ComputePreferredOwner(sessionID, nodes, test.weights), baselineOwner(sessionID, nodes); got != want {
+					t.Fatalf("%s moved from %s to %s", sessionID, want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestComputePreferredOwnerFollowsWeights(t *testing.T) {
+	const sessions = 20000
+	tests := []struct {
+		name    string
+		weights map[string]int
+	}{
+		{"2:1", map[string]int{"node-a": 2, "node-b": 1}},
+		{"3:2:1", map[string]int{"node-a": 3, "node-b": 2, "node-c": 1}},
+		{"1:1:2", map[string]int{"node-a": 1, "node-b": 1, "node-c": 2}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			nodes := make([]string, 0, len(test.weights))
+			total := 0
+			for node, weight := range test.weights {
+				nodes = append(nodes, node)
+				total += weight
+			}
+
+			owned := map[string]int{}
+			for i := range sessions {
+				owned[ComputePreferredOwner(fmt.Sprintf("session-%d", i), nodes, test.weights)]++
+			}
+
+			for node, weight := range test.weights {
+				want := float64(weight) / float64(total)
+				got := float64(owned[node]) / sessions
+				if math.Abs(got-want) > 0.02 {
+					t.Errorf("%s owns %.3f of the sessions, want %.3f", node, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestComputePreferredOwnerWithoutNodes(t *testing.T) {
+	if owner := ComputePreferredOwner("session", nil, nil); owner != "" {
+		t.Errorf("got owner %q without nodes, want none", owner)
+	}
+}
@@ -0,0 +1,213 @@
+// Package lease provides distributed polling lease coordination across API instances.
+package lease
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// DiscrepancyKind identifies how Redis and this instance's view of a lease disagree.
+type DiscrepancyKind string
+
+const (
+	// DiscrepancyCachedButNotInRedis indicates the cache says this instance owns the lease,
+	// but Redis records another owner or no lease at all.
+	DiscrepancyCachedButNotInRedis DiscrepancyKind = "cached_but_not_in_redis"
+
+	// DiscrepancyInRedisButNotCached indicates Redis records this instance as owner,
+	// but the cache does not track the lease (nothing is polling it here).
+	DiscrepancyInRedisButNotCached DiscrepancyKind = "in_redis_but_not_cached"
+
+	// DiscrepancyOwnerNotLive indicates the recorded owner has no active heartbeat.
+	DiscrepancyOwnerNotLive DiscrepancyKind = "owner_not_live"
+
+	// DiscrepancyNotPreferredOwner indicates the recorded owner differs from the
+	// rendezvous-hashing preferred owner. Expected briefly while rebalancing.
+	DiscrepancyNotPreferredOwner DiscrepancyKind = "not_preferred_owner"
+)
+
+// LeaseStatus classifies a lease by its recorded owner.
+type LeaseStatus string
+
+const (
+	// LeaseStatusLive indicates the recorded owner has an active heartbeat.
+	LeaseStatusLive LeaseStatus = "live"
+
+	// LeaseStatusOrphaned indicates the recorded owner has no active heartbeat,
+	// so nobody polls the session until the lease expires.
+	LeaseStatusOrphaned LeaseStatus = "orphaned"
+
+	// LeaseStatusUnowned indicates no lease key exists, the lease is only cached by this instance.
+	LeaseStatusUnowned LeaseStatus = "unowned"
+)
+
+// LeaseSnapshot is one lease as seen in Redis and by this instance.
+type LeaseSnapshot struct {
+	// SessionID is the session this lease controls.
+	SessionID string `json:"session_id"`
+
+	// OwnerID is the owner recorded in Redis (empty if no lease key exists).
+	OwnerID string `json:"owner_id"`
+
+	// AcquiredAt is when the recorded owner acquired the lease.
+	AcquiredAt time.Time `json:"acquired_at"`
+
+	// LastRenewedAt is when the recorded owner last renewed the lease.
+	LastRenewedAt time.Time `json:"last_renewed_at"`
+
+	// Token is the fencing token of the recorded acquisition.
+	Token int64 `json:"token"`
+
+	// OwnerLive is true when the recorded owner has an active heartbeat.
+	OwnerLive bool `json:"owner_live"`
+
+	// Status classifies the lease by its recorded owner.
+	Status LeaseStatus `json:"status"`
+
+	// PreferredOwner is the rendezvous-hashing preferred owner among live nodes.
+	PreferredOwner string `json:"preferred_owner"`
+
+	// CachedState is this instance's cached view of the lease.
+	CachedState string `json:"cached_state"`
+
+	// CacheAgrees is true when the cached state is consistent with Redis.
+	CacheAgrees bool `json:"cache_agrees"`
+}
+
+// LeaseDiscrepancy describes a single inconsistency found while taking a snapshot.
+type LeaseDiscrepancy struct {
+	// SessionID is the session the discrepancy applies to.
+	SessionID string `json:"session_id"`
+
+	// Kind is the type of inconsistency.
+	Kind DiscrepancyKind `json:"kind"`
+
+	// Detail is a human-readable explanation.
+	Detail string `json:"detail"`
+}
+
+// ClusterSnapshot is a consistent dump of lease ownership for debugging split-brain conditions.
+type ClusterSnapshot struct {
+	// InstanceID is the instance that took the snapshot.
+	InstanceID string `json:"instance_id"`
+
+	// TakenAt is when the snapshot was taken.
+	TakenAt time.Time `json:"taken_at"`
+
+	// LiveNodes are the instances with active heartbeats.
+	LiveNodes []string `json:"live_nodes"`
+
+	// Leases are all leases visible in Redis or tracked by this instance, sorted by session ID.
+	Leases []LeaseSnapshot `json:"leases"`
+
+	// Discrepancies lists every inconsistency found, sorted by session ID.
+	Discrepancies []LeaseDiscrepancy `json:"discrepancies"`
+}
+
+// ClusterSnapshot gathers every lease key visible in Redis together with this
+// instance's cached state and reports where they disagree.
+func (m *leaseManager) ClusterSnapshot(ctx context.Context) (*ClusterSnapshot, error) {
+	liveNodes, weights, err := m.liveNodeWeights(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get live nodes: %w", err)
+	}
+	sort.Strings(liveNodes)
+
+	live := make(map[string]bool, len(liveNodes))
+	for _, node := range liveNodes {
+		live[node] = true
+	}
+
+	sessionIDs := make(map[string]bool)
+	iter := m.client.RedisClient.Scan(ctx, 0, leaseKeyPrefix+"*", 0).Iterator()
+	for iter.Next(ctx) {
+		sessionIDs[iter.Val()[len(leaseKeyPrefix):]] = true
+	}
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("scan %s* keys: %w", leaseKeyPrefix, err)
+	}
+
+	m.mu.RLock()
+	cached := make(map[string]LeaseInfo, len(m.ownedLeases))
+	for sessionID, info := range m.ownedLeases {
+		cached[sessionID] = info
+		sessionIDs[sessionID] = true
+	}
+	m.mu.RUnlock()
+
+	sorted := make([]string, 0, len(sessionIDs))
+	for sessionID := range sessionIDs {
+		sorted = append(sorted, sessionID)
+	}
+	sort.Strings(sorted)
+
+	snapshot := &ClusterSnapshot{
+		InstanceID:    m.instanceID,
+		TakenAt:       time.Now(),
+		LiveNodes:     liveNodes,
+		Leases:        make([]LeaseSnapshot, 0, len(sorted)),
+		Discrepancies: make([]LeaseDiscrepancy, 0),
+	}
+
+	for _, sessionID := range sorted {
+		value, err := m.GetLeaseValue(ctx, sessionID)
+		if err != nil {
+			return nil, fmt.Errorf("get lease value for %s: %w", sessionID, err)
+		}
+
+		info, isCached := cached[sessionID]
+		cachedState := LeaseStateUnknown
+		if isCached {
+			cachedState = info.State
+		}
+
+		lease := LeaseSnapshot{
+			SessionID:      sessionID,
+			OwnerID:        value.OwnerID,
+			AcquiredAt:     value.AcquiredAt,
+			LastRenewedAt:  value.LastRenewedAt,
+			Token:          value.Token,
+			OwnerLive:      live[value.OwnerID],
+			Status:         LeaseStatusLive,
+			PreferredOwner: ComputePreferredOwner(sessionID, liveNodes, weights),
+			CachedState:    cachedState.String(),
+			CacheAgrees:    true,
+		}
+
+		addDiscrepancy := func(kind DiscrepancyKind, detail string) {
+			snapshot.Discrepancies = append(snapshot.Discrepancies, LeaseDiscrepancy{
+				SessionID: sessionID,
+				Kind:      kind,
+				Detail:    detail,
+			})
+		}
+
+		ownedInRedis := value.OwnerID == m.instanceID
+		// An uncertain lease is expected to disagree with Redis until it is re-acquired or dropped
+		if isCached && info.State == LeaseStateOwned && !ownedInRedis {
+			lease.CacheAgrees = false
+			addDiscrepancy(DiscrepancyCachedButNotInRedis, fmt.Sprintf("cache says %s owns the lease, redis says %q", m.instanceID, value.OwnerID))
+		}
+		if !isCached && ownedInRedis {
+			lease.CacheAgrees = false
+			addDiscrepancy(DiscrepancyInRedisButNotCached, fmt.Sprintf("redis says %s owns the lease, but it is not cached", m.instanceID))
+		}
+		if value.OwnerID == "" {
+			lease.Status = LeaseStatusUnowned
+		} else if !lease.OwnerLive {
+			lease.Status = LeaseStatusOrphaned
+		}
+		if value.OwnerID != "" && !live[value.OwnerID] {
+			addDiscrepancy(DiscrepancyOwnerNotLive, fmt.Sprintf("owner %s has no active heartbeat", value.OwnerID))
+		}
+		if value.OwnerID != "" && lease.PreferredOwner != "" && value.OwnerID != lease.PreferredOwner {
+			addDiscrepancy(DiscrepancyNotPreferredOwner, fmt.Sprintf("owner %s is not the preferred owner %s", value.OwnerID, lease.PreferredOwner))
+		}
+
+		snapshot.Leases = append(snapshot.Leases, lease)
+	}
+
+	return snapshot, nil
+}
@@ -0,0 +1,99 @@
+package lease
+
+import (
+	"context"
+	"testing"
+)
+
+// snapshotDiscrepancies returns the discrepancy kinds reported for each session
+func snapshotDiscrepancies(snapshot *ClusterSnapshot) map[string][]DiscrepancyKind {
+	kinds := make(map[string][]DiscrepancyKind)
+	for _, discrepancy := range snapshot.Discrepancies {
+		kinds[discrepancy.SessionID] = append(kinds[discrepancy.SessionID], discrepancy.Kind)
+	}
+	return kinds
+}
+
+func TestClusterSnapshotReportsCacheOwnedElsewhere(t *testing.T) {
+	_, client := newTestRedis(t)
+	m := newTestManager(t, client, "node-b", DefaultLeaseConfig())
+	goOnline(t, client, "node-a")
+	goOnline(t, client, "node-b")
+
+	// Redis says node-a owns the lease, the cache of node-b says it owns it
+	orphanLease(t, client, "session", "node-a")
+	m.ownedLeases["session"] = LeaseInfo{SessionID: "session", OwnerID: "node-b", State: LeaseStateOwned, Token: 1}
+
+	snapshot, err := m.ClusterSnapshot(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshot.Leases) != 1 {
+		t.Fatalf("got %d leases, want 1: %+v", len(snapshot.Leases), snapshot.Leases)
+	}
+	if lease := snapshot.Leases[0]; lease.OwnerID != "node-a" || lease.CachedState != "owned" || lease.CacheAgrees {
+		t.Errorf("got lease %+v, want owner node-a cached as owned and disagreeing", lease)
+	}
+
+	found := false
+	for _, kind := range snapshotDiscrepancies(snapshot)["session"] {
+		found = found || kind == DiscrepancyCachedButNotInRedis
+	}
+	if !found {
+		t.Errorf("got discrepancies %+v, want %s", snapshot.Discrepancies, DiscrepancyCachedButNotInRedis)
+	}
+}
+
+func TestClusterSnapshotClassifiesLeases(t *testing.T) {
+	_, client := newTestRedis(t)
+	m := newTestManager(t, client, "node-b", DefaultLeaseConfig())
+	goOnline(t, client, "node-a")
+	goOnline(t, client, "node-b")
+
+	mustAcquire(t, m, "live-owned")
+	orphanLease(t, client, "other-owned", "node-a")
+	orphanLease(t, client, "orphaned", "node-dead")
+
+	snapshot, err := m.ClusterSnapshot(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		sessionID       string
+		wantOwner       string
+		wantStatus      LeaseStatus
+		wantLive        bool
+		wantCachedState string
+	}{
+		{"live-owned", "node-b", LeaseStatusLive, true, "owned"},
+		{"orphaned", "node-dead", LeaseStatusOrphaned, false, "unknown"},
+		{"other-owned", "node-a", LeaseStatusLive, true, "unknown"},
+	}
+	if len(snapshot.Leases) != len(tests) {
+		t.Fatalf("got %d leases, want %d: %+v", len(snapshot.Leases), len(tests), snapshot.Leases)
+	}
+	for i, test := range tests {
+		t.Run(test.sessionID, func(t *testing.T) {
+			lease := snapshot.Leases[i]
+			if lease.SessionID != test.sessionID {
+				t.Fatalf("lease %d is %s, want %s in session order", i, lease.SessionID, test.sessionID)
+			}
+			if lease.OwnerID != test.wantOwner || lease.Status != test.wantStatus || lease.OwnerLive != test.wantLive {
+				t.Errorf("got owner %q status %s live %v, want %q %s %v",
+					lease.OwnerID, lease.Status, lease.OwnerLive, test.wantOwner, test.wantStatus, test.wantLive)
+			}
+			if lease.CachedState != test.wantCachedState || !lease.CacheAgrees {
+				t.Errorf("got cached state %s agreeing %v, want %s agreeing", lease.CachedState, lease.CacheAgrees, test.wantCachedState)
+			}
+		})
+	}
+
+	found := false
+	for _, kind := range snapshotDiscrepancies(snapshot)["orphaned"] {
+		found = found || kind == DiscrepancyOwnerNotLive
+	}
+	if !found {
+		t.Errorf("got discrepancies %+v, want %s for the orphaned lease", snapshot.Discrepancies, DiscrepancyOwnerNotLive)
+	}
+}
@@ -59,6 +59,13 @@ type LeaseInfo struct {
 	// UncertainSince is when the lease entered uncertain state (zero if not uncertain).
 	// Used to track how long a lease has been uncertain for re-acquisition attempts.
 	UncertainSince time.Time
+
+	// LastVerifiedAt is when ownership was last verified against Redis by reconciliation
+	// (zero if never verified).
+	LastVerifiedAt time.Time
+
+	// Token is the fencing token of the acquisition this instance holds (zero if not owned).
+	Token int64
 }
 
 // LeaseConfig contains configuration for the lease manager.
@@ -77,6 +84,62 @@ type LeaseConfig struct {
 
 	// NodeDiscoveryInterval is how often to refresh live node list. Default: 10s.
 	NodeDiscoveryInterval time.Duration
+
+	// ReconcileInterval is how often cached ownership is verified against Redis. Default: 30s.
+	ReconcileInterval time.Duration
+
+	// ReconcileBatchSize is the max number of owned leases verified per reconciliation,
+	// least recently verified first, to avoid hammering Redis. Default: 10.
+	ReconcileBatchSize int
+
+	// OrphanSweepInterval is how often leases of dead instances are looked for and reclaimed by
+	// their preferred owner. Each sweep scans every lease key, so it runs less often than renewals.
+	// Zero disables the sweep, leaving orphaned leases to expire. Default: 30s.
+	OrphanSweepInterval time.Duration
+
+	// StatusGracePeriod is how long a new node stays in "init" before going "online". Default: 10s.
+	StatusGracePeriod time.Duration
+
+	// HandoffTimeout is how long a lease handed off during rebalancing waits for the preferred
+	// owner to claim it before it is released instead. Default: 30s.
+	HandoffTimeout time.Duration
+
+	// Weight is this instance's relative capacity, reported in its heartbeat. Weighted rendezvous
+	// hashing gives each instance a share of sessions proportional to its weight. Default: 1.
+	Weight int
+}
+
+// defaultStatusGracePeriod is used when StatusGracePeriod is not set.
+const defaultStatusGracePeriod = 10 * time.Second
+
+// statusGracePeriod returns the configured status grace period, or the default if unset.
+func (c LeaseConfig) statusGracePeriod() time.Duration {
+	if c.StatusGracePeriod <= 0 {
+		return defaultStatusGracePeriod
+	}
+	return c.StatusGracePeriod
+}
+
+// defaultHandoffTimeout is used when HandoffTimeout is not set.
+const defaultHandoffTimeout = 30 * time.Second
+
+// handoffTimeout returns the configured handoff timeout, or the default if unset.
+func (c LeaseConfig) handoffTimeout() time.Duration {
+	if c.HandoffTimeout <= 0 {
+		return defaultHandoffTimeout
+	}
+	return c.HandoffTimeout
+}
+
+// defaultNodeWeight is used when Weight is not set, and for nodes not reporting a weight.
+const defaultNodeWeight = 1
+
+// weight returns the configured node weight, or the default if unset.
+func (c LeaseConfig) weight() int {
+	if c.Weight <= 0 {
+		return defaultNodeWeight
+	}
+	return c.Weight
 }
 
 // DefaultLeaseConfig returns the default configuration.
@@ -87,6 +150,12 @@ func DefaultLeaseConfig() LeaseConfig {
 		HeartbeatTTL:          30 * time.Second,
 		HeartbeatInterval:     10 * time.Second,
 		NodeDiscoveryInterval: 10 * time.Second,
+		ReconcileInterval:     30 * time.Second,
+		ReconcileBatchSize:    10,
+		OrphanSweepInterval:   30 * time.Second,
+		StatusGracePeriod:     defaultStatusGracePeriod,
+		HandoffTimeout:        defaultHandoffTimeout,
+		Weight:                defaultNodeWeight,
 	}
 }
 
@@ -114,24 +183,36 @@ const (
 
 	// LeaseEventTakenOver indicates another instance took over the lease.
 	LeaseEventTakenOver LeaseEventType = "taken_over"
+
+	// LeaseEventUncertain indicates ownership of a lease could not be confirmed.
+	LeaseEventUncertain LeaseEventType = "uncertain"
+
+	// LeaseEventReacquired indicates an uncertain lease was confirmed and renewed.
+	LeaseEventReacquired LeaseEventType = "reacquired"
 )
 
-// LeaseEvent represents a lease lifecycle event for structured logging.
+// leaseEventBuffer is the number of lease events kept for a slow reader before the oldest are dropped.
+const leaseEventBuffer = 64
+
+// LeaseEvent represents a lease lifecycle event for structured logging and the Events channel.
 type LeaseEvent struct {
 	// Type is the kind of event that occurred.
-	Type LeaseEventType
+	Type LeaseEventType `json:"type"`
 
 	// SessionID is the session affected by this event.
-	SessionID string
+	SessionID string `json:"session_id"`
 
 	// InstanceID is the instance involved in this event.
-	InstanceID string
+	InstanceID string `json:"instance_id"`
+
+	// Reason explains why the event occurred, e.g. "voluntary" or "ttl_expiry" for releases.
+	Reason string `json:"reason"`
 
 	// Timestamp is when the event occurred.
-	Timestamp time.Time
+	Timestamp time.Time `json:"timestamp"`
 
 	// Error contains the error if this is a failure event (nil for successful events).
-	Error error
+	Error error `json:"-"`
 }
 
 // RedisLeaseValue represents the JSON value stored in Redis lease keys.
@@ -146,6 +227,10 @@ type RedisLeaseValue struct {
 
 	// LastRenewedAt is when the lease was last successfully renewed.
 	LastRenewedAt time.Time `json:"last_renewed_at"`
+
+	// Token is a fencing token bumped on every acquisition of the session's lease.
+	// Renewals carrying an older token are rejected, so a stale owner cannot extend a lease.
+	Token int64 `json:"token"`
 }
 
 // Marshal converts the RedisLeaseValue to a JSON string for storage in Redis.
@@ -166,3 +251,25 @@ func ParseRedisLeaseValue(value string) (RedisLeaseValue, error) {
 	}
 	return v, nil
 }
+
+// RedisHandoffValue represents the JSON value stored in Redis handoff keys.
+// It names the instance a lease is being handed to during rebalancing.
+type RedisHandoffValue struct {
+	// From is the instance ID handing off the lease.
+	From string `json:"from"`
+
+	// To is the instance ID expected to claim the lease.
+	To string `json:"to"`
+
+	// OfferedAt is when the handoff was offered.
+	OfferedAt time.Time `json:"offered_at"`
+}
+
+// Marshal converts the RedisHandoffValue to a JSON string for storage in Redis.
+func (v RedisHandoffValue) Marshal() (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,643 @@
+package mock_client
+
+import (
+	"api/models/models"
+	"api/pkg/log"
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// AddressPrefix marks a session address served by the mock client, e.g. mock://full
+const AddressPrefix = "mock://"
+
+// Scenario selects how much of a factory the mock client reports
+type Scenario string
+
+const (
+	ScenarioBasic Scenario = "basic" // Production, power and players, infrastructure lists are empty
+	ScenarioFull  Scenario = "full"  // Every endpoint, with bounding boxes, vehicles and infrastructure
+)
+
+// Client is a client.Client that generates plausible factory data without a game server.
+// It is used for development and tests, the values vary between polls but stay consistent
+// with each other, e.g. generator stats match the circuits.
+type Client struct {
+	scenario Scenario
+	started  time.Time
+
+	mu  sync.Mutex // Guards rng, a rand.Rand is not safe for concurrent use
+	rng *rand.Rand
+}
+
+// NewClient creates a mock client for the scenario, seeded from the current time.
+// An unknown scenario falls back to the basic one.
+func NewClient(scenario Scenario) *Client {
+	return NewClientWithSeed(scenario, time.Now().UnixNano())
+}
+
+// NewClientWithSeed creates a mock client whose generated values are fully determined by the seed,
+// two clients with the same seed and scenario return the same data for the same sequence of calls
+func NewClientWithSeed(scenario Scenario, seed int64) *Client {
+	if scenario != ScenarioFull {
+		scenario = ScenarioBasic
+	}
+
+	return &Client{
+		scenario: scenario,
+		started:  time.Now(),
+		rng:      rand.New(rand.NewSource(seed)),
+	}
+}
+
+// NewClientFromAddress creates a mock client from a mock://<scenario> address
+func NewClientFromAddress(address string) *Client {
+	return NewClient(Scenario(address[min(len(AddressPrefix), len(address)):]))
+}
+
+// randRange returns a random value in [minimum, maximum)
+func (client *Client) randRange(minimum, maximum float64) float64 {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	return minimum + client.rng.Float64()*(maximum-minimum)
+}
+
+// randInt returns a random value in [minimum, maximum]
+func (client *Client) randInt(minimum, maximum int) int {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	return minimum + client.rng.Intn(maximum-minimum+1)
+}
+
+func (client *Client) full() bool {
+	return client.scenario == ScenarioFull
+}
+
+// SetupEventStream emits every event type on the same intervals as the FRM client
+func (client *Client) SetupEventStream(ctx context.Context, onEvent func(*models.SatisfactoryEvent)) error {
+	endpoints := []struct {
+		Type     models.SatisfactoryEventType
+		Endpoint func(context.Context) (interface{}, error)
+		Interval time.Duration
+	}{
+		{models.SatisfactoryEventApiStatus, func(c context.Context) (interface{}, error) { return client.GetSatisfactoryApiStatus(c) }, 5 * time.Second},
+		{models.SatisfactoryEventCircuits, func(c context.Context) (interface{}, error) { return client.ListCircuits(c) }, 4 * time.Second},
+		{models.SatisfactoryEventFactoryStats, func(c context.Context) (interface{}, error) { return client.GetFactoryStats(c) }, 4 * time.Second},
+		{models.SatisfactoryEventProdStats, func(c context.Context) (interface{}, error) { return client.GetProdStats(c) }, 4 * time.Second},
+		{models.SatisfactoryEventSinkStats, func(c context.Context) (interface{}, error) { return client.GetSinkStats(c) }, 4 * time.Second},
+		{models.SatisfactoryEventPlayers, func(c context.Context) (interface{}, error) { return client.ListPlayers(c) }, 4 * time.Second},
+		{models.SatisfactoryEventGeneratorStats, func(c context.Context) (interface{}, error) { return client.GetGeneratorStats(c) }, 4 * time.Second},
+		{models.SatisfactoryEventMachines, func(c context.Context) (interface{}, error) { return client.GetMachines(c) }, 4 * time.Second},
+		{models.SatisfactoryEventVehicles, func(c context.Context) (interface{}, error) { return client.GetVehicles(c) }, 4 * time.Second},
+		{models.SatisfactoryEventVehicleStations, func(c context.Context) (interface{}, error) { return client.GetVehicleStations(c) }, 4 * time.Second},
+		{models.SatisfactoryEventBelts, func(c context.Context) (interface{}, error) { return client.GetBelts(c) }, 120 * time.Second},
+		{models.SatisfactoryEventPipes, func(c context.Context) (interface{}, error) { return client.GetPipes(c) }, 120 * time.Second},
+		{models.SatisfactoryEventTrainRails, func(c context.Context) (interface{}, error) { return client.ListTrainRails(c) }, 120 * time.Second},
+		{models.SatisfactoryEventCables, func(c context.Context) (interface{}, error) { return client.ListCables(c) }, 120 * time.Second},
+		{models.SatisfactoryEventVehiclePaths, func(c context.Context) (interface{}, error) { return client.ListVehiclePaths(c) }, 30 * time.Second},
+		{models.SatisfactoryEventSpaceElevator, func(c context.Context) (interface{}, error) { return client.GetSpaceElevator(c) }, 30 * time.Second},
+		{models.SatisfactoryEventHub, func(c context.Context) (interface{}, error) { return client.GetHub(c) }, 30 * time.Second},
+		{models.SatisfactoryEventSchematics, func(c context.Context) (interface{}, error) { return client.ListSchematics(c) }, 30 * time.Second},
+	}
+
+	log.Infof("Starting mock event stream (%s scenario)", client.scenario)
+
+	for _, endpoint := range endpoints {
+		go func() {
+			ticker := time.NewTicker(endpoint.Interval)
+			defer ticker.Stop()
+
+			for {
+				if data, err := endpoint.Endpoint(ctx); err == nil {
+					onEvent(&models.SatisfactoryEvent{Type: endpoint.Type, Data: data})
+				}
+
+				select {
+				case <-ticker.C:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	return nil
+}
+
+// SetupLightPolling reports the server as running, the mock is never offline
+func (client *Client) SetupLightPolling(ctx context.Context, onEvent func(*models.SatisfactoryEvent)) error {
+	status, _ := client.GetSatisfactoryApiStatus(ctx)
+	onEvent(&models.SatisfactoryEvent{Type: models.SatisfactoryEventApiStatus, Data: status})
+	return nil
+}
+
+func (client *Client) GetSatisfactoryApiStatus(_ context.Context) (*models.SatisfactoryApiStatus, error) {
+	return &models.SatisfactoryApiStatus{Running: true, PingMS: client.randInt(5, 40)}, nil
+}
+
+func (client *Client) GetSessionInfo(_ context.Context) (*models.SessionInfo, error) {
+	played := int(time.Since(client.started).Seconds())
+	return &models.SessionInfo{
+		SessionName:           fmt.Sprintf("Mock %s", client.scenario),
+		DayLength:             50,
+		NightLength:           10,
+		PassedDays:            played / 3600,
+		Hours:                 (played / 150) % 24,
+		Minutes:               (played / 2) % 60,
+		IsDay:                 true,
+		TotalPlayDuration:     played,
+		TotalPlayDurationText: (time.Duration(played) * time.Second).String(),
+	}, nil
+}
+
+func (client *Client) GetFactoryStats(_ context.Context) (*models.FactoryStats, error) {
+	efficiency := models.MachineEfficiency{
+		MachinesOperating: client.randInt(80, 120),
+		MachinesIdle:      client.randInt(0, 10),
+		MachinesPaused:    client.randInt(0, 3),
+	}
+	efficiency.ComputeOverallEfficiency()
+
+	return &models.FactoryStats{
+		TotalMachines: efficiency.MachinesOperating + efficiency.MachinesIdle + efficiency.MachinesPaused,
+		Efficiency:    efficiency,
+	}, nil
+}
+
+// mockItems are the items reported by GetProdStats, with their base production per minute
+var mockItems = []struct {
+	name     string
+	category models.ItemCategory
+	form     models.ResourceForm
+	minable  bool
+	rate     float64
+}{
+	{"Iron Ore", models.ItemCategoryOre, models.ResourceFormSolid, true, 480},
+	{"Copper Ore", models.ItemCategoryOre, models.ResourceFormSolid, true, 240},
+	{"Water", models.ItemCategoryFluid, models.ResourceFormLiquid, true, 600},
+	{"Iron Ingot", models.ItemCategoryIngot, models.ResourceFormSolid, false, 480},
+	{"Copper Ingot", models.ItemCategoryIngot, models.ResourceFormSolid, false, 240},
+	{"Iron Plate", models.ItemCategoryStandardPart, models.ResourceFormSolid, false, 180},
+	{"Iron Rod", models.ItemCategoryStandardPart, models.ResourceFormSolid, false, 120},
+	{"Screws", models.ItemCategoryStandardPart, models.ResourceFormSolid, false, 240},
+	{"Wire", models.ItemCategoryElectronic, models.ResourceFormSolid, false, 240},
+	{"Reinforced Iron Plate", models.ItemCategoryStandardPart, models.ResourceFormSolid, false, 15},
+}
+
+func (client *Client) GetProdStats(_ context.Context) (*models.ProdStats, error) {
+	stats := &models.ProdStats{Items: make([]models.ItemProdStats, 0, len(mockItems))}
+
+	for _, item := range mockItems {
+		produced := item.rate * client.randRange(0.85, 1)
+		consumed := item.rate * client.randRange(0.7, 0.95)
+
+		stats.Items = append(stats.Items, models.ItemProdStats{
+			ItemStats: models.ItemStats{
+				Name:     item.name,
+				Count:    float64(client.randInt(0, 5000)),
+				Category: item.category,
+				Form:     item.form,
+			},
+			ProducedPerMinute:   produced,
+			MaxProducePerMinute: item.rate,
+			ProduceEfficiency:   produced / item.rate,
+			ConsumedPerMinute:   consumed,
+			MaxConsumePerMinute: item.rate,
+			ConsumeEfficiency:   consumed / item.rate,
+			Minable:             item.minable,
+		})
+
+		if item.minable {
+			stats.MinableProducedPerMinute += produced
+			stats.MinableConsumedPerMinute += consumed
+		} else {
+			stats.ItemsProducedPerMinute += produced
+			stats.ItemsConsumedPerMinute += consumed
+		}
+	}
+
+	return stats, nil
+}
+
+func (client *Client) GetGeneratorStats(ctx context.Context) (*models.GeneratorStats, error) {
+	circuits, _ := client.ListCircuits(ctx)
+
+	stats := &models.GeneratorStats{Sources: make(map[models.PowerType]models.PowerSource)}
+	for _, circuit := range circuits {
+		stats.TotalProduction += circuit.Production.Total
+		stats.TotalConsumption += circuit.Consumption.Total
+	}
+	stats.NetBalance = stats.TotalProduction - stats.TotalConsumption
+	stats.Sources[models.PowerTypeCoal] = models.PowerSource{Count: 16, TotalProduction: stats.TotalProduction * 0.8}
+	stats.Sources[models.PowerTypeBiomass] = models.PowerSource{Count: 8, TotalProduction: stats.TotalProduction * 0.2}
+
+	return stats, nil
+}
+
+func (client *Client) GetSinkStats(_ context.Context) (*models.SinkStats, error) {
+	played := time.Since(client.started).Minutes()
+	pointsPerMinute := client.randRange(9000, 11000)
+	return &models.SinkStats{
+		TotalPoints:        played * 10000,
+		Coupons:            int(played * 10000 / 50000),
+		NextCouponProgress: client.randRange(0, 1),
+		PointsPerMinute:    pointsPerMinute,
+	}, nil
+}
+
+func (client *Client) GetMachines(_ context.Context) ([]models.Machine, error) {
+	machines := make([]models.Machine, 0, 12)
+	for i := 0; i < 12; i++ {
+		location := models.Location{X: float64(i) * 1200, Y: 0, Z: 1000}
+		machine := models.Machine{
+			ID:           fmt.Sprintf("Build_ConstructorMk1_C_%d", i),
+			Type:         models.MachineTypeConstructor,
+			Status:       models.MachineStatusOperating,
+			Category:     models.MachineCategoryFactory,
+			Productivity: client.randRange(0.8, 1),
+			Input:        []models.MachineProdStats{{Name: "Iron Ingot", Current: 30, Max: 30, Efficiency: 1}},
+			Output:       []models.MachineProdStats{{Name: "Iron Plate", Current: 20, Max: 20, Efficiency: 1}},
+			Location:     location,
+			CircuitIDs:   models.CircuitIDs{CircuitID: 1},
+		}
+		if client.full() {
+			machine.BoundingBox = boxAround(location, 400)
+		}
+		machines = append(machines, machine)
+	}
+	return machines, nil
+}
+
+func (client *Client) ListCircuits(_ context.Context) ([]models.Circuit, error) {
+	production := client.randRange(900e6, 1100e6)
+	circuits := []models.Circuit{
+		{
+			ID:          "1",
+			Consumption: models.CircuitConsumption{Total: production * client.randRange(0.6, 0.9), Max: production},
+			Production:  models.CircuitProduction{Total: production},
+			Capacity:    models.CircuitCapacity{Total: 1200e6},
+			Battery:     models.CircuitBattery{Percentage: client.randRange(50, 100), Capacity: 500e6},
+		},
+	}
+
+	if client.full() {
+		// A second circuit with batteries only, it reports no production of its own
+		circuits = append(circuits, models.Circuit{
+			ID:          "2",
+			Consumption: models.CircuitConsumption{Total: 20e6, Max: 40e6},
+			Battery:     models.CircuitBattery{Percentage: client.randRange(20, 60), Capacity: 100e6, Differential: -20e6},
+		})
+	}
+
+	return circuits, nil
+}
+
+func (client *Client) ListPlayers(_ context.Context) ([]models.Player, error) {
+	return []models.Player{
+		{
+			ID:       "1",
+			Name:     "Pioneer",
+			Health:   100,
+			Items:    []models.ItemStats{{Name: "Iron Plate", Count: 100, Category: models.ItemCategoryStandardPart, Form: models.ResourceFormSolid}},
+			Location: models.Location{X: client.randRange(-1000, 1000), Y: client.randRange(-1000, 1000), Z: 1000},
+		},
+	}, nil
+}
+
+func (client *Client) ListDrones(ctx context.Context) ([]models.Drone, error) {
+	if !client.full() {
+		return []models.Drone{}, nil
+	}
+
+	stations, _ := client.ListDroneStations(ctx)
+	return []models.Drone{
+		{
+			Name:     "Drone 1",
+			Speed:    client.randRange(0, 250),
+			Status:   models.DroneStatusFlying,
+			Home:     stations[0],
+			Location: models.Location{X: client.randRange(0, 40000), Y: 20000, Z: 5000},
+		},
+	}, nil
+}
+
+func (client *Client) ListTrains(_ context.Context) ([]models.Train, error) {
+	if !client.full() {
+		return []models.Train{}, nil
+	}
+
+	return []models.Train{
+		{
+			ID:               "1",
+			Name:             "Iron Express",
+			Speed:            client.randRange(0, 120),
+			Status:           models.TrainStatusSelfDriving,
+			PowerConsumption: 25e6,
+			Vehicles: []models.TrainVehicle{
+				{Type: models.TrainTypeLocomotive},
+				{Type: models.TrainTypeFreight, Capacity: 3200, Inventory: []models.ItemStats{{Name: "Iron Ore", Count: 1600}}},
+			},
+			Timetable: []models.TrainTimetableEntry{
+				{Station: "Iron Mine", Location: &models.Location{X: 0, Y: 10000, Z: 1000}},
+				{Station: "Smeltery", Location: &models.Location{X: 40000, Y: 10000, Z: 1000}},
+			},
+			Location:   models.Location{X: client.randRange(0, 40000), Y: 10000, Z: 1000},
+			CircuitIDs: models.CircuitIDs{CircuitID: 1},
+		},
+	}, nil
+}
+
+func (client *Client) ListTractors(_ context.Context) ([]models.Tractor, error) {
+	if !client.full() {
+		return []models.Tractor{}, nil
+	}
+
+	return []models.Tractor{
+		{
+			ID:       "1",
+			Name:     "Tractor 1",
+			Speed:    client.randRange(0, 60),
+			Status:   models.TractorStatusSelfDriving,
+			Location: models.Location{X: client.randRange(-5000, 5000), Y: -5000, Z: 1000},
+		},
+	}, nil
+}
+
+func (client *Client) ListExplorers(_ context.Context) ([]models.Explorer, error) {
+	if !client.full() {
+		return []models.Explorer{}, nil
+	}
+
+	return []models.Explorer{
+		{
+			ID:       "1",
+			Name:     "Explorer 1",
+			Status:   models.ExplorerStatusParked,
+			Location: models.Location{X: 2000, Y: 2000, Z: 1000},
+		},
+	}, nil
+}
+
+func (client *Client) ListTrainStations(_ context.Context) ([]models.TrainStation, error) {
+	if !client.full() {
+		return []models.TrainStation{}, nil
+	}
+
+	stations := make([]models.TrainStation, 0, 2)
+	for i, name := range []string{"Iron Mine", "Smeltery"} {
+		location := models.Location{X: float64(i) * 40000, Y: 10000, Z: 1000}
+		platformLocation := models.Location{X: location.X + 1600, Y: location.Y, Z: location.Z}
+		mode := models.TrainStationPlatformModeExport
+		if i == 1 {
+			mode = models.TrainStationPlatformModeImport
+		}
+
+		stations = append(stations, models.TrainStation{
+			Name:        name,
+			BoundingBox: boxAround(location, 800),
+			Platforms: []models.TrainStationPlatform{
+				{
+					ID:          fmt.Sprintf("%d", i+1),
+					Type:        models.TrainStationPlatformTypeFreight,
+					Mode:        mode,
+					Status:      models.TrainStationPlatformStatusIdle,
+					BoundingBox: boxAround(platformLocation, 800),
+					Capacity:    4800,
+					Fill:        client.randRange(0, 1),
+					Location:    platformLocation,
+				},
+			},
+			Location:   location,
+			CircuitIDs: models.CircuitIDs{CircuitID: 1},
+		})
+	}
+	return stations, nil
+}
+
+func (client *Client) ListDroneStations(_ context.Context) ([]models.DroneStation, error) {
+	if !client.full() {
+		return []models.DroneStation{}, nil
+	}
+
+	location := models.Location{X: 0, Y: 20000, Z: 1000}
+	return []models.DroneStation{
+		{
+			Name:        "Drone Port 1",
+			BoundingBox: boxAround(location, 1200),
+			Location:    location,
+			CircuitIDs:  models.CircuitIDs{CircuitID: 1},
+		},
+	}, nil
+}
+
+func (client *Client) ListVehiclePaths(_ context.Context) ([]models.VehiclePath, error) {
+	return []models.VehiclePath{}, nil
+}
+
+func (client *Client) ListSchematics(_ context.Context) ([]models.Schematic, error) {
+	return []models.Schematic{}, nil
+}
+
+func (client *Client) ListBelts(_ context.Context) ([]models.Belt, error) {
+	if !client.full() {
+		return []models.Belt{}, nil
+	}
+
+	belts := make([]models.Belt, 0, 4)
+	for i := 0; i < 4; i++ {
+		start := models.Location{X: float64(i) * 2000, Y: 500, Z: 1000}
+		end := models.Location{X: start.X + 2000, Y: 500, Z: 1000}
+		itemsPerMinute := client.randRange(200, 270)
+		belts = append(belts, models.Belt{
+			ID:                fmt.Sprintf("Build_ConveyorBeltMk3_C_%d", i),
+			Name:              "Conveyor Belt Mk.3",
+			Location0:         start,
+			Location1:         end,
+			Connected0:        true,
+			Connected1:        true,
+			SplineData:        []models.Location{start, end},
+			Length:            2000,
+			ItemsPerMinute:    itemsPerMinute,
+			MaxItemsPerMinute: 270,
+			Saturation:        itemsPerMinute / 270,
+		})
+	}
+	return belts, nil
+}
+
+func (client *Client) ListPipes(_ context.Context) ([]models.Pipe, error) {
+	if !client.full() {
+		return []models.Pipe{}, nil
+	}
+
+	start := models.Location{X: 0, Y: -500, Z: 1000}
+	end := models.Location{X: 2000, Y: -500, Z: 1000}
+	flow := client.randRange(200, 300)
+	return []models.Pipe{
+		{
+			ID:             "Build_Pipeline_C_0",
+			Name:           "Pipeline Mk.1",
+			Location0:      start,
+			Location1:      end,
+			Connected0:     true,
+			Connected1:     true,
+			SplineData:     []models.Location{start, end},
+			Length:         2000,
+			ItemsPerMinute: flow,
+			FlowRate:       flow,
+			FillPercent:    client.randRange(0.5, 1),
+		},
+	}, nil
+}
+
+func (client *Client) ListPipeJunctions(_ context.Context) ([]models.PipeJunction, error) {
+	return []models.PipeJunction{}, nil
+}
+
+func (client *Client) ListTrainRails(_ context.Context) ([]models.TrainRail, error) {
+	if !client.full() {
+		return []models.TrainRail{}, nil
+	}
+
+	start := models.Location{X: 0, Y: 10000, Z: 1000}
+	end := models.Location{X: 40000, Y: 10000, Z: 1000}
+	return []models.TrainRail{
+		{
+			ID:         "Build_RailroadTrack_C_0",
+			Type:       models.TrainRailTypeRailway,
+			Location0:  start,
+			Location1:  end,
+			Connected0: true,
+			Connected1: true,
+			SplineData: []models.Location{start, end},
+			Length:     400,
+		},
+	}, nil
+}
+
+func (client *Client) ListSplitterMergers(_ context.Context) ([]models.SplitterMerger, error) {
+	return []models.SplitterMerger{}, nil
+}
+
+func (client *Client) ListCables(_ context.Context) ([]models.Cable, error) {
+	if !client.full() {
+		return []models.Cable{}, nil
+	}
+
+	return []models.Cable{
+		{
+			ID:         "Build_PowerLine_C_0",
+			Name:       "Power Line",
+			Location0:  models.Location{X: 0, Y: 0, Z: 1500},
+			Location1:  models.Location{X: 0, Y: 5000, Z: 1500},
+			Connected0: true,
+			Connected1: true,
+			Length:     5000,
+		},
+	}, nil
+}
+
+func (client *Client) GetBelts(ctx context.Context) (models.Belts, error) {
+	belts, _ := client.ListBelts(ctx)
+	splitterMergers, _ := client.ListSplitterMergers(ctx)
+	return models.Belts{Belts: belts, SplitterMergers: splitterMergers}, nil
+}
+
+func (client *Client) GetPipes(ctx context.Context) (models.Pipes, error) {
+	pipes, _ := client.ListPipes(ctx)
+	pipeJunctions, _ := client.ListPipeJunctions(ctx)
+	return models.Pipes{Pipes: pipes, PipeJunctions: pipeJunctions}, nil
+}
+
+func (client *Client) GetVehicles(ctx context.Context) (models.Vehicles, error) {
+	trains, _ := client.ListTrains(ctx)
+	drones, _ := client.ListDrones(ctx)
+	tractors, _ := client.ListTractors(ctx)
+	explorers, _ := client.ListExplorers(ctx)
+	return models.Vehicles{
+		Trains:    trains,
+		Drones:    drones,
+		Trucks:    []models.Truck{},
+		Tractors:  tractors,
+		Explorers: explorers,
+	}, nil
+}
+
+func (client *Client) GetVehicleStations(ctx context.Context) (models.VehicleStations, error) {
+	trainStations, _ := client.ListTrainStations(ctx)
+	droneStations, _ := client.ListDroneStations(ctx)
+	return models.VehicleStations{
+		TrainStations: trainStations,
+		DroneStations: droneStations,
+		TruckStations: []models.TruckStation{},
+	}, nil
+}
+
+func (client *Client) GetSpaceElevator(_ context.Context) (*models.SpaceElevator, error) {
+	if !client.full() {
+		return nil, nil
+	}
+
+	location := models.Location{X: -10000, Y: 0, Z: 1000}
+	return &models.SpaceElevator{
+		ID:          "1",
+		Name:        "Space Elevator",
+		BoundingBox: boxAround(location, 3000),
+		CurrentPhase: []models.SpaceElevatorPhaseObjective{
+			{Name: "Smart Plating", Amount: 40, TotalCost: 50},
+		},
+		Location: location,
+	}, nil
+}
+
+func (client *Client) GetHub(_ context.Context) (*models.Hub, error) {
+	location := models.Location{X: -2000, Y: -2000, Z: 1000}
+	hub := &models.Hub{
+		ID:         "1",
+		Name:       "The HUB",
+		ShipDocked: true,
+		Location:   location,
+	}
+	if client.full() {
+		hub.BoundingBox = boxAround(location, 1000)
+	}
+	return hub, nil
+}
+
+func (client *Client) ListRadarTowers(_ context.Context) ([]models.RadarTower, error) {
+	return []models.RadarTower{}, nil
+}
+
+func (client *Client) ListResourceNodes(_ context.Context) ([]models.ResourceNode, error) {
+	return []models.ResourceNode{}, nil
+}
+
+func (client *Client) GetAddress() string {
+	return AddressPrefix + string(client.scenario)
+}
+
+func (client *Client) GetFailureCount() int {
+	return 0
+}
+
+func (client *Client) IsDisconnected() bool {
+	return false
+}
+
+func (client *Client) SetDisconnectedCallback(_ func()) {}
+
+func (client *Client) GetEndpointErrors() []models.EndpointError {
+	return nil
+}
+
+func (client *Client) Close() {}
+
+// boxAround returns the bounding box extending size on every axis around the location
+func boxAround(location models.Location, size float64) models.BoundingBox {
+	return models.BoundingBox{
+		Min: models.Location{X: location.X - size, Y: location.Y - size, Z: location.Z - size},
+		Max: models.Location{X: location.X + size, Y: location.Y + size, Z: location.Z + size},
+	}
+}
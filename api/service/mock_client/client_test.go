@@ -0,0 +1,127 @@
+package mock_client
+
+import (
+	"api/models/models"
+	"api/pkg/log"
+	"context"
+	"os"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMain(m *testing.M) {
+	if err := log.SetupLogger("test"); err != nil {
+		panic(err)
+	}
+	os.Exit(m.Run())
+}
+
+func TestNewClientFromAddress(t *testing.T) {
+	tests := []struct {
+		address string
+		want    Scenario
+	}{
+		{"mock://full", ScenarioFull},
+		{"mock://basic", ScenarioBasic},
+		{"mock://", ScenarioBasic},
+		{"mock://unknown", ScenarioBasic},
+	}
+
+	for _, test := range tests {
+		t.Run(test.address, func(t *testing.T) {
+			client := NewClientFromAddress(test.address)
+			if client.scenario != test.want {
+				t.Errorf("scenario %s, want %s", client.scenario, test.want)
+			}
+		})
+	}
+}
+
+func TestSameSeedGivesSameProdStats(t *testing.T) {
+	first := NewClientWithSeed(ScenarioFull, 42)
+	second := NewClientWithSeed(ScenarioFull, 42)
+
+	for poll := 0; poll < 3; poll++ {
+		want, _ := first.GetProdStats(context.Background())
+		got, _ := second.GetProdStats(context.Background())
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("poll %d differs between clients with the same seed", poll)
+		}
+	}
+
+	other, _ := NewClientWithSeed(ScenarioFull, 43).GetProdStats(context.Background())
+	reference, _ := NewClientWithSeed(ScenarioFull, 42).GetProdStats(context.Background())
+	if reflect.DeepEqual(other, reference) {
+		t.Error("different seeds produced the same prod stats")
+	}
+}
+
+func TestFullScenarioReportsBoundingBoxes(t *testing.T) {
+	client := NewClient(ScenarioFull)
+
+	machines, _ := client.GetMachines(context.Background())
+	stations, _ := client.ListTrainStations(context.Background())
+	if len(machines) == 0 || len(stations) == 0 {
+		t.Fatalf("got %d machines and %d stations, want both", len(machines), len(stations))
+	}
+	if !machines[0].BoundingBox.Contains(machines[0].Location, 0) {
+		t.Error("machine bounding box does not contain the machine")
+	}
+	if !stations[0].BoundingBox.Contains(stations[0].Location, 0) {
+		t.Error("station bounding box does not contain the station")
+	}
+}
+
+func TestGeneratorStatsMatchCircuits(t *testing.T) {
+	client := NewClient(ScenarioFull)
+
+	stats, _ := client.GetGeneratorStats(context.Background())
+	if stats.TotalProduction <= 0 || stats.TotalConsumption <= 0 {
+		t.Fatalf("got %+v, want production and consumption", stats)
+	}
+	if stats.NetBalance != stats.TotalProduction-stats.TotalConsumption {
+		t.Errorf("net balance %f, want production minus consumption", stats.NetBalance)
+	}
+}
+
+func TestEventStreamEmitsRequiredEvents(t *testing.T) {
+	for _, scenario := range []Scenario{ScenarioBasic, ScenarioFull} {
+		t.Run(string(scenario), func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var mu sync.Mutex
+			seen := make(map[models.SatisfactoryEventType]bool)
+			if err := NewClient(scenario).SetupEventStream(ctx, func(event *models.SatisfactoryEvent) {
+				mu.Lock()
+				defer mu.Unlock()
+				seen[event.Type] = true
+			}); err != nil {
+				t.Fatal(err)
+			}
+
+			deadline := time.Now().Add(2 * time.Second)
+			for {
+				mu.Lock()
+				missing := models.SatisfactoryEventType("")
+				for _, eventType := range models.RequiredEventTypes {
+					if !seen[eventType] {
+						missing = eventType
+						break
+					}
+				}
+				mu.Unlock()
+
+				if missing == "" {
+					return
+				}
+				if time.Now().After(deadline) {
+					t.Fatalf("no %s event was emitted", missing)
+				}
+				time.Sleep(10 * time.Millisecond)
+			}
+		})
+	}
+}
@@ -0,0 +1,361 @@
+package recording
+
+import (
+	"api/models/models"
+	"api/pkg/log"
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// PlaybackAddressPrefix marks session addresses that should be served by a PlaybackClient,
+// e.g. playback://session.ndjson?speed=4. The path is relative to the recording directory.
+const PlaybackAddressPrefix = "playback://"
+
+// PlaybackClient replays a recording log as if it was a live client.
+// Events are re-emitted honoring the original inter-event timing divided by the speed multiplier,
+// and the Get/List methods return the most recently replayed data.
+type PlaybackClient struct {
+	address     string
+	path        string
+	loadErr     error
+	speed       float64
+	sessionInfo *models.SessionInfo // First session info in the recording, nil if it has none
+
+	mu     sync.RWMutex
+	latest map[models.SatisfactoryEventType]any
+}
+
+// NewPlaybackClient creates a PlaybackClient for the recording at path.
+// A speed of 2 replays twice as fast as recorded; non-positive speeds default to 1.
+// Errors reading the recording are surfaced by SetupEventStream and GetSessionInfo.
+func NewPlaybackClient(path string, speed float64) *PlaybackClient {
+	if speed <= 0 {
+		speed = 1
+	}
+
+	client := &PlaybackClient{
+		address: PlaybackAddressPrefix + path,
+		path:    path,
+		speed:   speed,
+		latest:  make(map[models.SatisfactoryEventType]any),
+	}
+	client.sessionInfo, client.loadErr = firstSessionInfo(path)
+	return client
+}
+
+// NewPlaybackClientFromAddress creates a PlaybackClient from a playback:// address, resolving the
+// recording inside recordingDir. The optional speed query parameter sets the speed multiplier.
+func NewPlaybackClientFromAddress(address, recordingDir string) *PlaybackClient {
+	name := strings.TrimPrefix(address, PlaybackAddressPrefix)
+	speed := 1.0
+
+	if rawName, rawQuery, found := strings.Cut(name, "?"); found {
+		name = rawName
+		query, err := url.ParseQuery(rawQuery)
+		if err == nil {
+			if parsed, err := strconv.ParseFloat(query.Get("speed"), 64); err == nil {
+				speed = parsed
+			}
+		}
+	}
+
+	path, err := ResolveRecordingPath(recordingDir, name)
+	if err != nil {
+		return &PlaybackClient{address: address, loadErr: err, speed: speed, latest: make(map[models.SatisfactoryEventType]any)}
+	}
+	return NewPlaybackClient(path, speed)
+}
+
+// firstSessionInfo reads the recording up to its first session info, which also checks that
+// the recording can be opened before playback starts
+func firstSessionInfo(path string) (*models.SessionInfo, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open recording %s. details: %w", path, err)
+	}
+	defer file.Close()
+
+	var sessionInfo *models.SessionInfo
+	var decodeErr error
+	err = EachRecord(file, func(record Record) bool {
+		if record.Type != sessionInfoRecordType {
+			return true
+		}
+		var data any
+		data, decodeErr = decodeEventData(record.Type, record.Data)
+		if decodeErr == nil {
+			sessionInfo = data.(*models.SessionInfo)
+		}
+		return false
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read recording %s. details: %w", path, err)
+	}
+	if decodeErr != nil {
+		return nil, fmt.Errorf("failed to decode recorded session info. details: %w", decodeErr)
+	}
+	return sessionInfo, nil
+}
+
+// SetupEventStream replays the recording in the background and sends each event via the callback.
+// Records are read from the file as they are replayed.
+func (client *PlaybackClient) SetupEventStream(ctx context.Context, onEvent func(*models.SatisfactoryEvent)) error {
+	if client.loadErr != nil {
+		return client.loadErr
+	}
+
+	file, err := os.Open(client.path)
+	if err != nil {
+		return fmt.Errorf("failed to open recording %s. details: %w", client.path, err)
+	}
+
+	go func() {
+		defer file.Close()
+
+		var previous time.Time
+		first := true
+		err := EachRecord(file, func(record Record) bool {
+			if !first {
+				delay := time.Duration(float64(record.Timestamp.Sub(previous)) / client.speed)
+				if delay > 0 {
+					select {
+					case <-time.After(delay):
+					case <-ctx.Done():
+						return false
+					}
+				}
+			}
+			first = false
+			previous = record.Timestamp
+
+			if ctx.Err() != nil {
+				return false
+			}
+
+			data, err := decodeEventData(record.Type, record.Data)
+			if err != nil {
+				log.PrettyError(fmt.Errorf("failed to decode recorded %s event. details: %w", record.Type, err))
+				return true
+			}
+
+			client.mu.Lock()
+			client.latest[record.Type] = data
+			client.mu.Unlock()
+
+			if record.Type != sessionInfoRecordType {
+				onEvent(&models.SatisfactoryEvent{Type: record.Type, Data: data, Partial: record.Partial})
+			}
+			return true
+		})
+		if err != nil {
+			log.PrettyError(fmt.Errorf("playback of %s stopped. details: %w", client.address, err))
+			return
+		}
+		if ctx.Err() == nil {
+			log.Infof("Playback finished: %s", client.address)
+		}
+	}()
+
+	return nil
+}
+
+// SetupLightPolling blocks until the context is cancelled, since a recording never goes offline
+func (client *PlaybackClient) SetupLightPolling(ctx context.Context, onEvent func(*models.SatisfactoryEvent)) error {
+	onEvent(&models.SatisfactoryEvent{
+		Type: models.SatisfactoryEventApiStatus,
+		Data: &models.SatisfactoryApiStatus{Running: client.loadErr == nil},
+	})
+	<-ctx.Done()
+	return nil
+}
+
+// GetSessionInfo returns the most recently replayed session info,
+// or the first one in the recording if playback has not reached it yet
+func (client *PlaybackClient) GetSessionInfo(_ context.Context) (*models.SessionInfo, error) {
+	if client.loadErr != nil {
+		return nil, client.loadErr
+	}
+
+	if sessionInfo, err := latest[*models.SessionInfo](client, sessionInfoRecordType); err == nil {
+		return sessionInfo, nil
+	}
+	if client.sessionInfo != nil {
+		return client.sessionInfo, nil
+	}
+
+	return nil, fmt.Errorf("recording %s contains no session info", client.address)
+}
+
+func (client *PlaybackClient) GetSatisfactoryApiStatus(_ context.Context) (*models.SatisfactoryApiStatus, error) {
+	return latest[*models.SatisfactoryApiStatus](client, models.SatisfactoryEventApiStatus)
+}
+
+func (client *PlaybackClient) GetFactoryStats(_ context.Context) (*models.FactoryStats, error) {
+	return latest[*models.FactoryStats](client, models.SatisfactoryEventFactoryStats)
+}
+
+func (client *PlaybackClient) GetProdStats(_ context.Context) (*models.ProdStats, error) {
+	return latest[*models.ProdStats](client, models.SatisfactoryEventProdStats)
+}
+
+func (client *PlaybackClient) GetGeneratorStats(_ context.Context) (*models.GeneratorStats, error) {
+	return latest[*models.GeneratorStats](client, models.SatisfactoryEventGeneratorStats)
+}
+
+func (client *PlaybackClient) GetSinkStats(_ context.Context) (*models.SinkStats, error) {
+	return latest[*models.SinkStats](client, models.SatisfactoryEventSinkStats)
+}
+
+func (client *PlaybackClient) GetMachines(_ context.Context) ([]models.Machine, error) {
+	return latest[[]models.Machine](client, models.SatisfactoryEventMachines)
+}
+
+func (client *PlaybackClient) ListCircuits(_ context.Context) ([]models.Circuit, error) {
+	return latest[[]models.Circuit](client, models.SatisfactoryEventCircuits)
+}
+
+func (client *PlaybackClient) ListPlayers(_ context.Context) ([]models.Player, error) {
+	return latest[[]models.Player](client, models.SatisfactoryEventPlayers)
+}
+
+func (client *PlaybackClient) ListDrones(ctx context.Context) ([]models.Drone, error) {
+	vehicles, err := client.GetVehicles(ctx)
+	return vehicles.Drones, err
+}
+
+func (client *PlaybackClient) ListTrains(ctx context.Context) ([]models.Train, error) {
+	vehicles, err := client.GetVehicles(ctx)
+	return vehicles.Trains, err
+}
+
+func (client *PlaybackClient) ListTractors(_ context.Context) ([]models.Tractor, error) {
+	return latest[[]models.Tractor](client, models.SatisfactoryEventTractors)
+}
+
+func (client *PlaybackClient) ListExplorers(_ context.Context) ([]models.Explorer, error) {
+	return latest[[]models.Explorer](client, models.SatisfactoryEventExplorers)
+}
+
+func (client *PlaybackClient) ListTrainStations(ctx context.Context) ([]models.TrainStation, error) {
+	stations, err := client.GetVehicleStations(ctx)
+	return stations.TrainStations, err
+}
+
+func (client *PlaybackClient) ListDroneStations(ctx context.Context) ([]models.DroneStation, error) {
+	stations, err := client.GetVehicleStations(ctx)
+	return stations.DroneStations, err
+}
+
+func (client *PlaybackClient) ListVehiclePaths(_ context.Context) ([]models.VehiclePath, error) {
+	return latest[[]models.VehiclePath](client, models.SatisfactoryEventVehiclePaths)
+}
+
+func (client *PlaybackClient) ListSchematics(_ context.Context) ([]models.Schematic, error) {
+	return latest[[]models.Schematic](client, models.SatisfactoryEventSchematics)
+}
+
+func (client *PlaybackClient) ListBelts(ctx context.Context) ([]models.Belt, error) {
+	belts, err := client.GetBelts(ctx)
+	return belts.Belts, err
+}
+
+func (client *PlaybackClient) ListPipes(ctx context.Context) ([]models.Pipe, error) {
+	pipes, err := client.GetPipes(ctx)
+	return pipes.Pipes, err
+}
+
+func (client *PlaybackClient) ListPipeJunctions(ctx context.Context) ([]models.PipeJunction, error) {
+	pipes, err := client.GetPipes(ctx)
+	return pipes.PipeJunctions, err
+}
+
+func (client *PlaybackClient) ListTrainRails(_ context.Context) ([]models.TrainRail, error) {
+	return latest[[]models.TrainRail](client, models.SatisfactoryEventTrainRails)
+}
+
+func (client *PlaybackClient) ListSplitterMergers(ctx context.Context) ([]models.SplitterMerger, error) {
+	belts, err := client.GetBelts(ctx)
+	return belts.SplitterMergers, err
+}
+
+func (client *PlaybackClient) ListCables(_ context.Context) ([]models.Cable, error) {
+	return latest[[]models.Cable](client, models.SatisfactoryEventCables)
+}
+
+func (client *PlaybackClient) GetBelts(_ context.Context) (models.Belts, error) {
+	return latest[models.Belts](client, models.SatisfactoryEventBelts)
+}
+
+func (client *PlaybackClient) GetPipes(_ context.Context) (models.Pipes, error) {
+	return latest[models.Pipes](client, models.SatisfactoryEventPipes)
+}
+
+func (client *PlaybackClient) GetVehicles(_ context.Context) (models.Vehicles, error) {
+	return latest[models.Vehicles](client, models.SatisfactoryEventVehicles)
+}
+
+func (client *PlaybackClient) GetVehicleStations(_ context.Context) (models.VehicleStations, error) {
+	return latest[models.VehicleStations](client, models.SatisfactoryEventVehicleStations)
+}
+
+func (client *PlaybackClient) GetSpaceElevator(_ context.Context) (*models.SpaceElevator, error) {
+	return latest[*models.SpaceElevator](client, models.SatisfactoryEventSpaceElevator)
+}
+
+func (client *PlaybackClient) GetHub(_ context.Context) (*models.Hub, error) {
+	return latest[*models.Hub](client, models.SatisfactoryEventHub)
+}
+
+func (client *PlaybackClient) ListRadarTowers(_ context.Context) ([]models.RadarTower, error) {
+	return latest[[]models.RadarTower](client, models.SatisfactoryEventRadarTowers)
+}
+
+func (client *PlaybackClient) ListResourceNodes(_ context.Context) ([]models.ResourceNode, error) {
+	return latest[[]models.ResourceNode](client, models.SatisfactoryEventResourceNodes)
+}
+
+// GetAddress returns the playback:// address of the recording
+func (client *PlaybackClient) GetAddress() string {
+	return client.address
+}
+
+func (client *PlaybackClient) GetFailureCount() int {
+	return 0
+}
+
+func (client *PlaybackClient) IsDisconnected() bool {
+	return false
+}
+
+func (client *PlaybackClient) GetEndpointErrors() []models.EndpointError {
+	return []models.EndpointError{}
+}
+
+func (client *PlaybackClient) SetDisconnectedCallback(_ func()) {}
+
+func (client *PlaybackClient) Close() {}
+
+// latest returns the most recently replayed data for an event type
+func latest[T any](client *PlaybackClient, eventType models.SatisfactoryEventType) (T, error) {
+	client.mu.RLock()
+	defer client.mu.RUnlock()
+
+	var zero T
+	data, ok := client.latest[eventType]
+	if !ok {
+		return zero, fmt.Errorf("no %s data has been replayed yet", eventType)
+	}
+
+	typed, ok := data.(T)
+	if !ok {
+		return zero, fmt.Errorf("unexpected data type %T for %s", data, eventType)
+	}
+
+	return typed, nil
+}
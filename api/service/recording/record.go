@@ -0,0 +1,139 @@
+package recording
+
+import (
+	"api/models/models"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// sessionInfoRecordType marks records holding GetSessionInfo results, which are not
+// part of the event stream but are needed to replay a session.
+const sessionInfoRecordType models.SatisfactoryEventType = "sessionInfo"
+
+// Record is a single line of a recording log
+type Record struct {
+	Timestamp time.Time                    `json:"timestamp"`
+	Type      models.SatisfactoryEventType `json:"type"`
+	Data      json.RawMessage              `json:"data"`
+	Partial   bool                         `json:"partial,omitempty"` // Set when the recorded event was partial, see PartialDataError
+}
+
+// ResolveRecordingPath returns the path of the recording name inside dir. Only plain relative
+// names are accepted, so a session address cannot read files outside the recordings directory.
+func ResolveRecordingPath(dir, name string) (string, error) {
+	if dir == "" {
+		return "", fmt.Errorf("playback is disabled, no recording directory is configured")
+	}
+	if name == "" || filepath.IsAbs(name) || strings.HasPrefix(name, "/") {
+		return "", fmt.Errorf("recording %q must be a path relative to the recording directory", name)
+	}
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool { return r == '/' || r == '\\' }) {
+		if part == ".." {
+			return "", fmt.Errorf("recording %q must not contain '..'", name)
+		}
+	}
+
+	root, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve recording directory. details: %w", err)
+	}
+	path := filepath.Join(root, filepath.Clean(name))
+	if !strings.HasPrefix(path, root+string(filepath.Separator)) {
+		return "", fmt.Errorf("recording %q is outside the recording directory", name)
+	}
+	return path, nil
+}
+
+// EachRecord decodes the records of a newline-delimited JSON recording log one at a time and
+// passes them to fn until it returns false, so a recording is never held in memory at once
+func EachRecord(file io.Reader, fn func(Record) bool) error {
+	decoder := json.NewDecoder(file)
+	for index := 1; ; index++ {
+		var record Record
+		if err := decoder.Decode(&record); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("failed to parse record %d. details: %w", index, err)
+		}
+		if !fn(record) {
+			return nil
+		}
+	}
+}
+
+// decodeEventData decodes recorded data into the same Go type the FRM client
+// emits for the event type, so playback consumers can type-assert as usual.
+func decodeEventData(eventType models.SatisfactoryEventType, data json.RawMessage) (any, error) {
+	switch eventType {
+	case models.SatisfactoryEventApiStatus:
+		return decodeAs[*models.SatisfactoryApiStatus](data)
+	case models.SatisfactoryEventCircuits:
+		return decodeAs[[]models.Circuit](data)
+	case models.SatisfactoryEventFactoryStats:
+		return decodeAs[*models.FactoryStats](data)
+	case models.SatisfactoryEventProdStats:
+		return decodeAs[*models.ProdStats](data)
+	case models.SatisfactoryEventSinkStats:
+		return decodeAs[*models.SinkStats](data)
+	case models.SatisfactoryEventPlayers:
+		return decodeAs[[]models.Player](data)
+	case models.SatisfactoryEventGeneratorStats:
+		return decodeAs[*models.GeneratorStats](data)
+	case models.SatisfactoryEventMachines:
+		return decodeAs[[]models.Machine](data)
+	case models.SatisfactoryEventVehicles:
+		return decodeAs[models.Vehicles](data)
+	case models.SatisfactoryEventVehicleStations:
+		return decodeAs[models.VehicleStations](data)
+	case models.SatisfactoryEventBelts:
+		return decodeAs[models.Belts](data)
+	case models.SatisfactoryEventPipes:
+		return decodeAs[models.Pipes](data)
+	case models.SatisfactoryEventTrainRails:
+		return decodeAs[[]models.TrainRail](data)
+	case models.SatisfactoryEventCables:
+		return decodeAs[[]models.Cable](data)
+	case models.SatisfactoryEventStorages:
+		return decodeAs[[]models.Storage](data)
+	case models.SatisfactoryEventTractors:
+		return decodeAs[[]models.Tractor](data)
+	case models.SatisfactoryEventExplorers:
+		return decodeAs[[]models.Explorer](data)
+	case models.SatisfactoryEventVehiclePaths:
+		return decodeAs[[]models.VehiclePath](data)
+	case models.SatisfactoryEventSpaceElevator:
+		return decodeAs[*models.SpaceElevator](data)
+	case models.SatisfactoryEventHub:
+		return decodeAs[*models.Hub](data)
+	case models.SatisfactoryEventRadarTowers:
+		return decodeAs[[]models.RadarTower](data)
+	case models.SatisfactoryEventResourceNodes:
+		return decodeAs[[]models.ResourceNode](data)
+	case models.SatisfactoryEventHypertubes:
+		return decodeAs[models.Hypertubes](data)
+	case models.SatisfactoryEventSchematics:
+		return decodeAs[[]models.Schematic](data)
+	case models.SatisfactoryEventDiagnostics:
+		return decodeAs[[]models.EndpointError](data)
+	case models.SatisfactoryEventPollBudget:
+		return decodeAs[*models.PollBudget](data)
+	case sessionInfoRecordType:
+		return decodeAs[*models.SessionInfo](data)
+	default:
+		return nil, fmt.Errorf("unknown event type %s", eventType)
+	}
+}
+
+func decodeAs[T any](data json.RawMessage) (any, error) {
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
@@ -0,0 +1,104 @@
+package recording
+
+import (
+	"api/models/models"
+	"api/pkg/log"
+	"api/service/client"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+)
+
+var unsafeFileChars = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// RecordingClient wraps a client and tees every event emitted by SetupEventStream
+// to a newline-delimited JSON log, for later replay with PlaybackClient.
+type RecordingClient struct {
+	client.Client
+
+	file    *os.File
+	encoder *json.Encoder
+	closed  bool
+	mu      sync.Mutex
+}
+
+// NewRecordingClient creates a RecordingClient that appends to the log at path
+func NewRecordingClient(inner client.Client, path string) (*RecordingClient, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create recording directory. details: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open recording %s. details: %w", path, err)
+	}
+
+	return &RecordingClient{
+		Client:  inner,
+		file:    file,
+		encoder: json.NewEncoder(file),
+	}, nil
+}
+
+// RecordingPath returns a unique recording log path in dir for the given session
+func RecordingPath(dir, sessionID string) string {
+	name := fmt.Sprintf("%s-%s.ndjson", unsafeFileChars.ReplaceAllString(sessionID, "_"), time.Now().UTC().Format("20060102T150405Z"))
+	return filepath.Join(dir, name)
+}
+
+// SetupEventStream starts the wrapped event stream and records every event before forwarding it
+func (client *RecordingClient) SetupEventStream(ctx context.Context, onEvent func(*models.SatisfactoryEvent)) error {
+	return client.Client.SetupEventStream(ctx, func(event *models.SatisfactoryEvent) {
+		client.record(event)
+		onEvent(event)
+	})
+}
+
+// GetSessionInfo fetches session info from the wrapped client and records it,
+// so playback can report the same save name and play duration
+func (client *RecordingClient) GetSessionInfo(ctx context.Context) (*models.SessionInfo, error) {
+	sessionInfo, err := client.Client.GetSessionInfo(ctx)
+	if err == nil {
+		client.record(&models.SatisfactoryEvent{Type: sessionInfoRecordType, Data: sessionInfo})
+	}
+	return sessionInfo, err
+}
+
+// CloseLog closes the recording log. The wrapped client is left running, Close stops it.
+func (client *RecordingClient) CloseLog() error {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	client.closed = true
+	return client.file.Close()
+}
+
+func (client *RecordingClient) record(event *models.SatisfactoryEvent) {
+	raw, err := json.Marshal(event.Data)
+	if err != nil {
+		log.PrettyError(fmt.Errorf("failed to marshal %s event for recording. details: %w", event.Type, err))
+		return
+	}
+
+	client.mu.Lock()
+	defer client.mu.Unlock()
+
+	// In-flight polls can still complete after the publisher stopped
+	if client.closed {
+		return
+	}
+
+	err = client.encoder.Encode(Record{
+		Timestamp: time.Now(),
+		Type:      event.Type,
+		Data:      raw,
+		Partial:   event.Partial,
+	})
+	if err != nil {
+		log.PrettyError(fmt.Errorf("failed to write %s event to recording. details: %w", event.Type, err))
+	}
+}
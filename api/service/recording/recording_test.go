@@ -0,0 +1,222 @@
+package recording
+
+import (
+	"api/models/models"
+	"api/pkg/log"
+	"api/service/client"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMain(m *testing.M) {
+	if err := log.SetupLogger("test"); err != nil {
+		panic(err)
+	}
+	os.Exit(m.Run())
+}
+
+// streamClient emits a fixed list of events from SetupEventStream
+type streamClient struct {
+	client.Client
+	events      []*models.SatisfactoryEvent
+	sessionInfo *models.SessionInfo
+}
+
+func (stream *streamClient) SetupEventStream(_ context.Context, onEvent func(*models.SatisfactoryEvent)) error {
+	for _, event := range stream.events {
+		onEvent(event)
+	}
+	return nil
+}
+
+func (stream *streamClient) GetSessionInfo(_ context.Context) (*models.SessionInfo, error) {
+	return stream.sessionInfo, nil
+}
+
+func TestResolveRecordingPath(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name    string
+		dir     string
+		path    string
+		want    string
+		wantErr bool
+	}{
+		{name: "plain name", dir: dir, path: "session.ndjson", want: filepath.Join(dir, "session.ndjson")},
+		{name: "subdirectory", dir: dir, path: "old/session.ndjson", want: filepath.Join(dir, "old", "session.ndjson")},
+		{name: "absolute path", dir: dir, path: "/etc/passwd", wantErr: true},
+		{name: "parent traversal", dir: dir, path: "../secret.ndjson", wantErr: true},
+		{name: "nested traversal", dir: dir, path: "old/../../secret.ndjson", wantErr: true},
+		{name: "empty name", dir: dir, path: "", wantErr: true},
+		{name: "no recording directory", dir: "", path: "session.ndjson", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ResolveRecordingPath(test.dir, test.path)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("got %s, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Errorf("got %s, want %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestPlaybackRejectsPathsOutsideRecordingDir(t *testing.T) {
+	playback := NewPlaybackClientFromAddress(PlaybackAddressPrefix+"../../etc/passwd", t.TempDir())
+	if err := playback.SetupEventStream(context.Background(), func(*models.SatisfactoryEvent) {}); err == nil {
+		t.Fatal("playback outside the recording directory started")
+	}
+}
+
+func TestRecordingPlaybackRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "session.ndjson")
+
+	sent := []*models.SatisfactoryEvent{
+		{Type: models.SatisfactoryEventApiStatus, Data: &models.SatisfactoryApiStatus{Running: true}},
+		{Type: models.SatisfactoryEventCircuits, Data: []models.Circuit{{ID: "1"}, {ID: "2"}}},
+		{Type: models.SatisfactoryEventPlayers, Data: []models.Player{{Name: "pioneer"}}},
+	}
+	inner := &streamClient{events: sent, sessionInfo: &models.SessionInfo{SessionName: "recorded"}}
+
+	recorder, err := NewRecordingClient(inner, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := recorder.GetSessionInfo(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := recorder.SetupEventStream(context.Background(), func(*models.SatisfactoryEvent) {}); err != nil {
+		t.Fatal(err)
+	}
+	if err := recorder.CloseLog(); err != nil {
+		t.Fatal(err)
+	}
+
+	playback := NewPlaybackClientFromAddress(PlaybackAddressPrefix+"session.ndjson?speed=1000", dir)
+	sessionInfo, err := playback.GetSessionInfo(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sessionInfo.SessionName != "recorded" {
+		t.Errorf("session name %q, want recorded", sessionInfo.SessionName)
+	}
+
+	received := make(chan *models.SatisfactoryEvent, len(sent))
+	if err := playback.SetupEventStream(context.Background(), func(event *models.SatisfactoryEvent) {
+		received <- event
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, want := range sent {
+		select {
+		case got := <-received:
+			if got.Type != want.Type {
+				t.Fatalf("event %d is %s, want %s", i, got.Type, want.Type)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("event %d was not replayed", i)
+		}
+	}
+
+	circuits, err := playback.ListCircuits(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(circuits) != 2 || circuits[1].ID != "2" {
+		t.Errorf("replayed circuits %v, want the recorded ones", circuits)
+	}
+}
+
+// recordAndReplay records the events to a log and returns them as replayed by playback
+func recordAndReplay(t *testing.T, sent []*models.SatisfactoryEvent) []*models.SatisfactoryEvent {
+	t.Helper()
+	dir := t.TempDir()
+
+	recorder, err := NewRecordingClient(&streamClient{events: sent}, filepath.Join(dir, "session.ndjson"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := recorder.SetupEventStream(context.Background(), func(*models.SatisfactoryEvent) {}); err != nil {
+		t.Fatal(err)
+	}
+	if err := recorder.CloseLog(); err != nil {
+		t.Fatal(err)
+	}
+
+	playback := NewPlaybackClientFromAddress(PlaybackAddressPrefix+"session.ndjson?speed=1000", dir)
+	received := make(chan *models.SatisfactoryEvent, len(sent))
+	if err := playback.SetupEventStream(context.Background(), func(event *models.SatisfactoryEvent) {
+		received <- event
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	replayed := make([]*models.SatisfactoryEvent, 0, len(sent))
+	for i := range sent {
+		select {
+		case event := <-received:
+			replayed = append(replayed, event)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("event %d was not replayed", i)
+		}
+	}
+	return replayed
+}
+
+func TestRecordingPlaybackRoundTripsDiagnostics(t *testing.T) {
+	endpointErrors := []models.EndpointError{{EventType: models.SatisfactoryEventBelts, Message: "timeout"}}
+	replayed := recordAndReplay(t, []*models.SatisfactoryEvent{
+		{Type: models.SatisfactoryEventDiagnostics, Data: endpointErrors},
+	})
+
+	got, ok := replayed[0].Data.([]models.EndpointError)
+	if !ok {
+		t.Fatalf("replayed diagnostics are %T, want []models.EndpointError", replayed[0].Data)
+	}
+	if len(got) != 1 || got[0].EventType != models.SatisfactoryEventBelts || got[0].Message != "timeout" {
+		t.Errorf("replayed diagnostics %v, want %v", got, endpointErrors)
+	}
+}
+
+func TestRecordingPlaybackRoundTripsPollBudget(t *testing.T) {
+	replayed := recordAndReplay(t, []*models.SatisfactoryEvent{
+		{Type: models.SatisfactoryEventPollBudget, Data: &models.PollBudget{Limit: 100, Used: 90, Remaining: 10, Throttle: models.PollBudgetThrottleLow}},
+	})
+
+	got, ok := replayed[0].Data.(*models.PollBudget)
+	if !ok {
+		t.Fatalf("replayed poll budget is %T, want *models.PollBudget", replayed[0].Data)
+	}
+	if got.Remaining != 10 || got.Throttle != models.PollBudgetThrottleLow {
+		t.Errorf("replayed poll budget %+v, want 10 remaining and low throttle", got)
+	}
+}
+
+func TestRecordingPlaybackKeepsPartialFlag(t *testing.T) {
+	replayed := recordAndReplay(t, []*models.SatisfactoryEvent{
+		{Type: models.SatisfactoryEventMachines, Data: []models.Machine{{ID: "smelter-1"}}, Partial: true},
+		{Type: models.SatisfactoryEventMachines, Data: []models.Machine{{ID: "smelter-1"}}},
+	})
+
+	if !replayed[0].Partial {
+		t.Error("replayed partial event is not partial")
+	}
+	if replayed[1].Partial {
+		t.Error("replayed complete event is partial")
+	}
+}
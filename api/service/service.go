@@ -1,11 +1,70 @@
 package service
 
 import (
+	"api/pkg/config"
 	"api/service/client"
 	"api/service/frm_client"
+	"api/service/mock_client"
+	"api/service/recording"
+	"fmt"
+	"strings"
 )
 
-// NewClientWithAddress creates a new client with a custom address
-func NewClientWithAddress(address string) client.Client {
-	return frm_client.NewClientWithAddress(address)
+// Every backend NewClient can return must implement the full client interface
+var (
+	_ client.Client = (*frm_client.Client)(nil)
+	_ client.Client = (*mock_client.Client)(nil)
+	_ client.Client = (*recording.PlaybackClient)(nil)
+	_ client.Client = (*recording.RecordingClient)(nil)
+)
+
+// ClientType selects the backend a session's client talks to
+type ClientType string
+
+const (
+	ClientTypeFRM      ClientType = "frm"      // Ficsit Remote Monitoring API of a game server
+	ClientTypeMock     ClientType = "mock"     // Generated data, see mock_client
+	ClientTypePlayback ClientType = "playback" // Recorded event stream from the recording directory
+)
+
+// ClientConfig describes the client to create for a session
+type ClientConfig struct {
+	Type    ClientType        // Empty infers the type from the address scheme, falling back to config.Config.ClientType
+	Address string            // FRM address, mock://<scenario> or playback://<file>
+	Headers map[string]string // Sent with every FRM request
+}
+
+// resolveType returns the client type to create. An explicit type wins, then the address scheme,
+// then the configured default, so playback:// and mock:// sessions work next to FRM sessions.
+func (cfg ClientConfig) resolveType() ClientType {
+	switch {
+	case cfg.Type != "":
+		return cfg.Type
+	case strings.HasPrefix(cfg.Address, recording.PlaybackAddressPrefix):
+		return ClientTypePlayback
+	case strings.HasPrefix(cfg.Address, mock_client.AddressPrefix):
+		return ClientTypeMock
+	case config.Config != nil && config.Config.ClientType != "":
+		return ClientType(config.Config.ClientType)
+	default:
+		return ClientTypeFRM
+	}
+}
+
+// NewClient creates the client for the configured backend
+func NewClient(cfg ClientConfig) (client.Client, error) {
+	switch clientType := cfg.resolveType(); clientType {
+	case ClientTypeFRM:
+		return frm_client.NewClientWithAddress(cfg.Address, cfg.Headers), nil
+	case ClientTypeMock:
+		return mock_client.NewClientFromAddress(cfg.Address), nil
+	case ClientTypePlayback:
+		recordingDir := ""
+		if config.Config != nil {
+			recordingDir = config.Config.RecordingDir
+		}
+		return recording.NewPlaybackClientFromAddress(cfg.Address, recordingDir), nil
+	default:
+		return nil, fmt.Errorf("unknown client type %q, must be one of frm, mock, playback", clientType)
+	}
 }
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"api/pkg/config"
+	"api/pkg/log"
+	"api/service/frm_client"
+	"api/service/mock_client"
+	"api/service/recording"
+	"os"
+	"testing"
+)
+
+func TestMain(m *testing.M) {
+	if err := log.SetupLogger("test"); err != nil {
+		panic(err)
+	}
+	config.Config = &config.Type{}
+	os.Exit(m.Run())
+}
+
+func TestNewClient(t *testing.T) {
+	tests := []struct {
+		name       string
+		cfg        ClientConfig
+		defaultFor string
+		check      func(any) bool
+		wantErr    bool
+	}{
+		{
+			name:  "frm by default",
+			cfg:   ClientConfig{Address: "http://localhost:8080"},
+			check: func(c any) bool { _, ok := c.(*frm_client.Client); return ok },
+		},
+		{
+			name:  "explicit frm",
+			cfg:   ClientConfig{Type: ClientTypeFRM, Address: "http://localhost:8080"},
+			check: func(c any) bool { _, ok := c.(*frm_client.Client); return ok },
+		},
+		{
+			name:  "mock from address",
+			cfg:   ClientConfig{Address: "mock://full"},
+			check: func(c any) bool { _, ok := c.(*mock_client.Client); return ok },
+		},
+		{
+			name:       "mock from configured default",
+			cfg:        ClientConfig{Address: "http://localhost:8080"},
+			defaultFor: "mock",
+			check:      func(c any) bool { _, ok := c.(*mock_client.Client); return ok },
+		},
+		{
+			name:       "playback address overrides the configured default",
+			cfg:        ClientConfig{Address: "playback://session.ndjson"},
+			defaultFor: "mock",
+			check:      func(c any) bool { _, ok := c.(*recording.PlaybackClient); return ok },
+		},
+		{
+			name:  "explicit playback",
+			cfg:   ClientConfig{Type: ClientTypePlayback, Address: "playback://session.ndjson"},
+			check: func(c any) bool { _, ok := c.(*recording.PlaybackClient); return ok },
+		},
+		{
+			name:    "unknown type",
+			cfg:     ClientConfig{Type: "impl", Address: "http://localhost:8080"},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config.Config.ClientType = test.defaultFor
+
+			got, err := NewClient(test.cfg)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("got %T, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !test.check(got) {
+				t.Errorf("got %T", got)
+			}
+		})
+	}
+}
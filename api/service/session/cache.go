@@ -59,6 +59,11 @@ func GetCachedState(sessionID, saveName string) *models.State {
 		RadarTowers:        []models.RadarTower{},
 		ResourceNodes:      []models.ResourceNode{},
 		Schematics:         []models.Schematic{},
+		Diagnostics:        []models.EndpointError{},
+		StalledVehicles:    []models.StalledVehicle{},
+		IdleConveyors:      []models.IdleConveyor{},
+		StuckStorageItems:  []models.StuckStorageItem{},
+		Alerts:             []models.Alert{},
 	}
 
 	// Helper to get cached data and unmarshal
@@ -93,6 +98,14 @@ func GetCachedState(sessionID, saveName string) *models.State {
 	getCached(models.SatisfactoryEventRadarTowers, &state.RadarTowers)
 	getCached(models.SatisfactoryEventResourceNodes, &state.ResourceNodes)
 	getCached(models.SatisfactoryEventSchematics, &state.Schematics)
+	getCached(models.SatisfactoryEventBaseScore, &state.BaseScore)
+	getCached(models.SatisfactoryEventDiagnostics, &state.Diagnostics)
+	getCached(models.SatisfactoryEventStalledVehicles, &state.StalledVehicles)
+	getCached(models.SatisfactoryEventIdleConveyors, &state.IdleConveyors)
+	getCached(models.SatisfactoryEventStuckStorageItems, &state.StuckStorageItems)
+	getCached(models.SatisfactoryEventPollBudget, &state.PollBudget)
+	getCached(models.SatisfactoryEventShipTimer, &state.ShipTimer)
+	getCached(models.SatisfactoryEventAlerts, &state.Alerts)
 
 	// Handle composite hypertubes event
 	var hypertubesData models.Hypertubes
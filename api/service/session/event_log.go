@@ -0,0 +1,72 @@
+package session
+
+import (
+	"api/models/models"
+	"api/pkg/db/key_value"
+	"encoding/json"
+	"fmt"
+)
+
+// eventLogSize is the number of entries kept per session; older entries are evicted first
+const eventLogSize = 200
+
+// eventLogKey generates the Redis key for a session's event log.
+// Format: eventlog:{sessionID}
+func eventLogKey(sessionID string) string {
+	return fmt.Sprintf("eventlog:%s", sessionID)
+}
+
+// AppendEventLog adds entries to the session's event log, evicting the oldest entries beyond eventLogSize.
+// Only the instance owning the session lease writes to the log, so the read-modify-write is not contended.
+// Returns early without error if the session has been deleted.
+func AppendEventLog(sessionID string, entries ...models.EventLogEntry) error {
+	if len(entries) == 0 || IsSessionDeleted(sessionID) {
+		return nil
+	}
+
+	eventLog, err := GetEventLog(sessionID)
+	if err != nil {
+		return err
+	}
+
+	eventLog = append(eventLog, entries...)
+	if len(eventLog) > eventLogSize {
+		eventLog = eventLog[len(eventLog)-eventLogSize:]
+	}
+
+	data, err := json.Marshal(eventLog)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event log: %w", err)
+	}
+
+	kvClient := key_value.New()
+	if err := kvClient.Set(eventLogKey(sessionID), string(data), 0); err != nil {
+		return fmt.Errorf("failed to store event log: %w", err)
+	}
+	return nil
+}
+
+// GetEventLog returns the session's event log, oldest entry first.
+func GetEventLog(sessionID string) ([]models.EventLogEntry, error) {
+	kvClient := key_value.New()
+	data, err := kvClient.Get(eventLogKey(sessionID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event log: %w", err)
+	}
+
+	eventLog := make([]models.EventLogEntry, 0)
+	if data == "" {
+		return eventLog, nil
+	}
+	if err := json.Unmarshal([]byte(data), &eventLog); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal event log: %w", err)
+	}
+	return eventLog, nil
+}
+
+// ClearEventLog removes the session's event log.
+// Call this when a session is deleted.
+func ClearEventLog(sessionID string) error {
+	kvClient := key_value.New()
+	return kvClient.Del(eventLogKey(sessionID))
+}
@@ -0,0 +1,66 @@
+package session
+
+import (
+	"api/models/models"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestEventLogKeepsNewestEntries(t *testing.T) {
+	useMiniredis(t)
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	entry := func(i int) models.EventLogEntry {
+		return models.EventLogEntry{
+			Timestamp:   start.Add(time.Duration(i) * time.Second),
+			Type:        models.EventLogEntryFuseTriggered,
+			Description: fmt.Sprintf("entry %d", i),
+		}
+	}
+
+	// Appended over several polls, overflowing the log by half its size
+	total := eventLogSize + eventLogSize/2
+	for i := 0; i < total; i += 10 {
+		batch := make([]models.EventLogEntry, 0, 10)
+		for j := i; j < i+10 && j < total; j++ {
+			batch = append(batch, entry(j))
+		}
+		if err := AppendEventLog("session", batch...); err != nil {
+			t.Fatalf("failed to append entries: %v", err)
+		}
+	}
+
+	eventLog, err := GetEventLog("session")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(eventLog) != eventLogSize {
+		t.Fatalf("got %d entries, want %d", len(eventLog), eventLogSize)
+	}
+	for i, got := range eventLog {
+		if want := entry(total - eventLogSize + i); got.Description != want.Description || !got.Timestamp.Equal(want.Timestamp) {
+			t.Fatalf("entry %d: got %+v, want %+v, oldest first", i, got, want)
+		}
+	}
+
+	if err := ClearEventLog("session"); err != nil {
+		t.Fatal(err)
+	}
+	if eventLog, _ := GetEventLog("session"); len(eventLog) != 0 {
+		t.Errorf("got %d entries after clearing, want none", len(eventLog))
+	}
+}
+
+func TestEventLogIgnoresDeletedSessions(t *testing.T) {
+	useMiniredis(t)
+	if err := MarkSessionDeleted("session"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := AppendEventLog("session", models.EventLogEntry{Description: "late poll"}); err != nil {
+		t.Fatalf("got error %v, want appends to a deleted session ignored", err)
+	}
+	if eventLog, _ := GetEventLog("session"); len(eventLog) != 0 {
+		t.Errorf("got %+v, want nothing logged for a deleted session", eventLog)
+	}
+}
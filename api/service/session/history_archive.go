@@ -0,0 +1,131 @@
+package session
+
+import (
+	"api/models/models"
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+var unsafeArchiveChars = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// historyArchivePath returns the append-only archive file of one history series.
+// Format: {dir}/{sessionID}/{saveName}/{dataType}.ndjson
+func historyArchivePath(dir, sessionID, saveName, dataType string) string {
+	return filepath.Join(dir,
+		unsafeArchiveChars.ReplaceAllString(sessionID, "_"),
+		unsafeArchiveChars.ReplaceAllString(saveName, "_"),
+		unsafeArchiveChars.ReplaceAllString(dataType, "_")+".ndjson")
+}
+
+// ArchiveHistoryPoint appends a history point to the archive file of its series, so the series
+// survives a loss of the Redis history.
+func ArchiveHistoryPoint(dir, sessionID, saveName, dataType string, gameTimeID int64, data any) error {
+	path := historyArchivePath(dir, sessionID, saveName, dataType)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create history archive directory: %w", err)
+	}
+
+	line, err := json.Marshal(models.DataPoint{GameTimeID: gameTimeID, DataType: dataType, Data: data})
+	if err != nil {
+		return fmt.Errorf("failed to marshal history point: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open history archive: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to append to history archive: %w", err)
+	}
+	return nil
+}
+
+// RestoreArchivedHistory stores the archived points of a series newer than sinceID back into the
+// Redis history, overwriting points that are still there. Points older than retainSinceID are
+// dropped from the archive file, 0 keeps every point. Returns the number of restored points.
+func RestoreArchivedHistory(dir, sessionID, saveName, dataType string, sinceID, retainSinceID int64) (int, error) {
+	path := historyArchivePath(dir, sessionID, saveName, dataType)
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to open history archive: %w", err)
+	}
+
+	var kept [][]byte
+	dropped := false
+	restored := 0
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		var point struct {
+			GameTimeID int64           `json:"gameTimeId"`
+			Data       json.RawMessage `json:"data"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &point); err != nil {
+			dropped = true
+			continue
+		}
+		if point.GameTimeID < retainSinceID {
+			dropped = true
+			continue
+		}
+		kept = append(kept, append([]byte(nil), scanner.Bytes()...))
+
+		if point.GameTimeID > sinceID {
+			if err := StoreHistoryPoint(sessionID, saveName, dataType, point.GameTimeID, point.Data); err != nil {
+				file.Close()
+				return restored, err
+			}
+			restored++
+		}
+	}
+	file.Close()
+	if err := scanner.Err(); err != nil {
+		return restored, fmt.Errorf("failed to read history archive: %w", err)
+	}
+
+	if dropped {
+		if err := rewriteHistoryArchive(path, kept); err != nil {
+			return restored, err
+		}
+	}
+	return restored, nil
+}
+
+// rewriteHistoryArchive replaces the archive file with the given lines, through a temporary file
+// so a crash never leaves a truncated archive
+func rewriteHistoryArchive(path string, lines [][]byte) error {
+	tmpPath := path + ".tmp"
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return fmt.Errorf("failed to create history archive: %w", err)
+	}
+
+	writer := bufio.NewWriter(file)
+	for _, line := range lines {
+		writer.Write(line)
+		writer.WriteByte('\n')
+	}
+	if err := writer.Flush(); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write history archive: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write history archive: %w", err)
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// ClearHistoryArchive removes all archived history of a session.
+// Call this when a session is deleted.
+func ClearHistoryArchive(dir, sessionID string) error {
+	return os.RemoveAll(filepath.Join(dir, unsafeArchiveChars.ReplaceAllString(sessionID, "_")))
+}
@@ -0,0 +1,106 @@
+package session
+
+import (
+	"api/pkg/log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMain(m *testing.M) {
+	if err := log.SetupLogger("test"); err != nil {
+		panic(err)
+	}
+	os.Exit(m.Run())
+}
+
+func TestHistoryArchiveRestoresIntoRedis(t *testing.T) {
+	useMiniredis(t)
+	dir := t.TempDir()
+	const sessionID, saveName, dataType = "../escape", "My Save", "prodStats"
+
+	for _, id := range []int64{10, 20, 30, 40} {
+		if err := ArchiveHistoryPoint(dir, sessionID, saveName, dataType, id, map[string]int64{"value": id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	path := historyArchivePath(dir, sessionID, saveName, dataType)
+	if !strings.HasPrefix(path, dir+string(filepath.Separator)) {
+		t.Fatalf("got archive %s outside %s", path, dir)
+	}
+
+	// Redis still holds a point, but with data the archive will overwrite
+	if err := StoreHistoryPoint(sessionID, saveName, dataType, 20, map[string]int64{"value": -1}); err != nil {
+		t.Fatal(err)
+	}
+
+	restored, err := RestoreArchivedHistory(dir, sessionID, saveName, dataType, 15, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored != 3 {
+		t.Errorf("got %d restored points, want 3", restored)
+	}
+
+	chunk, err := GetHistory(sessionID, saveName, dataType, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chunk.Points) != 3 || chunk.LatestID != 40 {
+		t.Fatalf("got %+v, want the points 20 to 40", chunk)
+	}
+	for _, point := range chunk.Points {
+		data, ok := point.Data.(map[string]any)
+		if !ok || data["value"] != float64(point.GameTimeID) {
+			t.Errorf("point %d: got data %v, want the archived value", point.GameTimeID, point.Data)
+		}
+	}
+}
+
+func TestHistoryArchiveRetention(t *testing.T) {
+	useMiniredis(t)
+	dir := t.TempDir()
+	const sessionID, saveName, dataType = "session", "save", "circuits"
+
+	for _, id := range []int64{10, 20, 30, 40} {
+		if err := ArchiveHistoryPoint(dir, sessionID, saveName, dataType, id, id); err != nil {
+			t.Fatal(err)
+		}
+	}
+	path := historyArchivePath(dir, sessionID, saveName, dataType)
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.WriteString("not json\n")
+	file.Close()
+
+	// Nothing is newer than sinceID, but the old and the corrupt lines are dropped from the file
+	restored, err := RestoreArchivedHistory(dir, sessionID, saveName, dataType, 100, 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored != 0 {
+		t.Errorf("got %d restored points, want 0", restored)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], `"gameTimeId":30`) || !strings.Contains(lines[1], `"gameTimeId":40`) {
+		t.Errorf("got archive %q, want only the points 30 and 40", content)
+	}
+
+	if restored, err := RestoreArchivedHistory(dir, sessionID, saveName, "missing", 0, 0); restored != 0 || err != nil {
+		t.Errorf("got %d, %v for a missing archive, want 0 and no error", restored, err)
+	}
+
+	if err := ClearHistoryArchive(dir, sessionID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("got archive still present after clearing: %v", err)
+	}
+}
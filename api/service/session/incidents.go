@@ -0,0 +1,96 @@
+package session
+
+import (
+	"api/models/models"
+	"api/pkg/db/key_value"
+	"encoding/json"
+	"fmt"
+)
+
+// incidentsKey generates the Redis key for a session's incident stats.
+// Format: incidents:{sessionID}
+func incidentsKey(sessionID string) string {
+	return fmt.Sprintf("incidents:%s", sessionID)
+}
+
+// RecordIncidents counts the event log entries and the alerts that started firing, and stores the new
+// alert states. Nothing is written when neither changed.
+// Only the instance owning the session lease records incidents, so the read-modify-write is not contended.
+// Returns early without error if the session has been deleted.
+func RecordIncidents(sessionID string, entries []models.EventLogEntry, alerts map[models.AlertName]bool) error {
+	if (len(entries) == 0 && len(alerts) == 0) || IsSessionDeleted(sessionID) {
+		return nil
+	}
+
+	stats, err := GetIncidentStats(sessionID)
+	if err != nil {
+		return err
+	}
+
+	changed := len(entries) > 0
+	for _, entry := range entries {
+		stats.Incidents[entry.Type]++
+		stats.Last[entry.Type] = entry
+	}
+	for alert, firing := range alerts {
+		if firing == stats.Firing[alert] {
+			continue
+		}
+		if firing {
+			stats.AlertsFired[alert]++
+		}
+		stats.Firing[alert] = firing
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+
+	data, err := json.Marshal(stats)
+	if err != nil {
+		return fmt.Errorf("failed to marshal incident stats: %w", err)
+	}
+
+	kvClient := key_value.New()
+	if err := kvClient.Set(incidentsKey(sessionID), string(data), 0); err != nil {
+		return fmt.Errorf("failed to store incident stats: %w", err)
+	}
+	return nil
+}
+
+// GetIncidentStats returns the session's incident stats, empty if nothing was recorded yet.
+func GetIncidentStats(sessionID string) (models.IncidentStats, error) {
+	stats := models.IncidentStats{}
+
+	kvClient := key_value.New()
+	data, err := kvClient.Get(incidentsKey(sessionID))
+	if err != nil {
+		return stats, fmt.Errorf("failed to get incident stats: %w", err)
+	}
+	if data != "" {
+		if err := json.Unmarshal([]byte(data), &stats); err != nil {
+			return stats, fmt.Errorf("failed to unmarshal incident stats: %w", err)
+		}
+	}
+
+	if stats.Firing == nil {
+		stats.Firing = make(map[models.AlertName]bool)
+	}
+	if stats.AlertsFired == nil {
+		stats.AlertsFired = make(map[models.AlertName]int)
+	}
+	if stats.Incidents == nil {
+		stats.Incidents = make(map[models.EventLogEntryType]int)
+	}
+	if stats.Last == nil {
+		stats.Last = make(map[models.EventLogEntryType]models.EventLogEntry)
+	}
+	return stats, nil
+}
+
+// ClearIncidentStats removes the session's incident stats.
+// Call this when a session is deleted.
+func ClearIncidentStats(sessionID string) error {
+	kvClient := key_value.New()
+	return kvClient.Del(incidentsKey(sessionID))
+}
@@ -0,0 +1,59 @@
+package session
+
+import (
+	"api/models/models"
+	"api/pkg/db/key_value"
+	"encoding/json"
+	"fmt"
+)
+
+// machineBuildsKey generates the Redis key for a session's machine build log.
+// Format: machinebuilds:{sessionID}
+func machineBuildsKey(sessionID string) string {
+	return fmt.Sprintf("machinebuilds:%s", sessionID)
+}
+
+// SetMachineBuildLog stores the session's machine build log.
+// Only the instance owning the session lease tracks builds, so it is the single writer.
+// Returns early without error if the session has been deleted.
+func SetMachineBuildLog(sessionID string, buildLog models.MachineBuildLog) error {
+	if IsSessionDeleted(sessionID) {
+		return nil
+	}
+
+	data, err := json.Marshal(buildLog)
+	if err != nil {
+		return fmt.Errorf("failed to marshal machine build log: %w", err)
+	}
+
+	kvClient := key_value.New()
+	if err := kvClient.Set(machineBuildsKey(sessionID), string(data), 0); err != nil {
+		return fmt.Errorf("failed to store machine build log: %w", err)
+	}
+	return nil
+}
+
+// GetMachineBuildLog returns the session's machine build log, empty if tracking has not started.
+func GetMachineBuildLog(sessionID string) (models.MachineBuildLog, error) {
+	var buildLog models.MachineBuildLog
+
+	kvClient := key_value.New()
+	data, err := kvClient.Get(machineBuildsKey(sessionID))
+	if err != nil {
+		return buildLog, fmt.Errorf("failed to get machine build log: %w", err)
+	}
+	if data == "" {
+		return buildLog, nil
+	}
+	if err := json.Unmarshal([]byte(data), &buildLog); err != nil {
+		return buildLog, fmt.Errorf("failed to unmarshal machine build log: %w", err)
+	}
+	return buildLog, nil
+}
+
+// ClearMachineBuildLog removes the session's machine build log.
+// Call this when a session is deleted.
+func ClearMachineBuildLog(sessionID string) error {
+	kvClient := key_value.New()
+	return kvClient.Del(machineBuildsKey(sessionID))
+}
@@ -0,0 +1,83 @@
+package session
+
+import (
+	"api/models/models"
+	"api/pkg/db/key_value"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// prodHistoryItemTTL is how long an item's samples are kept after it was last seen in the prod stats.
+// Items that stop being produced or consumed drop out of the history once it passes.
+const prodHistoryItemTTL = 15 * time.Minute
+
+// prodHistoryKey generates the Redis key for the production samples of one item of a session.
+// Format: prodhistory:{sessionID}:{itemName}
+func prodHistoryKey(sessionID, itemName string) string {
+	return fmt.Sprintf("prodhistory:%s:%s", sessionID, itemName)
+}
+
+// RecordProdSamples appends one sample per item of a prod stats poll, all sharing the timestamp.
+// Each item keeps at most size samples, the oldest are evicted first.
+// Returns early without error if the session has been deleted.
+func RecordProdSamples(sessionID string, items []models.ItemProdStats, timestamp time.Time, size int) error {
+	if len(items) == 0 || size <= 0 || IsSessionDeleted(sessionID) {
+		return nil
+	}
+
+	values := make(map[string]string, len(items))
+	for _, item := range items {
+		data, err := json.Marshal(models.ProdSample{
+			Timestamp:         timestamp,
+			ProducedPerMinute: item.ProducedPerMinute,
+			ConsumedPerMinute: item.ConsumedPerMinute,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to marshal prod sample: %w", err)
+		}
+		values[prodHistoryKey(sessionID, item.Name)] = string(data)
+	}
+
+	kvClient := key_value.New()
+	if err := kvClient.AppendCapped(values, int64(size), prodHistoryItemTTL); err != nil {
+		return fmt.Errorf("failed to store prod samples: %w", err)
+	}
+	return nil
+}
+
+// GetProdHistory returns the recorded samples of an item, oldest first.
+// The item name must match the name in the prod stats exactly.
+func GetProdHistory(sessionID, itemName string) ([]models.ProdSample, error) {
+	kvClient := key_value.New()
+	values, err := kvClient.LRange(prodHistoryKey(sessionID, itemName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get prod history: %w", err)
+	}
+
+	samples := make([]models.ProdSample, 0, len(values))
+	for _, value := range values {
+		var sample models.ProdSample
+		if err := json.Unmarshal([]byte(value), &sample); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal prod sample: %w", err)
+		}
+		samples = append(samples, sample)
+	}
+	return samples, nil
+}
+
+// ClearProdHistory removes the production samples of every item of the session.
+// Call this when a session is deleted.
+func ClearProdHistory(sessionID string) error {
+	kvClient := key_value.New()
+	keys, err := kvClient.List(prodHistoryKey(sessionID, "*"))
+	if err != nil {
+		return fmt.Errorf("failed to list prod history: %w", err)
+	}
+	for _, key := range keys {
+		if err := kvClient.Del(key); err != nil {
+			return fmt.Errorf("failed to delete prod history: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package session
+
+import (
+	"api/models/models"
+	"api/pkg/db"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// useMiniredis points the database at an in-memory Redis for the duration of the test
+func useMiniredis(t *testing.T) *miniredis.Miniredis {
+	t.Helper()
+	server := miniredis.RunT(t)
+	previous := db.DB.RedisClient
+	db.DB.RedisClient = redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() {
+		_ = db.DB.RedisClient.Close()
+		db.DB.RedisClient = previous
+	})
+	return server
+}
+
+func TestProdHistoryKeepsNewestSamples(t *testing.T) {
+	useMiniredis(t)
+	const size = 3
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for i := range 5 {
+		items := []models.ItemProdStats{{ItemStats: models.ItemStats{Name: "Iron Plate"}, ProducedPerMinute: float64(i), ConsumedPerMinute: float64(i) / 2}}
+		if err := RecordProdSamples("session", items, start.Add(time.Duration(i)*time.Second), size); err != nil {
+			t.Fatalf("failed to record samples: %v", err)
+		}
+	}
+
+	samples, err := GetProdHistory("session", "Iron Plate")
+	if err != nil {
+		t.Fatalf("failed to get history: %v", err)
+	}
+	if len(samples) != size {
+		t.Fatalf("got %d samples, want %d", len(samples), size)
+	}
+	for i, sample := range samples {
+		want := float64(i + 2)
+		if sample.ProducedPerMinute != want || sample.ConsumedPerMinute != want/2 {
+			t.Errorf("sample %d: got %v/%v, want %v/%v", i, sample.ProducedPerMinute, sample.ConsumedPerMinute, want, want/2)
+		}
+		if !sample.Timestamp.Equal(start.Add(time.Duration(i+2) * time.Second)) {
+			t.Errorf("sample %d: got timestamp %s", i, sample.Timestamp)
+		}
+	}
+}
+
+func TestProdHistoryEvictsItemsNoLongerSeen(t *testing.T) {
+	server := useMiniredis(t)
+	plate := models.ItemProdStats{ItemStats: models.ItemStats{Name: "Iron Plate"}, ProducedPerMinute: 30}
+	rod := models.ItemProdStats{ItemStats: models.ItemStats{Name: "Iron Rod"}, ProducedPerMinute: 15}
+
+	if err := RecordProdSamples("session", []models.ItemProdStats{plate, rod}, time.Now(), 10); err != nil {
+		t.Fatalf("failed to record samples: %v", err)
+	}
+	server.FastForward(prodHistoryItemTTL / 2)
+	if err := RecordProdSamples("session", []models.ItemProdStats{plate}, time.Now(), 10); err != nil {
+		t.Fatalf("failed to record samples: %v", err)
+	}
+	server.FastForward(prodHistoryItemTTL/2 + time.Second)
+
+	tests := []struct {
+		item string
+		want int
+	}{
+		{"Iron Plate", 2},
+		{"Iron Rod", 0},
+		{"Screw", 0},
+	}
+	for _, test := range tests {
+		t.Run(test.item, func(t *testing.T) {
+			samples, err := GetProdHistory("session", test.item)
+			if err != nil {
+				t.Fatalf("failed to get history: %v", err)
+			}
+			if len(samples) != test.want {
+				t.Errorf("got %d samples, want %d", len(samples), test.want)
+			}
+		})
+	}
+}
+
+func TestClearProdHistory(t *testing.T) {
+	useMiniredis(t)
+	items := []models.ItemProdStats{{ItemStats: models.ItemStats{Name: "Iron Plate"}}, {ItemStats: models.ItemStats{Name: "Iron Rod"}}}
+	for _, sessionID := range []string{"deleted", "kept"} {
+		if err := RecordProdSamples(sessionID, items, time.Now(), 10); err != nil {
+			t.Fatalf("failed to record samples: %v", err)
+		}
+	}
+
+	if err := ClearProdHistory("deleted"); err != nil {
+		t.Fatalf("failed to clear history: %v", err)
+	}
+
+	for _, item := range items {
+		if samples, _ := GetProdHistory("deleted", item.Name); len(samples) != 0 {
+			t.Errorf("%s: %d samples left after clearing", item.Name, len(samples))
+		}
+		if samples, _ := GetProdHistory("kept", item.Name); len(samples) != 1 {
+			t.Errorf("%s: other session has %d samples, want 1", item.Name, len(samples))
+		}
+	}
+}
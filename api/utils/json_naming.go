@@ -0,0 +1,198 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+type JSONNaming string
+
+const (
+	JSONNamingAsIs      JSONNaming = "asIs"
+	JSONNamingCamelCase JSONNaming = "camelCase"
+	JSONNamingSnakeCase JSONNaming = "snake_case"
+)
+
+// IsValidJSONNaming reports whether the naming strategy is known. An empty strategy means as-is.
+func IsValidJSONNaming(naming JSONNaming) bool {
+	switch naming {
+	case "", JSONNamingAsIs, JSONNamingCamelCase, JSONNamingSnakeCase:
+		return true
+	}
+	return false
+}
+
+// JSONNamer rewrites the keys of JSON documents to a naming strategy, guided by the Go type the
+// document was encoded from. Only keys that are field names of the struct type at their position
+// are renamed, so data keys such as endpoint names, IDs or power types are kept, as are the keys
+// of maps. Values of interface fields are left as they are; rename them with their concrete type.
+type JSONNamer struct {
+	rename func(string) string
+	fields sync.Map // reflect.Type of a struct -> map[string]reflect.Type, JSON name of each field -> field type
+}
+
+// NewJSONNamer creates a namer for the strategy. Returns nil for the as-is strategy.
+func NewJSONNamer(naming JSONNaming) *JSONNamer {
+	switch naming {
+	case JSONNamingCamelCase:
+		return &JSONNamer{rename: toCamelCase}
+	case JSONNamingSnakeCase:
+		return &JSONNamer{rename: toSnakeCase}
+	default:
+		return nil
+	}
+}
+
+// Apply rewrites the struct field keys of a JSON document encoded from a value of the type.
+// Values are left untouched, and numbers keep their original precision. A nil namer returns
+// the document as is.
+func (namer *JSONNamer) Apply(data []byte, typ reflect.Type) ([]byte, error) {
+	if namer == nil {
+		return data, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var document any
+	if err := decoder.Decode(&document); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(namer.Rename(document, typ))
+}
+
+// Rename rewrites the struct field keys of a decoded JSON document, as produced by json.Unmarshal
+// into an interface value, of a value of the type. Objects and arrays are renamed in place where
+// possible. A nil namer or type returns the document as is.
+func (namer *JSONNamer) Rename(document any, typ reflect.Type) any {
+	if namer == nil || typ == nil {
+		return document
+	}
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	switch typ.Kind() {
+	case reflect.Struct:
+		object, ok := document.(map[string]any)
+		if !ok {
+			return document
+		}
+		fields := namer.structFields(typ)
+		renamed := make(map[string]any, len(object))
+		for key, child := range object {
+			fieldType, ok := fields[key]
+			if !ok {
+				renamed[key] = child
+				continue
+			}
+			renamed[namer.rename(key)] = namer.Rename(child, fieldType)
+		}
+		return renamed
+	case reflect.Map:
+		object, ok := document.(map[string]any)
+		if !ok {
+			return document
+		}
+		for key, child := range object {
+			object[key] = namer.Rename(child, typ.Elem())
+		}
+		return object
+	case reflect.Slice, reflect.Array:
+		list, ok := document.([]any)
+		if !ok {
+			return document
+		}
+		for idx, child := range list {
+			list[idx] = namer.Rename(child, typ.Elem())
+		}
+		return list
+	default:
+		return document
+	}
+}
+
+// structFields returns the JSON names of the fields of the struct type, promoted fields included,
+// with their types
+func (namer *JSONNamer) structFields(typ reflect.Type) map[string]reflect.Type {
+	if fields, ok := namer.fields.Load(typ); ok {
+		return fields.(map[string]reflect.Type)
+	}
+
+	fields := make(map[string]reflect.Type)
+	for _, field := range reflect.VisibleFields(typ) {
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == "" {
+			// Promoted fields are listed by VisibleFields too
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = field.Type
+	}
+	namer.fields.Store(typ, fields)
+	return fields
+}
+
+// toSnakeCase converts camelCase and PascalCase keys, including acronyms such as XMeters, to snake_case
+func toSnakeCase(key string) string {
+	runes := []rune(key)
+	var builder strings.Builder
+	for idx, r := range runes {
+		if unicode.IsUpper(r) && idx > 0 {
+			prev := runes[idx-1]
+			nextIsLower := idx+1 < len(runes) && unicode.IsLower(runes[idx+1])
+			if prev != '_' && (unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower)) {
+				builder.WriteRune('_')
+			}
+		}
+		builder.WriteRune(unicode.ToLower(r))
+	}
+	return builder.String()
+}
+
+// toCamelCase converts snake_case and PascalCase keys to camelCase
+func toCamelCase(key string) string {
+	parts := strings.Split(key, "_")
+	var builder strings.Builder
+	for _, part := range parts {
+		if part == "" {
+			continue
+		}
+		runes := []rune(part)
+		if builder.Len() == 0 {
+			builder.WriteString(lowerLeadingRun(runes))
+			continue
+		}
+		builder.WriteRune(unicode.ToUpper(runes[0]))
+		builder.WriteString(string(runes[1:]))
+	}
+	return builder.String()
+}
+
+// lowerLeadingRun lowercases the leading uppercase run of a word, keeping the last
+// letter of the run upper when it starts the next word (XMeters -> xMeters, ID -> id)
+func lowerLeadingRun(runes []rune) string {
+	end := 0
+	for end < len(runes) && unicode.IsUpper(runes[end]) {
+		end++
+	}
+	if end > 1 && end < len(runes) && unicode.IsLower(runes[end]) {
+		end--
+	}
+	for idx := 0; idx < end; idx++ {
+		runes[idx] = unicode.ToLower(runes[idx])
+	}
+	return string(runes)
+}
@@ -0,0 +1,102 @@
+package utils
+
+import (
+	"api/models/models"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestJSONNamer(t *testing.T) {
+	xMeters := 12.5
+	location := models.Location{X: 1250, Y: 2, Z: 3, XMeters: &xMeters}
+	stats := models.GeneratorStats{
+		Sources:         map[models.PowerType]models.PowerSource{"nuclearPower": {Count: 2, TotalProduction: 5e9}},
+		TotalProduction: 5e9,
+	}
+
+	tests := []struct {
+		naming       JSONNaming
+		value        any
+		wantKeys     []string
+		wantNestedAt string // key of a map field whose data keys must be kept
+		wantNested   []string
+	}{
+		{JSONNamingAsIs, location, []string{"x", "xMeters", "rotation"}, "", nil},
+		{JSONNamingCamelCase, location, []string{"x", "xMeters", "rotation"}, "", nil},
+		{JSONNamingSnakeCase, location, []string{"x", "x_meters", "rotation"}, "", nil},
+		{JSONNamingCamelCase, stats, []string{"sources", "totalProduction", "netBalance"}, "sources", []string{"nuclearPower"}},
+		{JSONNamingSnakeCase, stats, []string{"sources", "total_production", "net_balance"}, "sources", []string{"nuclearPower"}},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.naming), func(t *testing.T) {
+			data, err := json.Marshal(test.value)
+			if err != nil {
+				t.Fatalf("failed to marshal: %v", err)
+			}
+
+			namer := NewJSONNamer(test.naming)
+			renamed, err := namer.Apply(data, reflect.TypeOf(test.value))
+			if err != nil {
+				t.Fatalf("failed to apply naming: %v", err)
+			}
+
+			var document map[string]json.RawMessage
+			if err := json.Unmarshal(renamed, &document); err != nil {
+				t.Fatalf("failed to unmarshal %s: %v", renamed, err)
+			}
+			for _, key := range test.wantKeys {
+				if _, ok := document[key]; !ok {
+					t.Errorf("expected key %q in %s", key, renamed)
+				}
+			}
+
+			if test.wantNestedAt == "" {
+				return
+			}
+			var nested map[string]json.RawMessage
+			if err := json.Unmarshal(document[test.wantNestedAt], &nested); err != nil {
+				t.Fatalf("failed to unmarshal %s: %v", test.wantNestedAt, err)
+			}
+			for _, key := range test.wantNested {
+				if _, ok := nested[key]; !ok {
+					t.Errorf("expected data key %q to be kept in %s", key, renamed)
+				}
+			}
+		})
+	}
+}
+
+func TestJSONNamerKeysFieldsByType(t *testing.T) {
+	// The same field name is a map in one type and a struct in another
+	type itemCounts struct {
+		Counts map[string]int `json:"counts"`
+	}
+	type totalCounts struct {
+		Counts struct {
+			TotalCount int `json:"totalCount"`
+		} `json:"counts"`
+	}
+	type document struct {
+		Items  itemCounts  `json:"items"`
+		Totals totalCounts `json:"totals"`
+	}
+
+	value := document{Items: itemCounts{Counts: map[string]int{"ironPlate": 1}}}
+	value.Totals.Counts.TotalCount = 1
+	data, err := json.Marshal(value)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+
+	renamed, err := NewJSONNamer(JSONNamingSnakeCase).Apply(data, reflect.TypeOf(value))
+	if err != nil {
+		t.Fatalf("failed to apply naming: %v", err)
+	}
+
+	want := `{"items":{"counts":{"ironPlate":1}},"totals":{"counts":{"total_count":1}}}`
+	if string(renamed) != want {
+		t.Errorf("got %s, want %s", renamed, want)
+	}
+}
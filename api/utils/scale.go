@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"api/models/models"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var siPrefixes = []struct {
+	prefix     string
+	multiplier float64
+}{
+	{"G", 1e9},
+	{"M", 1e6},
+	{"k", 1e3},
+}
+
+// ScaleValue scales a raw value to a k/M/G prefix so every consumer renders it the same way.
+// Values below 1000 keep their unit unprefixed. The display value is rounded to two decimals.
+func ScaleValue(raw float64, unit string) models.ScaledValue {
+	scaled := models.ScaledValue{Raw: raw, Value: raw, Unit: unit}
+	for _, si := range siPrefixes {
+		if math.Abs(raw) >= si.multiplier {
+			scaled.Value = raw / si.multiplier
+			scaled.Prefix = si.prefix
+			break
+		}
+	}
+
+	display := strconv.FormatFloat(math.Round(scaled.Value*100)/100, 'f', -1, 64)
+	if unit != "" {
+		// Compound units such as items/min take the prefix on the value, not the unit
+		if scaled.Prefix != "" && strings.Contains(unit, "/") {
+			display += scaled.Prefix + " " + unit
+		} else {
+			display += " " + scaled.Prefix + unit
+		}
+	} else {
+		display += scaled.Prefix
+	}
+	scaled.Display = display
+
+	return scaled
+}
@@ -0,0 +1,28 @@
+package utils
+
+import (
+	"api/models/models"
+	"testing"
+)
+
+func TestScaleValue(t *testing.T) {
+	tests := []struct {
+		raw  float64
+		unit string
+		want models.ScaledValue
+	}{
+		{2.3e9, "W", models.ScaledValue{Raw: 2.3e9, Value: 2.3, Prefix: "G", Unit: "W", Display: "2.3 GW"}},
+		{450, "items/min", models.ScaledValue{Raw: 450, Value: 450, Unit: "items/min", Display: "450 items/min"}},
+		{1500, "items/min", models.ScaledValue{Raw: 1500, Value: 1.5, Prefix: "k", Unit: "items/min", Display: "1.5k items/min"}},
+		{-7.5e6, "W", models.ScaledValue{Raw: -7.5e6, Value: -7.5, Prefix: "M", Unit: "W", Display: "-7.5 MW"}},
+		{12345, "", models.ScaledValue{Raw: 12345, Value: 12.345, Prefix: "k", Display: "12.35k"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.want.Display, func(t *testing.T) {
+			if got := ScaleValue(test.raw, test.unit); got != test.want {
+				t.Errorf("got %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
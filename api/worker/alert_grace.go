@@ -0,0 +1,83 @@
+package worker
+
+import (
+	"api/models/models"
+	"api/pkg/config"
+	"sync"
+	"time"
+)
+
+const defaultAlertGrace = 60 * time.Second
+
+// alertGracePeriod returns how long after a publisher starts alerts are held back
+func alertGracePeriod() time.Duration {
+	if config.Config.AlertGraceSeconds != nil {
+		return time.Duration(*config.Config.AlertGraceSeconds) * time.Second
+	}
+	return defaultAlertGrace
+}
+
+// alertGrace holds back alerts right after a publisher starts, when the first polls show machines
+// momentarily idle and infrastructure still loading. Detectors keep collecting their baseline, and
+// only the latest held alert of each type is published once the grace period is over.
+type alertGrace struct {
+	mu      sync.Mutex
+	now     func() time.Time
+	until   time.Time
+	held    map[models.SatisfactoryEventType]models.SatisfactoryEvent
+	heldIn  []models.SatisfactoryEventType // Held types in order of first appearance
+	expired bool
+}
+
+func newAlertGrace(now func() time.Time, period time.Duration) *alertGrace {
+	return &alertGrace{
+		now:   now,
+		until: now().Add(period),
+		held:  make(map[models.SatisfactoryEventType]models.SatisfactoryEvent),
+	}
+}
+
+// Active reports whether alerts are still held back
+func (grace *alertGrace) Active() bool {
+	grace.mu.Lock()
+	defer grace.mu.Unlock()
+	return grace.activeLocked()
+}
+
+func (grace *alertGrace) activeLocked() bool {
+	return !grace.expired && grace.now().Before(grace.until)
+}
+
+// Hold keeps the event as the latest of its type and returns true while the grace period lasts
+func (grace *alertGrace) Hold(event models.SatisfactoryEvent) bool {
+	grace.mu.Lock()
+	defer grace.mu.Unlock()
+
+	if !grace.activeLocked() {
+		return false
+	}
+	if _, ok := grace.held[event.Type]; !ok {
+		grace.heldIn = append(grace.heldIn, event.Type)
+	}
+	grace.held[event.Type] = event
+	return true
+}
+
+// Release returns the held alerts the first time it is called after the grace period, nil otherwise
+func (grace *alertGrace) Release() []models.SatisfactoryEvent {
+	grace.mu.Lock()
+	defer grace.mu.Unlock()
+
+	if grace.expired || grace.now().Before(grace.until) {
+		return nil
+	}
+	grace.expired = true
+
+	released := make([]models.SatisfactoryEvent, 0, len(grace.heldIn))
+	for _, eventType := range grace.heldIn {
+		released = append(released, grace.held[eventType])
+	}
+	grace.held = nil
+	grace.heldIn = nil
+	return released
+}
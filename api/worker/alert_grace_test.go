@@ -0,0 +1,84 @@
+package worker
+
+import (
+	"api/models/models"
+	"api/pkg/config"
+	"testing"
+	"time"
+)
+
+func TestAlertGraceHoldsLatestAlertPerType(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := start
+	grace := newAlertGrace(func() time.Time { return now }, time.Minute)
+	alert := func(eventType models.SatisfactoryEventType, ids ...string) models.SatisfactoryEvent {
+		return models.SatisfactoryEvent{Type: eventType, Data: ids}
+	}
+
+	held := []models.SatisfactoryEvent{
+		alert(models.SatisfactoryEventStalledVehicles, "truck"),
+		alert(models.SatisfactoryEventIdleConveyors, "belt"),
+		alert(models.SatisfactoryEventStalledVehicles, "truck", "tractor"),
+	}
+	for idx, event := range held {
+		now = start.Add(time.Duration(idx*10) * time.Second)
+		if !grace.Hold(event) {
+			t.Fatalf("alert %d: got it published during the grace period, want it held", idx)
+		}
+		if released := grace.Release(); released != nil {
+			t.Fatalf("alert %d: got %+v released during the grace period, want nothing", idx, released)
+		}
+	}
+	if !grace.Active() {
+		t.Error("got the grace period over after 20 seconds, want it active")
+	}
+
+	now = start.Add(time.Minute)
+	if grace.Active() {
+		t.Error("got the grace period active after a minute, want it over")
+	}
+	if grace.Hold(alert(models.SatisfactoryEventIdleConveyors, "pipe")) {
+		t.Error("got an alert held after the grace period, want it published")
+	}
+
+	// Held types in order of first appearance, with the latest event of each
+	released := grace.Release()
+	if len(released) != 2 {
+		t.Fatalf("got %d released alerts, want 2: %+v", len(released), released)
+	}
+	if released[0].Type != models.SatisfactoryEventStalledVehicles || len(released[0].Data.([]string)) != 2 {
+		t.Errorf("got %+v first, want the latest stalled vehicles", released[0])
+	}
+	if released[1].Type != models.SatisfactoryEventIdleConveyors || released[1].Data.([]string)[0] != "belt" {
+		t.Errorf("got %+v second, want the held idle conveyors", released[1])
+	}
+	if again := grace.Release(); again != nil {
+		t.Errorf("got %+v on a second release, want nothing", again)
+	}
+}
+
+func TestAlertGraceWithoutPeriod(t *testing.T) {
+	grace := newAlertGrace(time.Now, 0)
+	if grace.Hold(models.SatisfactoryEvent{Type: models.SatisfactoryEventIdleConveyors}) {
+		t.Error("got an alert held without a grace period, want it published")
+	}
+	if released := grace.Release(); len(released) != 0 {
+		t.Errorf("got %+v released, want nothing held", released)
+	}
+}
+
+func TestAlertGracePeriod(t *testing.T) {
+	previous := config.Config
+	t.Cleanup(func() { config.Config = previous })
+
+	config.Config = &config.Type{}
+	if period := alertGracePeriod(); period != defaultAlertGrace {
+		t.Errorf("got %v, want the default %v", period, defaultAlertGrace)
+	}
+	for _, seconds := range []int{0, 30} {
+		config.Config = &config.Type{AlertGraceSeconds: &seconds}
+		if period := alertGracePeriod(); period != time.Duration(seconds)*time.Second {
+			t.Errorf("got %v, want %ds", period, seconds)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package worker
+
+import (
+	"api/models/models"
+	"slices"
+)
+
+// alertStates returns the state of the alerts the events decide, leaving alerts of other event types out.
+// Partial events are skipped, since missing entities would clear alerts.
+func alertStates(events []models.SatisfactoryEvent) map[models.AlertName]bool {
+	alerts := make(map[models.AlertName]bool)
+	for _, event := range events {
+		if event.Partial {
+			continue
+		}
+		switch data := event.Data.(type) {
+		case []models.Circuit:
+			alerts[models.AlertFuseTriggered] = slices.ContainsFunc(data, func(c models.Circuit) bool { return c.FuseTriggered })
+		case models.Vehicles:
+			alerts[models.AlertTrainDerailed] = slices.ContainsFunc(data.Trains, func(t models.Train) bool { return t.Status == models.TrainStatusDerailed })
+		case []models.StalledVehicle:
+			alerts[models.AlertVehicleStalled] = len(data) > 0
+		case []models.IdleConveyor:
+			alerts[models.AlertConveyorIdle] = len(data) > 0
+		case []models.EndpointError:
+			alerts[models.AlertEndpointFailing] = len(data) > 0
+		case []models.Alert:
+			alerts[models.AlertBatteryLow] = slices.ContainsFunc(data, func(a models.Alert) bool { return a.Kind == models.AlertBatteryLow })
+			alerts[models.AlertPowerDeficit] = slices.ContainsFunc(data, func(a models.Alert) bool { return a.Kind == models.AlertPowerDeficit })
+		}
+	}
+	return alerts
+}
@@ -0,0 +1,122 @@
+package worker
+
+import (
+	"api/models/models"
+	"api/pkg/config"
+	"math"
+)
+
+// defaultCoordinateBound comfortably contains the whole map, which spans roughly ±400000 on X and Y
+const defaultCoordinateBound = 1_000_000.0
+
+// coordinateBound returns the configured max absolute coordinate of emitted entities, 0 meaning no bound
+func coordinateBound() float64 {
+	if config.Config.CoordinateBound != nil {
+		return *config.Config.CoordinateBound
+	}
+	return defaultCoordinateBound
+}
+
+// filterOutOfBounds drops entities of a list-based event lying outside ±bound on any axis, or exactly
+// at the origin where FRM places entities it has no location for, and counts them on the event.
+// A bound of zero disables filtering. Runs before the event is stored, so dropped entities never reach
+// history or the cached state.
+func filterOutOfBounds(event *models.SatisfactoryEvent, bound float64) {
+	if bound <= 0 {
+		return
+	}
+
+	data, filtered := filterData(event.Data, bound)
+	if filtered == 0 {
+		return
+	}
+	event.Data = data
+	event.Filtered = filtered
+}
+
+func filterData(data any, bound float64) (any, int) {
+	switch typed := data.(type) {
+	case []models.Machine:
+		return keepInBounds(typed, bound, func(m models.Machine) []models.Location { return at(m.Location) })
+	case []models.Player:
+		return keepInBounds(typed, bound, func(p models.Player) []models.Location { return at(p.Location) })
+	case []models.Storage:
+		return keepInBounds(typed, bound, func(s models.Storage) []models.Location { return at(s.Location) })
+	case []models.TrainRail:
+		return keepInBounds(typed, bound, func(r models.TrainRail) []models.Location { return at(r.Location0, r.Location1) })
+	case []models.Cable:
+		return keepInBounds(typed, bound, func(c models.Cable) []models.Location { return at(c.Location0, c.Location1) })
+	case []models.Tractor:
+		return keepInBounds(typed, bound, func(t models.Tractor) []models.Location { return at(t.Location) })
+	case []models.Explorer:
+		return keepInBounds(typed, bound, func(e models.Explorer) []models.Location { return at(e.Location) })
+	case []models.RadarTower:
+		return keepInBounds(typed, bound, func(r models.RadarTower) []models.Location { return at(r.Location) })
+	case []models.ResourceNode:
+		return keepInBounds(typed, bound, func(n models.ResourceNode) []models.Location { return at(n.Location) })
+	case models.Belts:
+		belts, beltsFiltered := keepInBounds(typed.Belts, bound, func(b models.Belt) []models.Location { return at(b.Location0, b.Location1) })
+		splitterMergers, splittersFiltered := keepInBounds(typed.SplitterMergers, bound, func(s models.SplitterMerger) []models.Location { return at(s.Location) })
+		return models.Belts{Belts: belts, SplitterMergers: splitterMergers}, beltsFiltered + splittersFiltered
+	case models.Pipes:
+		pipes, pipesFiltered := keepInBounds(typed.Pipes, bound, func(p models.Pipe) []models.Location { return at(p.Location0, p.Location1) })
+		junctions, junctionsFiltered := keepInBounds(typed.PipeJunctions, bound, func(j models.PipeJunction) []models.Location { return at(j.Location) })
+		return models.Pipes{Pipes: pipes, PipeJunctions: junctions}, pipesFiltered + junctionsFiltered
+	case models.Hypertubes:
+		tubes, tubesFiltered := keepInBounds(typed.Hypertubes, bound, func(h models.Hypertube) []models.Location { return at(h.Location0, h.Location1) })
+		entrances, entrancesFiltered := keepInBounds(typed.HypertubeEntrances, bound, func(e models.HypertubeEntrance) []models.Location { return at(e.Location) })
+		return models.Hypertubes{Hypertubes: tubes, HypertubeEntrances: entrances}, tubesFiltered + entrancesFiltered
+	case models.Vehicles:
+		trains, trainsFiltered := keepInBounds(typed.Trains, bound, func(t models.Train) []models.Location { return at(t.Location) })
+		drones, dronesFiltered := keepInBounds(typed.Drones, bound, func(d models.Drone) []models.Location { return at(d.Location) })
+		trucks, trucksFiltered := keepInBounds(typed.Trucks, bound, func(t models.Truck) []models.Location { return at(t.Location) })
+		tractors, tractorsFiltered := keepInBounds(typed.Tractors, bound, func(t models.Tractor) []models.Location { return at(t.Location) })
+		explorers, explorersFiltered := keepInBounds(typed.Explorers, bound, func(e models.Explorer) []models.Location { return at(e.Location) })
+		return models.Vehicles{Trains: trains, Drones: drones, Trucks: trucks, Tractors: tractors, Explorers: explorers},
+			trainsFiltered + dronesFiltered + trucksFiltered + tractorsFiltered + explorersFiltered
+	case models.VehicleStations:
+		trainStations, trainFiltered := keepInBounds(typed.TrainStations, bound, func(s models.TrainStation) []models.Location { return at(s.Location) })
+		droneStations, droneFiltered := keepInBounds(typed.DroneStations, bound, func(s models.DroneStation) []models.Location { return at(s.Location) })
+		truckStations, truckFiltered := keepInBounds(typed.TruckStations, bound, func(s models.TruckStation) []models.Location { return at(s.Location) })
+		return models.VehicleStations{TrainStations: trainStations, DroneStations: droneStations, TruckStations: truckStations},
+			trainFiltered + droneFiltered + truckFiltered
+	default:
+		return data, 0
+	}
+}
+
+func at(locations ...models.Location) []models.Location {
+	return locations
+}
+
+// keepInBounds returns the items whose locations all lie in bounds, and how many were dropped
+func keepInBounds[T any](items []T, bound float64, locations func(T) []models.Location) ([]T, int) {
+	var kept []T
+	for idx, item := range items {
+		if allInBounds(locations(item), bound) {
+			if kept != nil {
+				kept = append(kept, item)
+			}
+			continue
+		}
+		if kept == nil {
+			kept = append(make([]T, 0, len(items)-1), items[:idx]...)
+		}
+	}
+	if kept == nil {
+		return items, 0
+	}
+	return kept, len(items) - len(kept)
+}
+
+func allInBounds(locations []models.Location, bound float64) bool {
+	for _, location := range locations {
+		if location.X == 0 && location.Y == 0 && location.Z == 0 {
+			return false
+		}
+		if math.Abs(location.X) > bound || math.Abs(location.Y) > bound || math.Abs(location.Z) > bound {
+			return false
+		}
+	}
+	return true
+}
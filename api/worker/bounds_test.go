@@ -0,0 +1,76 @@
+package worker
+
+import (
+	"api/models/models"
+	"testing"
+)
+
+func TestFilterOutOfBounds(t *testing.T) {
+	machine := func(id string, x, y, z float64) models.Machine {
+		return models.Machine{ID: id, Location: models.Location{X: x, Y: y, Z: z}}
+	}
+	machines := []models.Machine{
+		machine("inside", 120000, -250000, 5000),
+		machine("far east", 2_000_000, 0, 100),
+		machine("origin", 0, 0, 0), // FRM places entities without a location here
+		machine("on the bound", -1000, 1000, 1000),
+		machine("deep", 100, 100, -1500),
+	}
+
+	tests := []struct {
+		name         string
+		bound        float64
+		want         []string
+		wantFiltered int
+	}{
+		{"disabled", 0, []string{"inside", "far east", "origin", "on the bound", "deep"}, 0},
+		{"wide bound", 1_000_000, []string{"inside", "on the bound", "deep"}, 2},
+		{"narrow bound", 1000, []string{"on the bound"}, 4},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			event := &models.SatisfactoryEvent{Type: models.SatisfactoryEventMachines, Data: machines}
+			filterOutOfBounds(event, test.bound)
+
+			if event.Filtered != test.wantFiltered {
+				t.Errorf("got %d filtered, want %d", event.Filtered, test.wantFiltered)
+			}
+			kept := event.Data.([]models.Machine)
+			if len(kept) != len(test.want) {
+				t.Fatalf("got %d machines, want %v", len(kept), test.want)
+			}
+			for i, machine := range kept {
+				if machine.ID != test.want[i] {
+					t.Errorf("machine %d: got %s, want %s", i, machine.ID, test.want[i])
+				}
+			}
+		})
+	}
+
+	if machines[1].ID != "far east" {
+		t.Error("filtering modified the original machines, want a filtered copy")
+	}
+}
+
+func TestFilterOutOfBoundsChecksBothEnds(t *testing.T) {
+	belts := models.Belts{
+		Belts: []models.Belt{
+			{ID: "ok", Location0: models.Location{X: 100, Y: 100}, Location1: models.Location{X: 900, Y: 100}},
+			{ID: "stray end", Location0: models.Location{X: 100, Y: 100}, Location1: models.Location{X: 5_000_000, Y: 100}},
+		},
+		SplitterMergers: []models.SplitterMerger{{ID: "unplaced"}},
+	}
+	event := &models.SatisfactoryEvent{Type: models.SatisfactoryEventBelts, Data: belts}
+	filterOutOfBounds(event, defaultCoordinateBound)
+
+	kept := event.Data.(models.Belts)
+	if event.Filtered != 2 || len(kept.Belts) != 1 || kept.Belts[0].ID != "ok" || len(kept.SplitterMergers) != 0 {
+		t.Errorf("got %+v with %d filtered, want only the ok belt and 2 filtered", kept, event.Filtered)
+	}
+
+	circuits := &models.SatisfactoryEvent{Type: models.SatisfactoryEventCircuits, Data: []models.Circuit{{ID: "1"}}}
+	if filterOutOfBounds(circuits, defaultCoordinateBound); circuits.Filtered != 0 || len(circuits.Data.([]models.Circuit)) != 1 {
+		t.Errorf("got %+v, want events without locations left as is", circuits)
+	}
+}
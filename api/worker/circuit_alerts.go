@@ -0,0 +1,169 @@
+package worker
+
+import (
+	"api/models/models"
+	"api/pkg/config"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	defaultAlertBatteryLowPercent   = 20.0
+	defaultAlertPowerDeficitPercent = 5.0
+	circuitAlertSustainPolls        = 2   // Consecutive circuit polls a condition must hold before it fires
+	batteryLowClearMargin           = 5.0 // Percentage points above the threshold the battery must recover to before the alert clears
+)
+
+type circuitAlertKey struct {
+	circuitID string
+	kind      models.AlertName
+}
+
+type pendingCircuitAlert struct {
+	since time.Time
+	polls int
+}
+
+// circuitAlertDetector evaluates each circuits poll for triggered fuses, low batteries and consumption
+// exceeding production. Conditions must hold for a few polls before they fire, and the low battery
+// alert only clears once the charge recovered past a margin, so a flapping value does not spam alerts.
+type circuitAlertDetector struct {
+	mu            sync.Mutex
+	now           func() time.Time
+	batteryLow    float64 // Percentage
+	deficitMargin float64 // Percentage
+	pending       map[circuitAlertKey]*pendingCircuitAlert
+	active        map[circuitAlertKey]models.Alert
+}
+
+func newCircuitAlertDetector(now func() time.Time, batteryLow, deficitMargin float64) *circuitAlertDetector {
+	return &circuitAlertDetector{
+		now:           now,
+		batteryLow:    batteryLow,
+		deficitMargin: deficitMargin,
+		pending:       make(map[circuitAlertKey]*pendingCircuitAlert),
+		active:        make(map[circuitAlertKey]models.Alert),
+	}
+}
+
+// newConfiguredCircuitAlertDetector creates a detector with the configured thresholds
+func newConfiguredCircuitAlertDetector() *circuitAlertDetector {
+	batteryLow := defaultAlertBatteryLowPercent
+	if config.Config.AlertBatteryLowPercent > 0 {
+		batteryLow = config.Config.AlertBatteryLowPercent
+	}
+	deficitMargin := defaultAlertPowerDeficitPercent
+	if config.Config.AlertPowerDeficitPercent > 0 {
+		deficitMargin = config.Config.AlertPowerDeficitPercent
+	}
+	return newCircuitAlertDetector(time.Now, batteryLow, deficitMargin)
+}
+
+// Observe evaluates a circuits event and returns the active alerts when an alert fired or cleared.
+// Other and partial events are ignored.
+func (detector *circuitAlertDetector) Observe(event *models.SatisfactoryEvent) ([]models.Alert, bool) {
+	circuits, ok := event.Data.([]models.Circuit)
+	if !ok || event.Partial {
+		return nil, false
+	}
+
+	detector.mu.Lock()
+	defer detector.mu.Unlock()
+
+	now := detector.now()
+	seen := make(map[circuitAlertKey]bool)
+	changed := false
+	for _, circuit := range circuits {
+		for _, candidate := range detector.evaluate(circuit) {
+			key := circuitAlertKey{circuitID: circuit.ID, kind: candidate.Kind}
+			seen[key] = true
+			if _, firing := detector.active[key]; firing {
+				continue
+			}
+
+			pending, ok := detector.pending[key]
+			if !ok {
+				pending = &pendingCircuitAlert{since: now}
+				detector.pending[key] = pending
+			}
+			pending.polls++
+			if pending.polls < circuitAlertSustainPolls {
+				continue
+			}
+
+			candidate.CircuitID = circuit.ID
+			candidate.Since = pending.since
+			detector.active[key] = candidate
+			delete(detector.pending, key)
+			changed = true
+		}
+	}
+
+	for key := range detector.pending {
+		if !seen[key] {
+			delete(detector.pending, key)
+		}
+	}
+	for key := range detector.active {
+		if !seen[key] {
+			delete(detector.active, key)
+			changed = true
+		}
+	}
+	if !changed {
+		return nil, false
+	}
+
+	alerts := make([]models.Alert, 0, len(detector.active))
+	for _, alert := range detector.active {
+		alerts = append(alerts, alert)
+	}
+	sort.Slice(alerts, func(i, j int) bool {
+		if alerts[i].CircuitID != alerts[j].CircuitID {
+			return alerts[i].CircuitID < alerts[j].CircuitID
+		}
+		return alerts[i].Kind < alerts[j].Kind
+	})
+	return alerts, true
+}
+
+// evaluate returns the conditions holding for the circuit. A low battery keeps holding while it is
+// active until the charge is past the clear margin.
+func (detector *circuitAlertDetector) evaluate(circuit models.Circuit) []models.Alert {
+	var alerts []models.Alert
+
+	if circuit.FuseTriggered {
+		alerts = append(alerts, models.Alert{
+			Severity: models.AlertSeverityCritical,
+			Kind:     models.AlertFuseTriggered,
+			Message:  fmt.Sprintf("Fuse triggered on circuit %s", circuit.ID),
+		})
+	}
+
+	if circuit.Battery.Capacity > 0 {
+		threshold := detector.batteryLow
+		if _, firing := detector.active[circuitAlertKey{circuitID: circuit.ID, kind: models.AlertBatteryLow}]; firing {
+			threshold += batteryLowClearMargin
+		}
+		if circuit.Battery.Percentage < threshold {
+			alerts = append(alerts, models.Alert{
+				Severity: models.AlertSeverityWarning,
+				Kind:     models.AlertBatteryLow,
+				Message:  fmt.Sprintf("Battery of circuit %s below %g%%", circuit.ID, detector.batteryLow),
+			})
+		}
+	}
+
+	consumption := circuit.Consumption.Total
+	if consumption > 0 && consumption > circuit.Production.Total*(1+detector.deficitMargin/100) {
+		alerts = append(alerts, models.Alert{
+			Severity: models.AlertSeverityWarning,
+			Kind:     models.AlertPowerDeficit,
+			Message:  fmt.Sprintf("Circuit %s consumes more power than it produces", circuit.ID),
+		})
+	}
+
+	return alerts
+}
@@ -0,0 +1,96 @@
+package worker
+
+import (
+	"api/models/models"
+	"testing"
+	"time"
+)
+
+func circuitsEvent(circuits ...models.Circuit) *models.SatisfactoryEvent {
+	return &models.SatisfactoryEvent{Type: models.SatisfactoryEventCircuits, Data: circuits}
+}
+
+func alertKinds(alerts []models.Alert) map[models.AlertName]bool {
+	kinds := make(map[models.AlertName]bool, len(alerts))
+	for _, alert := range alerts {
+		kinds[alert.Kind] = true
+	}
+	return kinds
+}
+
+func TestCircuitAlertDetector(t *testing.T) {
+	tripped := models.Circuit{
+		ID:            "1",
+		FuseTriggered: true,
+		Consumption:   models.CircuitConsumption{Total: 50e6},
+	}
+	healthy := models.Circuit{
+		ID:          "2",
+		Production:  models.CircuitProduction{Total: 100e6},
+		Consumption: models.CircuitConsumption{Total: 80e6},
+		Battery:     models.CircuitBattery{Capacity: 100e6, Percentage: 90},
+	}
+	lowBattery := models.Circuit{
+		ID:          "3",
+		Production:  models.CircuitProduction{Total: 100e6},
+		Consumption: models.CircuitConsumption{Total: 80e6},
+		Battery:     models.CircuitBattery{Capacity: 100e6, Percentage: 10},
+	}
+
+	tests := []struct {
+		name    string
+		circuit models.Circuit
+		want    []models.AlertName
+	}{
+		{"tripped zero production circuit", tripped, []models.AlertName{models.AlertFuseTriggered, models.AlertPowerDeficit}},
+		{"healthy circuit", healthy, nil},
+		{"low battery", lowBattery, []models.AlertName{models.AlertBatteryLow}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			detector := newCircuitAlertDetector(time.Now, defaultAlertBatteryLowPercent, defaultAlertPowerDeficitPercent)
+
+			if alerts, changed := detector.Observe(circuitsEvent(test.circuit)); changed {
+				t.Fatalf("first poll fired %v, conditions must be sustained", alerts)
+			}
+
+			alerts, changed := detector.Observe(circuitsEvent(test.circuit))
+			if len(test.want) == 0 {
+				if changed {
+					t.Fatalf("fired %v, want none", alerts)
+				}
+				return
+			}
+			if !changed {
+				t.Fatalf("second poll fired nothing, want %v", test.want)
+			}
+			kinds := alertKinds(alerts)
+			if len(kinds) != len(test.want) {
+				t.Fatalf("fired %v, want %v", alerts, test.want)
+			}
+			for _, kind := range test.want {
+				if !kinds[kind] {
+					t.Errorf("missing %s alert in %v", kind, alerts)
+				}
+			}
+		})
+	}
+}
+
+func TestCircuitAlertDetectorClears(t *testing.T) {
+	detector := newCircuitAlertDetector(time.Now, defaultAlertBatteryLowPercent, defaultAlertPowerDeficitPercent)
+	tripped := models.Circuit{ID: "1", FuseTriggered: true}
+
+	detector.Observe(circuitsEvent(tripped))
+	if _, changed := detector.Observe(circuitsEvent(tripped)); !changed {
+		t.Fatal("fuse alert did not fire")
+	}
+
+	tripped.FuseTriggered = false
+	tripped.Production.Total = 10e6
+	alerts, changed := detector.Observe(circuitsEvent(tripped))
+	if !changed || len(alerts) != 0 {
+		t.Fatalf("got %v changed=%v, want the alert cleared", alerts, changed)
+	}
+}
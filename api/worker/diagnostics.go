@@ -0,0 +1,294 @@
+package worker
+
+import (
+	"api/models/models"
+	"api/service/client"
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+var (
+	globalSessionManager   *SessionManager
+	globalSessionManagerMu sync.RWMutex
+)
+
+// SetGlobalSessionManager stores the session manager for access by API handlers.
+func SetGlobalSessionManager(sm *SessionManager) {
+	globalSessionManagerMu.Lock()
+	defer globalSessionManagerMu.Unlock()
+	globalSessionManager = sm
+}
+
+// GetGlobalSessionManager returns the global session manager instance.
+// Returns nil if the session manager has not been initialized.
+func GetGlobalSessionManager() *SessionManager {
+	globalSessionManagerMu.RLock()
+	defer globalSessionManagerMu.RUnlock()
+	return globalSessionManager
+}
+
+// clientDiagnostics is implemented by clients that can report their internal state
+type clientDiagnostics interface {
+	GetClientDiagnostics(ctx context.Context) (*models.ClientDiagnostics, error)
+}
+
+// DumpDiagnostics returns a snapshot of every publisher of this instance with the state of its
+// client and trackers. Sessions polled by other instances are not included.
+func (sm *SessionManager) DumpDiagnostics() models.DiagnosticsDump {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	dump := models.DiagnosticsDump{
+		InstanceID:  sm.leaseManager.InstanceID(),
+		GeneratedAt: time.Now(),
+		Publishers:  make([]models.PublisherDiagnostics, 0, len(sm.publishers)),
+	}
+	for sessionID, state := range sm.publishers {
+		dump.Publishers = append(dump.Publishers, models.PublisherDiagnostics{
+			SessionID:      sessionID,
+			SaveName:       state.GetSaveName(),
+			Disconnected:   state.isDisconnected,
+			LeaseOwned:     sm.leaseManager.IsOwned(sessionID),
+			LeaseUncertain: sm.leaseManager.IsUncertain(sessionID),
+			GameTimeID:     state.gameTimeTracker.CurrentGameTime(),
+			Client:         state.clientDiagnostics(),
+			Trackers:       state.trackerDiagnostics(),
+		})
+	}
+	sort.Slice(dump.Publishers, func(i, j int) bool {
+		return dump.Publishers[i].SessionID < dump.Publishers[j].SessionID
+	})
+	return dump
+}
+
+func (ps *publisherState) setClient(apiClient client.Client) {
+	ps.clientMu.Lock()
+	defer ps.clientMu.Unlock()
+	ps.client = apiClient
+}
+
+func (ps *publisherState) clientDiagnostics() *models.ClientDiagnostics {
+	ps.clientMu.Lock()
+	apiClient := ps.client
+	ps.clientMu.Unlock()
+
+	if apiClient == nil {
+		return nil
+	}
+	if reporter, ok := apiClient.(clientDiagnostics); ok {
+		if diagnostics, err := reporter.GetClientDiagnostics(context.Background()); err == nil {
+			return diagnostics
+		}
+	}
+	return &models.ClientDiagnostics{
+		FailureCount:   apiClient.GetFailureCount(),
+		Disconnected:   apiClient.IsDisconnected(),
+		EndpointErrors: apiClient.GetEndpointErrors(),
+	}
+}
+
+func (ps *publisherState) trackerDiagnostics() []models.TrackerDiagnostics {
+	restored := 0
+	ps.restoredHistory.Range(func(_, _ any) bool {
+		restored++
+		return true
+	})
+
+	return []models.TrackerDiagnostics{
+		ps.eventLog.diagnostics(),
+		ps.entities.diagnostics(),
+		ps.machineBuilds.diagnostics(),
+		ps.smoother.diagnostics(),
+		ps.stalls.diagnostics(),
+		ps.idleConveyors.diagnostics(),
+		ps.stuckStorage.diagnostics(),
+		ps.circuitAlerts.diagnostics(),
+		ps.shipTimer.diagnostics(),
+		ps.progression.diagnostics(),
+		ps.unchanged.diagnostics(),
+		ps.alertGrace.diagnostics(),
+		{Name: "historyArchive", Enabled: true, Entries: restored},
+	}
+}
+
+// oldestTime returns the earliest of the times, or nil if there are none
+func oldestTime[K comparable](times map[K]time.Time) *time.Time {
+	var oldest *time.Time
+	for _, t := range times {
+		if oldest == nil || t.Before(*oldest) {
+			t := t
+			oldest = &t
+		}
+	}
+	return oldest
+}
+
+func (detector *eventLogDetector) diagnostics() models.TrackerDiagnostics {
+	detector.mu.Lock()
+	defer detector.mu.Unlock()
+	return models.TrackerDiagnostics{
+		Name:    "eventLog",
+		Enabled: true,
+		Entries: len(detector.fusesTriggered) + len(detector.trainsDerailed) + len(detector.playersDead),
+		Details: map[string]any{"seededTypes": len(detector.seeded)},
+	}
+}
+
+func (tracker *entityTracker) diagnostics() models.TrackerDiagnostics {
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+	entries := 0
+	for _, kinds := range tracker.previous {
+		for _, keys := range kinds {
+			entries += len(keys)
+		}
+	}
+	return models.TrackerDiagnostics{
+		Name:    "tombstones",
+		Enabled: true,
+		Entries: entries,
+		Details: map[string]any{"eventTypes": len(tracker.previous)},
+	}
+}
+
+func (tracker *machineBuildTracker) diagnostics() models.TrackerDiagnostics {
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+	oldest := tracker.buildLog.TrackingSince
+	return models.TrackerDiagnostics{
+		Name:    "machineBuilds",
+		Enabled: true,
+		Entries: len(tracker.known),
+		Oldest:  &oldest,
+		Details: map[string]any{
+			"seeded":     tracker.seeded,
+			"missing":    len(tracker.missingSince),
+			"totalBuilt": tracker.buildLog.TotalBuilt,
+			"builds":     len(tracker.buildLog.Builds),
+		},
+	}
+}
+
+func (smoother *rateSmoother) diagnostics() models.TrackerDiagnostics {
+	if smoother == nil {
+		return models.TrackerDiagnostics{Name: "rateSmoothing"}
+	}
+	smoother.mu.Lock()
+	defer smoother.mu.Unlock()
+	entries := 0
+	for _, values := range smoother.values {
+		entries += len(values)
+	}
+	return models.TrackerDiagnostics{
+		Name:    "rateSmoothing",
+		Enabled: true,
+		Entries: entries,
+		Details: map[string]any{"alpha": smoother.alpha},
+	}
+}
+
+func (detector *stallDetector) diagnostics() models.TrackerDiagnostics {
+	detector.mu.Lock()
+	defer detector.mu.Unlock()
+	return models.TrackerDiagnostics{
+		Name:    "stalledVehicles",
+		Enabled: true,
+		Entries: len(detector.stillIn),
+		Oldest:  oldestTime(detector.stillIn),
+		Details: map[string]any{"reported": len(detector.reported), "stationsKnown": detector.stations != nil},
+	}
+}
+
+func (detector *idleConveyorDetector) diagnostics() models.TrackerDiagnostics {
+	detector.mu.Lock()
+	defer detector.mu.Unlock()
+	return models.TrackerDiagnostics{
+		Name:    "idleConveyors",
+		Enabled: true,
+		Entries: len(detector.tracked),
+		Details: map[string]any{"reported": len(detector.reported)},
+	}
+}
+
+func (detector *stuckStorageDetector) diagnostics() models.TrackerDiagnostics {
+	detector.mu.Lock()
+	defer detector.mu.Unlock()
+	since := make(map[string]time.Time, len(detector.growth))
+	for name, growth := range detector.growth {
+		since[name] = growth.since
+	}
+	return models.TrackerDiagnostics{
+		Name:    "stuckStorage",
+		Enabled: true,
+		Entries: len(detector.growth),
+		Oldest:  oldestTime(since),
+		Details: map[string]any{"starved": len(detector.starved), "reported": len(detector.reported)},
+	}
+}
+
+func (detector *circuitAlertDetector) diagnostics() models.TrackerDiagnostics {
+	detector.mu.Lock()
+	defer detector.mu.Unlock()
+	since := make(map[circuitAlertKey]time.Time, len(detector.active))
+	for key, alert := range detector.active {
+		since[key] = alert.Since
+	}
+	return models.TrackerDiagnostics{
+		Name:    "circuitAlerts",
+		Enabled: true,
+		Entries: len(detector.active),
+		Oldest:  oldestTime(since),
+		Details: map[string]any{"pending": len(detector.pending), "batteryLowPercent": detector.batteryLow, "powerDeficitPercent": detector.deficitMargin},
+	}
+}
+
+func (tracker *shipTimerTracker) diagnostics() models.TrackerDiagnostics {
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+	return models.TrackerDiagnostics{
+		Name:    "shipTimer",
+		Enabled: true,
+		Details: map[string]any{"known": tracker.known, "docked": tracker.docked, "returnTime": tracker.returnTime},
+	}
+}
+
+func (tracker *progressionTracker) diagnostics() models.TrackerDiagnostics {
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+	return models.TrackerDiagnostics{
+		Name:    "progression",
+		Enabled: true,
+		Entries: len(tracker.unlocked),
+		Details: map[string]any{
+			"schematicsSeeded": tracker.schematicsSeeded,
+			"elevatorSeeded":   tracker.elevatorSeeded,
+			"purchased":        len(tracker.purchased),
+		},
+	}
+}
+
+func (filter *unchangedFilter) diagnostics() models.TrackerDiagnostics {
+	if filter == nil {
+		return models.TrackerDiagnostics{Name: "unchangedFilter"}
+	}
+	filter.mu.Lock()
+	defer filter.mu.Unlock()
+	return models.TrackerDiagnostics{
+		Name:    "unchangedFilter",
+		Enabled: true,
+		Entries: len(filter.last),
+	}
+}
+
+func (grace *alertGrace) diagnostics() models.TrackerDiagnostics {
+	grace.mu.Lock()
+	defer grace.mu.Unlock()
+	return models.TrackerDiagnostics{
+		Name:    "alertGrace",
+		Enabled: true,
+		Entries: len(grace.held),
+		Details: map[string]any{"active": grace.activeLocked(), "until": grace.until},
+	}
+}
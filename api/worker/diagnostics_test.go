@@ -0,0 +1,111 @@
+package worker
+
+import (
+	"api/models/models"
+	"api/pkg/config"
+	"api/pkg/db/key_value"
+	"api/service/client"
+	"api/service/lease"
+	"api/service/session"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// failingClient reports its state through the basic client methods only
+type failingClient struct {
+	client.Client
+}
+
+func (failingClient) GetFailureCount() int { return 3 }
+func (failingClient) IsDisconnected() bool { return true }
+func (failingClient) GetEndpointErrors() []models.EndpointError {
+	return []models.EndpointError{{EventType: models.SatisfactoryEventBelts, Message: "timeout"}}
+}
+
+func newTestPublisherState(saveName string) *publisherState {
+	return &publisherState{
+		currentSaveName: saveName,
+		gameTimeTracker: session.NewGameTimeTracker(),
+		eventLog:        newEventLogDetector(time.Now),
+		entities:        newEntityTracker(),
+		machineBuilds:   newMachineBuildTracker(time.Now, models.MachineBuildLog{}),
+		smoother:        newConfiguredRateSmoother(),
+		stalls:          newStallDetector(time.Now, vehicleStallThreshold()),
+		idleConveyors:   newIdleConveyorDetector(time.Now, idleConveyorPolls),
+		shipTimer:       newShipTimerTracker(),
+		stuckStorage:    newStuckStorageDetector(time.Now, stuckStorageSustain),
+		circuitAlerts:   newConfiguredCircuitAlertDetector(),
+		progression:     newProgressionTracker(time.Now),
+		alertGrace:      newAlertGrace(time.Now, time.Minute),
+		unchanged:       newConfiguredUnchangedFilter(),
+	}
+}
+
+func TestDumpDiagnostics(t *testing.T) {
+	previous := config.Config
+	config.Config = &config.Type{}
+	t.Cleanup(func() { config.Config = previous })
+
+	server := miniredis.RunT(t)
+	redisClient := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = redisClient.Close() })
+	leaseManager := lease.NewLeaseManagerWithID("node-a", &key_value.Client{RedisClient: redisClient}, lease.DefaultLeaseConfig(), zap.NewNop())
+
+	connected := newTestPublisherState("First Save")
+	connected.setClient(failingClient{})
+	connected.restoredHistory.Store("First Save:circuits", true)
+	connected.restoredHistory.Store("First Save:prodStats", true)
+	connected.alertGrace.Hold(models.SatisfactoryEvent{Type: models.SatisfactoryEventIdleConveyors})
+	starting := newTestPublisherState("Second Save")
+
+	sm := &SessionManager{
+		leaseManager: leaseManager,
+		publishers:   map[string]*publisherState{"b": starting, "a": connected},
+	}
+	dump := sm.DumpDiagnostics()
+
+	if dump.InstanceID != "node-a" || len(dump.Publishers) != 2 {
+		t.Fatalf("got %+v, want both publishers of node-a", dump)
+	}
+	first, second := dump.Publishers[0], dump.Publishers[1]
+	if first.SessionID != "a" || first.SaveName != "First Save" || second.SessionID != "b" {
+		t.Errorf("got publishers %s and %s, want them ordered by session", first.SessionID, second.SessionID)
+	}
+	if second.Client != nil {
+		t.Errorf("got client %+v before the publisher created one, want none", second.Client)
+	}
+	if diagnostics := first.Client; diagnostics == nil || diagnostics.FailureCount != 3 || !diagnostics.Disconnected || len(diagnostics.EndpointErrors) != 1 {
+		t.Errorf("got client %+v, want the state of the basic client methods", diagnostics)
+	}
+
+	trackers := make(map[string]models.TrackerDiagnostics)
+	for _, tracker := range first.Trackers {
+		trackers[tracker.Name] = tracker
+	}
+	names := []string{"eventLog", "tombstones", "machineBuilds", "rateSmoothing", "stalledVehicles", "idleConveyors",
+		"stuckStorage", "circuitAlerts", "shipTimer", "progression", "unchangedFilter", "alertGrace", "historyArchive"}
+	if len(trackers) != len(names) {
+		t.Errorf("got %d trackers, want %d", len(trackers), len(names))
+	}
+	for _, name := range names {
+		if _, ok := trackers[name]; !ok {
+			t.Errorf("got no %s tracker", name)
+		}
+	}
+	// Optional trackers are listed but disabled
+	for _, name := range []string{"rateSmoothing", "unchangedFilter"} {
+		if trackers[name].Enabled {
+			t.Errorf("got %s enabled, want it disabled by default", name)
+		}
+	}
+	if entries := trackers["historyArchive"].Entries; entries != 2 {
+		t.Errorf("got %d restored history series, want 2", entries)
+	}
+	if grace := trackers["alertGrace"]; grace.Entries != 1 || grace.Details["active"] != true {
+		t.Errorf("got %+v, want the active grace period holding one alert", grace)
+	}
+}
@@ -0,0 +1,80 @@
+package worker
+
+import (
+	"api/models/models"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// eventLogDetector turns polled state into event log entries by detecting transitions.
+// The first observation of each event type only seeds the previous state, so a restart
+// does not log everything that is already true. Progression entries come from the progressionTracker.
+type eventLogDetector struct {
+	mu  sync.Mutex
+	now func() time.Time
+
+	seeded         map[models.SatisfactoryEventType]bool
+	fusesTriggered map[string]bool
+	trainsDerailed map[string]bool
+	playersDead    map[string]bool
+}
+
+func newEventLogDetector(now func() time.Time) *eventLogDetector {
+	return &eventLogDetector{
+		now:            now,
+		seeded:         make(map[models.SatisfactoryEventType]bool),
+		fusesTriggered: make(map[string]bool),
+		trainsDerailed: make(map[string]bool),
+		playersDead:    make(map[string]bool),
+	}
+}
+
+// Observe returns the event log entries caused by the event, in order of detection.
+func (detector *eventLogDetector) Observe(event *models.SatisfactoryEvent) []models.EventLogEntry {
+	detector.mu.Lock()
+	defer detector.mu.Unlock()
+
+	var entries []models.EventLogEntry
+	add := func(entryType models.EventLogEntryType, format string, args ...any) {
+		entries = append(entries, models.EventLogEntry{
+			Timestamp:   detector.now(),
+			Type:        entryType,
+			Description: fmt.Sprintf(format, args...),
+		})
+	}
+
+	switch data := event.Data.(type) {
+	case []models.Circuit:
+		for _, circuit := range data {
+			if circuit.FuseTriggered && !detector.fusesTriggered[circuit.ID] {
+				add(models.EventLogEntryFuseTriggered, "Fuse triggered on circuit %s", circuit.ID)
+			}
+			detector.fusesTriggered[circuit.ID] = circuit.FuseTriggered
+		}
+	case models.Vehicles:
+		for _, train := range data.Trains {
+			derailed := train.Status == models.TrainStatusDerailed
+			if derailed && !detector.trainsDerailed[train.ID] {
+				add(models.EventLogEntryTrainDerailed, "Train %s derailed", train.Name)
+			}
+			detector.trainsDerailed[train.ID] = derailed
+		}
+	case []models.Player:
+		for _, player := range data {
+			dead := player.Health <= 0
+			if dead && !detector.playersDead[player.ID] {
+				add(models.EventLogEntryPlayerDied, "Player %s died", player.Name)
+			}
+			detector.playersDead[player.ID] = dead
+		}
+	default:
+		return nil
+	}
+
+	if !detector.seeded[event.Type] {
+		detector.seeded[event.Type] = true
+		return nil
+	}
+	return entries
+}
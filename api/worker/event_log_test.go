@@ -0,0 +1,58 @@
+package worker
+
+import (
+	"api/models/models"
+	"testing"
+	"time"
+)
+
+func TestEventLogDetectorLogsTransitions(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	detector := newEventLogDetector(func() time.Time { return now })
+	trains := func(statuses ...models.TrainStatus) *models.SatisfactoryEvent {
+		vehicles := models.Vehicles{}
+		for i, status := range statuses {
+			vehicles.Trains = append(vehicles.Trains, models.Train{ID: string(rune('a' + i)), Name: string(rune('A' + i)), Status: status})
+		}
+		return &models.SatisfactoryEvent{Type: models.SatisfactoryEventVehicles, Data: vehicles}
+	}
+	descriptions := func(entries []models.EventLogEntry) []string {
+		result := make([]string, len(entries))
+		for i, entry := range entries {
+			result[i] = entry.Description
+		}
+		return result
+	}
+
+	steps := []struct {
+		name  string
+		event *models.SatisfactoryEvent
+		want  []string
+	}{
+		{"first poll only seeds", circuitsEvent(models.Circuit{ID: "1", FuseTriggered: true}), nil},
+		{"fuse still tripped", circuitsEvent(models.Circuit{ID: "1", FuseTriggered: true}), nil},
+		{"fuse reset", circuitsEvent(models.Circuit{ID: "1"}), nil},
+		{"fuse tripped again", circuitsEvent(models.Circuit{ID: "1", FuseTriggered: true}), []string{"Fuse triggered on circuit 1"}},
+		{"trains seeded separately", trains(models.TrainStatusDerailed, models.TrainStatusSelfDriving), nil},
+		{"second train derails", trains(models.TrainStatusDerailed, models.TrainStatusDerailed), []string{"Train B derailed"}},
+		{"unrelated event", &models.SatisfactoryEvent{Type: models.SatisfactoryEventProdStats, Data: models.ProdStats{}}, nil},
+	}
+
+	for _, step := range steps {
+		entries := detector.Observe(step.event)
+		got := descriptions(entries)
+		if len(got) != len(step.want) {
+			t.Fatalf("%s: got %v, want %v", step.name, got, step.want)
+		}
+		for i := range got {
+			if got[i] != step.want[i] {
+				t.Errorf("%s: got %v, want %v", step.name, got, step.want)
+			}
+		}
+		for _, entry := range entries {
+			if !entry.Timestamp.Equal(now) {
+				t.Errorf("%s: got timestamp %v, want %v", step.name, entry.Timestamp, now)
+			}
+		}
+	}
+}
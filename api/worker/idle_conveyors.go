@@ -0,0 +1,108 @@
+package worker
+
+import (
+	"api/models/models"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+)
+
+// idleConveyorPolls is how many consecutive polls a connected conveyor must carry nothing before it is reported
+const idleConveyorPolls = 5
+
+// idleConveyorDetector counts the consecutive polls in which belts and pipes connected at both ends
+// carry nothing, so dead sections of a working-looking network can be reported. Disconnected
+// conveyors are left to the connectivity analysis.
+type idleConveyorDetector struct {
+	mu       sync.Mutex
+	now      func() time.Time
+	minPolls int
+	tracked  map[string]*models.IdleConveyor // Kind:ID -> latest conveyor with its idle polls and last flow
+	reported []string                        // Sorted keys of the last reported idle conveyors
+}
+
+func newIdleConveyorDetector(now func() time.Time, minPolls int) *idleConveyorDetector {
+	return &idleConveyorDetector{
+		now:      now,
+		minPolls: minPolls,
+		tracked:  make(map[string]*models.IdleConveyor),
+	}
+}
+
+// Observe updates the counts from a belts or pipes event and returns all idle conveyors, of both
+// kinds, when that set changed since the last report. Partial events are ignored.
+func (detector *idleConveyorDetector) Observe(event *models.SatisfactoryEvent) ([]models.IdleConveyor, bool) {
+	if event.Partial {
+		return nil, false
+	}
+
+	var conveyors []models.IdleConveyor
+	var flows []float64
+	var kind models.ConveyorKind
+	switch data := event.Data.(type) {
+	case models.Belts:
+		kind = models.ConveyorKindBelt
+		for _, belt := range data.Belts {
+			if belt.Connected0 && belt.Connected1 {
+				conveyors = append(conveyors, models.IdleConveyor{ID: belt.ID, Name: belt.Name, Kind: kind, Location0: belt.Location0, Location1: belt.Location1})
+				flows = append(flows, belt.ItemsPerMinute)
+			}
+		}
+	case models.Pipes:
+		kind = models.ConveyorKindPipe
+		for _, pipe := range data.Pipes {
+			if pipe.Connected0 && pipe.Connected1 {
+				conveyors = append(conveyors, models.IdleConveyor{ID: pipe.ID, Name: pipe.Name, Kind: kind, Location0: pipe.Location0, Location1: pipe.Location1})
+				flows = append(flows, pipe.ItemsPerMinute)
+			}
+		}
+	default:
+		return nil, false
+	}
+
+	detector.mu.Lock()
+	defer detector.mu.Unlock()
+
+	now := detector.now()
+	seen := make(map[string]bool, len(conveyors))
+	for idx, conveyor := range conveyors {
+		key := string(kind) + ":" + conveyor.ID
+		seen[key] = true
+		previous, ok := detector.tracked[key]
+		if ok {
+			conveyor.IdlePolls = previous.IdlePolls
+			conveyor.LastFlowAt = previous.LastFlowAt
+		}
+		if flows[idx] != 0 {
+			conveyor.IdlePolls = 0
+			conveyor.LastFlowAt = &now
+		} else {
+			conveyor.IdlePolls++
+		}
+		detector.tracked[key] = &conveyor
+	}
+	for key, conveyor := range detector.tracked {
+		if conveyor.Kind == kind && !seen[key] {
+			delete(detector.tracked, key)
+		}
+	}
+
+	keys := make([]string, 0)
+	for key, conveyor := range detector.tracked {
+		if conveyor.IdlePolls >= detector.minPolls {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	if slices.Equal(keys, detector.reported) {
+		return nil, false
+	}
+	detector.reported = keys
+
+	idle := make([]models.IdleConveyor, 0, len(keys))
+	for _, key := range keys {
+		idle = append(idle, *detector.tracked[key])
+	}
+	return idle, true
+}
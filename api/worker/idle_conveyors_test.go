@@ -0,0 +1,86 @@
+package worker
+
+import (
+	"api/models/models"
+	"testing"
+	"time"
+)
+
+func TestIdleConveyorDetectorWaitsForSeveralPolls(t *testing.T) {
+	// The threshold counts polls, so the clock never moves
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	detector := newIdleConveyorDetector(func() time.Time { return start }, 3)
+	belt := func(id string, perMinute float64) models.Belt {
+		return models.Belt{ID: id, Connected0: true, Connected1: true, ItemsPerMinute: perMinute}
+	}
+	dangling := models.Belt{ID: "dangling", Connected0: true} // Left to the connectivity analysis
+	beltsPoll := func(belts ...models.Belt) *models.SatisfactoryEvent {
+		return &models.SatisfactoryEvent{Type: models.SatisfactoryEventBelts, Data: models.Belts{Belts: belts}}
+	}
+	pipesPoll := func(pipes ...models.Pipe) *models.SatisfactoryEvent {
+		return &models.SatisfactoryEvent{Type: models.SatisfactoryEventPipes, Data: models.Pipes{Pipes: pipes}}
+	}
+	emptyPipe := models.Pipe{ID: "empty", Connected0: true, Connected1: true}
+
+	steps := []struct {
+		name        string
+		event       *models.SatisfactoryEvent
+		wantIdle    []string
+		wantChanged bool
+	}{
+		{"first poll", beltsPoll(belt("dead", 0), belt("stopping", 60), dangling), nil, false},
+		{"second poll", beltsPoll(belt("dead", 0), belt("stopping", 0), dangling), nil, false},
+		{"threshold reached", beltsPoll(belt("dead", 0), belt("stopping", 0), dangling), []string{"dead"}, true},
+		{"partial poll is ignored", &models.SatisfactoryEvent{Type: models.SatisfactoryEventBelts, Data: models.Belts{}, Partial: true}, nil, false},
+		{"second belt reaches it", beltsPoll(belt("dead", 0), belt("stopping", 0), dangling), []string{"dead", "stopping"}, true},
+		{"pipes counted separately", pipesPoll(emptyPipe), nil, false},
+		{"pipe reaches it", pipesPoll(emptyPipe), nil, false},
+		{"both kinds reported", pipesPoll(emptyPipe), []string{"dead", "stopping", "empty"}, true},
+		{"flow resets the count", beltsPoll(belt("dead", 0), belt("stopping", 30)), []string{"dead", "empty"}, true},
+	}
+
+	var idle []models.IdleConveyor
+	for _, step := range steps {
+		got, changed := detector.Observe(step.event)
+		if changed != step.wantChanged {
+			t.Errorf("%s: got changed %v, want %v", step.name, changed, step.wantChanged)
+		}
+		if !changed {
+			continue
+		}
+		idle = got
+		if len(got) != len(step.wantIdle) {
+			t.Errorf("%s: got %+v, want %v", step.name, got, step.wantIdle)
+			continue
+		}
+		for i, conveyor := range got {
+			if conveyor.ID != step.wantIdle[i] {
+				t.Errorf("%s: got %+v, want %v", step.name, got, step.wantIdle)
+			}
+		}
+	}
+
+	dead := idle[0]
+	if dead.Kind != models.ConveyorKindBelt || dead.IdlePolls != 5 || dead.LastFlowAt != nil {
+		t.Errorf("got %+v, want a belt idle for 5 belt polls that never carried anything", dead)
+	}
+}
+
+func TestIdleConveyorDetectorRemembersLastFlow(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := start
+	detector := newIdleConveyorDetector(func() time.Time { return now }, 2)
+	poll := func(perMinute float64) ([]models.IdleConveyor, bool) {
+		belts := models.Belts{Belts: []models.Belt{{ID: "belt", Connected0: true, Connected1: true, ItemsPerMinute: perMinute}}}
+		idle, changed := detector.Observe(&models.SatisfactoryEvent{Type: models.SatisfactoryEventBelts, Data: belts})
+		now = now.Add(time.Minute)
+		return idle, changed
+	}
+
+	poll(120)
+	poll(0)
+	idle, changed := poll(0)
+	if !changed || len(idle) != 1 || idle[0].LastFlowAt == nil || !idle[0].LastFlowAt.Equal(start) {
+		t.Errorf("got %+v, want the belt reported with its last flow at %v", idle, start)
+	}
+}
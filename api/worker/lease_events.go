@@ -0,0 +1,65 @@
+package worker
+
+import (
+	"api/service/lease"
+	"context"
+	"sync"
+)
+
+// leaseEventHistory is the number of recent lease events kept for the nodes API.
+const leaseEventHistory = 200
+
+// leaseEventRecorder drains the lease manager's event channel into a bounded history,
+// so the renewal loop never has to drop events for lack of a reader.
+type leaseEventRecorder struct {
+	mu     sync.RWMutex
+	size   int
+	events []lease.LeaseEvent
+}
+
+func newLeaseEventRecorder(size int) *leaseEventRecorder {
+	return &leaseEventRecorder{size: size}
+}
+
+var globalLeaseEvents = newLeaseEventRecorder(leaseEventHistory)
+
+// Run records events until the context is cancelled or the channel is closed.
+func (recorder *leaseEventRecorder) Run(ctx context.Context, events <-chan lease.LeaseEvent) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			recorder.record(event)
+		}
+	}
+}
+
+func (recorder *leaseEventRecorder) record(event lease.LeaseEvent) {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+
+	recorder.events = append(recorder.events, event)
+	if overflow := len(recorder.events) - recorder.size; overflow > 0 {
+		recorder.events = append(recorder.events[:0], recorder.events[overflow:]...)
+	}
+}
+
+// Recent returns a copy of the recorded events, oldest first.
+func (recorder *leaseEventRecorder) Recent() []lease.LeaseEvent {
+	recorder.mu.RLock()
+	defer recorder.mu.RUnlock()
+
+	events := make([]lease.LeaseEvent, len(recorder.events))
+	copy(events, recorder.events)
+	return events
+}
+
+// RecentLeaseEvents returns the lease events of this instance seen since startup,
+// oldest first and at most leaseEventHistory of them.
+func RecentLeaseEvents() []lease.LeaseEvent {
+	return globalLeaseEvents.Recent()
+}
@@ -0,0 +1,45 @@
+package worker
+
+import (
+	"api/service/lease"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestLeaseEventRecorder(t *testing.T) {
+	const size = 3
+	recorder := newLeaseEventRecorder(size)
+	events := make(chan lease.LeaseEvent)
+	done := make(chan struct{})
+	go func() {
+		recorder.Run(context.Background(), events)
+		close(done)
+	}()
+
+	for i := range size + 2 {
+		events <- lease.LeaseEvent{Type: lease.LeaseEventAcquired, SessionID: fmt.Sprintf("session-%d", i)}
+	}
+	close(events)
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("recorder did not stop when the channel closed")
+	}
+
+	recent := recorder.Recent()
+	if len(recent) != size {
+		t.Fatalf("got %d events, want %d", len(recent), size)
+	}
+	for i, event := range recent {
+		if want := fmt.Sprintf("session-%d", i+2); event.SessionID != want {
+			t.Errorf("event %d is for %s, want %s", i, event.SessionID, want)
+		}
+	}
+
+	recent[0].SessionID = "changed"
+	if recorder.Recent()[0].SessionID == "changed" {
+		t.Error("Recent returned the recorder's own slice")
+	}
+}
@@ -0,0 +1,114 @@
+package worker
+
+import (
+	"api/models/models"
+	"api/pkg/log"
+	"api/service/session"
+	"sync"
+	"time"
+)
+
+const (
+	// machineRebuildGrace is how long a machine may be missing and still count as the same machine
+	// when one reappears at its location, so rebuilding in place is not a new build
+	machineRebuildGrace = 5 * time.Minute
+
+	maxMachineBuilds      = 1000
+	maxMachineCountPoints = 500
+)
+
+// machineBuildTracker records when machines are first seen, identified by type and location.
+// Machines present at the first observation are the baseline and not counted as builds.
+type machineBuildTracker struct {
+	mu     sync.Mutex
+	now    func() time.Time
+	seeded bool
+
+	known        map[string]bool
+	missingSince map[string]time.Time
+	buildLog     models.MachineBuildLog
+}
+
+// newMachineBuildTracker creates a tracker continuing the stored build log, so tracking survives
+// the session moving to another instance. Machines built while no instance polled become baseline.
+func newMachineBuildTracker(now func() time.Time, buildLog models.MachineBuildLog) *machineBuildTracker {
+	return &machineBuildTracker{
+		now:          now,
+		known:        make(map[string]bool),
+		missingSince: make(map[string]time.Time),
+		buildLog:     buildLog,
+	}
+}
+
+// loadMachineBuildTracker creates a tracker continuing the session's stored build log, starting over if it cannot be read.
+func loadMachineBuildTracker(sessionID string) *machineBuildTracker {
+	buildLog, err := session.GetMachineBuildLog(sessionID)
+	if err != nil {
+		log.Warnf("Failed to load machine build log for session %s, starting over: %v", sessionID, err)
+	}
+	return newMachineBuildTracker(time.Now, buildLog)
+}
+
+// Observe records one poll of machines and returns the build log, and whether it changed.
+func (tracker *machineBuildTracker) Observe(machines []models.Machine) (models.MachineBuildLog, bool) {
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+
+	now := tracker.now()
+	seeding := !tracker.seeded
+	tracker.seeded = true
+	if tracker.buildLog.TrackingSince.IsZero() {
+		tracker.buildLog.TrackingSince = now
+	}
+	changed := seeding
+
+	present := make(map[string]bool, len(machines))
+	for _, machine := range machines {
+		key := machine.ID
+		present[key] = true
+		delete(tracker.missingSince, key)
+		if tracker.known[key] {
+			continue
+		}
+
+		tracker.known[key] = true
+		if seeding {
+			continue
+		}
+		tracker.buildLog.TotalBuilt++
+		tracker.buildLog.Builds = append(tracker.buildLog.Builds, models.MachineBuild{
+			Type:      machine.Type,
+			FirstSeen: now,
+			Location:  machine.Location,
+		})
+		changed = true
+	}
+
+	for key := range tracker.known {
+		if present[key] {
+			continue
+		}
+		missingSince, missing := tracker.missingSince[key]
+		if !missing {
+			tracker.missingSince[key] = now
+		} else if now.Sub(missingSince) > machineRebuildGrace {
+			delete(tracker.known, key)
+			delete(tracker.missingSince, key)
+		}
+	}
+
+	counts := tracker.buildLog.Counts
+	if len(counts) == 0 || counts[len(counts)-1].Count != len(machines) {
+		tracker.buildLog.Counts = append(counts, models.MachineCountPoint{Timestamp: now, Count: len(machines)})
+		changed = true
+	}
+
+	if len(tracker.buildLog.Builds) > maxMachineBuilds {
+		tracker.buildLog.Builds = tracker.buildLog.Builds[len(tracker.buildLog.Builds)-maxMachineBuilds:]
+	}
+	if len(tracker.buildLog.Counts) > maxMachineCountPoints {
+		tracker.buildLog.Counts = tracker.buildLog.Counts[len(tracker.buildLog.Counts)-maxMachineCountPoints:]
+	}
+
+	return tracker.buildLog, changed
+}
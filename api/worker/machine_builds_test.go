@@ -0,0 +1,73 @@
+package worker
+
+import (
+	"api/models/models"
+	"testing"
+	"time"
+)
+
+func TestMachineBuildTrackerRecordsFirstSeen(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := start
+	tracker := newMachineBuildTracker(func() time.Time { return now }, models.MachineBuildLog{})
+	constructor := func(id string) models.Machine {
+		return models.Machine{ID: id, Type: models.MachineTypeConstructor}
+	}
+
+	steps := []struct {
+		name      string
+		after     time.Duration
+		machines  []models.Machine
+		wantBuilt int
+	}{
+		{"baseline is not counted", 0, []models.Machine{constructor("a"), constructor("b")}, 0},
+		{"new machine", time.Minute, []models.Machine{constructor("a"), constructor("b"), constructor("c")}, 1},
+		{"machine dismantled", 2 * time.Minute, []models.Machine{constructor("a"), constructor("c")}, 1},
+		{"rebuilt in place within the grace", 4 * time.Minute, []models.Machine{constructor("a"), constructor("b"), constructor("c")}, 1},
+		{"dismantled for good", 5 * time.Minute, []models.Machine{constructor("a"), constructor("c")}, 1},
+		{"grace expires", 11 * time.Minute, []models.Machine{constructor("a"), constructor("c")}, 1},
+		{"rebuilt after the grace", 12 * time.Minute, []models.Machine{constructor("a"), constructor("b"), constructor("c")}, 2},
+	}
+
+	var buildLog models.MachineBuildLog
+	for _, step := range steps {
+		now = start.Add(step.after)
+		buildLog, _ = tracker.Observe(step.machines)
+		if buildLog.TotalBuilt != step.wantBuilt {
+			t.Errorf("%s: got %d built, want %d", step.name, buildLog.TotalBuilt, step.wantBuilt)
+		}
+	}
+
+	if !buildLog.TrackingSince.Equal(start) {
+		t.Errorf("got tracking since %v, want %v", buildLog.TrackingSince, start)
+	}
+	if len(buildLog.Builds) != 2 || !buildLog.Builds[0].FirstSeen.Equal(start.Add(time.Minute)) || !buildLog.Builds[1].FirstSeen.Equal(start.Add(12*time.Minute)) {
+		t.Errorf("got builds %+v, want the new machine at 1m and the rebuild at 12m", buildLog.Builds)
+	}
+	// Only polls that changed the machine count add a point
+	wantCounts := []int{2, 3, 2, 3, 2, 3}
+	if len(buildLog.Counts) != len(wantCounts) {
+		t.Fatalf("got %d count points, want %d", len(buildLog.Counts), len(wantCounts))
+	}
+	for i, point := range buildLog.Counts {
+		if point.Count != wantCounts[i] {
+			t.Errorf("count point %d: got %d, want %d", i, point.Count, wantCounts[i])
+		}
+	}
+
+	if _, changed := tracker.Observe([]models.Machine{constructor("a"), constructor("b"), constructor("c")}); changed {
+		t.Error("got a change from an identical poll, want none")
+	}
+}
+
+func TestMachineBuildTrackerContinuesStoredLog(t *testing.T) {
+	since := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	stored := models.MachineBuildLog{TrackingSince: since, TotalBuilt: 7}
+	tracker := newMachineBuildTracker(func() time.Time { return since.Add(time.Hour) }, stored)
+
+	// Machines built while no instance polled become the new baseline
+	buildLog, _ := tracker.Observe([]models.Machine{{ID: "a"}, {ID: "b"}})
+	if buildLog.TotalBuilt != 7 || !buildLog.TrackingSince.Equal(since) {
+		t.Errorf("got %d built tracking since %v, want the stored 7 since %v", buildLog.TotalBuilt, buildLog.TrackingSince, since)
+	}
+}
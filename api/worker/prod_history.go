@@ -0,0 +1,15 @@
+package worker
+
+import (
+	"api/pkg/config"
+)
+
+const defaultProdHistorySamples = 60
+
+// prodHistorySamples returns the configured number of production samples kept per item
+func prodHistorySamples() int {
+	if config.Config.ProdHistorySamples > 0 {
+		return config.Config.ProdHistorySamples
+	}
+	return defaultProdHistorySamples
+}
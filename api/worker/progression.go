@@ -0,0 +1,112 @@
+package worker
+
+import (
+	"api/models/models"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// progressionTracker detects schematics being unlocked, milestones being completed and space
+// elevator phases being delivered between polls. The first observation of schematics and of the
+// space elevator only seeds the previous state, so a restart reports nothing already unlocked.
+type progressionTracker struct {
+	mu  sync.Mutex
+	now func() time.Time
+
+	schematicsSeeded bool
+	unlocked         map[string]bool
+	purchased        map[string]bool
+	elevatorSeeded   bool
+	phaseObjectives  string
+	fullyUpgraded    bool
+}
+
+func newProgressionTracker(now func() time.Time) *progressionTracker {
+	return &progressionTracker{
+		now:       now,
+		unlocked:  make(map[string]bool),
+		purchased: make(map[string]bool),
+	}
+}
+
+// Observe returns the progressions caused by a schematics or space elevator event. Partial events are ignored.
+func (tracker *progressionTracker) Observe(event *models.SatisfactoryEvent) []models.Progression {
+	if event.Partial {
+		return nil
+	}
+
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+
+	var progressions []models.Progression
+	add := func(kind models.ProgressionKind, name string, tier int) {
+		progressions = append(progressions, models.Progression{Kind: kind, Name: name, Tier: tier, Timestamp: tracker.now()})
+	}
+
+	switch data := event.Data.(type) {
+	case []models.Schematic:
+		for _, schematic := range data {
+			unlocked := !schematic.Locked
+			if unlocked && !tracker.unlocked[schematic.ID] {
+				add(models.ProgressionSchematicUnlocked, schematic.Name, schematic.Tier)
+			}
+			if schematic.Purchased && !tracker.purchased[schematic.ID] {
+				add(models.ProgressionMilestoneCompleted, schematic.Name, schematic.Tier)
+			}
+			tracker.unlocked[schematic.ID] = unlocked
+			tracker.purchased[schematic.ID] = schematic.Purchased
+		}
+		if !tracker.schematicsSeeded {
+			tracker.schematicsSeeded = true
+			return nil
+		}
+	case *models.SpaceElevator:
+		if data == nil {
+			return nil
+		}
+		// A new set of objectives means the previous phase was delivered
+		names := make([]string, len(data.CurrentPhase))
+		for i, objective := range data.CurrentPhase {
+			names[i] = objective.Name
+		}
+		objectives := strings.Join(names, ", ")
+		if (tracker.phaseObjectives != "" && objectives != tracker.phaseObjectives) || (data.FullyUpgraded && !tracker.fullyUpgraded) {
+			add(models.ProgressionPhaseCompleted, tracker.phaseObjectives, 0)
+		}
+		tracker.phaseObjectives = objectives
+		tracker.fullyUpgraded = data.FullyUpgraded
+		if !tracker.elevatorSeeded {
+			tracker.elevatorSeeded = true
+			return nil
+		}
+	}
+	return progressions
+}
+
+// progressionLogEntries turns progressions into event log entries
+func progressionLogEntries(progressions []models.Progression) []models.EventLogEntry {
+	entries := make([]models.EventLogEntry, 0, len(progressions))
+	for _, progression := range progressions {
+		var entryType models.EventLogEntryType
+		var description string
+		switch progression.Kind {
+		case models.ProgressionSchematicUnlocked:
+			entryType = models.EventLogEntrySchematicUnlocked
+			description = fmt.Sprintf("Schematic %s unlocked", progression.Name)
+		case models.ProgressionMilestoneCompleted:
+			entryType = models.EventLogEntryMilestoneCompleted
+			description = fmt.Sprintf("Milestone %s completed", progression.Name)
+		case models.ProgressionPhaseCompleted:
+			entryType = models.EventLogEntryPhaseCompleted
+			description = fmt.Sprintf("Space elevator phase completed (%s)", progression.Name)
+		}
+		entries = append(entries, models.EventLogEntry{
+			Timestamp:   progression.Timestamp,
+			Type:        entryType,
+			Description: description,
+		})
+	}
+	return entries
+}
@@ -0,0 +1,108 @@
+package worker
+
+import (
+	"api/models/models"
+	"testing"
+	"time"
+)
+
+func TestProgressionTrackerDetectsTransitions(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := start
+	tracker := newProgressionTracker(func() time.Time { return now })
+
+	schematics := func(schematics ...models.Schematic) *models.SatisfactoryEvent {
+		return &models.SatisfactoryEvent{Type: models.SatisfactoryEventSchematics, Data: schematics}
+	}
+	schematic := func(id string, tier int, locked, purchased bool) models.Schematic {
+		return models.Schematic{ID: id, Name: "Schematic " + id, Tier: tier, Locked: locked, Purchased: purchased}
+	}
+	elevator := func(fullyUpgraded bool, objectives ...string) *models.SatisfactoryEvent {
+		phase := make([]models.SpaceElevatorPhaseObjective, len(objectives))
+		for i, name := range objectives {
+			phase[i].Name = name
+		}
+		return &models.SatisfactoryEvent{Type: models.SatisfactoryEventSpaceElevator, Data: &models.SpaceElevator{CurrentPhase: phase, FullyUpgraded: fullyUpgraded}}
+	}
+	progression := func(kind models.ProgressionKind, name string, tier int) models.Progression {
+		return models.Progression{Kind: kind, Name: name, Tier: tier}
+	}
+
+	steps := []struct {
+		name     string
+		event    *models.SatisfactoryEvent
+		expected []models.Progression
+	}{
+		{"schematics seeded", schematics(schematic("A", 1, false, true), schematic("B", 2, true, false)), nil},
+		{"unlocked", schematics(schematic("A", 1, false, true), schematic("B", 2, false, false)), []models.Progression{
+			progression(models.ProgressionSchematicUnlocked, "Schematic B", 2),
+		}},
+		{"partial ignored", &models.SatisfactoryEvent{Type: models.SatisfactoryEventSchematics, Data: []models.Schematic{}, Partial: true}, nil},
+		{"purchased", schematics(schematic("A", 1, false, true), schematic("B", 2, false, true)), []models.Progression{
+			progression(models.ProgressionMilestoneCompleted, "Schematic B", 2),
+		}},
+		{"unchanged", schematics(schematic("A", 1, false, true), schematic("B", 2, false, true)), nil},
+		{"new schematic", schematics(schematic("A", 1, false, true), schematic("B", 2, false, true), schematic("C", 3, false, true)), []models.Progression{
+			progression(models.ProgressionSchematicUnlocked, "Schematic C", 3),
+			progression(models.ProgressionMilestoneCompleted, "Schematic C", 3),
+		}},
+		{"elevator seeded", elevator(false, "Smart Plating"), nil},
+		{"same phase", elevator(false, "Smart Plating"), nil},
+		{"next phase", elevator(false, "Versatile Framework", "Automated Wiring"), []models.Progression{
+			progression(models.ProgressionPhaseCompleted, "Smart Plating", 0),
+		}},
+		{"final phase delivered", elevator(true, "Versatile Framework", "Automated Wiring"), []models.Progression{
+			progression(models.ProgressionPhaseCompleted, "Versatile Framework, Automated Wiring", 0),
+		}},
+		{"stays upgraded", elevator(true, "Versatile Framework", "Automated Wiring"), nil},
+	}
+
+	for idx, step := range steps {
+		now = start.Add(time.Duration(idx) * time.Minute)
+		progressions := tracker.Observe(step.event)
+		if len(progressions) != len(step.expected) {
+			t.Errorf("%s: got %+v, want %+v", step.name, progressions, step.expected)
+			continue
+		}
+		for i, want := range step.expected {
+			want.Timestamp = now
+			if got := progressions[i]; got != want {
+				t.Errorf("%s: got %+v, want %+v", step.name, got, want)
+			}
+		}
+	}
+}
+
+func TestProgressionTrackerSeedsEachSourceSeparately(t *testing.T) {
+	tracker := newProgressionTracker(time.Now)
+	tracker.Observe(&models.SatisfactoryEvent{Type: models.SatisfactoryEventSchematics, Data: []models.Schematic{}})
+
+	// Schematics being seeded does not seed the space elevator
+	elevator := &models.SpaceElevator{CurrentPhase: []models.SpaceElevatorPhaseObjective{{Name: "Smart Plating"}}, FullyUpgraded: true}
+	if progressions := tracker.Observe(&models.SatisfactoryEvent{Type: models.SatisfactoryEventSpaceElevator, Data: elevator}); len(progressions) != 0 {
+		t.Errorf("got %+v on the first space elevator poll, want nothing", progressions)
+	}
+}
+
+func TestProgressionLogEntries(t *testing.T) {
+	timestamp := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	entries := progressionLogEntries([]models.Progression{
+		{Kind: models.ProgressionSchematicUnlocked, Name: "Logistics", Timestamp: timestamp},
+		{Kind: models.ProgressionMilestoneCompleted, Name: "Logistics", Timestamp: timestamp},
+		{Kind: models.ProgressionPhaseCompleted, Name: "Smart Plating", Timestamp: timestamp},
+	})
+
+	expected := []models.EventLogEntry{
+		{Timestamp: timestamp, Type: models.EventLogEntrySchematicUnlocked, Description: "Schematic Logistics unlocked"},
+		{Timestamp: timestamp, Type: models.EventLogEntryMilestoneCompleted, Description: "Milestone Logistics completed"},
+		{Timestamp: timestamp, Type: models.EventLogEntryPhaseCompleted, Description: "Space elevator phase completed (Smart Plating)"},
+	}
+	if len(entries) != len(expected) {
+		t.Fatalf("got %d entries, want %d", len(entries), len(expected))
+	}
+	for i, want := range expected {
+		if entries[i] != want {
+			t.Errorf("entry %d: got %+v, want %+v", i, entries[i], want)
+		}
+	}
+}
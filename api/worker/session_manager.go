@@ -6,14 +6,19 @@ import (
 	"api/pkg/db/key_value"
 	"api/pkg/log"
 	"api/service"
+	"api/service/analysis"
 	"api/service/client"
 	"api/service/lease"
+	"api/service/recording"
 	"api/service/session"
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 // historyEnabledTypes defines which event types support historical data storage.
@@ -37,6 +42,23 @@ type publisherState struct {
 	currentSaveName string
 	saveNameMu      sync.RWMutex
 	gameTimeTracker *session.GameTimeTracker
+	baseScoreInputs analysis.BaseScoreInputs
+	baseScoreMu     sync.Mutex
+	eventLog        *eventLogDetector
+	entities        *entityTracker
+	machineBuilds   *machineBuildTracker
+	smoother        *rateSmoother // Nil when rate smoothing is disabled
+	stalls          *stallDetector
+	idleConveyors   *idleConveyorDetector
+	shipTimer       *shipTimerTracker
+	stuckStorage    *stuckStorageDetector
+	circuitAlerts   *circuitAlertDetector
+	progression     *progressionTracker
+	alertGrace      *alertGrace      // Started anew with every publisher, e.g. on reconnect
+	unchanged       *unchangedFilter // Nil when unchanged events are not suppressed
+	client          client.Client    // Set once the publisher created its client, read for diagnostics
+	clientMu        sync.Mutex
+	restoredHistory sync.Map // "{saveName}:{dataType}" -> true once restored from the history archive
 }
 
 // GetSaveName returns the current save name for this publisher.
@@ -53,6 +75,80 @@ func (ps *publisherState) SetSaveName(name string) {
 	ps.currentSaveName = name
 }
 
+// ObserveBaseScoreInput records the event if it feeds the base score.
+func (ps *publisherState) ObserveBaseScoreInput(event *models.SatisfactoryEvent) {
+	ps.baseScoreMu.Lock()
+	defer ps.baseScoreMu.Unlock()
+
+	switch data := event.Data.(type) {
+	case *models.FactoryStats:
+		ps.baseScoreInputs.FactoryStats = data
+	case []models.Circuit:
+		ps.baseScoreInputs.Circuits = data
+	case *models.ProdStats:
+		ps.baseScoreInputs.ProdStats = data
+	case models.Vehicles:
+		ps.baseScoreInputs.Vehicles = &data
+	case models.VehicleStations:
+		ps.baseScoreInputs.VehicleStations = &data
+	}
+}
+
+// archiveHistoryPoint appends a history point to the history archive. The first point of each series
+// seen by this publisher first restores the archived points still within the sample window, so
+// a lost Redis history continues from the archive.
+func (ps *publisherState) archiveHistoryPoint(sessionID, saveName, dataType string, gameTimeID int64, data any) {
+	dir := config.Config.HistoryArchiveDir
+	if _, restored := ps.restoredHistory.LoadOrStore(saveName+":"+dataType, true); !restored {
+		var sinceID, retainSinceID int64
+		if config.Config.MaxSampleGameDuration > 0 {
+			sinceID = gameTimeID - config.Config.MaxSampleGameDuration
+		}
+		if config.Config.HistoryArchiveRetention > 0 {
+			retainSinceID = gameTimeID - config.Config.HistoryArchiveRetention
+		}
+		count, err := session.RestoreArchivedHistory(dir, sessionID, saveName, dataType, sinceID, retainSinceID)
+		if err != nil {
+			log.Warnf("Failed to restore archived history for session %s type %s: %v", sessionID, dataType, err)
+		} else if count > 0 {
+			log.Infof("Restored %d archived history points for session %s type %s", count, sessionID, dataType)
+		}
+	}
+
+	if err := session.ArchiveHistoryPoint(dir, sessionID, saveName, dataType, gameTimeID, data); err != nil {
+		log.Warnf("Failed to archive history point for session %s type %s: %v", sessionID, dataType, err)
+	}
+}
+
+// LatestCircuits returns the circuits of the latest circuits event, nil if none was observed yet.
+func (ps *publisherState) LatestCircuits() []models.Circuit {
+	ps.baseScoreMu.Lock()
+	defer ps.baseScoreMu.Unlock()
+	return ps.baseScoreInputs.Circuits
+}
+
+// BaseScore computes the base score from the latest observed inputs.
+func (ps *publisherState) BaseScore() models.BaseScore {
+	ps.baseScoreMu.Lock()
+	defer ps.baseScoreMu.Unlock()
+	return analysis.ComputeBaseScore(ps.baseScoreInputs, baseScoreWeights())
+}
+
+// baseScoreWeights returns the configured base score weights, or equal weights if none are configured.
+func baseScoreWeights() analysis.BaseScoreWeights {
+	configured := config.Config.BaseScore
+	weights := analysis.BaseScoreWeights{
+		Efficiency: configured.EfficiencyWeight,
+		Power:      configured.PowerWeight,
+		Production: configured.ProductionWeight,
+		Logistics:  configured.LogisticsWeight,
+	}
+	if weights == (analysis.BaseScoreWeights{}) {
+		return analysis.DefaultBaseScoreWeights
+	}
+	return weights
+}
+
 // GameTimeTracker returns the game time tracker for this publisher.
 func (ps *publisherState) GameTimeTracker() *session.GameTimeTracker {
 	return ps.gameTimeTracker
@@ -63,6 +159,9 @@ var (
 	globalLeaseManagerMu sync.RWMutex
 )
 
+// LeaseMetricsRegistry holds the Prometheus metrics of the lease manager, served at /internal/metrics/lease.
+var LeaseMetricsRegistry = prometheus.NewRegistry()
+
 // SetGlobalLeaseManager stores the lease manager for access by API handlers.
 func SetGlobalLeaseManager(lm lease.LeaseManager) {
 	globalLeaseManagerMu.Lock()
@@ -209,6 +308,18 @@ func (sm *SessionManager) StartSession(parentCtx context.Context, sess *models.S
 		isDisconnected:  sess.IsDisconnected,
 		currentSaveName: sess.SessionName,
 		gameTimeTracker: session.NewGameTimeTracker(),
+		eventLog:        newEventLogDetector(time.Now),
+		entities:        newEntityTracker(),
+		machineBuilds:   loadMachineBuildTracker(sess.ID),
+		smoother:        newConfiguredRateSmoother(),
+		stalls:          newStallDetector(time.Now, vehicleStallThreshold()),
+		idleConveyors:   newIdleConveyorDetector(time.Now, idleConveyorPolls),
+		shipTimer:       newShipTimerTracker(),
+		stuckStorage:    newStuckStorageDetector(time.Now, stuckStorageSustain),
+		circuitAlerts:   newConfiguredCircuitAlertDetector(),
+		progression:     newProgressionTracker(time.Now),
+		alertGrace:      newAlertGrace(time.Now, alertGracePeriod()),
+		unchanged:       newConfiguredUnchangedFilter(),
 	}
 	sm.publishers[sess.ID] = state
 
@@ -249,12 +360,48 @@ func (sm *SessionManager) Stop() {
 	log.Infoln("Session manager stopped gracefully")
 }
 
+// publishEvents caches and publishes the events of one poll, tagging each with the session it belongs to
+func (sm *SessionManager) publishEvents(sessionID, saveName, channelKey string, eventSession *models.EventSession, events []models.SatisfactoryEvent) {
+	for _, e := range events {
+		e.Session = eventSession
+		asJson, err := json.Marshal(truncateEvent(e, config.Config.MaxEventEntities))
+		if err != nil {
+			log.PrettyError(fmt.Errorf("failed to marshal event for session %s: %w", sessionID, err))
+			return
+		}
+
+		// Cache the event data for /state endpoint (no expiration - updated by polling)
+		// Only cache if we have a save name, removals only make sense as a stream
+		if saveName != "" && e.Type != models.SatisfactoryEventRemoved {
+			cacheKey := fmt.Sprintf("state:%s:%s:%s", sessionID, saveName, e.Type)
+			eventData, cacheErr := json.Marshal(e.Data)
+			if cacheErr == nil {
+				if setErr := sm.kvClient.Set(cacheKey, string(eventData), 0); setErr != nil {
+					log.Warnf("Failed to cache event %s for session %s: %v", e.Type, sessionID, setErr)
+				}
+			}
+		}
+
+		// Publish to SSE subscribers
+		err = sm.kvClient.Publish(channelKey, asJson)
+		if err != nil {
+			log.PrettyError(fmt.Errorf("failed to publish event for session %s: %w", sessionID, err))
+		}
+	}
+}
+
 // publishLoop runs the event publishing loop for a session
 func (sm *SessionManager) publishLoop(ctx context.Context, sess *models.Session, state *publisherState) {
 	channelKey := fmt.Sprintf("%s:%s", models.SatisfactoryEventKey, sess.ID)
+	eventSession := sess.EventSession()
 
-	// Create the FRM client for this session
-	frmClient := service.NewClientWithAddress(sess.Address)
+	// Create the client for this session's backend
+	frmClient, err := service.NewClient(service.ClientConfig{Address: sess.Address, Headers: sess.Headers})
+	if err != nil {
+		log.PrettyError(fmt.Errorf("failed to create client for session %s: %w", sess.ID, err))
+		return
+	}
+	state.setClient(frmClient)
 
 	// Set up disconnection callback
 	frmClient.SetDisconnectedCallback(func() {
@@ -264,6 +411,16 @@ func (sm *SessionManager) publishLoop(ctx context.Context, sess *models.Session,
 
 	var apiClient client.Client = frmClient
 
+	if config.Config.RecordingDir != "" && !strings.HasPrefix(sess.Address, recording.PlaybackAddressPrefix) {
+		recordingClient, err := recording.NewRecordingClient(frmClient, recording.RecordingPath(config.Config.RecordingDir, sess.ID))
+		if err != nil {
+			log.PrettyError(fmt.Errorf("failed to start recording for session %s: %w", sess.ID, err))
+		} else {
+			defer recordingClient.CloseLog()
+			apiClient = recordingClient
+		}
+	}
+
 	handler := func(event *models.SatisfactoryEvent) {
 		// Check if session was deleted before processing
 		if session.IsSessionDeleted(sess.ID) {
@@ -285,6 +442,9 @@ func (sm *SessionManager) publishLoop(ctx context.Context, sess *models.Session,
 			return
 		}
 
+		// Dropped before anything stores the event, so neither history nor the cached state keeps them
+		filterOutOfBounds(event, coordinateBound())
+
 		// Store history and set gameTimeId for time-series data types
 		if isHistoryEnabledType(event.Type) {
 			saveName := state.GetSaveName()
@@ -293,6 +453,10 @@ func (sm *SessionManager) publishLoop(ctx context.Context, sess *models.Session,
 				// Set gameTimeId on event for SSE subscribers to track position
 				event.GameTimeID = gameTimeID
 
+				if config.Config.HistoryArchiveDir != "" {
+					state.archiveHistoryPoint(sess.ID, saveName, string(event.Type), gameTimeID, event.Data)
+				}
+
 				if err := session.StoreHistoryPoint(sess.ID, saveName, string(event.Type), gameTimeID, event.Data); err != nil {
 					log.Warnf("Failed to store history point for session %s type %s: %v", sess.ID, event.Type, err)
 				}
@@ -303,9 +467,114 @@ func (sm *SessionManager) publishLoop(ctx context.Context, sess *models.Session,
 			}
 		}
 
+		// Per-item production samples keep the raw rates too, on wall-clock time
+		if prodStats, ok := event.Data.(*models.ProdStats); ok {
+			if err := session.RecordProdSamples(sess.ID, prodStats.Items, time.Now(), prodHistorySamples()); err != nil {
+				log.Warnf("Failed to record prod history for session %s: %v", sess.ID, err)
+			}
+		}
+
+		// History keeps the raw rates, only the emitted and cached state is smoothed
+		if state.smoother != nil {
+			state.smoother.Apply(event)
+		}
+
+		if machines, ok := event.Data.([]models.Machine); ok {
+			analysis.ClassifyIdleMachines(machines, state.LatestCircuits())
+		}
+
 		toPublish := []models.SatisfactoryEvent{*event}
 
+		if config.Config.EntityTombstones {
+			for _, removed := range state.entities.Removed(event) {
+				toPublish = append(toPublish, models.SatisfactoryEvent{
+					Type: models.SatisfactoryEventRemoved,
+					Data: removed,
+				})
+			}
+		}
+
+		state.ObserveBaseScoreInput(event)
+
+		// Detectors keep observing during the startup grace, their alerts are held back until it ends
+		toPublish = append(toPublish, state.alertGrace.Release()...)
+		publishAlert := func(alert models.SatisfactoryEvent) {
+			if !state.alertGrace.Hold(alert) {
+				toPublish = append(toPublish, alert)
+			}
+		}
+
+		if stalled, changed := state.stalls.Observe(event); changed {
+			publishAlert(models.SatisfactoryEvent{
+				Type: models.SatisfactoryEventStalledVehicles,
+				Data: stalled,
+			})
+		}
+
+		if idle, changed := state.idleConveyors.Observe(event); changed {
+			publishAlert(models.SatisfactoryEvent{
+				Type: models.SatisfactoryEventIdleConveyors,
+				Data: idle,
+			})
+		}
+
+		if stuck, changed := state.stuckStorage.Observe(event); changed {
+			publishAlert(models.SatisfactoryEvent{
+				Type: models.SatisfactoryEventStuckStorageItems,
+				Data: stuck,
+			})
+		}
+
+		if alerts, changed := state.circuitAlerts.Observe(event); changed {
+			publishAlert(models.SatisfactoryEvent{
+				Type: models.SatisfactoryEventAlerts,
+				Data: alerts,
+			})
+		}
+
+		if timer, changed := state.shipTimer.Observe(event); changed {
+			toPublish = append(toPublish, models.SatisfactoryEvent{
+				Type: models.SatisfactoryEventShipTimer,
+				Data: timer,
+			})
+		}
+
+		entries := state.eventLog.Observe(event)
+		if progressions := state.progression.Observe(event); len(progressions) > 0 {
+			toPublish = append(toPublish, models.SatisfactoryEvent{
+				Type: models.SatisfactoryEventProgression,
+				Data: progressions,
+			})
+			entries = append(entries, progressionLogEntries(progressions)...)
+		}
+		if len(entries) > 0 {
+			if err := session.AppendEventLog(sess.ID, entries...); err != nil {
+				log.Warnf("Failed to append event log for session %s: %v", sess.ID, err)
+			}
+		}
+		if !state.alertGrace.Active() {
+			if err := session.RecordIncidents(sess.ID, entries, alertStates(toPublish)); err != nil {
+				log.Warnf("Failed to record incidents for session %s: %v", sess.ID, err)
+			}
+		}
+
 		switch event.Type {
+		case models.SatisfactoryEventFactoryStats:
+			// Factory stats are polled every few seconds, so they pace the derived base score
+			baseScore := state.BaseScore()
+			toPublish = append(toPublish, models.SatisfactoryEvent{
+				Type: models.SatisfactoryEventBaseScore,
+				Data: &baseScore,
+			})
+		case models.SatisfactoryEventMachines:
+			// Machines missing from a partial poll were not demolished
+			if machines, ok := event.Data.([]models.Machine); ok && !event.Partial {
+				if buildLog, changed := state.machineBuilds.Observe(machines); changed {
+					if err := session.SetMachineBuildLog(sess.ID, buildLog); err != nil {
+						log.Warnf("Failed to store machine build log for session %s: %v", sess.ID, err)
+					}
+				}
+			}
 		case models.SatisfactoryEventApiStatus:
 			// Update session online status
 			status := event.Data.(*models.SatisfactoryApiStatus)
@@ -319,32 +588,11 @@ func (sm *SessionManager) publishLoop(ctx context.Context, sess *models.Session,
 			}
 		}
 
-		for _, e := range toPublish {
-			asJson, err := json.Marshal(e)
-			if err != nil {
-				log.PrettyError(fmt.Errorf("failed to marshal event for session %s: %w", sess.ID, err))
-				return
-			}
-
-			// Cache the event data for /state endpoint (no expiration - updated by polling)
-			// Only cache if we have a save name
-			saveName := state.GetSaveName()
-			if saveName != "" {
-				cacheKey := fmt.Sprintf("state:%s:%s:%s", sess.ID, saveName, e.Type)
-				eventData, cacheErr := json.Marshal(e.Data)
-				if cacheErr == nil {
-					if setErr := sm.kvClient.Set(cacheKey, string(eventData), 0); setErr != nil {
-						log.Warnf("Failed to cache event %s for session %s: %v", e.Type, sess.ID, setErr)
-					}
-				}
-			}
-
-			// Publish to SSE subscribers
-			err = sm.kvClient.Publish(channelKey, asJson)
-			if err != nil {
-				log.PrettyError(fmt.Errorf("failed to publish event for session %s: %w", sess.ID, err))
-			}
+		if state.unchanged != nil && state.unchanged.Unchanged(&toPublish[0]) {
+			toPublish = toPublish[1:]
 		}
+
+		sm.publishEvents(sess.ID, state.GetSaveName(), channelKey, eventSession, toPublish)
 	}
 
 	// Start session info monitor in background
@@ -433,10 +681,10 @@ func (sm *SessionManager) monitorSessionInfo(ctx context.Context, sess *models.S
 						continue
 					}
 
-					// Publish session update event
+					// Publish session update event, as a DTO so header values never reach subscribers
 					event := models.SatisfactoryEvent{
 						Type: models.SatisfactoryEventSessionUpdate,
-						Data: currentSession,
+						Data: currentSession.ToDTO(session.GetSessionStage(currentSession.ID, currentSession.SessionName)),
 					}
 					asJson, err := json.Marshal(event)
 					if err != nil {
@@ -510,14 +758,47 @@ func (sm *SessionManager) restartPublisherLocked(sessionID string, sess *models.
 	// Preserve state from the existing publisher
 	var currentSaveName string
 	var gameTimeTracker *session.GameTimeTracker
+	var eventLog *eventLogDetector
+	var entities *entityTracker
+	var machineBuilds *machineBuildTracker
+	var smoother *rateSmoother
+	var stalls *stallDetector
+	var idleConveyors *idleConveyorDetector
+	var shipTimer *shipTimerTracker
+	var stuckStorage *stuckStorageDetector
+	var circuitAlerts *circuitAlertDetector
+	var progression *progressionTracker
+	var unchanged *unchangedFilter
 	if existingState, exists := sm.publishers[sessionID]; exists {
 		currentSaveName = existingState.GetSaveName()
 		gameTimeTracker = existingState.gameTimeTracker
+		eventLog = existingState.eventLog
+		entities = existingState.entities
+		machineBuilds = existingState.machineBuilds
+		smoother = existingState.smoother
+		stalls = existingState.stalls
+		idleConveyors = existingState.idleConveyors
+		shipTimer = existingState.shipTimer
+		stuckStorage = existingState.stuckStorage
+		circuitAlerts = existingState.circuitAlerts
+		progression = existingState.progression
+		unchanged = existingState.unchanged
 		existingState.cancel()
 		delete(sm.publishers, sessionID)
 	} else {
 		currentSaveName = sess.SessionName
 		gameTimeTracker = session.NewGameTimeTracker()
+		eventLog = newEventLogDetector(time.Now)
+		entities = newEntityTracker()
+		machineBuilds = loadMachineBuildTracker(sessionID)
+		smoother = newConfiguredRateSmoother()
+		stalls = newStallDetector(time.Now, vehicleStallThreshold())
+		idleConveyors = newIdleConveyorDetector(time.Now, idleConveyorPolls)
+		shipTimer = newShipTimerTracker()
+		stuckStorage = newStuckStorageDetector(time.Now, stuckStorageSustain)
+		circuitAlerts = newConfiguredCircuitAlertDetector()
+		progression = newProgressionTracker(time.Now)
+		unchanged = newConfiguredUnchangedFilter()
 	}
 
 	// Start new publisher with updated session state
@@ -527,6 +808,18 @@ func (sm *SessionManager) restartPublisherLocked(sessionID string, sess *models.
 		isDisconnected:  sess.IsDisconnected,
 		currentSaveName: currentSaveName,
 		gameTimeTracker: gameTimeTracker,
+		eventLog:        eventLog,
+		entities:        entities,
+		machineBuilds:   machineBuilds,
+		smoother:        smoother,
+		stalls:          stalls,
+		idleConveyors:   idleConveyors,
+		shipTimer:       shipTimer,
+		stuckStorage:    stuckStorage,
+		circuitAlerts:   circuitAlerts,
+		progression:     progression,
+		alertGrace:      newAlertGrace(time.Now, alertGracePeriod()),
+		unchanged:       unchanged,
 	}
 	sm.publishers[sessionID] = state
 
@@ -538,23 +831,41 @@ func SessionManagerWorker(ctx context.Context) {
 	kvClient := key_value.New()
 	logger := log.GetBaseLogger()
 
+	leaseConfig := lease.DefaultLeaseConfig()
+	if config.Config.LeaseStatusGraceSeconds > 0 {
+		leaseConfig.StatusGracePeriod = time.Duration(config.Config.LeaseStatusGraceSeconds) * time.Second
+	}
+	if config.Config.LeaseWeight > 0 {
+		leaseConfig.Weight = config.Config.LeaseWeight
+	}
+
 	// Create lease manager with optional custom node name from config
 	leaseManager := lease.NewLeaseManager(
 		kvClient,
-		lease.DefaultLeaseConfig(),
+		leaseConfig,
 		logger,
 		config.Config.NodeName, // Pass node name from config (may be empty)
 	)
 
+	if err := leaseManager.RegisterMetrics(LeaseMetricsRegistry); err != nil {
+		log.PrettyError(fmt.Errorf("failed to register lease metrics: %w", err))
+	}
+
 	if err := leaseManager.Start(ctx); err != nil {
 		log.PrettyError(fmt.Errorf("failed to start lease manager: %w", err))
 		return
 	}
+	go globalLeaseEvents.Run(ctx, leaseManager.Events())
 
 	// Store globally for API handlers
 	SetGlobalLeaseManager(leaseManager)
 
 	manager := NewSessionManager(leaseManager)
+	SetGlobalSessionManager(manager)
+	leaseManager.SetLeaseLostCallback(func(sessionID string) {
+		log.Infof("Lease lost for session %s, stopping publisher", sessionID)
+		manager.StopSession(sessionID)
+	})
 	manager.Start(ctx)
 	manager.Stop()
 }
@@ -0,0 +1,65 @@
+package worker
+
+import (
+	"api/models/models"
+	"api/pkg/config"
+	"api/pkg/db/key_value"
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestPublishEventsTagsEverySession(t *testing.T) {
+	previous := config.Config
+	config.Config = &config.Type{}
+	t.Cleanup(func() { config.Config = previous })
+
+	server := miniredis.RunT(t)
+	redisClient := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = redisClient.Close() })
+	ctx := context.Background()
+	subscription := redisClient.Subscribe(ctx, "events:session")
+	t.Cleanup(func() { _ = subscription.Close() })
+	if _, err := subscription.Receive(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	sm := &SessionManager{kvClient: &key_value.Client{RedisClient: redisClient}}
+	eventSession := (&models.Session{ID: "session", Label: "Main base", Metadata: map[string]string{"region": "eu"}}).EventSession()
+	events := []models.SatisfactoryEvent{
+		{Type: models.SatisfactoryEventCircuits, Data: []models.Circuit{{ID: "1"}}},
+		{Type: models.SatisfactoryEventRemoved, Data: models.RemovedEntities{EventType: models.SatisfactoryEventCircuits, Kind: "circuits", IDs: []string{"2"}}},
+		{Type: models.SatisfactoryEventProdStats, Data: &models.ProdStats{}},
+	}
+	sm.publishEvents("session", "save", "events:session", eventSession, events)
+
+	messages := subscription.Channel()
+	for _, want := range events {
+		select {
+		case message := <-messages:
+			var published models.SatisfactoryEvent
+			if err := json.Unmarshal([]byte(message.Payload), &published); err != nil {
+				t.Fatal(err)
+			}
+			if published.Type != want.Type || !reflect.DeepEqual(published.Session, eventSession) {
+				t.Errorf("got %s event with session %+v, want %s with %+v", published.Type, published.Session, want.Type, eventSession)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("no %s event published", want.Type)
+		}
+	}
+
+	for _, eventType := range []models.SatisfactoryEventType{models.SatisfactoryEventCircuits, models.SatisfactoryEventProdStats} {
+		if !server.Exists("state:session:save:" + string(eventType)) {
+			t.Errorf("%s event was not cached", eventType)
+		}
+	}
+	if server.Exists("state:session:save:" + string(models.SatisfactoryEventRemoved)) {
+		t.Error("removals were cached, want them only streamed")
+	}
+}
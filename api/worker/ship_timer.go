@@ -0,0 +1,72 @@
+package worker
+
+import (
+	"api/models/models"
+	"sync"
+)
+
+// shipTimerDriftMillis is how far the polled return time may move before the countdown is re-emitted.
+// The game reports the remaining time in whole seconds, so a few seconds of jitter between polls is expected.
+const shipTimerDriftMillis = 5000
+
+// shipTimerTracker turns the polled hub ship state into timer events: one when the ship launches
+// with its return time, a correction when that time drifts, and one when the ship docks again.
+type shipTimerTracker struct {
+	mu         sync.Mutex
+	known      bool
+	docked     bool
+	returnTime int64
+}
+
+func newShipTimerTracker() *shipTimerTracker {
+	return &shipTimerTracker{}
+}
+
+// Observe updates the tracker from a hub event and returns the timer to emit, if the ship state changed.
+// The first observation is always emitted, so clients learn the current state.
+func (tracker *shipTimerTracker) Observe(event *models.SatisfactoryEvent) (*models.ShipTimer, bool) {
+	hub, ok := event.Data.(*models.Hub)
+	if !ok || hub == nil || event.Partial {
+		return nil, false
+	}
+
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+
+	if hub.ShipDocked {
+		if tracker.known && tracker.docked {
+			return nil, false
+		}
+		tracker.known = true
+		tracker.docked = true
+		tracker.returnTime = 0
+		return &models.ShipTimer{State: models.ShipTimerStateDocked}, true
+	}
+
+	// The countdown is at zero while the ship lands, the docked state follows on a later poll
+	if hub.ShipReturnTime == nil {
+		return nil, false
+	}
+
+	returnTime := *hub.ShipReturnTime
+	corrected := false
+	if tracker.known && !tracker.docked {
+		drift := returnTime - tracker.returnTime
+		if drift < 0 {
+			drift = -drift
+		}
+		if drift <= shipTimerDriftMillis {
+			return nil, false
+		}
+		corrected = true
+	}
+
+	tracker.known = true
+	tracker.docked = false
+	tracker.returnTime = returnTime
+	return &models.ShipTimer{
+		State:      models.ShipTimerStateLaunched,
+		ReturnTime: &returnTime,
+		Corrected:  corrected,
+	}, true
+}
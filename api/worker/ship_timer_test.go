@@ -0,0 +1,63 @@
+package worker
+
+import (
+	"api/models/models"
+	"testing"
+)
+
+func TestShipTimerTrackerEmitsLaunchDriftAndDock(t *testing.T) {
+	tracker := newShipTimerTracker()
+	hubEvent := func(docked bool, returnTime *int64) *models.SatisfactoryEvent {
+		return &models.SatisfactoryEvent{Type: models.SatisfactoryEventHub, Data: &models.Hub{ShipDocked: docked, ShipReturnTime: returnTime}}
+	}
+	launched := func(returnTime int64) *models.SatisfactoryEvent { return hubEvent(false, &returnTime) }
+	const returnTime = 1_700_000_000_000
+
+	steps := []struct {
+		name       string
+		event      *models.SatisfactoryEvent
+		emitted    bool
+		state      models.ShipTimerState
+		returnTime int64
+		corrected  bool
+	}{
+		{"first observation", hubEvent(true, nil), true, models.ShipTimerStateDocked, 0, false},
+		{"still docked", hubEvent(true, nil), false, "", 0, false},
+		{"launch", launched(returnTime), true, models.ShipTimerStateLaunched, returnTime, false},
+		{"jitter", launched(returnTime + 3000), false, "", 0, false},
+		{"jitter the other way", launched(returnTime - shipTimerDriftMillis), false, "", 0, false},
+		{"drift", launched(returnTime + 8000), true, models.ShipTimerStateLaunched, returnTime + 8000, true},
+		{"partial", &models.SatisfactoryEvent{Type: models.SatisfactoryEventHub, Data: &models.Hub{ShipDocked: true}, Partial: true}, false, "", 0, false},
+		{"landing", hubEvent(false, nil), false, "", 0, false},
+		{"docked", hubEvent(true, nil), true, models.ShipTimerStateDocked, 0, false},
+		// A launch right after docking is never a correction
+		{"relaunch", launched(returnTime + 600_000), true, models.ShipTimerStateLaunched, returnTime + 600_000, false},
+	}
+
+	for _, step := range steps {
+		timer, emitted := tracker.Observe(step.event)
+		if emitted != step.emitted {
+			t.Fatalf("%s: got emitted %v, want %v", step.name, emitted, step.emitted)
+		}
+		if !emitted {
+			continue
+		}
+		if timer.State != step.state || timer.Corrected != step.corrected {
+			t.Errorf("%s: got %+v, want state %s corrected %v", step.name, timer, step.state, step.corrected)
+		}
+		if step.state == models.ShipTimerStateDocked && timer.ReturnTime != nil {
+			t.Errorf("%s: got return time %d, want none while docked", step.name, *timer.ReturnTime)
+		}
+		if step.state == models.ShipTimerStateLaunched && (timer.ReturnTime == nil || *timer.ReturnTime != step.returnTime) {
+			t.Errorf("%s: got return time %v, want %d", step.name, timer.ReturnTime, step.returnTime)
+		}
+	}
+}
+
+func TestShipTimerTrackerFirstObservationInFlight(t *testing.T) {
+	returnTime := int64(1_700_000_000_000)
+	timer, emitted := newShipTimerTracker().Observe(&models.SatisfactoryEvent{Type: models.SatisfactoryEventHub, Data: &models.Hub{ShipReturnTime: &returnTime}})
+	if !emitted || timer.State != models.ShipTimerStateLaunched || timer.Corrected {
+		t.Errorf("got %+v, %v, want the running countdown emitted without correction", timer, emitted)
+	}
+}
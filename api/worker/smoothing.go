@@ -0,0 +1,135 @@
+package worker
+
+import (
+	"api/models/models"
+	"api/pkg/config"
+	"sync"
+)
+
+// rateSmoother applies an exponential moving average to prod stats rates and vehicle speeds, so
+// the displayed numbers do not jitter from poll to poll. The unsmoothed value is kept next to each
+// smoothed field. Entities missing from a poll are forgotten, so one that reappears starts from its raw value.
+type rateSmoother struct {
+	mu     sync.Mutex
+	alpha  float64                       // Weight of the newest value, 0-1
+	values map[string]map[string]float64 // Group -> entity key -> smoothed value
+}
+
+// newConfiguredRateSmoother returns a smoother using the configured smoothing factor, or nil if smoothing is disabled
+func newConfiguredRateSmoother() *rateSmoother {
+	if config.Config.RateSmoothing <= 0 || config.Config.RateSmoothing >= 1 {
+		return nil
+	}
+	return newRateSmoother(config.Config.RateSmoothing)
+}
+
+func newRateSmoother(alpha float64) *rateSmoother {
+	return &rateSmoother{
+		alpha:  alpha,
+		values: make(map[string]map[string]float64),
+	}
+}
+
+// Apply smooths the rates of the event data in place. Events without rates are left untouched.
+func (smoother *rateSmoother) Apply(event *models.SatisfactoryEvent) {
+	if event.Partial {
+		return
+	}
+
+	smoother.mu.Lock()
+	defer smoother.mu.Unlock()
+
+	switch data := event.Data.(type) {
+	case *models.ProdStats:
+		produced := smoother.group("produced")
+		consumed := smoother.group("consumed")
+		for idx := range data.Items {
+			item := &data.Items[idx]
+			item.ProducedPerMinute, item.ProducedPerMinuteRaw = produced.smooth(item.Name, item.ProducedPerMinute)
+			item.ConsumedPerMinute, item.ConsumedPerMinuteRaw = consumed.smooth(item.Name, item.ConsumedPerMinute)
+		}
+		produced.done()
+		consumed.done()
+	case models.Vehicles:
+		smoother.smoothTrains(data.Trains)
+		smoother.smoothDrones(data.Drones)
+		smoother.smoothTrucks(data.Trucks)
+		smoother.smoothTractors(data.Tractors)
+		smoother.smoothExplorers(data.Explorers)
+	case []models.Tractor:
+		smoother.smoothTractors(data)
+	case []models.Explorer:
+		smoother.smoothExplorers(data)
+	}
+}
+
+func (smoother *rateSmoother) smoothTrains(trains []models.Train) {
+	speeds := smoother.group("trains")
+	for idx := range trains {
+		trains[idx].Speed, trains[idx].SpeedRaw = speeds.smooth(trains[idx].ID, trains[idx].Speed)
+	}
+	speeds.done()
+}
+
+func (smoother *rateSmoother) smoothDrones(drones []models.Drone) {
+	speeds := smoother.group("drones")
+	for idx := range drones {
+		drones[idx].Speed, drones[idx].SpeedRaw = speeds.smooth(drones[idx].Name, drones[idx].Speed)
+	}
+	speeds.done()
+}
+
+func (smoother *rateSmoother) smoothTrucks(trucks []models.Truck) {
+	speeds := smoother.group("trucks")
+	for idx := range trucks {
+		trucks[idx].Speed, trucks[idx].SpeedRaw = speeds.smooth(trucks[idx].ID, trucks[idx].Speed)
+	}
+	speeds.done()
+}
+
+func (smoother *rateSmoother) smoothTractors(tractors []models.Tractor) {
+	speeds := smoother.group("tractors")
+	for idx := range tractors {
+		tractors[idx].Speed, tractors[idx].SpeedRaw = speeds.smooth(tractors[idx].ID, tractors[idx].Speed)
+	}
+	speeds.done()
+}
+
+func (smoother *rateSmoother) smoothExplorers(explorers []models.Explorer) {
+	speeds := smoother.group("explorers")
+	for idx := range explorers {
+		explorers[idx].Speed, explorers[idx].SpeedRaw = speeds.smooth(explorers[idx].ID, explorers[idx].Speed)
+	}
+	speeds.done()
+}
+
+// smoothingGroup smooths one poll of a group of entities; done drops entities absent from the poll
+type smoothingGroup struct {
+	smoother *rateSmoother
+	name     string
+	previous map[string]float64
+	current  map[string]float64
+}
+
+func (smoother *rateSmoother) group(name string) *smoothingGroup {
+	return &smoothingGroup{
+		smoother: smoother,
+		name:     name,
+		previous: smoother.values[name],
+		current:  make(map[string]float64),
+	}
+}
+
+// smooth returns the smoothed value and the raw value to keep next to it
+func (group *smoothingGroup) smooth(key string, raw float64) (float64, *float64) {
+	smoothed := raw
+	if previous, ok := group.previous[key]; ok {
+		smoothed = group.smoother.alpha*raw + (1-group.smoother.alpha)*previous
+	}
+	group.current[key] = smoothed
+	return smoothed, &raw
+}
+
+func (group *smoothingGroup) done() {
+	group.smoother.values[group.name] = group.current
+}
@@ -0,0 +1,90 @@
+package worker
+
+import (
+	"api/models/models"
+	"api/pkg/config"
+	"testing"
+)
+
+func TestRateSmootherAveragesProdStats(t *testing.T) {
+	smoother := newRateSmoother(0.5)
+	poll := func(partial bool, items ...models.ItemProdStats) *models.ProdStats {
+		prodStats := &models.ProdStats{Items: items}
+		smoother.Apply(&models.SatisfactoryEvent{Type: models.SatisfactoryEventProdStats, Data: prodStats, Partial: partial})
+		return prodStats
+	}
+	item := func(name string, produced float64) models.ItemProdStats {
+		return models.ItemProdStats{ItemStats: models.ItemStats{Name: name}, ProducedPerMinute: produced, ConsumedPerMinute: produced / 2}
+	}
+
+	steps := []struct {
+		name      string
+		partial   bool
+		items     []models.ItemProdStats
+		want      map[string]float64 // Smoothed produced rate per item
+		wantRaw   map[string]float64
+		unchanged bool
+	}{
+		{"first poll starts at the raw value", false, []models.ItemProdStats{item("Iron Plate", 100)}, map[string]float64{"Iron Plate": 100}, map[string]float64{"Iron Plate": 100}, false},
+		{"jump is halved", false, []models.ItemProdStats{item("Iron Plate", 200)}, map[string]float64{"Iron Plate": 150}, map[string]float64{"Iron Plate": 200}, false},
+		{"partial poll is left as is", true, []models.ItemProdStats{item("Iron Plate", 0)}, map[string]float64{"Iron Plate": 0}, nil, true},
+		{"continues from the last full poll", false, []models.ItemProdStats{item("Iron Plate", 50), item("Wire", 30)}, map[string]float64{"Iron Plate": 100, "Wire": 30}, map[string]float64{"Iron Plate": 50, "Wire": 30}, false},
+		{"missing item is forgotten", false, []models.ItemProdStats{item("Wire", 30)}, map[string]float64{"Wire": 30}, map[string]float64{"Wire": 30}, false},
+		{"reappearing item starts over", false, []models.ItemProdStats{item("Iron Plate", 80)}, map[string]float64{"Iron Plate": 80}, map[string]float64{"Iron Plate": 80}, false},
+	}
+
+	for _, step := range steps {
+		prodStats := poll(step.partial, step.items...)
+		for _, got := range prodStats.Items {
+			if want := step.want[got.Name]; got.ProducedPerMinute != want || got.ConsumedPerMinute != want/2 {
+				t.Errorf("%s: got %s produced %v consumed %v, want %v and %v", step.name, got.Name, got.ProducedPerMinute, got.ConsumedPerMinute, want, want/2)
+			}
+			if step.unchanged {
+				if got.ProducedPerMinuteRaw != nil {
+					t.Errorf("%s: got raw %v on an unsmoothed item, want none", step.name, *got.ProducedPerMinuteRaw)
+				}
+				continue
+			}
+			if got.ProducedPerMinuteRaw == nil || *got.ProducedPerMinuteRaw != step.wantRaw[got.Name] {
+				t.Errorf("%s: got raw %v for %s, want %v", step.name, got.ProducedPerMinuteRaw, got.Name, step.wantRaw[got.Name])
+			}
+		}
+	}
+}
+
+func TestRateSmootherSmoothsVehicleSpeeds(t *testing.T) {
+	smoother := newRateSmoother(0.25)
+	poll := func(speed float64) models.Vehicles {
+		vehicles := models.Vehicles{
+			Trains: []models.Train{{ID: "train", Speed: speed}},
+			Trucks: []models.Truck{{ID: "truck", Speed: speed / 2}},
+		}
+		smoother.Apply(&models.SatisfactoryEvent{Type: models.SatisfactoryEventVehicles, Data: vehicles})
+		return vehicles
+	}
+
+	poll(0)
+	vehicles := poll(100)
+	if train := vehicles.Trains[0]; train.Speed != 25 || train.SpeedRaw == nil || *train.SpeedRaw != 100 {
+		t.Errorf("got train speed %v (raw %v), want 25 (raw 100)", train.Speed, train.SpeedRaw)
+	}
+	if truck := vehicles.Trucks[0]; truck.Speed != 12.5 {
+		t.Errorf("got truck speed %v, want 12.5 smoothed separately from the train", truck.Speed)
+	}
+}
+
+func TestConfiguredRateSmoother(t *testing.T) {
+	previous := config.Config
+	t.Cleanup(func() { config.Config = previous })
+
+	for _, factor := range []float64{0, 1, -0.5} {
+		config.Config = &config.Type{RateSmoothing: factor}
+		if smoother := newConfiguredRateSmoother(); smoother != nil {
+			t.Errorf("got a smoother for factor %v, want smoothing disabled", factor)
+		}
+	}
+	config.Config = &config.Type{RateSmoothing: 0.3}
+	if smoother := newConfiguredRateSmoother(); smoother == nil || smoother.alpha != 0.3 {
+		t.Errorf("got %+v, want a smoother with factor 0.3", smoother)
+	}
+}
@@ -0,0 +1,151 @@
+package worker
+
+import (
+	"api/models/models"
+	"api/pkg/config"
+	"math"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	defaultVehicleStallThreshold = 60 * time.Second
+	stallSpeedEpsilon            = 0.1    // Speeds below this count as standing still
+	stationDockRadius            = 3000.0 // Vehicles this close to a station of their kind are assumed to be docking
+)
+
+// vehicleStallThreshold returns how long a self-driving vehicle may stand still before it is reported stalled
+func vehicleStallThreshold() time.Duration {
+	if config.Config.VehicleStallSeconds > 0 {
+		return time.Duration(config.Config.VehicleStallSeconds) * time.Second
+	}
+	return defaultVehicleStallThreshold
+}
+
+// stallDetector tracks how long self-driving vehicles have been standing still away from stations,
+// so vehicles stuck on a blocked path, out of fuel or after a collision can be reported.
+type stallDetector struct {
+	mu        sync.Mutex
+	now       func() time.Time
+	threshold time.Duration
+	stations  *models.VehicleStations
+	stillIn   map[string]time.Time // Vehicle key -> when it was first seen standing still
+	reported  []string             // Sorted keys of the last reported stalled vehicles
+}
+
+func newStallDetector(now func() time.Time, threshold time.Duration) *stallDetector {
+	return &stallDetector{
+		now:       now,
+		threshold: threshold,
+		stillIn:   make(map[string]time.Time),
+	}
+}
+
+// Observe records vehicle stations and vehicle speeds, and returns the stalled vehicles when that
+// set changed since the last report. Vehicles near a station of their kind are never stalled.
+func (detector *stallDetector) Observe(event *models.SatisfactoryEvent) ([]models.StalledVehicle, bool) {
+	detector.mu.Lock()
+	defer detector.mu.Unlock()
+
+	switch data := event.Data.(type) {
+	case models.VehicleStations:
+		detector.stations = &data
+		return nil, false
+	case models.Vehicles:
+		return detector.observeVehicles(data)
+	default:
+		return nil, false
+	}
+}
+
+func (detector *stallDetector) observeVehicles(vehicles models.Vehicles) ([]models.StalledVehicle, bool) {
+	var truckStations, droneStations []models.Location
+	if detector.stations != nil {
+		for _, station := range detector.stations.TruckStations {
+			truckStations = append(truckStations, station.Location)
+		}
+		for _, station := range detector.stations.DroneStations {
+			droneStations = append(droneStations, station.Location)
+		}
+	}
+
+	now := detector.now()
+	seen := make(map[string]bool)
+	stalled := make([]models.StalledVehicle, 0)
+	check := func(vehicle models.StalledVehicle, selfDriving bool, speed float64, stations []models.Location) {
+		key := string(vehicle.Kind) + ":" + vehicle.ID
+		if !selfDriving || math.Abs(speed) >= stallSpeedEpsilon || nearAny(vehicle.Location, stations, stationDockRadius) {
+			return
+		}
+		seen[key] = true
+		since, ok := detector.stillIn[key]
+		if !ok {
+			since = now
+			detector.stillIn[key] = now
+		}
+		if now.Sub(since) >= detector.threshold {
+			vehicle.StalledSince = since
+			stalled = append(stalled, vehicle)
+		}
+	}
+
+	for _, truck := range vehicles.Trucks {
+		check(models.StalledVehicle{ID: truck.ID, Name: truck.Name, Kind: models.StalledVehicleKindTruck, Location: truck.Location},
+			truck.Status == models.TruckStatusSelfDriving, rawSpeed(truck.Speed, truck.SpeedRaw), truckStations)
+	}
+	for _, tractor := range vehicles.Tractors {
+		check(models.StalledVehicle{ID: tractor.ID, Name: tractor.Name, Kind: models.StalledVehicleKindTractor, Location: tractor.Location},
+			tractor.Status == models.TractorStatusSelfDriving, rawSpeed(tractor.Speed, tractor.SpeedRaw), truckStations)
+	}
+	for _, explorer := range vehicles.Explorers {
+		check(models.StalledVehicle{ID: explorer.ID, Name: explorer.Name, Kind: models.StalledVehicleKindExplorer, Location: explorer.Location},
+			explorer.Status == models.ExplorerStatusSelfDriving, rawSpeed(explorer.Speed, explorer.SpeedRaw), truckStations)
+	}
+	for _, drone := range vehicles.Drones {
+		check(models.StalledVehicle{ID: drone.Name, Name: drone.Name, Kind: models.StalledVehicleKindDrone, Location: drone.Location},
+			drone.Status == models.DroneStatusFlying, rawSpeed(drone.Speed, drone.SpeedRaw), droneStations)
+	}
+
+	for key := range detector.stillIn {
+		if !seen[key] {
+			delete(detector.stillIn, key)
+		}
+	}
+
+	sort.Slice(stalled, func(i, j int) bool {
+		if stalled[i].Kind != stalled[j].Kind {
+			return stalled[i].Kind < stalled[j].Kind
+		}
+		return stalled[i].ID < stalled[j].ID
+	})
+
+	keys := make([]string, len(stalled))
+	for idx, vehicle := range stalled {
+		keys[idx] = string(vehicle.Kind) + ":" + vehicle.ID
+	}
+	if slices.Equal(keys, detector.reported) {
+		return nil, false
+	}
+	detector.reported = keys
+	return stalled, true
+}
+
+// rawSpeed prefers the unsmoothed speed, since a smoothed one only approaches zero
+func rawSpeed(speed float64, raw *float64) float64 {
+	if raw != nil {
+		return *raw
+	}
+	return speed
+}
+
+func nearAny(location models.Location, others []models.Location, radius float64) bool {
+	for _, other := range others {
+		dx, dy, dz := location.X-other.X, location.Y-other.Y, location.Z-other.Z
+		if math.Sqrt(dx*dx+dy*dy+dz*dz) <= radius {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,85 @@
+package worker
+
+import (
+	"api/models/models"
+	"testing"
+	"time"
+)
+
+func TestStallDetectorReportsStandingVehicles(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := start
+	detector := newStallDetector(func() time.Time { return now }, time.Minute)
+	stations := models.VehicleStations{TruckStations: []models.TruckStation{{Name: "Depot", Location: models.Location{X: 10000}}}}
+	detector.Observe(&models.SatisfactoryEvent{Type: models.SatisfactoryEventVehicleStations, Data: stations})
+
+	truck := func(id string, status models.TruckStatus, x, speed float64) models.Truck {
+		return models.Truck{ID: id, Name: id, Status: status, Speed: speed, Location: models.Location{X: x}}
+	}
+	poll := func(after time.Duration, trucks ...models.Truck) ([]models.StalledVehicle, bool) {
+		now = start.Add(after)
+		return detector.Observe(&models.SatisfactoryEvent{Type: models.SatisfactoryEventVehicles, Data: models.Vehicles{Trucks: trucks}})
+	}
+	ids := func(stalled []models.StalledVehicle) []string {
+		result := make([]string, len(stalled))
+		for i, vehicle := range stalled {
+			result[i] = vehicle.ID
+		}
+		return result
+	}
+
+	stuck := truck("stuck", models.TruckStatusSelfDriving, 50000, 0)
+	docking := truck("docking", models.TruckStatusSelfDriving, 11000, 0) // Within the dock radius of the depot
+	parked := truck("parked", models.TruckStatusParked, 60000, 0)
+	moving := truck("moving", models.TruckStatusSelfDriving, 70000, 40)
+
+	steps := []struct {
+		name        string
+		after       time.Duration
+		trucks      []models.Truck
+		wantStalled []string
+		wantChanged bool
+	}{
+		{"standing still starts the clock", 0, []models.Truck{stuck, docking, parked, moving}, nil, false},
+		{"below the threshold", 30 * time.Second, []models.Truck{stuck, docking, parked, moving}, nil, false},
+		{"threshold reached", time.Minute, []models.Truck{stuck, docking, parked, moving}, []string{"stuck"}, true},
+		{"still stalled is not reported again", 2 * time.Minute, []models.Truck{stuck, docking, parked, moving}, nil, false},
+		{"moving again clears it", 3 * time.Minute, []models.Truck{truck("stuck", models.TruckStatusSelfDriving, 50100, 30)}, []string{}, true},
+		{"clock restarts", 3*time.Minute + 30*time.Second, []models.Truck{stuck}, nil, false},
+	}
+
+	for _, step := range steps {
+		stalled, changed := poll(step.after, step.trucks...)
+		if changed != step.wantChanged {
+			t.Errorf("%s: got changed %v, want %v", step.name, changed, step.wantChanged)
+		}
+		got := ids(stalled)
+		if len(got) != len(step.wantStalled) {
+			t.Errorf("%s: got stalled %v, want %v", step.name, got, step.wantStalled)
+			continue
+		}
+		for i := range got {
+			if got[i] != step.wantStalled[i] {
+				t.Errorf("%s: got stalled %v, want %v", step.name, got, step.wantStalled)
+			}
+		}
+	}
+
+	stalled, _ := poll(5*time.Minute, stuck)
+	if len(stalled) != 1 || !stalled[0].StalledSince.Equal(start.Add(3*time.Minute+30*time.Second)) {
+		t.Errorf("got %+v, want the truck stalled since it stopped again", stalled)
+	}
+}
+
+func TestStallDetectorUsesRawSpeed(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	detector := newStallDetector(func() time.Time { return now }, 0)
+	raw := 0.0
+	// The smoothed speed is still decaying while the drone has already stopped
+	drones := models.Vehicles{Drones: []models.Drone{{Name: "Drone 1", Status: models.DroneStatusFlying, Speed: 12, SpeedRaw: &raw}}}
+
+	stalled, changed := detector.Observe(&models.SatisfactoryEvent{Type: models.SatisfactoryEventVehicles, Data: drones})
+	if !changed || len(stalled) != 1 || stalled[0].Kind != models.StalledVehicleKindDrone {
+		t.Errorf("got %+v, want the stopped drone reported", stalled)
+	}
+}
@@ -0,0 +1,167 @@
+package worker
+
+import (
+	"api/models/models"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	stuckStorageSustain       = 10 * time.Minute // How long growth and starvation must co-occur, storages are polled every 2 minutes
+	stuckStorageStarvedInput  = 0.5              // Average consumer input efficiency below which an item counts as starved
+	stuckStorageGrowthEpsilon = 0.5              // Stored amount changes below this count as unchanged
+)
+
+// stuckStorageDetector cross-references storage contents growing over time with the consumers of the
+// same item starving, so items stuck in storage instead of reaching their consumers can be reported.
+type stuckStorageDetector struct {
+	mu       sync.Mutex
+	now      func() time.Time
+	sustain  time.Duration
+	growth   map[string]*storageGrowth // Item -> stored amount since it started growing
+	starved  map[string]*itemStarvation
+	reported []string // Sorted names of the last reported items
+}
+
+type storageGrowth struct {
+	since    time.Time
+	baseline float64
+	last     float64
+}
+
+type itemStarvation struct {
+	since      time.Time
+	consumers  int
+	efficiency float64
+}
+
+func newStuckStorageDetector(now func() time.Time, sustain time.Duration) *stuckStorageDetector {
+	return &stuckStorageDetector{
+		now:     now,
+		sustain: sustain,
+		growth:  make(map[string]*storageGrowth),
+		starved: make(map[string]*itemStarvation),
+	}
+}
+
+// Observe updates growth from storages events and starvation from machines events, and returns the
+// stuck items when that set changed since the last report. Partial events are ignored.
+func (detector *stuckStorageDetector) Observe(event *models.SatisfactoryEvent) ([]models.StuckStorageItem, bool) {
+	if event.Partial {
+		return nil, false
+	}
+
+	detector.mu.Lock()
+	defer detector.mu.Unlock()
+
+	switch data := event.Data.(type) {
+	case []models.Storage:
+		detector.observeStorages(data)
+	case []models.Machine:
+		detector.observeMachines(data)
+	default:
+		return nil, false
+	}
+
+	now := detector.now()
+	names := make([]string, 0)
+	for name, growth := range detector.growth {
+		starvation, ok := detector.starved[name]
+		if !ok || growth.last-growth.baseline < stuckStorageGrowthEpsilon {
+			continue
+		}
+		if now.Sub(growth.since) >= detector.sustain && now.Sub(starvation.since) >= detector.sustain {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	if slices.Equal(names, detector.reported) {
+		return nil, false
+	}
+	detector.reported = names
+
+	stuck := make([]models.StuckStorageItem, 0, len(names))
+	for _, name := range names {
+		growth := detector.growth[name]
+		starvation := detector.starved[name]
+		since := growth.since
+		if starvation.since.After(since) {
+			since = starvation.since
+		}
+		stuck = append(stuck, models.StuckStorageItem{
+			Name:               name,
+			Stored:             growth.last,
+			GrowthPerMinute:    (growth.last - growth.baseline) / now.Sub(growth.since).Minutes(),
+			Consumers:          starvation.consumers,
+			ConsumerEfficiency: starvation.efficiency,
+			Since:              since,
+		})
+	}
+	return stuck, true
+}
+
+// observeStorages restarts the growth of items whose stored amount dropped. Dimensional depot
+// uploaders are skipped, their contents leave for the cloud.
+func (detector *stuckStorageDetector) observeStorages(storages []models.Storage) {
+	now := detector.now()
+	totals := make(map[string]float64)
+	for _, storage := range storages {
+		if storage.Type == models.StorageTypeDimensionalDepotUploader {
+			continue
+		}
+		for _, item := range storage.Inventory {
+			if item.Count > 0 {
+				totals[item.Name] += item.Count
+			}
+		}
+	}
+
+	for name, total := range totals {
+		growth, ok := detector.growth[name]
+		if !ok || total < growth.last-stuckStorageGrowthEpsilon {
+			detector.growth[name] = &storageGrowth{since: now, baseline: total, last: total}
+			continue
+		}
+		growth.last = total
+	}
+	for name := range detector.growth {
+		if _, ok := totals[name]; !ok {
+			delete(detector.growth, name)
+		}
+	}
+}
+
+// observeMachines tracks since when the consumers of each item have been starved of it on average
+func (detector *stuckStorageDetector) observeMachines(machines []models.Machine) {
+	now := detector.now()
+	consumers := make(map[string]int)
+	efficiency := make(map[string]float64)
+	for _, machine := range machines {
+		for _, input := range machine.Input {
+			consumers[input.Name]++
+			efficiency[input.Name] += input.Efficiency
+		}
+	}
+
+	for name, count := range consumers {
+		average := efficiency[name] / float64(count)
+		if average >= stuckStorageStarvedInput {
+			delete(detector.starved, name)
+			continue
+		}
+		starvation, ok := detector.starved[name]
+		if !ok {
+			starvation = &itemStarvation{since: now}
+			detector.starved[name] = starvation
+		}
+		starvation.consumers = count
+		starvation.efficiency = average
+	}
+	for name := range detector.starved {
+		if _, ok := consumers[name]; !ok {
+			delete(detector.starved, name)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package worker
+
+import (
+	"api/models/models"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestStuckStorageDetectorNeedsSustainedGrowthAndStarvation(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := start
+	detector := newStuckStorageDetector(func() time.Time { return now }, 10*time.Minute)
+
+	storages := func(screws float64) *models.SatisfactoryEvent {
+		return &models.SatisfactoryEvent{Type: models.SatisfactoryEventStorages, Data: []models.Storage{
+			{Inventory: []models.ItemStats{{Name: "Screw", Count: screws}, {Name: "Iron Plate", Count: 50}}},
+			// Uploaded items leave for the cloud, so their growth is never tracked
+			{Type: models.StorageTypeDimensionalDepotUploader, Inventory: []models.ItemStats{{Name: "Rotor", Count: screws}}},
+		}}
+	}
+	machines := func(screwEfficiency float64) *models.SatisfactoryEvent {
+		input := func(name string, efficiency float64) models.Machine {
+			return models.Machine{Input: []models.MachineProdStats{{Name: name, Efficiency: efficiency}}}
+		}
+		return &models.SatisfactoryEvent{Type: models.SatisfactoryEventMachines, Data: []models.Machine{
+			input("Screw", screwEfficiency), input("Screw", screwEfficiency+0.2), input("Iron Plate", 0.1), input("Rotor", 0.1),
+		}}
+	}
+
+	steps := []struct {
+		name    string
+		minute  int
+		event   *models.SatisfactoryEvent
+		changed bool
+		stuck   []models.StuckStorageItem
+	}{
+		{"storages seen", 0, storages(100), false, nil},
+		{"consumers starved", 0, machines(0.2), false, nil},
+		{"growing, not sustained", 5, storages(200), false, nil},
+		{"partial ignored", 5, &models.SatisfactoryEvent{Type: models.SatisfactoryEventStorages, Data: []models.Storage{}, Partial: true}, false, nil},
+		{"sustained", 10, storages(300), true, []models.StuckStorageItem{
+			{Name: "Screw", Stored: 300, GrowthPerMinute: 20, Consumers: 2, ConsumerEfficiency: 0.3, Since: start},
+		}},
+		{"consumers recover", 12, machines(0.8), true, []models.StuckStorageItem{}},
+		// The drop restarts the growth, so both conditions are sustained only 10 minutes later
+		{"storage drained", 14, storages(50), false, nil},
+		{"starved again", 14, machines(0), false, nil},
+		{"growing again", 20, storages(80), false, nil},
+		{"sustained again", 24, storages(100), true, []models.StuckStorageItem{
+			{Name: "Screw", Stored: 100, GrowthPerMinute: 5, Consumers: 2, ConsumerEfficiency: 0.1, Since: start.Add(14 * time.Minute)},
+		}},
+	}
+
+	for _, step := range steps {
+		now = start.Add(time.Duration(step.minute) * time.Minute)
+		stuck, changed := detector.Observe(step.event)
+		if changed != step.changed {
+			t.Fatalf("%s: got changed %v, want %v (%+v)", step.name, changed, step.changed, stuck)
+		}
+		if !changed {
+			continue
+		}
+		if len(stuck) != len(step.stuck) {
+			t.Fatalf("%s: got %+v, want %+v", step.name, stuck, step.stuck)
+		}
+		for i, want := range step.stuck {
+			got := stuck[i]
+			if got.Name != want.Name || got.Stored != want.Stored || got.Consumers != want.Consumers || !got.Since.Equal(want.Since) ||
+				math.Abs(got.GrowthPerMinute-want.GrowthPerMinute) > 1e-9 || math.Abs(got.ConsumerEfficiency-want.ConsumerEfficiency) > 1e-9 {
+				t.Errorf("%s: got %+v, want %+v", step.name, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package worker
+
+import (
+	"api/models/models"
+	"sort"
+	"sync"
+)
+
+// entityTracker remembers the entities of the previous poll of every list-based event,
+// so entities that disappear (e.g. demolished machines or belts) can be signalled explicitly.
+type entityTracker struct {
+	mu       sync.Mutex
+	previous map[models.SatisfactoryEventType]map[string]map[string]bool // event type -> kind -> keys
+}
+
+func newEntityTracker() *entityTracker {
+	return &entityTracker{
+		previous: make(map[models.SatisfactoryEventType]map[string]map[string]bool),
+	}
+}
+
+// Removed returns the entities missing from the event compared to the previous poll of the same type.
+// The first poll of a type only records its entities, and partial polls are ignored since their
+// missing entities were not removed.
+func (tracker *entityTracker) Removed(event *models.SatisfactoryEvent) []models.RemovedEntities {
+	if event.Partial {
+		return nil
+	}
+
+	current := entityKeys(event.Data)
+	if current == nil {
+		return nil
+	}
+
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+
+	previous, seen := tracker.previous[event.Type]
+	tracker.previous[event.Type] = current
+	if !seen {
+		return nil
+	}
+
+	var result []models.RemovedEntities
+	for _, kind := range sortedKeys(previous) {
+		var removed []string
+		for key := range previous[kind] {
+			if !current[kind][key] {
+				removed = append(removed, key)
+			}
+		}
+		if len(removed) == 0 {
+			continue
+		}
+		sort.Strings(removed)
+		result = append(result, models.RemovedEntities{
+			EventType: event.Type,
+			Kind:      kind,
+			IDs:       removed,
+		})
+	}
+	return result
+}
+
+// entityKeys returns the entity keys per kind of a list-based event, or nil for other events
+func entityKeys(data any) map[string]map[string]bool {
+	switch typed := data.(type) {
+	case []models.Circuit:
+		return kinds("circuits", keysOf(typed, func(c models.Circuit) string { return c.ID }))
+	case []models.Player:
+		return kinds("players", keysOf(typed, func(p models.Player) string { return p.ID }))
+	case []models.Machine:
+		return kinds("machines", keysOf(typed, func(m models.Machine) string { return m.ID }))
+	case []models.TrainRail:
+		return kinds("trainRails", keysOf(typed, func(r models.TrainRail) string { return r.ID }))
+	case []models.Cable:
+		return kinds("cables", keysOf(typed, func(c models.Cable) string { return c.ID }))
+	case []models.Storage:
+		return kinds("storages", keysOf(typed, func(s models.Storage) string { return s.ID }))
+	case []models.Tractor:
+		return kinds("tractors", keysOf(typed, func(t models.Tractor) string { return t.ID }))
+	case []models.Explorer:
+		return kinds("explorers", keysOf(typed, func(e models.Explorer) string { return e.ID }))
+	case []models.VehiclePath:
+		return kinds("vehiclePaths", keysOf(typed, func(p models.VehiclePath) string { return p.Name }))
+	case []models.RadarTower:
+		return kinds("radarTowers", keysOf(typed, func(r models.RadarTower) string { return r.ID }))
+	case []models.ResourceNode:
+		return kinds("resourceNodes", keysOf(typed, func(n models.ResourceNode) string { return n.ID }))
+	case models.Belts:
+		return map[string]map[string]bool{
+			"belts":           keysOf(typed.Belts, func(b models.Belt) string { return b.ID }),
+			"splitterMergers": keysOf(typed.SplitterMergers, func(s models.SplitterMerger) string { return s.ID }),
+		}
+	case models.Pipes:
+		return map[string]map[string]bool{
+			"pipes":         keysOf(typed.Pipes, func(p models.Pipe) string { return p.ID }),
+			"pipeJunctions": keysOf(typed.PipeJunctions, func(j models.PipeJunction) string { return j.ID }),
+		}
+	case models.Hypertubes:
+		return map[string]map[string]bool{
+			"hypertubes":         keysOf(typed.Hypertubes, func(h models.Hypertube) string { return h.ID }),
+			"hypertubeEntrances": keysOf(typed.HypertubeEntrances, func(e models.HypertubeEntrance) string { return e.ID }),
+		}
+	case models.Vehicles:
+		return map[string]map[string]bool{
+			"trains":    keysOf(typed.Trains, func(t models.Train) string { return t.ID }),
+			"drones":    keysOf(typed.Drones, func(d models.Drone) string { return d.Name }),
+			"trucks":    keysOf(typed.Trucks, func(t models.Truck) string { return t.ID }),
+			"tractors":  keysOf(typed.Tractors, func(t models.Tractor) string { return t.ID }),
+			"explorers": keysOf(typed.Explorers, func(e models.Explorer) string { return e.ID }),
+		}
+	case models.VehicleStations:
+		return map[string]map[string]bool{
+			"trainStations": keysOf(typed.TrainStations, func(s models.TrainStation) string { return s.Name }),
+			"droneStations": keysOf(typed.DroneStations, func(s models.DroneStation) string { return s.Name }),
+			"truckStations": keysOf(typed.TruckStations, func(s models.TruckStation) string { return s.Name }),
+		}
+	default:
+		return nil
+	}
+}
+
+func kinds(kind string, keys map[string]bool) map[string]map[string]bool {
+	return map[string]map[string]bool{kind: keys}
+}
+
+func keysOf[T any](items []T, key func(T) string) map[string]bool {
+	keys := make(map[string]bool, len(items))
+	for _, item := range items {
+		keys[key(item)] = true
+	}
+	return keys
+}
+
+func sortedKeys[V any](set map[string]V) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,52 @@
+package worker
+
+import (
+	"api/models/models"
+	"reflect"
+	"testing"
+)
+
+func TestEntityTrackerEmitsRemovedIDs(t *testing.T) {
+	tracker := newEntityTracker()
+	beltsEvent := func(partial bool, beltIDs []string, splitterIDs ...string) *models.SatisfactoryEvent {
+		belts := models.Belts{}
+		for _, id := range beltIDs {
+			belts.Belts = append(belts.Belts, models.Belt{ID: id})
+		}
+		for _, id := range splitterIDs {
+			belts.SplitterMergers = append(belts.SplitterMergers, models.SplitterMerger{ID: id})
+		}
+		return &models.SatisfactoryEvent{Type: models.SatisfactoryEventBelts, Data: belts, Partial: partial}
+	}
+
+	steps := []struct {
+		name  string
+		event *models.SatisfactoryEvent
+		want  []models.RemovedEntities
+	}{
+		{"first poll only records", beltsEvent(false, []string{"b1", "b2", "b3"}, "s1"), nil},
+		{"nothing removed", beltsEvent(false, []string{"b1", "b2", "b3", "b4"}, "s1"), nil},
+		{"partial poll is ignored", beltsEvent(true, nil), nil},
+		{
+			name:  "belts and splitter demolished",
+			event: beltsEvent(false, []string{"b2"}),
+			want: []models.RemovedEntities{
+				{EventType: models.SatisfactoryEventBelts, Kind: "belts", IDs: []string{"b1", "b3", "b4"}},
+				{EventType: models.SatisfactoryEventBelts, Kind: "splitterMergers", IDs: []string{"s1"}},
+			},
+		},
+		{"other event types are tracked separately", circuitsEvent(models.Circuit{ID: "1"}), nil},
+		{"events without entities are skipped", &models.SatisfactoryEvent{Type: models.SatisfactoryEventProdStats, Data: models.ProdStats{}}, nil},
+		{
+			name:  "circuit removed",
+			event: circuitsEvent(),
+			want:  []models.RemovedEntities{{EventType: models.SatisfactoryEventCircuits, Kind: "circuits", IDs: []string{"1"}}},
+		},
+	}
+
+	for _, step := range steps {
+		if got := tracker.Removed(step.event); !reflect.DeepEqual(got, step.want) {
+			t.Errorf("%s: got %+v, want %+v", step.name, got, step.want)
+		}
+	}
+}
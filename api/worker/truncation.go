@@ -0,0 +1,94 @@
+package worker
+
+import (
+	"api/models/models"
+	"slices"
+	"sort"
+)
+
+// truncateEvent caps each entity list of a list-based event to limit entities, keeping the
+// highest-priority ones, and flags the event with the true total. A limit of zero disables capping.
+// Only the streamed copy is capped, the cached state keeps every entity.
+func truncateEvent(event models.SatisfactoryEvent, limit int) models.SatisfactoryEvent {
+	if limit <= 0 {
+		return event
+	}
+
+	data, count, total := truncateData(event.Data, limit)
+	if count == total {
+		return event
+	}
+
+	event.Data = data
+	event.Truncated = true
+	event.TotalCount = total
+	return event
+}
+
+func truncateData(data any, limit int) (any, int, int) {
+	switch typed := data.(type) {
+	case []models.Machine:
+		return capSlice(typed, limit, machineHasPriority)
+	case []models.Storage:
+		return capSlice(typed, limit, nil)
+	case []models.TrainRail:
+		return capSlice(typed, limit, nil)
+	case []models.Cable:
+		return capSlice(typed, limit, nil)
+	case []models.ResourceNode:
+		return capSlice(typed, limit, nil)
+	case models.Belts:
+		belts, beltCount, beltTotal := capSlice(typed.Belts, limit, func(a, b models.Belt) bool {
+			return a.ItemsPerMinute > b.ItemsPerMinute
+		})
+		splitterMergers, splitterCount, splitterTotal := capSlice(typed.SplitterMergers, limit, nil)
+		return models.Belts{Belts: belts, SplitterMergers: splitterMergers},
+			beltCount + splitterCount, beltTotal + splitterTotal
+	case models.Pipes:
+		pipes, pipeCount, pipeTotal := capSlice(typed.Pipes, limit, func(a, b models.Pipe) bool {
+			return a.ItemsPerMinute > b.ItemsPerMinute
+		})
+		junctions, junctionCount, junctionTotal := capSlice(typed.PipeJunctions, limit, nil)
+		return models.Pipes{Pipes: pipes, PipeJunctions: junctions},
+			pipeCount + junctionCount, pipeTotal + junctionTotal
+	default:
+		return data, 0, 0
+	}
+}
+
+// capSlice keeps the first limit items, after ordering them by priority if one is given
+func capSlice[T any](items []T, limit int, priority func(a, b T) bool) ([]T, int, int) {
+	if len(items) <= limit {
+		return items, len(items), len(items)
+	}
+
+	kept := items
+	if priority != nil {
+		kept = slices.Clone(items)
+		sort.SliceStable(kept, func(i, j int) bool { return priority(kept[i], kept[j]) })
+	}
+	return kept[:limit], limit, len(items)
+}
+
+// machineHasPriority orders machines needing attention first, then by output throughput
+func machineHasPriority(a, b models.Machine) bool {
+	aAlert, bAlert := machineInAlert(a), machineInAlert(b)
+	if aAlert != bAlert {
+		return aAlert
+	}
+	return machineThroughput(a) > machineThroughput(b)
+}
+
+func machineInAlert(machine models.Machine) bool {
+	return machine.Status == models.MachineStatusIdle ||
+		machine.Status == models.MachineStatusUnconfigured ||
+		machine.Status == models.MachineStatusUnknown
+}
+
+func machineThroughput(machine models.Machine) float64 {
+	total := 0.0
+	for _, output := range machine.Output {
+		total += output.Current
+	}
+	return total
+}
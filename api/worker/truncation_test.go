@@ -0,0 +1,78 @@
+package worker
+
+import (
+	"api/models/models"
+	"testing"
+)
+
+func TestTruncateEventCapsMachines(t *testing.T) {
+	machine := func(id string, status models.MachineStatus, current float64) models.Machine {
+		return models.Machine{ID: id, Status: status, Output: []models.MachineProdStats{{Current: current}}}
+	}
+	machines := []models.Machine{
+		machine("slow", models.MachineStatusOperating, 5),
+		machine("fast", models.MachineStatusOperating, 60),
+		machine("idle", models.MachineStatusIdle, 0),
+		machine("medium", models.MachineStatusOperating, 30),
+		machine("unconfigured", models.MachineStatusUnconfigured, 0),
+	}
+	event := models.SatisfactoryEvent{Type: models.SatisfactoryEventMachines, Data: machines}
+
+	tests := []struct {
+		name      string
+		limit     int
+		want      []string
+		truncated bool
+	}{
+		{"disabled", 0, []string{"slow", "fast", "idle", "medium", "unconfigured"}, false},
+		{"below the limit", 5, []string{"slow", "fast", "idle", "medium", "unconfigured"}, false},
+		{"alerts first", 2, []string{"idle", "unconfigured"}, true},
+		{"then by throughput", 4, []string{"idle", "unconfigured", "fast", "medium"}, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			truncated := truncateEvent(event, test.limit)
+			if truncated.Truncated != test.truncated {
+				t.Errorf("got truncated %v, want %v", truncated.Truncated, test.truncated)
+			}
+			if test.truncated && truncated.TotalCount != len(machines) {
+				t.Errorf("got total count %d, want %d", truncated.TotalCount, len(machines))
+			}
+			kept := truncated.Data.([]models.Machine)
+			if len(kept) != len(test.want) {
+				t.Fatalf("got %d machines, want %v", len(kept), test.want)
+			}
+			for i, machine := range kept {
+				if machine.ID != test.want[i] {
+					t.Errorf("machine %d: got %s, want %s", i, machine.ID, test.want[i])
+				}
+			}
+		})
+	}
+
+	if event.Data.([]models.Machine)[0].ID != "slow" {
+		t.Error("truncation reordered the machines of the original event, want the cached state left untouched")
+	}
+}
+
+func TestTruncateEventCountsBothBeltLists(t *testing.T) {
+	belts := models.Belts{
+		Belts:           []models.Belt{{ID: "b1", ItemsPerMinute: 60}, {ID: "b2", ItemsPerMinute: 480}, {ID: "b3", ItemsPerMinute: 270}},
+		SplitterMergers: []models.SplitterMerger{{ID: "s1"}},
+	}
+	event := truncateEvent(models.SatisfactoryEvent{Type: models.SatisfactoryEventBelts, Data: belts}, 2)
+
+	if !event.Truncated || event.TotalCount != 4 {
+		t.Errorf("got truncated %v with total %d, want truncated with total 4", event.Truncated, event.TotalCount)
+	}
+	kept := event.Data.(models.Belts)
+	if len(kept.Belts) != 2 || kept.Belts[0].ID != "b2" || kept.Belts[1].ID != "b3" || len(kept.SplitterMergers) != 1 {
+		t.Errorf("got %+v, want the two busiest belts and the splitter", kept)
+	}
+
+	players := models.SatisfactoryEvent{Type: models.SatisfactoryEventPlayers, Data: []models.Player{{ID: "1"}, {ID: "2"}, {ID: "3"}}}
+	if event := truncateEvent(players, 1); event.Truncated || len(event.Data.([]models.Player)) != 3 {
+		t.Errorf("got %+v, want events without a cap left as is", event)
+	}
+}
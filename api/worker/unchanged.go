@@ -0,0 +1,141 @@
+package worker
+
+import (
+	"api/models/models"
+	"api/pkg/config"
+	"encoding/json"
+	"math"
+	"strings"
+	"sync"
+)
+
+const (
+	defaultEfficiencyDeadband   = 0.001
+	defaultRateRelativeDeadband = 0.005
+	defaultPercentageDeadband   = 0.1
+)
+
+type deadbands struct {
+	efficiency   float64 // Absolute
+	rateRelative float64 // Relative to the larger magnitude
+	percentage   float64 // Absolute
+}
+
+// unchangedFilter remembers the last published data of every polled event type, so a poll that only
+// differs by float jitter within the deadbands is not published again. The reference is only replaced
+// when an event is published, so slow drift still shows once it exceeds a deadband.
+type unchangedFilter struct {
+	mu        sync.Mutex
+	deadbands deadbands
+	last      map[models.SatisfactoryEventType]any // Event type -> data decoded as generic JSON
+}
+
+// newConfiguredUnchangedFilter returns a filter using the configured deadbands, or nil if suppression is disabled
+func newConfiguredUnchangedFilter() *unchangedFilter {
+	if !config.Config.SuppressUnchanged {
+		return nil
+	}
+	configured := config.Config.Deadbands
+	bands := deadbands{
+		efficiency:   defaultEfficiencyDeadband,
+		rateRelative: defaultRateRelativeDeadband,
+		percentage:   defaultPercentageDeadband,
+	}
+	if configured.Efficiency > 0 {
+		bands.efficiency = configured.Efficiency
+	}
+	if configured.RateRelative > 0 {
+		bands.rateRelative = configured.RateRelative
+	}
+	if configured.Percentage > 0 {
+		bands.percentage = configured.Percentage
+	}
+	return newUnchangedFilter(bands)
+}
+
+func newUnchangedFilter(bands deadbands) *unchangedFilter {
+	return &unchangedFilter{
+		deadbands: bands,
+		last:      make(map[models.SatisfactoryEventType]any),
+	}
+}
+
+// Unchanged reports whether the event equals the last published event of its type within the
+// deadbands, and otherwise records it as the new reference. API status and partial events always pass.
+func (filter *unchangedFilter) Unchanged(event *models.SatisfactoryEvent) bool {
+	if event.Partial || event.Type == models.SatisfactoryEventApiStatus {
+		return false
+	}
+
+	encoded, err := json.Marshal(event.Data)
+	if err != nil {
+		return false
+	}
+	var current any
+	if err := json.Unmarshal(encoded, &current); err != nil {
+		return false
+	}
+
+	filter.mu.Lock()
+	defer filter.mu.Unlock()
+
+	if previous, ok := filter.last[event.Type]; ok && filter.deadbands.equal(previous, current, "") {
+		return true
+	}
+	filter.last[event.Type] = current
+	return false
+}
+
+// equal compares generic JSON values, applying the deadband matching the key of numbers
+func (bands deadbands) equal(a, b any, key string) bool {
+	switch typedA := a.(type) {
+	case map[string]any:
+		typedB, ok := b.(map[string]any)
+		if !ok || len(typedA) != len(typedB) {
+			return false
+		}
+		for field, valueA := range typedA {
+			valueB, ok := typedB[field]
+			if !ok || !bands.equal(valueA, valueB, field) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		typedB, ok := b.([]any)
+		if !ok || len(typedA) != len(typedB) {
+			return false
+		}
+		for idx := range typedA {
+			if !bands.equal(typedA[idx], typedB[idx], key) {
+				return false
+			}
+		}
+		return true
+	case float64:
+		typedB, ok := b.(float64)
+		if !ok {
+			return false
+		}
+		return bands.numbersEqual(typedA, typedB, key)
+	default:
+		return a == b
+	}
+}
+
+func (bands deadbands) numbersEqual(a, b float64, key string) bool {
+	if a == b {
+		return true
+	}
+	lower := strings.TrimSuffix(strings.ToLower(key), "raw")
+	switch {
+	case strings.Contains(lower, "efficiency") || lower == "productivity":
+		return math.Abs(a-b) <= bands.efficiency
+	case lower == "percentage":
+		return math.Abs(a-b) <= bands.percentage
+	case strings.HasSuffix(lower, "perminute") || lower == "current" || lower == "speed":
+		return math.Abs(a-b) <= bands.rateRelative*math.Max(math.Abs(a), math.Abs(b))
+	default:
+		return false
+	}
+}
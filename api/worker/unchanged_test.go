@@ -0,0 +1,88 @@
+package worker
+
+import (
+	"api/models/models"
+	"api/pkg/config"
+	"testing"
+)
+
+func TestUnchangedFilterSuppressesWithinDeadbands(t *testing.T) {
+	filter := newUnchangedFilter(deadbands{
+		efficiency:   defaultEfficiencyDeadband,
+		rateRelative: defaultRateRelativeDeadband,
+		percentage:   defaultPercentageDeadband,
+	})
+	prodStats := func(perMinute, efficiency, count float64) *models.SatisfactoryEvent {
+		return &models.SatisfactoryEvent{Type: models.SatisfactoryEventProdStats, Data: models.ProdStats{Items: []models.ItemProdStats{{
+			ItemStats:         models.ItemStats{Name: "Iron Plate", Count: count},
+			ProducedPerMinute: perMinute,
+			ProduceEfficiency: efficiency,
+		}}}}
+	}
+
+	steps := []struct {
+		name      string
+		event     *models.SatisfactoryEvent
+		unchanged bool
+	}{
+		{"first poll", prodStats(100, 0.5, 10), false},
+		{"identical", prodStats(100, 0.5, 10), true},
+		{"rate jitter", prodStats(100.4, 0.5, 10), true},
+		{"efficiency jitter", prodStats(100, 0.5005, 10), true},
+		// Still compared to the published 100, so the drift adds up past the deadband
+		{"rate drift", prodStats(100.8, 0.5, 10), false},
+		{"efficiency change", prodStats(100.8, 0.51, 10), false},
+		{"count without deadband", prodStats(100.8, 0.51, 10.01), false},
+		{"partial always passes", &models.SatisfactoryEvent{Type: models.SatisfactoryEventProdStats, Data: models.ProdStats{}, Partial: true}, false},
+		{"other types have their own reference", &models.SatisfactoryEvent{Type: models.SatisfactoryEventBelts, Data: models.Belts{}}, false},
+		{"belts unchanged", &models.SatisfactoryEvent{Type: models.SatisfactoryEventBelts, Data: models.Belts{}}, true},
+		{"prod stats reference kept", prodStats(100.8, 0.51, 10.01), true},
+	}
+
+	for _, step := range steps {
+		if got := filter.Unchanged(step.event); got != step.unchanged {
+			t.Errorf("%s: got unchanged %v, want %v", step.name, got, step.unchanged)
+		}
+	}
+}
+
+func TestDeadbandsMatchKeys(t *testing.T) {
+	bands := deadbands{efficiency: 0.01, rateRelative: 0.1, percentage: 1}
+	tests := []struct {
+		key   string
+		a, b  float64
+		equal bool
+	}{
+		{"productivity", 0.5, 0.505, true},
+		{"consumeEfficiency", 0.5, 0.52, false},
+		{"percentage", 50, 50.9, true},
+		{"percentage", 50, 51.5, false},
+		{"consumedPerMinuteRaw", 100, 109, true},
+		{"current", 100, 115, false},
+		{"speed", 0, 0.01, false},
+		{"x", 1000, 1000.001, false},
+	}
+	for _, test := range tests {
+		if got := bands.numbersEqual(test.a, test.b, test.key); got != test.equal {
+			t.Errorf("%s %v vs %v: got equal %v, want %v", test.key, test.a, test.b, got, test.equal)
+		}
+	}
+}
+
+func TestConfiguredUnchangedFilter(t *testing.T) {
+	previous := config.Config
+	t.Cleanup(func() { config.Config = previous })
+
+	config.Config = &config.Type{}
+	if filter := newConfiguredUnchangedFilter(); filter != nil {
+		t.Errorf("got %+v, want suppression disabled", filter)
+	}
+
+	config.Config = &config.Type{SuppressUnchanged: true}
+	config.Config.Deadbands.RateRelative = 0.05
+	filter := newConfiguredUnchangedFilter()
+	want := deadbands{efficiency: defaultEfficiencyDeadband, rateRelative: 0.05, percentage: defaultPercentageDeadband}
+	if filter == nil || filter.deadbands != want {
+		t.Errorf("got %+v, want deadbands %+v", filter, want)
+	}
+}
@@ -1,5 +1,39 @@
 // Code generated by tygo. DO NOT EDIT.
 
+//////////
+// source: active_recipe.go
+
+/**
+ * ActiveRecipe is a recipe inferred from the inputs and outputs of the machines running it
+ */
+export interface ActiveRecipe {
+  product: string; // Main output
+  byproducts: string[];
+  inputs: string[];
+  machineType: MachineType;
+  machines: number /* int */;
+  alternate: boolean; // Inputs differ from the standard recipe of the product
+  known: boolean; // The standard recipe of the product is known, otherwise Alternate is always false
+}
+
+//////////
+// source: alert.go
+
+export type AlertSeverity = string;
+export const AlertSeverityWarning: AlertSeverity = 'warning';
+export const AlertSeverityCritical: AlertSeverity = 'critical';
+/**
+ * Alert is an ongoing power condition of a circuit. Since is kept while the condition lasts,
+ * so consumers can tell a new alert from one reported before.
+ */
+export interface Alert {
+  severity: AlertSeverity;
+  kind: AlertName;
+  circuitId: string;
+  message: string;
+  since: string; // When the condition was first seen
+}
+
 //////////
 // source: api_status.go
 
@@ -52,6 +86,25 @@ export interface LogoutResponse {
   success: boolean;
 }
 
+//////////
+// source: base_score.go
+
+export type BaseScoreFactor = string;
+export const BaseScoreFactorEfficiency: BaseScoreFactor = 'efficiency';
+export const BaseScoreFactorPower: BaseScoreFactor = 'power';
+export const BaseScoreFactorProduction: BaseScoreFactor = 'production';
+export const BaseScoreFactorLogistics: BaseScoreFactor = 'logistics';
+export interface BaseScoreComponent {
+  factor: BaseScoreFactor;
+  score: number /* float64 */; // 0-100
+  weight: number /* float64 */; // Share of the overall score, 0-1
+  value: number /* float64 */; // Raw input, a ratio for efficiency and power, a count for production and logistics
+}
+export interface BaseScore {
+  score: number /* float64 */; // Weighted 0-100 score over the available components
+  components: BaseScoreComponent[]; // Only factors whose inputs have been polled
+}
+
 //////////
 // source: belt.go
 
@@ -65,6 +118,8 @@ export interface Belt {
   splineData: Location[];
   length: number /* float64 */;
   itemsPerMinute: number /* float64 */;
+  maxItemsPerMinute: number /* float64 */; // Rated capacity of the belt's mark
+  saturation: number /* float64 */; // ItemsPerMinute relative to the rated capacity, 0-1
 }
 export interface Belts {
   belts: Belt[];
@@ -90,17 +145,23 @@ export interface Cable {
 export interface CircuitConsumption {
   total: number /* float64 */;
   max: number /* float64 */;
+  totalMegawatts?: number /* float64 */; // Set only when dual units are enabled
+  maxMegawatts?: number /* float64 */; // Set only when dual units are enabled
 }
 export interface CircuitProduction {
   total: number /* float64 */;
+  totalMegawatts?: number /* float64 */; // Set only when dual units are enabled
 }
 export interface CircuitCapacity {
   total: number /* float64 */;
+  totalMegawatts?: number /* float64 */; // Set only when dual units are enabled
 }
 export interface CircuitBattery {
   percentage: number /* float64 */;
   capacity: number /* float64 */;
   differential: number /* float64 */;
+  capacityMegawattHours?: number /* float64 */; // Set only when dual units are enabled
+  differentialMegawatts?: number /* float64 */; // Set only when dual units are enabled
   untilFull: number /* float64 */; // parsed from 00:00:00 to float64
   untilEmpty: number /* float64 */; // parsed from 00:00:00 to float64
 }
@@ -121,6 +182,39 @@ export interface CircuitIDs {
   circuitGroupId?: number /* int */;
 }
 
+//////////
+// source: collectible_progress.go
+
+export type CollectibleUnit = string;
+export const CollectibleUnitItems: CollectibleUnit = 'items';
+export const CollectibleUnitPowerShards: CollectibleUnit = 'powerShards'; // Power slugs in the power shards they refine into
+/**
+ * CollectibleProgress compares the collectibles discovered by radar towers with those held in inventories
+ */
+export interface CollectibleProgress {
+  name: string;
+  unit: CollectibleUnit;
+  discovered: number /* int */; // Uncollected signals revealed by radar towers
+  collected: number /* int */; // Held in player and storage inventories
+  remaining: number /* int */; // Discovered minus collected, never negative
+  note: string;
+}
+
+//////////
+// source: conveyor_throughput.go
+
+export interface ItemThroughput {
+  name: string;
+  form: ResourceForm;
+  perMinute: number /* float64 */; // Summed flow over every belt or pipe carrying the item
+  conveyors: number /* int */; // Number of belts or pipes carrying the item
+}
+export interface ConveyorThroughput {
+  items: ItemThroughput[];
+  unattributedBeltPerMinute: number /* float64 */; // Flow on belts whose items could not be resolved
+  unattributedPipePerMinute: number /* float64 */; // Flow in pipes whose fluid could not be resolved
+}
+
 //////////
 // source: data_point.go
 
@@ -134,6 +228,81 @@ export interface DataPoint {
   data: any; // The actual data payload (type depends on DataType)
 }
 
+//////////
+// source: diagnostics_dump.go
+
+/**
+ * DiagnosticsDump is a snapshot of the internal state of this instance, for bug reports
+ */
+export interface DiagnosticsDump {
+  instanceId: string;
+  generatedAt: string;
+  publishers: PublisherDiagnostics[]; // Sessions polled by this instance
+}
+/**
+ * PublisherDiagnostics is the state of one session publisher and its trackers
+ */
+export interface PublisherDiagnostics {
+  sessionId: string;
+  saveName: string;
+  disconnected: boolean;
+  leaseOwned: boolean;
+  leaseUncertain: boolean;
+  gameTimeId: number /* int64 */;
+  client?: ClientDiagnostics; // Null until the publisher created its client
+  trackers: TrackerDiagnostics[];
+}
+/**
+ * TrackerDiagnostics summarizes the state of one stateful tracker
+ */
+export interface TrackerDiagnostics {
+  name: string;
+  enabled: boolean;
+  entries: number /* int */; // Tracked entities or values
+  oldest?: string; // Earliest timestamp held, if the tracker keeps any
+  details?: { [key: string]: any };
+}
+/**
+ * ClientDiagnostics is the connection state of a session's game server client
+ */
+export interface ClientDiagnostics {
+  apiUp: boolean;
+  gamePaused: boolean;
+  failureCount: number /* int */;
+  disconnected: boolean;
+  endpointErrors: EndpointError[];
+  suppressedErrors: { [key: SatisfactoryEventType]: number /* int */ }; // Failing endpoints -> errors not logged since the last logged one
+  pollBudget?: PollBudget; // Null when no poll budget is configured
+  backoffSeconds: { [key: SatisfactoryEventType]: number /* float64 */ }; // Endpoints backed off after repeated failures -> seconds until the next attempt
+  queueDepth: number /* int */; // Polls waiting for a request queue worker
+  queueStats: { [key: SatisfactoryEventType]: EndpointStat }; // Endpoints -> timing of their polls
+}
+/**
+ * EndpointStat is the timing of an endpoint's polls through the request queue
+ */
+export interface EndpointStat {
+  lastDurationSeconds: number /* float64 */;
+  averageDurationSeconds: number /* float64 */; // Exponentially weighted, recent polls count most
+  successes: number /* int */;
+  failures: number /* int */;
+}
+
+//////////
+// source: draining_battery.go
+
+/**
+ * DrainingBattery is a circuit whose batteries only ever discharged over the history window,
+ * meaning the grid lacks generation to recharge them
+ */
+export interface DrainingBattery {
+  circuitId: string;
+  samples: number /* int */; // History samples in which the circuit had battery capacity
+  drainingSamples: number /* int */; // Samples with a negative battery differential
+  averageDifferential: number /* float64 */; // Average battery differential over the samples, negative
+  percentage: number /* float64 */; // Latest battery charge, 0-100
+  untilEmpty: number /* float64 */; // Latest estimate of the seconds until the batteries are empty
+}
+
 //////////
 // source: drone.go
 
@@ -144,10 +313,13 @@ export const DroneStatusDocking: DroneStatus = 'docking';
 export interface Drone extends Location, CircuitIDs {
   name: string;
   speed: number /* float64 */;
+  speedRaw?: number /* float64 */; // Unsmoothed speed, set only when rate smoothing is enabled
   status: DroneStatus;
   home: DroneStation;
   paired?: DroneStation;
   destination?: DroneStation;
+  fuel?: Fuel; // Active fuel of the home station, FRM reports no fuel or battery per drone
+  estimatedArrival: number /* float64 */; // Seconds until the destination is reached at the current speed, zero unless flying
   circuitId: number /* int */;
   circuitGroupId: number /* int */;
 }
@@ -161,6 +333,7 @@ export interface DroneStation extends Location, CircuitIDs {
   boundingBox: BoundingBox;
   incomingRate: number /* float64 */; // Average incoming items/minute
   outgoingRate: number /* float64 */; // Average outgoing items/minute
+  rateUnit: RateUnit; // Unit of IncomingRate and OutgoingRate
   inputInventory: ItemStats[]; // Items being received
   outputInventory: ItemStats[]; // Items being sent
 }
@@ -199,6 +372,44 @@ export type StorageDTO = Storage;
 export type SpaceElevatorDTO = SpaceElevator;
 export type HubDTO = Hub;
 export type RadarTowerDTO = RadarTower;
+export type MisroutedBeltDTO = MisroutedBelt;
+export type OrphanedItemDTO = OrphanedItem;
+export type DuplicateTrainStationNameDTO = DuplicateTrainStationName;
+export type BaseScoreDTO = BaseScore;
+export type ItemProducersDTO = ItemProducers;
+export type ConveyorThroughputDTO = ConveyorThroughput;
+export type ResourceCapacityDTO = ResourceCapacity;
+export type OscillatingItemDTO = OscillatingItem;
+export type RemovedEntitiesDTO = RemovedEntities;
+export type FuelBalanceDTO = FuelBalance;
+export type MachineBuildStatsDTO = MachineBuildStats;
+export type TrainRouteDTO = TrainRoute;
+export type PipeNetworkBalanceDTO = PipeNetworkBalance;
+export type EndpointErrorDTO = EndpointError;
+export type PowerPlantDTO = PowerPlant;
+export type WaterBalanceDTO = WaterBalance;
+export type StalledVehicleDTO = StalledVehicle;
+export type ActiveRecipeDTO = ActiveRecipe;
+export type IdleConveyorDTO = IdleConveyor;
+export type CollectibleProgressDTO = CollectibleProgress;
+export type RecipeRatioIssueDTO = RecipeRatioIssue;
+export type DrainingBatteryDTO = DrainingBattery;
+export type FactoryStatusPointDTO = FactoryStatusPoint;
+export type ItemRunwayDTO = ItemRunway;
+export type TrainPlatformMismatchDTO = TrainPlatformMismatch;
+export type SinkCompositionDTO = SinkComposition;
+export type PackagedCommodityDTO = PackagedCommodity;
+export type DiagnosticsDumpDTO = DiagnosticsDump;
+export type EventDeltaDTO = EventDelta;
+export type ResourceNodeSummaryDTO = ResourceNodeSummary;
+export type TrainCycleDTO = TrainCycle;
+export type ProdSampleDTO = ProdSample;
+export type AlertDTO = Alert;
+export type SplitterOutputFilterDTO = SplitterOutputFilter;
+export type WorldSnapshotDTO = WorldSnapshot;
+export type WorldSnapshotLineDTO = WorldSnapshotLine;
+export type StorageSummaryDTO = StorageSummary;
+export type TechProgressDTO = TechProgress;
 export interface DroneSetupDTO {
   drones: DroneDTO[];
   droneStations: DroneStationDTO[];
@@ -216,6 +427,26 @@ export interface PipesDTO {
   pipeJunctions: PipeJunctionDTO[];
 }
 
+//////////
+// source: duplicate_train_station.go
+
+export interface DuplicateTrainStationName {
+  name: string;
+  locations: Location[]; // Location of every station sharing the name
+}
+
+//////////
+// source: endpoint_error.go
+
+/**
+ * EndpointError is the last failure of an endpoint, kept until its next successful fetch
+ */
+export interface EndpointError {
+  eventType: SatisfactoryEventType;
+  message: string;
+  timestamp: string;
+}
+
 //////////
 // source: error.go
 
@@ -235,6 +466,69 @@ export interface ApiError {
 export interface SatisfactoryApiError {
   Message: string;
 }
+/**
+ * NonJSONResponseError is returned when the Satisfactory API answers with something other than JSON,
+ * such as an HTML error page from the mod's web server. The server is reachable but misbehaving.
+ */
+export interface NonJSONResponseError {
+  Path: string;
+  ContentType: string;
+  Snippet: string;
+}
+/**
+ * PartialDataError is returned together with data when some of the sub-fetches behind it failed.
+ * The data is usable but incomplete, callers wanting all-or-nothing treat it like any other error.
+ */
+export interface PartialDataError {
+  Failed: string[]; // Sources that could not be fetched
+  Err: error; // First failure
+}
+
+//////////
+// source: event_delta.go
+
+/**
+ * EventDelta is the data of a list-based event sent as changes against the previous event of the same type.
+ * A consumer applies the removals and upserts per kind to its entities, or replaces them if Snapshot is set.
+ */
+export interface EventDelta {
+  snapshot: boolean; // If set, the upserted entities replace all previously received ones
+  kinds: EntityDelta[];
+}
+/**
+ * EntityDelta holds the changes of one kind of entity, e.g. belts or splitterMergers of the belts event
+ */
+export interface EntityDelta {
+  kind: string;
+  upserted: DeltaEntity[]; // Added and changed entities, in payload order
+  removed: string[]; // IDs of entities no longer present
+}
+/**
+ * DeltaEntity is an entity with the ID it is tracked by in deltas
+ */
+export interface DeltaEntity {
+  id: string;
+  data: any;
+}
+
+//////////
+// source: event_log.go
+
+export type EventLogEntryType = string;
+export const EventLogEntryFuseTriggered: EventLogEntryType = 'fuseTriggered';
+export const EventLogEntryTrainDerailed: EventLogEntryType = 'trainDerailed';
+export const EventLogEntryPlayerDied: EventLogEntryType = 'playerDied';
+export const EventLogEntryMilestoneCompleted: EventLogEntryType = 'milestoneCompleted';
+export const EventLogEntryPhaseCompleted: EventLogEntryType = 'phaseCompleted';
+export const EventLogEntrySchematicUnlocked: EventLogEntryType = 'schematicUnlocked';
+/**
+ * EventLogEntry is a notable discrete change in a session, kept for scrollback
+ */
+export interface EventLogEntry {
+  timestamp: string;
+  type: EventLogEntryType;
+  description: string;
+}
 
 //////////
 // source: explorer.go
@@ -248,6 +542,7 @@ export interface Explorer extends Location, CircuitIDs {
   id: string;
   name: string;
   speed: number /* float64 */;
+  speedRaw?: number /* float64 */; // Unsmoothed speed, set only when rate smoothing is enabled
   status: ExplorerStatus;
   fuel?: Fuel;
   inventory: ItemStats[];
@@ -262,12 +557,28 @@ export interface MachineEfficiency {
   machinesPaused: number /* int */;
   machinesUnconfigured: number /* int */;
   machinesUnknown: number /* int */;
+  overallEfficiency: number /* float64 */; // Operating / (operating + idle + paused), 0-1, 0 without such machines
 }
 export interface FactoryStats {
   totalMachines: number /* int */;
   efficiency: MachineEfficiency;
 }
 
+//////////
+// source: factory_status_point.go
+
+/**
+ * FactoryStatusPoint is the machine count per status at a point in game time
+ */
+export interface FactoryStatusPoint {
+  gameTimeId: number /* int64 */;
+  operating: number /* int */;
+  idle: number /* int */;
+  paused: number /* int */;
+  unconfigured: number /* int */;
+  unknown: number /* int */;
+}
+
 //////////
 // source: fuel.go
 
@@ -276,6 +587,19 @@ export interface Fuel {
   amount: number /* float64 */;
 }
 
+//////////
+// source: fuel_balance.go
+
+export interface FuelBalance {
+  fuel: string;
+  generators: number /* int */; // Fuel generators burning this fuel
+  producedPerMinute: number /* float64 */; // From prod stats
+  consumedPerMinute: number /* float64 */; // Summed generator inputs
+  netPerMinute: number /* float64 */; // Produced minus consumed, negative when generators burn faster than fuel is made
+  stored: number /* float64 */; // Fuel buffered in storage and generator inventories
+  runwayMinutes?: number /* float64 */; // Minutes until the stored fuel runs out, nil when the balance is not negative
+}
+
 //////////
 // source: game_time.go
 
@@ -302,8 +626,15 @@ export interface PowerSource {
   count: number /* int */;
   totalProduction: number /* float64 */;
 }
+/**
+ * GeneratorStats holds power production per generator type and the power balance of the whole base.
+ * The totals are zero when the circuits could not be fetched.
+ */
 export interface GeneratorStats {
   sources: { [key: PowerType]: PowerSource };
+  totalProduction: number /* float64 */; // W, summed over all circuits
+  totalConsumption: number /* float64 */; // W, summed over all circuits
+  netBalance: number /* float64 */; // W, production minus consumption
 }
 
 //////////
@@ -347,6 +678,8 @@ export interface HubMilestone {
   techTier: number /* int */;
   type: string; // "Milestone" or "No Milestone Selected"
   cost: HubMilestoneCost[];
+  progressPercent: number /* float64 */; // 0-1, mean progress of the cost items
+  completable: boolean; // Every cost item is fully submitted
 }
 /**
  * Hub represents the HUB Terminal
@@ -381,12 +714,110 @@ export interface Hypertubes {
   hypertubeEntrances: HypertubeEntrance[];
 }
 
+//////////
+// source: idle_conveyor.go
+
+export type ConveyorKind = string;
+export const ConveyorKindBelt: ConveyorKind = 'belt';
+export const ConveyorKindPipe: ConveyorKind = 'pipe';
+/**
+ * IdleConveyor is a belt or pipe connected at both ends that has carried nothing for several polls,
+ * usually because an upstream machine stopped or a splitter is misconfigured
+ */
+export interface IdleConveyor {
+  id: string;
+  name: string;
+  kind: ConveyorKind;
+  location0: Location;
+  location1: Location;
+  idlePolls: number /* int */; // Consecutive polls without throughput
+  lastFlowAt?: string; // Last poll with throughput, nil if none was seen since tracking started
+}
+
+//////////
+// source: incident_stats.go
+
+export type AlertName = string;
+export const AlertFuseTriggered: AlertName = 'fuseTriggered'; // A circuit has a triggered fuse
+export const AlertTrainDerailed: AlertName = 'trainDerailed'; // A train is derailed
+export const AlertVehicleStalled: AlertName = 'vehicleStalled'; // A self-driving vehicle is stalled
+export const AlertConveyorIdle: AlertName = 'conveyorIdle'; // A connected belt or pipe carries nothing
+export const AlertEndpointFailing: AlertName = 'endpointFailing'; // An FRM endpoint is failing
+export const AlertBatteryLow: AlertName = 'batteryLow'; // A circuit battery is below the configured charge
+export const AlertPowerDeficit: AlertName = 'powerDeficit'; // A circuit consumes more than it produces
+/**
+ * IncidentStats accumulates the alerts and incidents of a session over its lifetime, for metrics export.
+ * Counts only ever increase until the session is deleted.
+ */
+export interface IncidentStats {
+  firing: { [key: AlertName]: boolean }; // Alert -> currently firing
+  alertsFired: { [key: AlertName]: number /* int */ }; // Alert -> times it started firing
+  incidents: { [key: EventLogEntryType]: number /* int */ }; // Event log entry type -> occurrences
+  last: { [key: EventLogEntryType]: EventLogEntry }; // Latest entry per type
+}
+
+//////////
+// source: item_producers.go
+
+export interface ItemProducer extends Location {
+  type: MachineType;
+  status: MachineStatus;
+  current: number /* float64 */; // Items produced per minute
+  max: number /* float64 */; // Items produced per minute at full efficiency
+  efficiency: number /* float64 */; // 0-1
+}
+export interface ItemProducers {
+  item: string;
+  producers: ItemProducer[];
+  totalCurrent: number /* float64 */;
+  totalMax: number /* float64 */;
+}
+
+//////////
+// source: item_runway.go
+
+/**
+ * ItemRunway is how long the stored amount of an item lasts at its current consumption if production stopped
+ */
+export interface ItemRunway {
+  name: string;
+  stored: number /* float64 */; // Global stored amount
+  consumedPerMinute: number /* float64 */; // Current consumption
+  runwayMinutes?: number /* float64 */; // Stored divided by consumption, null if nothing consumes the item
+  low: boolean; // Runway is below the configured floor
+}
+
 //////////
 // source: item_stats.go
 
+export type ItemCategory = string;
+export const ItemCategoryOre: ItemCategory = 'ore';
+export const ItemCategoryIngot: ItemCategory = 'ingot';
+export const ItemCategoryMineral: ItemCategory = 'mineral';
+export const ItemCategoryStandardPart: ItemCategory = 'standardPart';
+export const ItemCategoryIndustrial: ItemCategory = 'industrial';
+export const ItemCategoryElectronic: ItemCategory = 'electronic';
+export const ItemCategoryCommunication: ItemCategory = 'communication';
+export const ItemCategoryOilDerived: ItemCategory = 'oilDerived';
+export const ItemCategoryFluid: ItemCategory = 'fluid';
+export const ItemCategoryPackaged: ItemCategory = 'packaged';
+export const ItemCategoryFuel: ItemCategory = 'fuel';
+export const ItemCategoryBiomass: ItemCategory = 'biomass';
+export const ItemCategoryNuclear: ItemCategory = 'nuclear';
+export const ItemCategoryQuantum: ItemCategory = 'quantum';
+export const ItemCategorySpaceElevator: ItemCategory = 'spaceElevator';
+export const ItemCategoryAmmunition: ItemCategory = 'ammunition';
+export const ItemCategoryOther: ItemCategory = 'other';
+export type ResourceForm = string;
+export const ResourceFormSolid: ResourceForm = 'solid';
+export const ResourceFormLiquid: ResourceForm = 'liquid';
+export const ResourceFormGas: ResourceForm = 'gas';
 export interface ItemStats {
   name: string;
   count: number /* float64 */;
+  category: ItemCategory;
+  form: ResourceForm;
+  tier: number /* int */; // Tier the item is first unlocked in, 0 if unknown
 }
 
 //////////
@@ -397,6 +828,12 @@ export interface Location {
   y: number /* float64 */;
   z: number /* float64 */;
   rotation: number /* float64 */;
+  /**
+   * Set only when dual units are enabled
+   */
+  xMeters?: number /* float64 */;
+  yMeters?: number /* float64 */;
+  zMeters?: number /* float64 */;
 }
 export interface BoundingBox {
   min: Location;
@@ -434,6 +871,12 @@ export const MachineStatusIdle: MachineStatus = 'idle';
 export const MachineStatusPaused: MachineStatus = 'paused';
 export const MachineStatusUnconfigured: MachineStatus = 'unconfigured';
 export const MachineStatusUnknown: MachineStatus = 'unknown';
+/**
+ * MachineIdleReason explains why an idle machine is not producing
+ */
+export type MachineIdleReason = string;
+export const MachineIdleReasonUnpowered: MachineIdleReason = 'unpowered'; // Its circuit has no power available, e.g. a tripped fuse
+export const MachineIdleReasonStarved: MachineIdleReason = 'starved'; // Powered, but lacking input material or output space
 export interface MachineProdStats {
   name: string;
   stored: number /* float64 */;
@@ -442,8 +885,10 @@ export interface MachineProdStats {
   efficiency: number /* float64 */;
 }
 export interface Machine extends Location, CircuitIDs {
+  id: string; // Stable across polls: the building ID if known, otherwise type and rounded location
   type: MachineType;
   status: MachineStatus;
+  idleReason?: MachineIdleReason; // Set for idle factory machines and extractors once circuits are known
   category: MachineCategory;
   productivity: number /* float64 */; // 0-1
   input: MachineProdStats[];
@@ -451,6 +896,49 @@ export interface Machine extends Location, CircuitIDs {
   boundingBox: BoundingBox;
 }
 
+//////////
+// source: machine_build.go
+
+export interface MachineBuild extends Location {
+  type: MachineType;
+  firstSeen: string;
+}
+export interface MachineCountPoint {
+  timestamp: string;
+  count: number /* int */;
+}
+/**
+ * MachineBuildLog is the persisted record of machines first seen since tracking started.
+ * Machines already present when tracking started are not builds.
+ */
+export interface MachineBuildLog {
+  trackingSince: string;
+  totalBuilt: number /* int */; // All builds since tracking started, Builds only keeps the most recent
+  builds: MachineBuild[]; // Oldest first
+  counts: MachineCountPoint[]; // Total machine count, one point per change
+}
+export interface MachineBuildStats {
+  trackingSince: string;
+  totalMachines: number /* int */;
+  totalBuilt: number /* int */;
+  builtPerHour: number /* float64 */; // Average since tracking started
+  newMachines: MachineBuild[]; // Builds first seen after the requested time
+  counts: MachineCountPoint[];
+}
+
+//////////
+// source: misrouted_belt.go
+
+export interface MisroutedBelt {
+  beltId: string;
+  beltName: string;
+  items: string[]; // Items the belt can carry given its upstream sources
+  acceptedItems: string[]; // Inputs of the downstream machine
+  machineType: MachineType;
+  location0: Location;
+  location1: Location;
+}
+
 //////////
 // source: nodes.go
 
@@ -488,6 +976,68 @@ export interface NodesResponse {
   timestamp: string; // When this snapshot was taken
 }
 
+//////////
+// source: orphaned_item.go
+
+export type OrphanedItemDestination = string;
+export const OrphanedItemDestinationStorage: OrphanedItemDestination = 'storage';
+export const OrphanedItemDestinationDimensionalDepot: OrphanedItemDestination = 'dimensionalDepot';
+export const OrphanedItemDestinationSink: OrphanedItemDestination = 'sink';
+export const OrphanedItemDestinationNone: OrphanedItemDestination = 'none';
+export const OrphanedItemDestinationUnknown: OrphanedItemDestination = 'unknown'; // The sink is active, but the item's sink value is unknown
+export interface OrphanedItem {
+  name: string;
+  producedPerMinute: number /* float64 */;
+  destination: OrphanedItemDestination; // Best guess of where the unconsumed items go
+  wasted: boolean; // True when the items are neither stored nor can be going to the sink
+}
+
+//////////
+// source: oscillating_item.go
+
+/**
+ * OscillatingItem is an item whose production periodically starves and recovers,
+ * which points to under-buffered inputs rather than a steady deficit
+ */
+export interface OscillatingItem {
+  name: string;
+  cycles: number /* int */; // Number of full peak-to-peak cycles in the history
+  periodSeconds: number /* float64 */; // Average game time between peaks
+  minPerMinute: number /* float64 */;
+  maxPerMinute: number /* float64 */;
+  meanPerMinute: number /* float64 */;
+}
+
+//////////
+// source: packaged_commodity.go
+
+export type PackagingDirection = string;
+export const PackagingDirectionPackage: PackagingDirection = 'package'; // Fluid into packaged items
+export const PackagingDirectionUnpackage: PackagingDirection = 'unpackage'; // Packaged items back into fluid
+/**
+ * PackagingSite is a packager converting a commodity between its fluid and packaged form
+ */
+export interface PackagingSite extends Location {
+  direction: PackagingDirection;
+  rate: number /* float64 */; // Converted per minute, 1 packaged item per m³
+}
+/**
+ * PackagedCommodity combines a fluid and its packaged form, which are one commodity in two transport states
+ */
+export interface PackagedCommodity {
+  fluid: string;
+  packaged: string;
+  producedPerMinute: number /* float64 */; // Both forms, excluding conversions by packagers
+  consumedPerMinute: number /* float64 */; // Both forms, excluding conversions by packagers
+  fluidProducedPerMinute: number /* float64 */;
+  fluidConsumedPerMinute: number /* float64 */;
+  packagedProducedPerMinute: number /* float64 */;
+  packagedConsumedPerMinute: number /* float64 */;
+  packagedPerMinute: number /* float64 */; // Fluid packaged by packagers
+  unpackagedPerMinute: number /* float64 */; // Packaged items turned back into fluid by packagers
+  packagers: PackagingSite[];
+}
+
 //////////
 // source: pipe.go
 
@@ -501,6 +1051,8 @@ export interface Pipe {
   splineData: Location[];
   length: number /* float64 */;
   itemsPerMinute: number /* float64 */;
+  flowRate: number /* float64 */; // Signed, positive from location0 to location1. Zero when FRM does not report flow
+  fillPercent: number /* float64 */; // 0-1. Zero when FRM does not report the fluid content
 }
 export interface Pipes {
   pipes: Pipe[];
@@ -515,6 +1067,17 @@ export interface PipeJunction extends Location {
   name: string;
 }
 
+//////////
+// source: pipe_network_balance.go
+
+export interface PipeNetworkBalance extends Location {
+  junctionIds: string[]; // Junctions joined into one network by the pipes between them
+  inflowPerMinute: number /* float64 */;
+  outflowCapacityPerMinute: number /* float64 */; // Max fluid intake of the consumers fed by the network
+  netPerMinute: number /* float64 */; // Inflow minus outflow capacity, positive when over-supplied
+  overSupplied: boolean;
+}
+
 //////////
 // source: player.go
 
@@ -525,6 +1088,25 @@ export interface Player extends Location {
   items: ItemStats[];
 }
 
+//////////
+// source: poll_budget.go
+
+export type PollBudgetThrottle = string;
+export const PollBudgetThrottleNone: PollBudgetThrottle = 'none'; // All endpoints are polled
+export const PollBudgetThrottleLow: PollBudgetThrottle = 'low'; // Low-priority endpoints are skipped
+export const PollBudgetThrottleNormal: PollBudgetThrottle = 'normal'; // Only critical endpoints are polled
+export const PollBudgetThrottleAll: PollBudgetThrottle = 'all'; // The budget is exhausted until the window resets
+/**
+ * PollBudget is the state of the per-session cap on requests to the game server
+ */
+export interface PollBudget {
+  limit: number /* int */; // Requests allowed per window
+  used: number /* int */;
+  remaining: number /* int */;
+  resetsAt: string;
+  throttle: PollBudgetThrottle;
+}
+
 //////////
 // source: power_info.go
 
@@ -535,14 +1117,47 @@ export interface PowerInfo {
   maxPowerConsumed: number /* float64 */;
 }
 
+//////////
+// source: power_plant.go
+
+export interface PowerPlantFuel {
+  name: string;
+  perMinute: number /* float64 */;
+}
+/**
+ * PowerPlant is a cluster of nearby generators of the same type, e.g. "Coal Generator Plant A"
+ */
+export interface PowerPlant extends Location {
+  name: string;
+  type: MachineType;
+  generators: number /* int */;
+  output: number /* float64 */; // W
+  maxOutput: number /* float64 */; // W
+  fuel: PowerPlantFuel[]; // Summed generator inputs, empty until generators report fuel inputs
+}
+
+//////////
+// source: prod_sample.go
+
+/**
+ * ProdSample is the production and consumption rate of one item at a single GetProdStats poll
+ */
+export interface ProdSample {
+  timestamp: string;
+  producedPerMinute: number /* float64 */;
+  consumedPerMinute: number /* float64 */;
+}
+
 //////////
 // source: prod_stats.go
 
 export interface ItemProdStats extends ItemStats {
   producedPerMinute: number /* float64 */;
+  producedPerMinuteRaw?: number /* float64 */; // Unsmoothed rate, set only when rate smoothing is enabled
   maxProducePerMinute: number /* float64 */;
   produceEfficiency: number /* float64 */;
   consumedPerMinute: number /* float64 */;
+  consumedPerMinuteRaw?: number /* float64 */; // Unsmoothed rate, set only when rate smoothing is enabled
   maxConsumePerMinute: number /* float64 */;
   consumeEfficiency: number /* float64 */;
   cloudCount: number /* float64 */;
@@ -556,6 +1171,23 @@ export interface ProdStats {
   items: ItemProdStats[];
 }
 
+//////////
+// source: progression.go
+
+export type ProgressionKind = string;
+export const ProgressionSchematicUnlocked: ProgressionKind = 'schematicUnlocked';
+export const ProgressionMilestoneCompleted: ProgressionKind = 'milestoneCompleted';
+export const ProgressionPhaseCompleted: ProgressionKind = 'phaseCompleted';
+/**
+ * Progression is a schematic, milestone or space elevator phase transition between two polls
+ */
+export interface Progression {
+  kind: ProgressionKind;
+  name: string; // Schematic name, or the delivered objectives of a phase
+  tier?: number /* int */; // Tech tier, not set for phases
+  timestamp: string;
+}
+
 //////////
 // source: radar_tower.go
 
@@ -653,6 +1285,61 @@ export interface RadarTower extends Location {
   boundingBox: BoundingBox;
 }
 
+//////////
+// source: rate_unit.go
+
+/**
+ * RateUnit is the unit a transfer rate is reported in by FRM
+ */
+export type RateUnit = string;
+export const RateUnitStacksPerSecond: RateUnit = 'stacks/sec';
+export const RateUnitItemsPerMinute: RateUnit = 'items/min';
+
+//////////
+// source: recipe_ratio.go
+
+/**
+ * RecipeRatioIssue is a machine running below capacity because one input arrives too slowly while
+ * the others pile up, e.g. plenty of screws but too few iron plates for reinforced iron plates
+ */
+export interface RecipeRatioIssue extends Location {
+  machineType: MachineType;
+  product: string;
+  productivity: number /* float64 */; // 0-1
+  limiter: string; // Input holding the machine back
+  limiterBuffer: number /* float64 */; // Minutes of the limiter buffered at full speed
+  oversupplied: string[]; // Inputs buffered well beyond what the limiter allows
+}
+
+//////////
+// source: resource_capacity.go
+
+export interface ResourceCapacity {
+  resourceType: ResourceType;
+  exploitedNodes: number /* int */;
+  availableNodes: number /* int */;
+  availableImpure: number /* int */;
+  availableNormal: number /* int */;
+  availablePure: number /* int */;
+  currentPerMinute: number /* float64 */; // Output of extractors currently producing the resource
+  additionalPerMinute: number /* float64 */; // Extra output if every available node was exploited at the given miner tier
+}
+
+//////////
+// source: resource_node_summary.go
+
+export interface PurityCounts {
+  impure: number /* int */;
+  normal: number /* int */;
+  pure: number /* int */;
+  total: number /* int */;
+}
+export interface ResourceNodeSummary {
+  resourceType: ResourceType;
+  exploited: PurityCounts;
+  unexploited: PurityCounts;
+}
+
 //////////
 // source: satisfactory_event.go
 
@@ -682,16 +1369,62 @@ export const SatisfactoryEventRadarTowers: SatisfactoryEventType = 'radarTowers'
 export const SatisfactoryEventResourceNodes: SatisfactoryEventType = 'resourceNodes';
 export const SatisfactoryEventHypertubes: SatisfactoryEventType = 'hypertubes';
 export const SatisfactoryEventSchematics: SatisfactoryEventType = 'schematics';
+export const SatisfactoryEventBaseScore: SatisfactoryEventType = 'baseScore';
+export const SatisfactoryEventRemoved: SatisfactoryEventType = 'removed';
+export const SatisfactoryEventDiagnostics: SatisfactoryEventType = 'diagnostics';
+export const SatisfactoryEventStalledVehicles: SatisfactoryEventType = 'stalledVehicles';
+export const SatisfactoryEventIdleConveyors: SatisfactoryEventType = 'idleConveyors';
+export const SatisfactoryEventStuckStorageItems: SatisfactoryEventType = 'stuckStorageItems';
+export const SatisfactoryEventPollBudget: SatisfactoryEventType = 'pollBudget';
+export const SatisfactoryEventProgression: SatisfactoryEventType = 'progression';
+export const SatisfactoryEventShipTimer: SatisfactoryEventType = 'shipTimer';
+export const SatisfactoryEventAlerts: SatisfactoryEventType = 'alerts';
 export const SatisfactoryEventKey: string = 'satisfactory_events';
 export interface SatisfactoryEvent {
   type: SatisfactoryEventType;
   data: any;
   gameTimeId: number /* int64 */; // Game time when event was captured (0 for non-history types)
+  partial?: boolean; // Set when part of the data could not be fetched, see PartialDataError
+  truncated?: boolean; // Set when the entity lists were capped to the configured maximum
+  totalCount?: number /* int */; // Number of entities before capping, only set when truncated
+  filtered?: number /* int */; // Entities dropped for lying outside the valid coordinate bounds
+  delta?: boolean; // Set when Data is an EventDelta, only on streams opened in delta mode
+  session?: EventSession; // Session the event belongs to, set when published
+}
+/**
+ * EventSession identifies the session of an event, so consumers can show its label without a lookup
+ */
+export interface EventSession {
+  id: string;
+  label: string;
+  metadata?: { [key: string]: string };
+}
+/**
+ * RemovedEntities lists entities that were present in the previous poll of a list-based event but are gone now
+ */
+export interface RemovedEntities {
+  eventType: SatisfactoryEventType; // Event the entities were listed in
+  kind: string; // Field of the event data holding the entities, e.g. belts or splitterMergers
+  ids: string[]; // Entity IDs, or the name (location for machines) for entities without an ID
 }
 export interface SseSatisfactoryEvent extends SatisfactoryEvent {
   clientId: number /* int64 */;
 }
 
+//////////
+// source: scaled_value.go
+
+/**
+ * ScaledValue is a display hint for a raw value, scaled to the largest SI prefix that keeps it at or above 1
+ */
+export interface ScaledValue {
+  raw: number /* float64 */;
+  value: number /* float64 */; // Raw divided by the prefix multiplier
+  prefix: string; // "", "k", "M" or "G"
+  unit: string; // Unit of the raw value
+  display: string; // Value, prefix and unit ready for rendering, e.g. "2.3 GW"
+}
+
 //////////
 // source: schematic.go
 
@@ -727,6 +1460,9 @@ export interface Session {
   id: string; // UUID
   name: string; // User-provided display name
   address: string; // IP:port (e.g., "192.168.1.100:8080")
+  headers?: { [key: string]: string }; // Sent with every FRM request, e.g. Authorization for a reverse proxy
+  label: string; // Set on creation, defaults to the name, carried by every event
+  metadata?: { [key: string]: string }; // Set on creation, e.g. region or owner, carried by every event
   sessionName: string; // From getSessionInfo API
   isOnline: boolean; // Current connection status
   isPaused: boolean; // True if polling is paused by user
@@ -773,6 +1509,9 @@ export interface SessionInfo {
 export interface CreateSessionRequest {
   name: string;
   address: string;
+  headers?: { [key: string]: string };
+  label?: string; // Defaults to the name, cannot be changed later
+  metadata?: { [key: string]: string }; // Cannot be changed later
 }
 /**
  * UpdateSessionRequest is the request body for updating a session (all fields optional)
@@ -781,6 +1520,7 @@ export interface UpdateSessionRequest {
   name?: string;
   isPaused?: boolean;
   address?: string;
+  headers?: { [key: string]: string }; // Replaces the whole header set, an empty object clears it
 }
 /**
  * SessionDTO is the data transfer object for Session with computed fields
@@ -793,6 +1533,9 @@ export interface SessionDTO {
   isOnline: boolean;
   isPaused: boolean;
   isDisconnected: boolean; // True if session is in disconnected state
+  headerNames: string[]; // Names of the configured FRM request headers, values are never exposed
+  label: string;
+  metadata: { [key: string]: string };
   createdAt: string;
   stage: SessionStage;
 }
@@ -834,6 +1577,50 @@ export interface SettingsChangedEvent {
   changes: SettingsChange[]; // List of what changed
 }
 
+//////////
+// source: ship_timer.go
+
+export type ShipTimerState = string;
+export const ShipTimerStateLaunched: ShipTimerState = 'launched';
+export const ShipTimerStateDocked: ShipTimerState = 'docked';
+/**
+ * ShipTimer announces a hub ship launch or return, so clients can run the return countdown locally
+ */
+export interface ShipTimer {
+  state: ShipTimerState;
+  returnTime?: number /* int64 */; // Unix timestamp (ms) when the ship returns, only set when launched
+  corrected?: boolean; // Set when re-emitted because the countdown drifted from the game
+}
+
+//////////
+// source: sink_composition.go
+
+export type SinkCompositionConfidence = string;
+export const SinkCompositionConfidenceHigh: SinkCompositionConfidence = 'high'; // Estimate within 25% of the measured points
+export const SinkCompositionConfidenceMedium: SinkCompositionConfidence = 'medium'; // Estimate within a factor of two
+export const SinkCompositionConfidenceLow: SinkCompositionConfidence = 'low';
+/**
+ * SinkFeedItem is an item estimated to be fed into the AWESOME sink
+ */
+export interface SinkFeedItem {
+  name: string;
+  surplusPerMinute: number /* float64 */; // Produced minus consumed
+  pointsPerItem?: number /* float64 */; // Sink value, null if unknown
+  pointsPerMinute: number /* float64 */; // Estimated contribution, scaled to the measured sink rate
+  share: number /* float64 */; // 0-1 of the measured sink rate
+  stored: boolean; // Also found in storage, so part of the surplus may fill containers instead
+}
+/**
+ * SinkComposition estimates what is going into the AWESOME sink, as the game only reports total points
+ */
+export interface SinkComposition {
+  pointsPerMinute: number /* float64 */; // Measured sink rate
+  estimatedPointsPerMinute: number /* float64 */; // Sum of surplus times sink value, before scaling
+  confidence: SinkCompositionConfidence;
+  note: string;
+  items: SinkFeedItem[];
+}
+
 //////////
 // source: sink_stats.go
 
@@ -851,6 +1638,9 @@ export interface SpaceElevatorPhaseObjective {
   name: string;
   amount: number /* float64 */;
   totalCost: number /* float64 */;
+  deliveryRate: number /* float64 */; // Items per minute submitted since the previous poll
+  estimatedCompletion: number /* float64 */; // Seconds until done at the current rate, zero when no progress is detected
+  stalled: boolean; // Unfinished and nothing was submitted since the previous poll
 }
 export interface SpaceElevator extends Location {
   id: string;
@@ -859,6 +1649,7 @@ export interface SpaceElevator extends Location {
   currentPhase: SpaceElevatorPhaseObjective[];
   fullyUpgraded: boolean;
   upgradeReady: boolean;
+  estimatedCompletion: number /* float64 */; // Seconds until the slowest objective is done, zero while any unfinished objective has no progress
 }
 
 //////////
@@ -869,10 +1660,42 @@ export const SplitterMergerTypeConveyorMerger: SplitterMergerType = 'Conveyor Me
 export const SplitterMergerTypeConveyorSplitter: SplitterMergerType = 'Conveyor Splitter';
 export const SplitterMergerTypeProgrammableSplitter: SplitterMergerType = 'Programmable Splitter';
 export const SplitterMergerTypeSmartSplitter: SplitterMergerType = 'Smart Splitter';
+export type SplitterOutput = string;
+export const SplitterOutputLeft: SplitterOutput = 'left';
+export const SplitterOutputCenter: SplitterOutput = 'center';
+export const SplitterOutputRight: SplitterOutput = 'right';
+/**
+ * SplitterOutputFilter is an item a smart or programmable splitter routes to one of its outputs.
+ * Item may also be a wildcard such as Any, None or Overflow.
+ */
+export interface SplitterOutputFilter {
+  output: SplitterOutput;
+  item: string;
+  itemClass: string;
+}
 export interface SplitterMerger extends Location {
   id: string;
   type: SplitterMergerType;
   boundingBox: BoundingBox;
+  filters: SplitterOutputFilter[]; // Empty for plain splitters and mergers
+}
+
+//////////
+// source: stalled_vehicle.go
+
+export type StalledVehicleKind = string;
+export const StalledVehicleKindTruck: StalledVehicleKind = 'truck';
+export const StalledVehicleKindTractor: StalledVehicleKind = 'tractor';
+export const StalledVehicleKindExplorer: StalledVehicleKind = 'explorer';
+export const StalledVehicleKindDrone: StalledVehicleKind = 'drone';
+/**
+ * StalledVehicle is a self-driving vehicle standing still away from any station, e.g. on a blocked path or out of fuel
+ */
+export interface StalledVehicle extends Location {
+  id: string; // Name for drones, which have no ID
+  name: string;
+  kind: StalledVehicleKind;
+  stalledSince: string;
 }
 
 //////////
@@ -884,6 +1707,7 @@ export interface State {
   prodStats: ProdStats;
   generatorStats: GeneratorStats;
   sinkStats: SinkStats;
+  baseScore?: BaseScore;
   circuits: Circuit[];
   players: Player[];
   drones: Drone[];
@@ -908,6 +1732,13 @@ export interface State {
   radarTowers: RadarTower[];
   resourceNodes: ResourceNode[];
   schematics: Schematic[];
+  diagnostics: EndpointError[];
+  stalledVehicles: StalledVehicle[];
+  idleConveyors: IdleConveyor[];
+  stuckStorageItems: StuckStorageItem[];
+  pollBudget?: PollBudget;
+  shipTimer?: ShipTimer;
+  alerts: Alert[];
 }
 
 //////////
@@ -924,6 +1755,55 @@ export interface Storage extends Location {
   type: StorageType;
   inventory: ItemStats[];
   boundingBox: BoundingBox;
+  fillPercent: number /* float64 */; // 0-1 of the summed MaxAmount of the inventory items. Zero when no capacity is reported
+  capacities: { [key: string]: number /* float64 */ }; // Summed MaxAmount per item name of the inventory
+}
+export interface StorageItemTotal {
+  name: string;
+  stored: number /* float64 */;
+  capacity: number /* float64 */;
+  fillPercent: number /* float64 */; // 0-1
+}
+export interface StorageSummary {
+  type: StorageType;
+  containers: number /* int */;
+  stored: number /* float64 */;
+  capacity: number /* float64 */;
+  fillPercent: number /* float64 */; // 0-1
+  items: StorageItemTotal[];
+}
+
+//////////
+// source: stuck_storage_item.go
+
+/**
+ * StuckStorageItem is an item piling up in storage while the machines consuming it starve,
+ * which usually means a belt or routing gap between the storage and those consumers
+ */
+export interface StuckStorageItem {
+  name: string;
+  stored: number /* float64 */; // Amount across storage containers
+  growthPerMinute: number /* float64 */; // Stored amount growth since it started growing
+  consumers: number /* int */; // Machines taking the item as input
+  consumerEfficiency: number /* float64 */; // Average input efficiency of those machines, 0-1
+  since: string; // When growth and starvation both started
+}
+
+//////////
+// source: tech_progress.go
+
+export interface TechTierProgress {
+  tier: number /* int */;
+  total: number /* int */;
+  purchased: number /* int */;
+  completionPercent: number /* float64 */; // 0-1 of the tier's milestones purchased
+  schematics: Schematic[];
+}
+export interface TechProgress {
+  tiers: TechTierProgress[];
+  unlockable: Schematic[]; // Not purchased and not locked, can be selected at the HUB now
+  phaseLocked: Schematic[]; // Waiting for a space elevator phase
+  tierLocked: Schematic[]; // Locked for another reason, e.g. a previous tier
 }
 
 //////////
@@ -938,6 +1818,7 @@ export interface Tractor extends Location, CircuitIDs {
   id: string;
   name: string;
   speed: number /* float64 */;
+  speedRaw?: number /* float64 */; // Unsmoothed speed, set only when rate smoothing is enabled
   status: TractorStatus;
   fuel?: Fuel;
   inventory: ItemStats[];
@@ -963,16 +1844,54 @@ export interface TrainVehicle {
 }
 export interface TrainTimetableEntry {
   station: string;
+  location?: Location; // Nearest station with this name, nil if no such station exists
 }
 export interface Train extends Location, CircuitIDs {
   id: string;
   name: string;
   speed: number /* float64 */;
+  speedRaw?: number /* float64 */; // Unsmoothed speed, set only when rate smoothing is enabled
   status: TrainStatus;
   powerConsumption: number /* float64 */;
   vehicles: TrainVehicle[];
   timetable: TrainTimetableEntry[];
   timetableIndex: number /* int */;
+  cycleSeconds?: number /* float64 */; // Estimated loop time of the timetable, set only by the trains route when the rails connect every stop
+}
+
+//////////
+// source: train_cycle.go
+
+/**
+ * TrainCycle is the estimated time a train takes for one loop of its timetable
+ */
+export interface TrainCycle {
+  trainId: string;
+  trainName: string;
+  stops: number /* int */;
+  distanceMeters: number /* float64 */; // Rail distance of one loop
+  cycleSeconds?: number /* float64 */; // Travel at cruising speed plus a docking allowance per stop, nil if it could not be estimated
+  error?: string; // Why the cycle could not be estimated
+}
+
+//////////
+// source: train_platform_mismatch.go
+
+/**
+ * TrainPlatformMismatch is a docked train whose car composition does not fit the platforms of its station,
+ * which leaves cargo untransferred on every stop
+ */
+export interface TrainPlatformMismatch {
+  trainId: string;
+  trainName: string;
+  station: string;
+  locomotives: number /* int */;
+  freightCars: number /* int */;
+  platforms: number /* int */;
+  unservedPlatforms: number /* int */; // Platforms past the end of the train
+  unservedCars: number /* int */; // Freight cars past the last platform
+  locomotivesAtPlatforms: number /* int */; // Locomotives lined up with a platform instead of a freight car
+  underpowered: boolean; // More freight cars per locomotive than can keep up speed
 }
 
 //////////
@@ -991,6 +1910,24 @@ export interface TrainRail {
   length: number /* float64 */;
 }
 
+//////////
+// source: train_route.go
+
+export type TrainRouteLoad = string;
+export const TrainRouteLoadOverTrained: TrainRouteLoad = 'overTrained'; // Trains spend long docked without transferring, waiting on each other
+export const TrainRouteLoadUnderTrained: TrainRouteLoad = 'underTrained'; // Platforms sit full or empty waiting for a train
+export const TrainRouteLoadBalanced: TrainRouteLoad = 'balanced';
+export interface TrainRoute {
+  stations: string[]; // Timetable station sequence shared by the trains
+  trainIds: string[];
+  trainCount: number /* int */;
+  dockCount: number /* int */; // Completed dock visits at the route's stations
+  averageDockTime: number /* float64 */; // Seconds, weighted by dock count
+  averageBlockedTime: number /* float64 */; // Seconds docked without transferring, weighted by dock count
+  stalledPlatforms: number /* int */; // Full export or empty import platforms at the route's stations
+  load: TrainRouteLoad;
+}
+
 //////////
 // source: train_station.go
 
@@ -1010,14 +1947,25 @@ export interface TrainStationPlatform extends Location {
   status: TrainStationPlatformStatus;
   boundingBox: BoundingBox;
   inventory: ItemStats[];
+  capacity: number /* float64 */; // Total inventory capacity, 0 if unknown
+  fill: number /* float64 */; // 0-1
+  stalled: boolean; // Full export or empty import platform
   transferRate: number /* float64 */; // Solid items rate
   inflowRate: number /* float64 */; // Fluid incoming rate
   outflowRate: number /* float64 */; // Fluid outgoing rate
 }
+export interface TrainStationDockStats {
+  dockCount: number /* int */;
+  averageDockTime: number /* float64 */; // Seconds
+  lastDockTime: number /* float64 */; // Seconds
+  averageLoadingTime: number /* float64 */; // Seconds spent with a platform transferring cargo
+  averageBlockedTime: number /* float64 */; // Seconds docked without transferring, e.g. waiting on a signal
+}
 export interface TrainStation extends Location, CircuitIDs {
   name: string;
   boundingBox: BoundingBox;
   platforms: TrainStationPlatform[];
+  dockStats: TrainStationDockStats;
 }
 
 //////////
@@ -1032,6 +1980,7 @@ export interface Truck extends Location, CircuitIDs {
   id: string;
   name: string;
   speed: number /* float64 */;
+  speedRaw?: number /* float64 */; // Unsmoothed speed, set only when rate smoothing is enabled
   status: TruckStatus;
   fuel?: Fuel;
   inventory: ItemStats[];
@@ -1045,6 +1994,7 @@ export interface TruckStation extends Location, CircuitIDs {
   boundingBox: BoundingBox;
   transferRate: number /* float64 */; // Current transfer rate
   maxTransferRate: number /* float64 */; // Max stacks/sec for all vehicles
+  transferRateUnit: RateUnit; // Unit of TransferRate and MaxTransferRate
   inventory: ItemStats[]; // Station inventory
   circuitId: number /* int */;
 }
@@ -1079,3 +2029,53 @@ export interface VehicleStations {
   droneStations: DroneStation[];
   truckStations: TruckStation[];
 }
+
+//////////
+// source: water_balance.go
+
+export interface WaterBalance {
+  producedPerMinute: number /* float64 */; // Summed Water outputs, mostly water extractors
+  consumedPerMinute: number /* float64 */; // Summed Water inputs
+  netPerMinute: number /* float64 */; // Produced minus consumed
+  producers: number /* int */; // Machines outputting Water
+  consumers: number /* int */; // Machines taking Water as input
+  nuclearConsumedPerMinute: number /* float64 */; // Part of the consumption going to nuclear power plants
+  deficit: boolean; // Consumption exceeds production
+  nuclearThrottled: boolean; // The deficit reaches nuclear power plants
+  affectedPower: number /* float64 */; // W, estimated nuclear output lost to the deficit
+}
+
+//////////
+// source: world_snapshot.go
+
+/**
+ * WorldSnapshot is the full state of a game server fetched in one go. Sections that could not be
+ * fetched are left empty and their error is kept in Errors, keyed by section name.
+ */
+export interface WorldSnapshot {
+  takenAt: string;
+  machines: Machine[];
+  circuits: Circuit[];
+  prodStats?: ProdStats;
+  vehicles: Vehicles;
+  vehicleStations: VehicleStations;
+  belts: Belts;
+  pipes: Pipes;
+  storages: Storage[];
+  radarTowers: RadarTower[];
+  spaceElevator?: SpaceElevator;
+  hub?: Hub;
+  schematics: Schematic[];
+  errors?: { [key: string]: string };
+}
+/**
+ * WorldSnapshotLine is one line of a JSON Lines world snapshot export. The first line only carries
+ * TakenAt, then every entity of a list section is a line of its own so huge sections are streamed,
+ * and a section that failed to fetch is a single line carrying its Error.
+ */
+export interface WorldSnapshotLine {
+  section: string;
+  takenAt?: string;
+  data?: any;
+  error?: string;
+}